@@ -67,11 +67,12 @@ export function ChatArea({
 		if (!roomName || !keys) return;
 
 		// Sign the message using Nostr-style cryptography
-		const { signature, timestamp } = await signMessage({
+		const { signature, timestamp, version } = await signMessage({
 			privateKey: keys.privateKey,
 			publicKey: keys.publicKey,
 			content,
 			room: roomName,
+			user: username,
 		});
 
 		sendMessageMutation.mutate({
@@ -83,6 +84,7 @@ export function ChatArea({
 				pubkey: keys.publicKey,
 				room_password: password,
 				timestamp,
+				version,
 			},
 		});
 	};
@@ -108,10 +108,35 @@ func getAllPets(ctx fuego.ContextNoBody) (*MyResponse, error) {
 
  * OpenAPI spec version: 0.0.1
  */
+/**
+ * AddReactionRequest schema
+ */
+export interface AddReactionRequest {
+	/** @maxLength 32 */
+	emoji: string;
+	pubkey: string;
+	room_password?: string | null;
+	sig_scheme?: string | null;
+	signature: string;
+	tags: string[][];
+	timestamp: number;
+	version?: number | null;
+}
+
+/**
+ * AdminChallenge schema
+ */
+export interface AdminChallenge {
+	challenge?: string;
+	expires_at?: string;
+}
+
 /**
  * CreateRoomRequest schema
  */
 export interface CreateRoomRequest {
+	encrypted?: boolean;
+	key_salt?: string;
 	/**
 	 * @minLength 1
 	 * @maxLength 50
@@ -122,6 +147,57 @@ export interface CreateRoomRequest {
 	 * @maxLength 72
 	 */
 	password?: string | null;
+	private?: boolean;
+}
+
+/**
+ * CreateInviteRequest schema
+ */
+export interface CreateInviteRequest {
+	/** @minimum 0 */
+	duration?: number | null;
+	/** @minimum 0 */
+	max_uses?: number | null;
+}
+
+/**
+ * CreateSanctionRequest schema
+ */
+export interface CreateSanctionRequest {
+	/** @minimum 0 */
+	duration?: number | null;
+	ip?: string | null;
+	kind: string;
+	pubkey?: string | null;
+	/** @maxLength 280 */
+	reason?: string | null;
+}
+
+/**
+ * DirectMessage schema
+ */
+export interface DirectMessage {
+	content?: string;
+	id?: string;
+	recipient?: string;
+	sender?: string;
+	signature?: string;
+	signed_timestamp?: number;
+	tags?: string[][];
+	timestamp?: string;
+	version?: number;
+}
+
+/**
+ * EditMessageRequest schema
+ */
+export interface EditMessageRequest {
+	content: string;
+	sig_scheme?: string | null;
+	signature: string;
+	tags: string[][];
+	timestamp: number;
+	version?: number | null;
 }
 
 /**
@@ -154,29 +230,186 @@ export interface HTTPError {
 	type?: string | null;
 }
 
+/**
+ * Invite schema
+ */
+export interface Invite {
+	created_at?: string;
+	created_by?: string;
+	expires_at?: string | null;
+	id?: string;
+	link?: string | null;
+	max_uses?: number | null;
+	room?: string;
+	token?: string | null;
+	uses?: number;
+}
+
+export type MessageReactionsItem = {
+	count?: number;
+	emoji?: string;
+} | null;
+
 /**
  * Message schema
  */
 export interface Message {
 	content?: string;
+	deleted_at?: string | null;
+	edited_at?: string | null;
 	id?: string;
 	pubkey?: string | null;
+	reactions?: (MessageReactionsItem)[] | null;
+	replies?: number;
+	reply_to?: string;
+	revisions?: number;
 	room?: string;
+	sig_scheme?: string | null;
 	signature?: string | null;
 	signed_timestamp?: number | null;
+	tags?: ((string | null)[] | null)[] | null;
 	timestamp?: string;
 	user?: string;
+	version?: number;
+}
+
+/**
+ * MessageRevision schema
+ */
+export interface MessageRevision {
+	content?: string;
+	created_at?: string;
+	revision?: number;
+	sig_scheme?: string | null;
+	signature?: string | null;
+	signed_timestamp?: number | null;
+	tags?: ((string | null)[] | null)[] | null;
+	version?: number | null;
+}
+
+/**
+ * MessagePage schema
+ */
+export interface MessagePage {
+	messages?: Message[] | null;
+	next_cursor?: string;
+	prev_cursor?: string;
+}
+
+/**
+ * MessageThread schema
+ */
+export interface MessageThread {
+	replies?: Message[] | null;
+	root?: Message;
+}
+
+/**
+ * Reaction schema
+ */
+export interface Reaction {
+	created_at?: string;
+	emoji?: string;
+	message_id?: string;
+	pubkey?: string;
+	sig_scheme?: string | null;
+	signature?: string;
+	signed_timestamp?: number;
+	tags?: ((string | null)[] | null)[] | null;
+	version?: number | null;
+}
+
+/**
+ * ReactionCount schema
+ */
+export interface ReactionCount {
+	count?: number;
+	emoji?: string;
+}
+
+/**
+ * ResetRoomPasswordRequest schema
+ */
+export interface ResetRoomPasswordRequest {
+	/**
+	 * @minLength 4
+	 * @maxLength 72
+	 */
+	password?: string | null;
+}
+
+/**
+ * RetentionPolicy schema
+ */
+export interface RetentionPolicy {
+	/** @minimum 0 */
+	max_age?: number;
+	/** @minimum 0 */
+	max_messages?: number;
+}
+
+/**
+ * RetentionStats schema
+ */
+export interface RetentionStats {
+	last_error?: string;
+	last_pruned?: number;
+	last_run_at?: string | null;
+	pruned_messages?: number;
+	runs?: number;
 }
 
 /**
  * Room schema
  */
 export interface Room {
+	description?: string;
+	encrypted?: boolean;
 	has_password?: boolean;
+	key_salt?: string;
 	last_message_content?: string | null;
 	last_message_timestamp?: string | null;
 	last_message_user?: string | null;
 	name?: string;
+	private?: boolean;
+	retention?: RetentionPolicy;
+}
+
+/**
+ * RoomMember schema
+ */
+export interface RoomMember {
+	pubkey?: string;
+	role?: string;
+}
+
+/**
+ * Sanction schema
+ */
+export interface Sanction {
+	created_at?: string;
+	created_by?: string;
+	expires_at?: string | null;
+	id?: string;
+	ip?: string | null;
+	kind?: string;
+	pubkey?: string | null;
+	reason?: string | null;
+	room?: string | null;
+}
+
+/**
+ * SendDirectMessageRequest schema
+ */
+export interface SendDirectMessageRequest {
+	/** @maxLength 87472 */
+	content: string;
+	pubkey: string;
+	recipient: string;
+	signature: string;
+	tags: string[][];
+	timestamp: number;
+	version?: number;
 }
 
 /**
@@ -185,10 +418,15 @@ export interface Room {
 export interface SendMessageRequest {
 	content: string;
 	pubkey: string;
+	reply_to?: string;
+	room_invite?: string | null;
 	room_password?: string | null;
+	sig_scheme?: string | null;
 	signature: string;
+	tags?: ((string | null)[] | null)[] | null;
 	timestamp: number;
 	user: string;
+	version?: number;
 }
 
 /**
@@ -196,10 +434,20 @@ export interface SendMessageRequest {
  */
 export interface ServerInfoResponse {
 	description?: string;
+	retention?: RetentionPolicy;
+	signature_schemes?: string[];
 	suggested_quickname?: string;
 	suggested_servers?: (string | null)[] | null;
 }
 
+/**
+ * SetRoomDescriptionRequest schema
+ */
+export interface SetRoomDescriptionRequest {
+	/** @maxLength 280 */
+	description?: string;
+}
+
 /**
  * User schema
  */
@@ -210,6 +458,17 @@ export interface User {
 	verified?: boolean;
 }
 
+/**
+ * UserWithPostCount schema
+ */
+export interface UserWithPostCount {
+	created_at?: string;
+	post_count?: number;
+	public_key?: string;
+	updated_at?: string;
+	verified?: boolean;
+}
+
 /**
  * unknown-interface schema
  */
@@ -228,4 +487,46 @@ export type GETApiRoomsRoomMessagesParams = {
 	password?: string;
 	limit?: number;
 	before?: string;
+	after?: string;
+};
+
+export type GETApiRoomsRoomMessagesPageParams = {
+	password?: string;
+	invite?: string;
+	limit?: number;
+	before?: string;
+	after?: string;
+};
+
+export type GETApiRoomsRoomMessagesIdRevisionsParams = {
+	password?: string;
+};
+
+export type GETApiRoomsRoomMessagesIdThreadParams = {
+	password?: string;
+	limit?: number;
+};
+
+export type GETApiRoomsRoomMessagesIdReactionsParams = {
+	password?: string;
+};
+
+export type DELETEApiRoomsRoomMessagesIdReactionsEmojiParams = {
+	password?: string;
+};
+
+export type GETApiSearchParams = {
+	q?: string;
+	room?: string;
+	author?: string;
+	before?: string;
+	password?: string;
+	invite?: string;
+	limit?: number;
+};
+
+export type GETApiDmsParams = {
+	with?: string;
+	limit?: number;
+	before?: string;
 };
@@ -28,48 +28,145 @@ export async function generateKeyPair(): Promise<KeyPair> {
 }
 
 /**
- * Create a canonical event hash for signing
- * This follows Nostr's event serialization format
+ * Latest event format version. Version 0 signs
+ * [0, pubkey, created_at, content, room]; version 1 also covers the
+ * display name and tags: [1, pubkey, created_at, content, room, user, tags].
  */
-function createEventHash(params: {
+export const LATEST_EVENT_VERSION = 1;
+
+export type EventTags = string[][];
+
+/** Signature schemes: ECDSA over createEventHash, or BIP-340 Schnorr over the NIP-01 event id. */
+export type SigScheme = "ecdsa" | "schnorr";
+
+/** Nostr kind and tag used for room messages (NIP-29 group chat). */
+const NOSTR_KIND_CHAT_MESSAGE = 9;
+const NOSTR_ROOM_TAG = "h";
+/** Tag carrying the display name of a Schnorr message. */
+const NOSTR_NAME_TAG = "name";
+
+/** Drop the prefix byte of a compressed public key (Nostr uses x-only keys). */
+function toXOnly(publicKey: string): string {
+	return publicKey.length === 66 ? publicKey.slice(2) : publicKey;
+}
+
+/**
+ * Compute the NIP-01 event id: sha256 of
+ * [0, pubkey, created_at, kind, tags, content], with the x-only public key.
+ * The tags must include the room tag and, unless the user is the name the
+ * server derives from the public key, the name tag, as the server requires.
+ */
+function createNostrEventId(params: {
 	publicKey: string;
 	timestamp: number;
 	content: string;
 	room: string;
+	user?: string;
+	tags?: EventTags;
 }): string {
-	// Nostr event format: [0, pubkey, created_at, kind, tags, content]
-	// We simplify by using: [0, pubkey, created_at, content, room]
+	const tags = params.tags ?? [];
+	const hasRoomTag = tags.some(
+		(tag) => tag[0] === NOSTR_ROOM_TAG && tag[1] === params.room,
+	);
+	if (!hasRoomTag) {
+		throw new Error("Schnorr events must carry the room tag");
+	}
+	if (params.user) {
+		const nameTag = tags.find(
+			(tag) => tag.length >= 2 && tag[0] === NOSTR_NAME_TAG,
+		);
+		const name =
+			nameTag?.[1] ?? `nostr:${toXOnly(params.publicKey).slice(0, 8)}`;
+		if (name !== params.user) {
+			throw new Error("Schnorr events must carry the name tag");
+		}
+	}
 	const serialized = JSON.stringify([
-		0, // reserved for future use
-		params.publicKey,
+		0,
+		toXOnly(params.publicKey),
 		params.timestamp,
+		NOSTR_KIND_CHAT_MESSAGE,
+		tags,
 		params.content,
-		params.room,
 	]);
+	return bytesToHex(sha256(new TextEncoder().encode(serialized)));
+}
 
-	const hash = sha256(new TextEncoder().encode(serialized));
+/**
+ * Create a canonical event hash for signing
+ * This follows Nostr's event serialization format
+ */
+function createEventHash(params: {
+	version: number;
+	publicKey: string;
+	timestamp: number;
+	content: string;
+	room: string;
+	user?: string;
+	tags?: EventTags;
+}): string {
+	// Nostr event format: [0, pubkey, created_at, kind, tags, content]
+	// We simplify by using: [version, pubkey, created_at, content, room, ...]
+	let event: unknown[];
+	switch (params.version) {
+		case 0:
+			event = [
+				0,
+				params.publicKey,
+				params.timestamp,
+				params.content,
+				params.room,
+			];
+			break;
+		case 1:
+			event = [
+				1,
+				params.publicKey,
+				params.timestamp,
+				params.content,
+				params.room,
+				params.user ?? "",
+				params.tags ?? [],
+			];
+			break;
+		default:
+			throw new Error(`Unsupported event version ${params.version}`);
+	}
+
+	const hash = sha256(new TextEncoder().encode(JSON.stringify(event)));
 	return bytesToHex(hash);
 }
 
 /**
  * Sign a message using the private key
- * Returns the signature as a hex string
+ * Returns the signature as a hex string, along with the event version used
  */
 export async function signMessage(params: {
 	privateKey: string;
 	publicKey: string;
 	content: string;
 	room: string;
+	user: string;
+	tags?: EventTags;
 	timestamp?: number;
-}): Promise<{ signature: string; timestamp: number; eventHash: string }> {
+}): Promise<{
+	signature: string;
+	timestamp: number;
+	eventHash: string;
+	version: number;
+}> {
 	const timestamp = params.timestamp || Math.floor(Date.now() / 1000);
+	const version = LATEST_EVENT_VERSION;
 
 	// Create the event hash
 	const eventHash = createEventHash({
+		version,
 		publicKey: params.publicKey,
 		timestamp,
 		content: params.content,
 		room: params.room,
+		user: params.user,
+		tags: params.tags,
 	});
 
 	// Sign the hash
@@ -102,12 +199,14 @@ export async function signMessage(params: {
 		signature: signatureHex,
 		timestamp,
 		eventHash,
+		version,
 	};
 }
 
 /**
  * Verify a message signature
- * Returns true if the signature is valid
+ * Returns true if the signature is valid. Messages without a version are
+ * treated as version 0.
  * @public
  */
 export async function verifySignature(params: {
@@ -116,13 +215,29 @@ export async function verifySignature(params: {
 	content: string;
 	room: string;
 	timestamp: number;
+	version?: number;
+	user?: string;
+	tags?: EventTags;
+	sigScheme?: SigScheme | string;
 }): Promise<boolean> {
 	try {
+		if (params.sigScheme === "schnorr") {
+			const eventId = createNostrEventId(params);
+			return await secp256k1.schnorr.verifyAsync(
+				hexToBytes(params.signature),
+				hexToBytes(eventId),
+				hexToBytes(toXOnly(params.publicKey)),
+			);
+		}
+
 		const eventHash = createEventHash({
+			version: params.version ?? 0,
 			publicKey: params.publicKey,
 			timestamp: params.timestamp,
 			content: params.content,
 			room: params.room,
+			user: params.user,
+			tags: params.tags,
 		});
 
 		const publicKeyBytes = hexToBytes(params.publicKey);
@@ -30,6 +30,10 @@ export function useSigVerification(
 						content: msg.content ?? "",
 						room: roomName,
 						timestamp: msg.signed_timestamp,
+						version: msg.version,
+						user: msg.user,
+						tags: msg.tags?.map((tag) => (tag ?? []).map((v) => v ?? "")),
+						sigScheme: msg.sig_scheme ?? undefined,
 					});
 					next[msg.id] = ok ? "valid" : "invalid";
 				} catch {
@@ -18,10 +18,69 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// AddReactionRequest AddReactionRequest schema
+type AddReactionRequest struct {
+	Emoji        string     `json:"emoji"`
+	Pubkey       string     `json:"pubkey"`
+	RoomPassword *string    `json:"room_password,omitempty"`
+	SigScheme    *string    `json:"sig_scheme,omitempty"`
+	Signature    string     `json:"signature"`
+	Tags         [][]string `json:"tags"`
+	Timestamp    int64      `json:"timestamp"`
+	Version      *int       `json:"version,omitempty"`
+}
+
+// AdminChallenge AdminChallenge schema
+type AdminChallenge struct {
+	Challenge *string    `json:"challenge,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateInviteRequest CreateInviteRequest schema
+type CreateInviteRequest struct {
+	Duration *int64 `json:"duration,omitempty"`
+	MaxUses  *int   `json:"max_uses,omitempty"`
+}
+
 // CreateRoomRequest CreateRoomRequest schema
 type CreateRoomRequest struct {
-	Name     string  `json:"name"`
-	Password *string `json:"password,omitempty"`
+	Encrypted *bool   `json:"encrypted,omitempty"`
+	KeySalt   *string `json:"key_salt,omitempty"`
+	Name      string  `json:"name"`
+	Password  *string `json:"password,omitempty"`
+	Private   *bool   `json:"private,omitempty"`
+}
+
+// CreateSanctionRequest CreateSanctionRequest schema
+type CreateSanctionRequest struct {
+	Duration *int64  `json:"duration,omitempty"`
+	Ip       *string `json:"ip,omitempty"`
+	Kind     string  `json:"kind"`
+	Pubkey   *string `json:"pubkey,omitempty"`
+	Reason   *string `json:"reason,omitempty"`
+}
+
+// DirectMessage DirectMessage schema
+type DirectMessage struct {
+	Content         *string     `json:"content,omitempty"`
+	Id              *string     `json:"id,omitempty"`
+	Recipient       *string     `json:"recipient,omitempty"`
+	Sender          *string     `json:"sender,omitempty"`
+	Signature       *string     `json:"signature,omitempty"`
+	SignedTimestamp *int64      `json:"signed_timestamp,omitempty"`
+	Tags            *[][]string `json:"tags,omitempty"`
+	Timestamp       *time.Time  `json:"timestamp,omitempty"`
+	Version         *int        `json:"version,omitempty"`
+}
+
+// EditMessageRequest EditMessageRequest schema
+type EditMessageRequest struct {
+	Content   string     `json:"content"`
+	SigScheme *string    `json:"sig_scheme,omitempty"`
+	Signature string     `json:"signature"`
+	Tags      [][]string `json:"tags"`
+	Timestamp int64      `json:"timestamp"`
+	Version   *int       `json:"version,omitempty"`
 }
 
 // HTTPError HTTPError schema
@@ -50,42 +109,247 @@ type HTTPError struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// Invite Invite schema
+type Invite struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+	Link      *string    `json:"link,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	Room      *string    `json:"room,omitempty"`
+	Token     *string    `json:"token,omitempty"`
+	Uses      *int       `json:"uses,omitempty"`
+}
+
 // Message Message schema
 type Message struct {
-	Content         *string    `json:"content,omitempty"`
-	Id              *string    `json:"id,omitempty"`
-	Pubkey          *string    `json:"pubkey,omitempty"`
-	Room            *string    `json:"room,omitempty"`
-	Signature       *string    `json:"signature,omitempty"`
-	SignedTimestamp *int64     `json:"signed_timestamp,omitempty"`
-	Timestamp       *time.Time `json:"timestamp,omitempty"`
-	User            *string    `json:"user,omitempty"`
+	Content   *string    `json:"content,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+	Pubkey    *string    `json:"pubkey,omitempty"`
+	Reactions *[]*struct {
+		Count *int    `json:"count,omitempty"`
+		Emoji *string `json:"emoji,omitempty"`
+	} `json:"reactions,omitempty"`
+	Replies         *int          `json:"replies,omitempty"`
+	ReplyTo         *string       `json:"reply_to,omitempty"`
+	Revisions       *int          `json:"revisions,omitempty"`
+	Room            *string       `json:"room,omitempty"`
+	SigScheme       *string       `json:"sig_scheme,omitempty"`
+	Signature       *string       `json:"signature,omitempty"`
+	SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+	Tags            *[]*[]*string `json:"tags,omitempty"`
+	Timestamp       *time.Time    `json:"timestamp,omitempty"`
+	User            *string       `json:"user,omitempty"`
+	Version         *int          `json:"version,omitempty"`
+}
+
+// MessagePage MessagePage schema
+type MessagePage struct {
+	Messages *[]struct {
+		Content   *string    `json:"content,omitempty"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+		EditedAt  *time.Time `json:"edited_at,omitempty"`
+		Id        *string    `json:"id,omitempty"`
+		Pubkey    *string    `json:"pubkey,omitempty"`
+		Reactions *[]*struct {
+			Count *int    `json:"count,omitempty"`
+			Emoji *string `json:"emoji,omitempty"`
+		} `json:"reactions,omitempty"`
+		Replies         *int          `json:"replies,omitempty"`
+		ReplyTo         *string       `json:"reply_to,omitempty"`
+		Revisions       *int          `json:"revisions,omitempty"`
+		Room            *string       `json:"room,omitempty"`
+		SigScheme       *string       `json:"sig_scheme,omitempty"`
+		Signature       *string       `json:"signature,omitempty"`
+		SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+		Tags            *[]*[]*string `json:"tags,omitempty"`
+		Timestamp       *time.Time    `json:"timestamp,omitempty"`
+		User            *string       `json:"user,omitempty"`
+		Version         *int          `json:"version,omitempty"`
+	} `json:"messages,omitempty"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+}
+
+// MessageRevision MessageRevision schema
+type MessageRevision struct {
+	Content         *string       `json:"content,omitempty"`
+	CreatedAt       *time.Time    `json:"created_at,omitempty"`
+	Revision        *int          `json:"revision,omitempty"`
+	SigScheme       *string       `json:"sig_scheme,omitempty"`
+	Signature       *string       `json:"signature,omitempty"`
+	SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+	Tags            *[]*[]*string `json:"tags,omitempty"`
+	Version         *int          `json:"version,omitempty"`
+}
+
+// MessageThread MessageThread schema
+type MessageThread struct {
+	Replies *[]struct {
+		Content   *string    `json:"content,omitempty"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+		EditedAt  *time.Time `json:"edited_at,omitempty"`
+		Id        *string    `json:"id,omitempty"`
+		Pubkey    *string    `json:"pubkey,omitempty"`
+		Reactions *[]*struct {
+			Count *int    `json:"count,omitempty"`
+			Emoji *string `json:"emoji,omitempty"`
+		} `json:"reactions,omitempty"`
+		Replies         *int          `json:"replies,omitempty"`
+		ReplyTo         *string       `json:"reply_to,omitempty"`
+		Revisions       *int          `json:"revisions,omitempty"`
+		Room            *string       `json:"room,omitempty"`
+		SigScheme       *string       `json:"sig_scheme,omitempty"`
+		Signature       *string       `json:"signature,omitempty"`
+		SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+		Tags            *[]*[]*string `json:"tags,omitempty"`
+		Timestamp       *time.Time    `json:"timestamp,omitempty"`
+		User            *string       `json:"user,omitempty"`
+		Version         *int          `json:"version,omitempty"`
+	} `json:"replies,omitempty"`
+	Root *struct {
+		Content   *string    `json:"content,omitempty"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+		EditedAt  *time.Time `json:"edited_at,omitempty"`
+		Id        *string    `json:"id,omitempty"`
+		Pubkey    *string    `json:"pubkey,omitempty"`
+		Reactions *[]*struct {
+			Count *int    `json:"count,omitempty"`
+			Emoji *string `json:"emoji,omitempty"`
+		} `json:"reactions,omitempty"`
+		Replies         *int          `json:"replies,omitempty"`
+		ReplyTo         *string       `json:"reply_to,omitempty"`
+		Revisions       *int          `json:"revisions,omitempty"`
+		Room            *string       `json:"room,omitempty"`
+		SigScheme       *string       `json:"sig_scheme,omitempty"`
+		Signature       *string       `json:"signature,omitempty"`
+		SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+		Tags            *[]*[]*string `json:"tags,omitempty"`
+		Timestamp       *time.Time    `json:"timestamp,omitempty"`
+		User            *string       `json:"user,omitempty"`
+		Version         *int          `json:"version,omitempty"`
+	} `json:"root,omitempty"`
+}
+
+// Reaction Reaction schema
+type Reaction struct {
+	CreatedAt       *time.Time    `json:"created_at,omitempty"`
+	Emoji           *string       `json:"emoji,omitempty"`
+	MessageId       *string       `json:"message_id,omitempty"`
+	Pubkey          *string       `json:"pubkey,omitempty"`
+	SigScheme       *string       `json:"sig_scheme,omitempty"`
+	Signature       *string       `json:"signature,omitempty"`
+	SignedTimestamp *int64        `json:"signed_timestamp,omitempty"`
+	Tags            *[]*[]*string `json:"tags,omitempty"`
+	Version         *int          `json:"version,omitempty"`
+}
+
+// ReactionCount ReactionCount schema
+type ReactionCount struct {
+	Count *int    `json:"count,omitempty"`
+	Emoji *string `json:"emoji,omitempty"`
+}
+
+// ResetRoomPasswordRequest ResetRoomPasswordRequest schema
+type ResetRoomPasswordRequest struct {
+	Password *string `json:"password,omitempty"`
+}
+
+// RetentionPolicy RetentionPolicy schema
+type RetentionPolicy struct {
+	MaxAge      *int64 `json:"max_age,omitempty"`
+	MaxMessages *int64 `json:"max_messages,omitempty"`
+}
+
+// RetentionStats RetentionStats schema
+type RetentionStats struct {
+	LastError      *string    `json:"last_error,omitempty"`
+	LastPruned     *int64     `json:"last_pruned,omitempty"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	PrunedMessages *int64     `json:"pruned_messages,omitempty"`
+	Runs           *int64     `json:"runs,omitempty"`
 }
 
 // Room Room schema
 type Room struct {
+	Description          *string `json:"description,omitempty"`
+	Encrypted            *bool   `json:"encrypted,omitempty"`
 	HasPassword          *bool   `json:"has_password,omitempty"`
+	KeySalt              *string `json:"key_salt,omitempty"`
 	LastMessageContent   *string `json:"last_message_content,omitempty"`
 	LastMessageTimestamp *string `json:"last_message_timestamp,omitempty"`
 	LastMessageUser      *string `json:"last_message_user,omitempty"`
 	Name                 *string `json:"name,omitempty"`
+	Private              *bool   `json:"private,omitempty"`
+	Retention            *struct {
+		MaxAge      *int64 `json:"max_age,omitempty"`
+		MaxMessages *int64 `json:"max_messages,omitempty"`
+	} `json:"retention,omitempty"`
+}
+
+// RoomMember RoomMember schema
+type RoomMember struct {
+	Pubkey *string `json:"pubkey,omitempty"`
+	Role   *string `json:"role,omitempty"`
+}
+
+// Sanction Sanction schema
+type Sanction struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        *string    `json:"id,omitempty"`
+	Ip        *string    `json:"ip,omitempty"`
+	Kind      *string    `json:"kind,omitempty"`
+	Pubkey    *string    `json:"pubkey,omitempty"`
+	Reason    *string    `json:"reason,omitempty"`
+	Room      *string    `json:"room,omitempty"`
+}
+
+// SendDirectMessageRequest SendDirectMessageRequest schema
+type SendDirectMessageRequest struct {
+	Content   string     `json:"content"`
+	Pubkey    string     `json:"pubkey"`
+	Recipient string     `json:"recipient"`
+	Signature string     `json:"signature"`
+	Tags      [][]string `json:"tags"`
+	Timestamp int64      `json:"timestamp"`
+	Version   *int       `json:"version,omitempty"`
 }
 
 // SendMessageRequest SendMessageRequest schema
 type SendMessageRequest struct {
-	Content      string  `json:"content"`
-	Pubkey       string  `json:"pubkey"`
-	RoomPassword *string `json:"room_password,omitempty"`
-	Signature    string  `json:"signature"`
-	Timestamp    int64   `json:"timestamp"`
-	User         string  `json:"user"`
+	Content      string        `json:"content"`
+	Pubkey       string        `json:"pubkey"`
+	ReplyTo      *string       `json:"reply_to,omitempty"`
+	RoomInvite   *string       `json:"room_invite,omitempty"`
+	RoomPassword *string       `json:"room_password,omitempty"`
+	SigScheme    *string       `json:"sig_scheme,omitempty"`
+	Signature    string        `json:"signature"`
+	Tags         *[]*[]*string `json:"tags,omitempty"`
+	Timestamp    int64         `json:"timestamp"`
+	User         string        `json:"user"`
+	Version      *int          `json:"version,omitempty"`
 }
 
 // ServerInfoResponse ServerInfoResponse schema
 type ServerInfoResponse struct {
-	Description        *string  `json:"description,omitempty"`
-	SuggestedQuickname *string  `json:"suggested_quickname,omitempty"`
-	SuggestedServers   []string `json:"suggested_servers,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Retention   *struct {
+		MaxAge      *int64 `json:"max_age,omitempty"`
+		MaxMessages *int64 `json:"max_messages,omitempty"`
+	} `json:"retention,omitempty"`
+	SignatureSchemes   *[]string `json:"signature_schemes,omitempty"`
+	SuggestedQuickname *string   `json:"suggested_quickname,omitempty"`
+	SuggestedServers   []string  `json:"suggested_servers,omitempty"`
+}
+
+// SetRoomDescriptionRequest SetRoomDescriptionRequest schema
+type SetRoomDescriptionRequest struct {
+	Description *string `json:"description,omitempty"`
 }
 
 // User User schema
@@ -96,9 +360,88 @@ type User struct {
 	Verified  *bool      `json:"verified,omitempty"`
 }
 
+// UserWithPostCount UserWithPostCount schema
+type UserWithPostCount struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	PostCount *int64     `json:"post_count,omitempty"`
+	PublicKey *string    `json:"public_key,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Verified  *bool      `json:"verified,omitempty"`
+}
+
 // UnknownInterface unknown-interface schema
 type UnknownInterface = interface{}
 
+// POSTapiadminchallengeParams defines parameters for POSTapiadminchallenge.
+type POSTapiadminchallengeParams struct {
+	// XAdminPubkey admin public key the challenge is for
+	XAdminPubkey string  `json:"X-Admin-Pubkey"`
+	Accept       *string `json:"Accept,omitempty"`
+}
+
+// GETapiadminretentionParams defines parameters for GETapiadminretention.
+type GETapiadminretentionParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiadminroomsRoomParams defines parameters for DELETEapiadminroomsRoom.
+type DELETEapiadminroomsRoomParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiadminroomsRoommessagesIdParams defines parameters for DELETEapiadminroomsRoommessagesId.
+type DELETEapiadminroomsRoommessagesIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiadminroomsRoompasswordParams defines parameters for POSTapiadminroomsRoompassword.
+type POSTapiadminroomsRoompasswordParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiadminsanctionsParams defines parameters for GETapiadminsanctions.
+type GETapiadminsanctionsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiadminsanctionsParams defines parameters for POSTapiadminsanctions.
+type POSTapiadminsanctionsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiadminsanctionsIdParams defines parameters for DELETEapiadminsanctionsId.
+type DELETEapiadminsanctionsIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiadminusersParams defines parameters for GETapiadminusers.
+type GETapiadminusersParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiadminusersPublicKeyunverifyParams defines parameters for POSTapiadminusersPublicKeyunverify.
+type POSTapiadminusersPublicKeyunverifyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiadminusersPublicKeyverifyParams defines parameters for POSTapiadminusersPublicKeyverify.
+type POSTapiadminusersPublicKeyverifyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapidmsParams defines parameters for GETapidms.
+type GETapidmsParams struct {
+	With   *string `form:"with,omitempty" json:"with,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapidmsParams defines parameters for POSTapidms.
+type POSTapidmsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
 // GETapiroomsParams defines parameters for GETapirooms.
 type GETapiroomsParams struct {
 	Visited *string `form:"visited,omitempty" json:"visited,omitempty"`
@@ -117,11 +460,48 @@ type GETapiroomssearchParams struct {
 	Accept  *string `json:"Accept,omitempty"`
 }
 
+// PUTapiroomsRoomdescriptionParams defines parameters for PUTapiroomsRoomdescription.
+type PUTapiroomsRoomdescriptionParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoominvitesParams defines parameters for GETapiroomsRoominvites.
+type GETapiroomsRoominvitesParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiroomsRoominvitesParams defines parameters for POSTapiroomsRoominvites.
+type POSTapiroomsRoominvitesParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoominvitesIdParams defines parameters for DELETEapiroomsRoominvitesId.
+type DELETEapiroomsRoominvitesIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoommembersParams defines parameters for GETapiroomsRoommembers.
+type GETapiroomsRoommembersParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoommembersPubkeyParams defines parameters for DELETEapiroomsRoommembersPubkey.
+type DELETEapiroomsRoommembersPubkeyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// PUTapiroomsRoommembersPubkeyParams defines parameters for PUTapiroomsRoommembersPubkey.
+type PUTapiroomsRoommembersPubkeyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
 // GETapiroomsRoommessagesParams defines parameters for GETapiroomsRoommessages.
 type GETapiroomsRoommessagesParams struct {
 	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Invite   *string `form:"invite,omitempty" json:"invite,omitempty"`
 	Limit    *int    `form:"limit,omitempty" json:"limit,omitempty"`
 	Before   *string `form:"before,omitempty" json:"before,omitempty"`
+	After    *string `form:"after,omitempty" json:"after,omitempty"`
 	Accept   *string `json:"Accept,omitempty"`
 }
 
@@ -130,22 +510,154 @@ type POSTapiroomsRoommessagesParams struct {
 	Accept *string `json:"Accept,omitempty"`
 }
 
+// GETapiroomsRoommessagespageParams defines parameters for GETapiroomsRoommessagespage.
+type GETapiroomsRoommessagespageParams struct {
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Invite   *string `form:"invite,omitempty" json:"invite,omitempty"`
+	Limit    *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Before   *string `form:"before,omitempty" json:"before,omitempty"`
+	After    *string `form:"after,omitempty" json:"after,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoommessagesIdParams defines parameters for DELETEapiroomsRoommessagesId.
+type DELETEapiroomsRoommessagesIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// PUTapiroomsRoommessagesIdParams defines parameters for PUTapiroomsRoommessagesId.
+type PUTapiroomsRoommessagesIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoommessagesIdreactionsParams defines parameters for GETapiroomsRoommessagesIdreactions.
+type GETapiroomsRoommessagesIdreactionsParams struct {
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
+// POSTapiroomsRoommessagesIdreactionsParams defines parameters for POSTapiroomsRoommessagesIdreactions.
+type POSTapiroomsRoommessagesIdreactionsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoommessagesIdreactionsEmojiParams defines parameters for DELETEapiroomsRoommessagesIdreactionsEmoji.
+type DELETEapiroomsRoommessagesIdreactionsEmojiParams struct {
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoommessagesIdrevisionsParams defines parameters for GETapiroomsRoommessagesIdrevisions.
+type GETapiroomsRoommessagesIdrevisionsParams struct {
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoommessagesIdthreadParams defines parameters for GETapiroomsRoommessagesIdthread.
+type GETapiroomsRoommessagesIdthreadParams struct {
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Limit    *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoommoderatorsPubkeyParams defines parameters for DELETEapiroomsRoommoderatorsPubkey.
+type DELETEapiroomsRoommoderatorsPubkeyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// PUTapiroomsRoommoderatorsPubkeyParams defines parameters for PUTapiroomsRoommoderatorsPubkey.
+type PUTapiroomsRoommoderatorsPubkeyParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// PUTapiroomsRoompasswordParams defines parameters for PUTapiroomsRoompassword.
+type PUTapiroomsRoompasswordParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// PUTapiroomsRoomretentionParams defines parameters for PUTapiroomsRoomretention.
+type PUTapiroomsRoomretentionParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapiroomsRoomsanctionsParams defines parameters for GETapiroomsRoomsanctions.
+type GETapiroomsRoomsanctionsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// POSTapiroomsRoomsanctionsParams defines parameters for POSTapiroomsRoomsanctions.
+type POSTapiroomsRoomsanctionsParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// DELETEapiroomsRoomsanctionsIdParams defines parameters for DELETEapiroomsRoomsanctionsId.
+type DELETEapiroomsRoomsanctionsIdParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
+// GETapisearchParams defines parameters for GETapisearch.
+type GETapisearchParams struct {
+	Q        *string `form:"q,omitempty" json:"q,omitempty"`
+	Room     *string `form:"room,omitempty" json:"room,omitempty"`
+	Author   *string `form:"author,omitempty" json:"author,omitempty"`
+	Before   *string `form:"before,omitempty" json:"before,omitempty"`
+	Password *string `form:"password,omitempty" json:"password,omitempty"`
+	Invite   *string `form:"invite,omitempty" json:"invite,omitempty"`
+	Limit    *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Accept   *string `json:"Accept,omitempty"`
+}
+
 // GETapiserverInfoParams defines parameters for GETapiserverInfo.
 type GETapiserverInfoParams struct {
 	Accept *string `json:"Accept,omitempty"`
 }
 
+// GETapiusersmeParams defines parameters for GETapiusersme.
+type GETapiusersmeParams struct {
+	Accept *string `json:"Accept,omitempty"`
+}
+
 // GETapiusersPublicKeyParams defines parameters for GETapiusersPublicKey.
 type GETapiusersPublicKeyParams struct {
 	Accept *string `json:"Accept,omitempty"`
 }
 
+// POSTapiadminroomsRoompasswordJSONRequestBody defines body for POSTapiadminroomsRoompassword for application/json ContentType.
+type POSTapiadminroomsRoompasswordJSONRequestBody = ResetRoomPasswordRequest
+
+// POSTapiadminsanctionsJSONRequestBody defines body for POSTapiadminsanctions for application/json ContentType.
+type POSTapiadminsanctionsJSONRequestBody = CreateSanctionRequest
+
+// POSTapidmsJSONRequestBody defines body for POSTapidms for application/json ContentType.
+type POSTapidmsJSONRequestBody = SendDirectMessageRequest
+
 // POSTapiroomsJSONRequestBody defines body for POSTapirooms for application/json ContentType.
 type POSTapiroomsJSONRequestBody = CreateRoomRequest
 
+// PUTapiroomsRoomdescriptionJSONRequestBody defines body for PUTapiroomsRoomdescription for application/json ContentType.
+type PUTapiroomsRoomdescriptionJSONRequestBody = SetRoomDescriptionRequest
+
+// POSTapiroomsRoominvitesJSONRequestBody defines body for POSTapiroomsRoominvites for application/json ContentType.
+type POSTapiroomsRoominvitesJSONRequestBody = CreateInviteRequest
+
 // POSTapiroomsRoommessagesJSONRequestBody defines body for POSTapiroomsRoommessages for application/json ContentType.
 type POSTapiroomsRoommessagesJSONRequestBody = SendMessageRequest
 
+// PUTapiroomsRoommessagesIdJSONRequestBody defines body for PUTapiroomsRoommessagesId for application/json ContentType.
+type PUTapiroomsRoommessagesIdJSONRequestBody = EditMessageRequest
+
+// POSTapiroomsRoommessagesIdreactionsJSONRequestBody defines body for POSTapiroomsRoommessagesIdreactions for application/json ContentType.
+type POSTapiroomsRoommessagesIdreactionsJSONRequestBody = AddReactionRequest
+
+// PUTapiroomsRoompasswordJSONRequestBody defines body for PUTapiroomsRoompassword for application/json ContentType.
+type PUTapiroomsRoompasswordJSONRequestBody = ResetRoomPasswordRequest
+
+// PUTapiroomsRoomretentionJSONRequestBody defines body for PUTapiroomsRoomretention for application/json ContentType.
+type PUTapiroomsRoomretentionJSONRequestBody = RetentionPolicy
+
+// POSTapiroomsRoomsanctionsJSONRequestBody defines body for POSTapiroomsRoomsanctions for application/json ContentType.
+type POSTapiroomsRoomsanctionsJSONRequestBody = CreateSanctionRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -222,6 +734,54 @@ type ClientInterface interface {
 	// GET request
 	GET(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// POSTapiadminchallenge request
+	POSTapiadminchallenge(ctx context.Context, params *POSTapiadminchallengeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiadminretention request
+	GETapiadminretention(ctx context.Context, params *GETapiadminretentionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiadminroomsRoom request
+	DELETEapiadminroomsRoom(ctx context.Context, room string, params *DELETEapiadminroomsRoomParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiadminroomsRoommessagesId request
+	DELETEapiadminroomsRoommessagesId(ctx context.Context, room string, id string, params *DELETEapiadminroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiadminroomsRoompasswordWithBody request with any body
+	POSTapiadminroomsRoompasswordWithBody(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapiadminroomsRoompassword(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, body POSTapiadminroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiadminsanctions request
+	GETapiadminsanctions(ctx context.Context, params *GETapiadminsanctionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiadminsanctionsWithBody request with any body
+	POSTapiadminsanctionsWithBody(ctx context.Context, params *POSTapiadminsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapiadminsanctions(ctx context.Context, params *POSTapiadminsanctionsParams, body POSTapiadminsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiadminsanctionsId request
+	DELETEapiadminsanctionsId(ctx context.Context, id string, params *DELETEapiadminsanctionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiadminusers request
+	GETapiadminusers(ctx context.Context, params *GETapiadminusersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiadminusersPublicKeyunverify request
+	POSTapiadminusersPublicKeyunverify(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyunverifyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiadminusersPublicKeyverify request
+	POSTapiadminusersPublicKeyverify(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyverifyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapidms request
+	GETapidms(ctx context.Context, params *GETapidmsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapidmsWithBody request with any body
+	POSTapidmsWithBody(ctx context.Context, params *POSTapidmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapidms(ctx context.Context, params *POSTapidmsParams, body POSTapidmsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapinostr request
+	GETapinostr(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GETapirooms request
 	GETapirooms(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -233,6 +793,31 @@ type ClientInterface interface {
 	// GETapiroomssearch request
 	GETapiroomssearch(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PUTapiroomsRoomdescriptionWithBody request with any body
+	PUTapiroomsRoomdescriptionWithBody(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PUTapiroomsRoomdescription(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, body PUTapiroomsRoomdescriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoominvites request
+	GETapiroomsRoominvites(ctx context.Context, room string, params *GETapiroomsRoominvitesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiroomsRoominvitesWithBody request with any body
+	POSTapiroomsRoominvitesWithBody(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapiroomsRoominvites(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, body POSTapiroomsRoominvitesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoominvitesId request
+	DELETEapiroomsRoominvitesId(ctx context.Context, room string, id string, params *DELETEapiroomsRoominvitesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoommembers request
+	GETapiroomsRoommembers(ctx context.Context, room string, params *GETapiroomsRoommembersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoommembersPubkey request
+	DELETEapiroomsRoommembersPubkey(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PUTapiroomsRoommembersPubkey request
+	PUTapiroomsRoommembersPubkey(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GETapiroomsRoommessages request
 	GETapiroomsRoommessages(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -241,11 +826,78 @@ type ClientInterface interface {
 
 	POSTapiroomsRoommessages(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GETapiroomsRoommessagespage request
+	GETapiroomsRoommessagespage(ctx context.Context, room string, params *GETapiroomsRoommessagespageParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoommessagesId request
+	DELETEapiroomsRoommessagesId(ctx context.Context, room string, id string, params *DELETEapiroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PUTapiroomsRoommessagesIdWithBody request with any body
+	PUTapiroomsRoommessagesIdWithBody(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PUTapiroomsRoommessagesId(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, body PUTapiroomsRoommessagesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoommessagesIdreactions request
+	GETapiroomsRoommessagesIdreactions(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdreactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiroomsRoommessagesIdreactionsWithBody request with any body
+	POSTapiroomsRoommessagesIdreactionsWithBody(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapiroomsRoommessagesIdreactions(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, body POSTapiroomsRoommessagesIdreactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoommessagesIdreactionsEmoji request
+	DELETEapiroomsRoommessagesIdreactionsEmoji(ctx context.Context, room string, id string, emoji string, params *DELETEapiroomsRoommessagesIdreactionsEmojiParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoommessagesIdrevisions request
+	GETapiroomsRoommessagesIdrevisions(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdrevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoommessagesIdthread request
+	GETapiroomsRoommessagesIdthread(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdthreadParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoommoderatorsPubkey request
+	DELETEapiroomsRoommoderatorsPubkey(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PUTapiroomsRoommoderatorsPubkey request
+	PUTapiroomsRoommoderatorsPubkey(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PUTapiroomsRoompasswordWithBody request with any body
+	PUTapiroomsRoompasswordWithBody(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PUTapiroomsRoompassword(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, body PUTapiroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PUTapiroomsRoomretentionWithBody request with any body
+	PUTapiroomsRoomretentionWithBody(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PUTapiroomsRoomretention(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, body PUTapiroomsRoomretentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoomsanctions request
+	GETapiroomsRoomsanctions(ctx context.Context, room string, params *GETapiroomsRoomsanctionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// POSTapiroomsRoomsanctionsWithBody request with any body
+	POSTapiroomsRoomsanctionsWithBody(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	POSTapiroomsRoomsanctions(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, body POSTapiroomsRoomsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DELETEapiroomsRoomsanctionsId request
+	DELETEapiroomsRoomsanctionsId(ctx context.Context, room string, id string, params *DELETEapiroomsRoomsanctionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiroomsRoomstream request
+	GETapiroomsRoomstream(ctx context.Context, room string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapisearch request
+	GETapisearch(ctx context.Context, params *GETapisearchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GETapiserverInfo request
 	GETapiserverInfo(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GETapiusersme request
+	GETapiusersme(ctx context.Context, params *GETapiusersmeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GETapiusersPublicKey request
 	GETapiusersPublicKey(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GETapiws request
+	GETapiws(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GET(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -260,8 +912,8 @@ func (c *Client) GET(ctx context.Context, reqEditors ...RequestEditorFn) (*http.
 	return c.Client.Do(req)
 }
 
-func (c *Client) GETapirooms(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGETapiroomsRequest(c.Server, params)
+func (c *Client) POSTapiadminchallenge(ctx context.Context, params *POSTapiadminchallengeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminchallengeRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -272,8 +924,8 @@ func (c *Client) GETapirooms(ctx context.Context, params *GETapiroomsParams, req
 	return c.Client.Do(req)
 }
 
-func (c *Client) POSTapiroomsWithBody(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPOSTapiroomsRequestWithBody(c.Server, params, contentType, body)
+func (c *Client) GETapiadminretention(ctx context.Context, params *GETapiadminretentionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiadminretentionRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -284,8 +936,8 @@ func (c *Client) POSTapiroomsWithBody(ctx context.Context, params *POSTapiroomsP
 	return c.Client.Do(req)
 }
 
-func (c *Client) POSTapirooms(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPOSTapiroomsRequest(c.Server, params, body)
+func (c *Client) DELETEapiadminroomsRoom(ctx context.Context, room string, params *DELETEapiadminroomsRoomParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiadminroomsRoomRequest(c.Server, room, params)
 	if err != nil {
 		return nil, err
 	}
@@ -296,8 +948,8 @@ func (c *Client) POSTapirooms(ctx context.Context, params *POSTapiroomsParams, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) GETapiroomssearch(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGETapiroomssearchRequest(c.Server, params)
+func (c *Client) DELETEapiadminroomsRoommessagesId(ctx context.Context, room string, id string, params *DELETEapiadminroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiadminroomsRoommessagesIdRequest(c.Server, room, id, params)
 	if err != nil {
 		return nil, err
 	}
@@ -308,8 +960,8 @@ func (c *Client) GETapiroomssearch(ctx context.Context, params *GETapiroomssearc
 	return c.Client.Do(req)
 }
 
-func (c *Client) GETapiroomsRoommessages(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGETapiroomsRoommessagesRequest(c.Server, room, params)
+func (c *Client) POSTapiadminroomsRoompasswordWithBody(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminroomsRoompasswordRequestWithBody(c.Server, room, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -320,8 +972,8 @@ func (c *Client) GETapiroomsRoommessages(ctx context.Context, room string, param
 	return c.Client.Do(req)
 }
 
-func (c *Client) POSTapiroomsRoommessagesWithBody(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPOSTapiroomsRoommessagesRequestWithBody(c.Server, room, params, contentType, body)
+func (c *Client) POSTapiadminroomsRoompassword(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, body POSTapiadminroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminroomsRoompasswordRequest(c.Server, room, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -332,8 +984,8 @@ func (c *Client) POSTapiroomsRoommessagesWithBody(ctx context.Context, room stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) POSTapiroomsRoommessages(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPOSTapiroomsRoommessagesRequest(c.Server, room, params, body)
+func (c *Client) GETapiadminsanctions(ctx context.Context, params *GETapiadminsanctionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiadminsanctionsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -344,8 +996,8 @@ func (c *Client) POSTapiroomsRoommessages(ctx context.Context, room string, para
 	return c.Client.Do(req)
 }
 
-func (c *Client) GETapiserverInfo(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGETapiserverInfoRequest(c.Server, params)
+func (c *Client) POSTapiadminsanctionsWithBody(ctx context.Context, params *POSTapiadminsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminsanctionsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -356,8 +1008,8 @@ func (c *Client) GETapiserverInfo(ctx context.Context, params *GETapiserverInfoP
 	return c.Client.Do(req)
 }
 
-func (c *Client) GETapiusersPublicKey(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGETapiusersPublicKeyRequest(c.Server, publicKey, params)
+func (c *Client) POSTapiadminsanctions(ctx context.Context, params *POSTapiadminsanctionsParams, body POSTapiadminsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminsanctionsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -368,880 +1020,7995 @@ func (c *Client) GETapiusersPublicKey(ctx context.Context, publicKey string, par
 	return c.Client.Do(req)
 }
 
-// NewGETRequest generates requests for GET
-func NewGETRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DELETEapiadminsanctionsId(ctx context.Context, id string, params *DELETEapiadminsanctionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiadminsanctionsIdRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GETapiadminusers(ctx context.Context, params *GETapiadminusersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiadminusersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) POSTapiadminusersPublicKeyunverify(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyunverifyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminusersPublicKeyunverifyRequest(c.Server, publicKey, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGETapiroomsRequest generates requests for GETapirooms
-func NewGETapiroomsRequest(server string, params *GETapiroomsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) POSTapiadminusersPublicKeyverify(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyverifyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiadminusersPublicKeyverifyRequest(c.Server, publicKey, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/rooms")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GETapidms(ctx context.Context, params *GETapidmsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapidmsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Visited != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "visited", *params.Visited, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) POSTapidmsWithBody(ctx context.Context, params *POSTapidmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapidmsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPOSTapiroomsRequest calls the generic POSTapirooms builder with application/json body
-func NewPOSTapiroomsRequest(server string, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) POSTapidms(ctx context.Context, params *POSTapidmsParams, body POSTapidmsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapidmsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPOSTapiroomsRequestWithBody(server, params, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPOSTapiroomsRequestWithBody generates requests for POSTapirooms with any type of body
-func NewPOSTapiroomsRequestWithBody(server string, params *POSTapiroomsParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GETapinostr(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapinostrRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/rooms")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GETapirooms(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) POSTapiroomsWithBody(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGETapiroomssearchRequest generates requests for GETapiroomssearch
-func NewGETapiroomssearchRequest(server string, params *GETapiroomssearchParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) POSTapirooms(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/rooms/search")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GETapiroomssearch(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomssearchRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Visited != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "visited", *params.Visited, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.Q != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "q", *params.Q, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PUTapiroomsRoomdescriptionWithBody(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoomdescriptionRequestWithBody(c.Server, room, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGETapiroomsRoommessagesRequest generates requests for GETapiroomsRoommessages
-func NewGETapiroomsRoommessagesRequest(server string, room string, params *GETapiroomsRoommessagesParams) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+func (c *Client) PUTapiroomsRoomdescription(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, body PUTapiroomsRoomdescriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoomdescriptionRequest(c.Server, room, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GETapiroomsRoominvites(ctx context.Context, room string, params *GETapiroomsRoominvitesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoominvitesRequest(c.Server, room, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/rooms/%s/messages", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) POSTapiroomsRoominvitesWithBody(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoominvitesRequestWithBody(c.Server, room, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Password != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.Limit != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.Before != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "before", *params.Before, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) POSTapiroomsRoominvites(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, body POSTapiroomsRoominvitesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoominvitesRequest(c.Server, room, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPOSTapiroomsRoommessagesRequest calls the generic POSTapiroomsRoommessages builder with application/json body
-func NewPOSTapiroomsRoommessagesRequest(server string, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) DELETEapiroomsRoominvitesId(ctx context.Context, room string, id string, params *DELETEapiroomsRoominvitesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoominvitesIdRequest(c.Server, room, id, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPOSTapiroomsRoommessagesRequestWithBody(server, room, params, "application/json", bodyReader)
-}
-
-// NewPOSTapiroomsRoommessagesRequestWithBody generates requests for POSTapiroomsRoommessages with any type of body
-func NewPOSTapiroomsRoommessagesRequestWithBody(server string, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GETapiroomsRoommembers(ctx context.Context, room string, params *GETapiroomsRoommembersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommembersRequest(c.Server, room, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/rooms/%s/messages", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) DELETEapiroomsRoommembersPubkey(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoommembersPubkeyRequest(c.Server, room, pubkey, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) PUTapiroomsRoommembersPubkey(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoommembersPubkeyRequest(c.Server, room, pubkey, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGETapiserverInfoRequest generates requests for GETapiserverInfo
-func NewGETapiserverInfoRequest(server string, params *GETapiserverInfoParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GETapiroomsRoommessages(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommessagesRequest(c.Server, room, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/server-info")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) POSTapiroomsRoommessagesWithBody(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoommessagesRequestWithBody(c.Server, room, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) POSTapiroomsRoommessages(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoommessagesRequest(c.Server, room, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGETapiusersPublicKeyRequest generates requests for GETapiusersPublicKey
-func NewGETapiusersPublicKeyRequest(server string, publicKey string, params *GETapiusersPublicKeyParams) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "publicKey", publicKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+func (c *Client) GETapiroomsRoommessagespage(ctx context.Context, room string, params *GETapiroomsRoommessagespageParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommessagespageRequest(c.Server, room, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) DELETEapiroomsRoommessagesId(ctx context.Context, room string, id string, params *DELETEapiroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoommessagesIdRequest(c.Server, room, id, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/users/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PUTapiroomsRoommessagesIdWithBody(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoommessagesIdRequestWithBody(c.Server, room, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PUTapiroomsRoommessagesId(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, body PUTapiroomsRoommessagesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoommessagesIdRequest(c.Server, room, id, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-
-		if params.Accept != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("Accept", headerParam0)
-		}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) GETapiroomsRoommessagesIdreactions(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdreactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommessagesIdreactionsRequest(c.Server, room, id, params)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) POSTapiroomsRoommessagesIdreactionsWithBody(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoommessagesIdreactionsRequestWithBody(c.Server, room, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) POSTapiroomsRoommessagesIdreactions(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, body POSTapiroomsRoommessagesIdreactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoommessagesIdreactionsRequest(c.Server, room, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GETWithResponse request
-	GETWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETResponse, error)
-
-	// GETapiroomsWithResponse request
-	GETapiroomsWithResponse(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*GETapiroomsResponse, error)
-
-	// POSTapiroomsWithBodyWithResponse request with any body
-	POSTapiroomsWithBodyWithResponse(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error)
-
-	POSTapiroomsWithResponse(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error)
-
-	// GETapiroomssearchWithResponse request
-	GETapiroomssearchWithResponse(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*GETapiroomssearchResponse, error)
-
-	// GETapiroomsRoommessagesWithResponse request
-	GETapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesResponse, error)
-
-	// POSTapiroomsRoommessagesWithBodyWithResponse request with any body
-	POSTapiroomsRoommessagesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error)
-
-	POSTapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error)
-
-	// GETapiserverInfoWithResponse request
-	GETapiserverInfoWithResponse(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*GETapiserverInfoResponse, error)
-
-	// GETapiusersPublicKeyWithResponse request
-	GETapiusersPublicKeyWithResponse(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*GETapiusersPublicKeyResponse, error)
+func (c *Client) DELETEapiroomsRoommessagesIdreactionsEmoji(ctx context.Context, room string, id string, emoji string, params *DELETEapiroomsRoommessagesIdreactionsEmojiParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoommessagesIdreactionsEmojiRequest(c.Server, room, id, emoji, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GETResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *UnknownInterface
-	XML200       *UnknownInterface
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) GETapiroomsRoommessagesIdrevisions(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdrevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommessagesIdrevisionsRequest(c.Server, room, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GETResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) GETapiroomsRoommessagesIdthread(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdthreadParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoommessagesIdthreadRequest(c.Server, room, id, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) DELETEapiroomsRoommoderatorsPubkey(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoommoderatorsPubkeyRequest(c.Server, room, pubkey, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GETapiroomsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Room
-	XML200       *[]Room
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) PUTapiroomsRoommoderatorsPubkey(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoommoderatorsPubkeyRequest(c.Server, room, pubkey, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GETapiroomsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PUTapiroomsRoompasswordWithBody(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoompasswordRequestWithBody(c.Server, room, params, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETapiroomsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PUTapiroomsRoompassword(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, body PUTapiroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoompasswordRequest(c.Server, room, params, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type POSTapiroomsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Room
-	XML200       *Room
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) PUTapiroomsRoomretentionWithBody(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoomretentionRequestWithBody(c.Server, room, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r POSTapiroomsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PUTapiroomsRoomretention(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, body PUTapiroomsRoomretentionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPUTapiroomsRoomretentionRequest(c.Server, room, params, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r POSTapiroomsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GETapiroomsRoomsanctions(ctx context.Context, room string, params *GETapiroomsRoomsanctionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoomsanctionsRequest(c.Server, room, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GETapiroomssearchResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Room
-	XML200       *[]Room
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) POSTapiroomsRoomsanctionsWithBody(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoomsanctionsRequestWithBody(c.Server, room, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GETapiroomssearchResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) POSTapiroomsRoomsanctions(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, body POSTapiroomsRoomsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPOSTapiroomsRoomsanctionsRequest(c.Server, room, params, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETapiroomssearchResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) DELETEapiroomsRoomsanctionsId(ctx context.Context, room string, id string, params *DELETEapiroomsRoomsanctionsIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDELETEapiroomsRoomsanctionsIdRequest(c.Server, room, id, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GETapiroomsRoommessagesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Message
-	XML200       *[]Message
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) GETapiroomsRoomstream(ctx context.Context, room string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiroomsRoomstreamRequest(c.Server, room)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GETapiroomsRoommessagesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) GETapisearch(ctx context.Context, params *GETapisearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapisearchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETapiroomsRoommessagesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GETapiserverInfo(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiserverInfoRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type POSTapiroomsRoommessagesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Message
-	XML200       *Message
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+func (c *Client) GETapiusersme(ctx context.Context, params *GETapiusersmeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiusersmeRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r POSTapiroomsRoommessagesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) GETapiusersPublicKey(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiusersPublicKeyRequest(c.Server, publicKey, params)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r POSTapiroomsRoommessagesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GETapiws(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGETapiwsRequest(c.Server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GETapiserverInfoResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ServerInfoResponse
-	XML200       *ServerInfoResponse
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
-}
+// NewGETRequest generates requests for GET
+func NewGETRequest(server string) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r GETapiserverInfoResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETapiserverInfoResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type GETapiusersPublicKeyResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *User
-	XML200       *User
-	JSON400      *HTTPError
-	XML400       *HTTPError
-	JSON500      *HTTPError
-	XML500       *HTTPError
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GETapiusersPublicKeyResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPOSTapiadminchallengeRequest generates requests for POSTapiadminchallenge
+func NewPOSTapiadminchallengeRequest(server string, params *POSTapiadminchallengeParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	operationPath := fmt.Sprintf("/api/admin/challenge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Admin-Pubkey", params.XAdminPubkey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Admin-Pubkey", headerParam0)
+
+		if params.Accept != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam1)
+		}
+
+	}
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GETapiusersPublicKeyResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewGETapiadminretentionRequest generates requests for GETapiadminretention
+func NewGETapiadminretentionRequest(server string, params *GETapiadminretentionParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	operationPath := fmt.Sprintf("/api/admin/retention")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
 }
 
-// GETWithResponse request returning *GETResponse
-func (c *ClientWithResponses) GETWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETResponse, error) {
-	rsp, err := c.GET(ctx, reqEditors...)
+// NewDELETEapiadminroomsRoomRequest generates requests for DELETEapiadminroomsRoom
+func NewDELETEapiadminroomsRoomRequest(server string, room string, params *DELETEapiadminroomsRoomParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
 	if err != nil {
 		return nil, err
 	}
-	return ParseGETResponse(rsp)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/rooms/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
 }
 
-// GETapiroomsWithResponse request returning *GETapiroomsResponse
-func (c *ClientWithResponses) GETapiroomsWithResponse(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*GETapiroomsResponse, error) {
-	rsp, err := c.GETapirooms(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+// NewDELETEapiadminroomsRoommessagesIdRequest generates requests for DELETEapiadminroomsRoommessagesId
+func NewDELETEapiadminroomsRoommessagesIdRequest(server string, room string, id string, params *DELETEapiadminroomsRoommessagesIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/rooms/%s/messages/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiadminroomsRoompasswordRequest calls the generic POSTapiadminroomsRoompassword builder with application/json body
+func NewPOSTapiadminroomsRoompasswordRequest(server string, room string, params *POSTapiadminroomsRoompasswordParams, body POSTapiadminroomsRoompasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiadminroomsRoompasswordRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiadminroomsRoompasswordRequestWithBody generates requests for POSTapiadminroomsRoompassword with any type of body
+func NewPOSTapiadminroomsRoompasswordRequestWithBody(server string, room string, params *POSTapiadminroomsRoompasswordParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/rooms/%s/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiadminsanctionsRequest generates requests for GETapiadminsanctions
+func NewGETapiadminsanctionsRequest(server string, params *GETapiadminsanctionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/sanctions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiadminsanctionsRequest calls the generic POSTapiadminsanctions builder with application/json body
+func NewPOSTapiadminsanctionsRequest(server string, params *POSTapiadminsanctionsParams, body POSTapiadminsanctionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiadminsanctionsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiadminsanctionsRequestWithBody generates requests for POSTapiadminsanctions with any type of body
+func NewPOSTapiadminsanctionsRequestWithBody(server string, params *POSTapiadminsanctionsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/sanctions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiadminsanctionsIdRequest generates requests for DELETEapiadminsanctionsId
+func NewDELETEapiadminsanctionsIdRequest(server string, id string, params *DELETEapiadminsanctionsIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/sanctions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiadminusersRequest generates requests for GETapiadminusers
+func NewGETapiadminusersRequest(server string, params *GETapiadminusersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiadminusersPublicKeyunverifyRequest generates requests for POSTapiadminusersPublicKeyunverify
+func NewPOSTapiadminusersPublicKeyunverifyRequest(server string, publicKey string, params *POSTapiadminusersPublicKeyunverifyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "publicKey", publicKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/users/%s/unverify", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiadminusersPublicKeyverifyRequest generates requests for POSTapiadminusersPublicKeyverify
+func NewPOSTapiadminusersPublicKeyverifyRequest(server string, publicKey string, params *POSTapiadminusersPublicKeyverifyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "publicKey", publicKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/users/%s/verify", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapidmsRequest generates requests for GETapidms
+func NewGETapidmsRequest(server string, params *GETapidmsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/dms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.With != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "with", *params.With, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "before", *params.Before, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapidmsRequest calls the generic POSTapidms builder with application/json body
+func NewPOSTapidmsRequest(server string, params *POSTapidmsParams, body POSTapidmsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapidmsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPOSTapidmsRequestWithBody generates requests for POSTapidms with any type of body
+func NewPOSTapidmsRequestWithBody(server string, params *POSTapidmsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/dms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapinostrRequest generates requests for GETapinostr
+func NewGETapinostrRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/nostr")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRequest generates requests for GETapirooms
+func NewGETapiroomsRequest(server string, params *GETapiroomsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Visited != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "visited", *params.Visited, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiroomsRequest calls the generic POSTapirooms builder with application/json body
+func NewPOSTapiroomsRequest(server string, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiroomsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiroomsRequestWithBody generates requests for POSTapirooms with any type of body
+func NewPOSTapiroomsRequestWithBody(server string, params *POSTapiroomsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomssearchRequest generates requests for GETapiroomssearch
+func NewGETapiroomssearchRequest(server string, params *GETapiroomssearchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/search")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Visited != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "visited", *params.Visited, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "q", *params.Q, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoomdescriptionRequest calls the generic PUTapiroomsRoomdescription builder with application/json body
+func NewPUTapiroomsRoomdescriptionRequest(server string, room string, params *PUTapiroomsRoomdescriptionParams, body PUTapiroomsRoomdescriptionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPUTapiroomsRoomdescriptionRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPUTapiroomsRoomdescriptionRequestWithBody generates requests for PUTapiroomsRoomdescription with any type of body
+func NewPUTapiroomsRoomdescriptionRequestWithBody(server string, room string, params *PUTapiroomsRoomdescriptionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/description", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoominvitesRequest generates requests for GETapiroomsRoominvites
+func NewGETapiroomsRoominvitesRequest(server string, room string, params *GETapiroomsRoominvitesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiroomsRoominvitesRequest calls the generic POSTapiroomsRoominvites builder with application/json body
+func NewPOSTapiroomsRoominvitesRequest(server string, room string, params *POSTapiroomsRoominvitesParams, body POSTapiroomsRoominvitesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiroomsRoominvitesRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiroomsRoominvitesRequestWithBody generates requests for POSTapiroomsRoominvites with any type of body
+func NewPOSTapiroomsRoominvitesRequestWithBody(server string, room string, params *POSTapiroomsRoominvitesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/invites", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoominvitesIdRequest generates requests for DELETEapiroomsRoominvitesId
+func NewDELETEapiroomsRoominvitesIdRequest(server string, room string, id string, params *DELETEapiroomsRoominvitesIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/invites/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommembersRequest generates requests for GETapiroomsRoommembers
+func NewGETapiroomsRoommembersRequest(server string, room string, params *GETapiroomsRoommembersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoommembersPubkeyRequest generates requests for DELETEapiroomsRoommembersPubkey
+func NewDELETEapiroomsRoommembersPubkeyRequest(server string, room string, pubkey string, params *DELETEapiroomsRoommembersPubkeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "pubkey", pubkey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoommembersPubkeyRequest generates requests for PUTapiroomsRoommembersPubkey
+func NewPUTapiroomsRoommembersPubkeyRequest(server string, room string, pubkey string, params *PUTapiroomsRoommembersPubkeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "pubkey", pubkey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommessagesRequest generates requests for GETapiroomsRoommessages
+func NewGETapiroomsRoommessagesRequest(server string, room string, params *GETapiroomsRoommessagesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Invite != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "invite", *params.Invite, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "before", *params.Before, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "after", *params.After, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiroomsRoommessagesRequest calls the generic POSTapiroomsRoommessages builder with application/json body
+func NewPOSTapiroomsRoommessagesRequest(server string, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiroomsRoommessagesRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiroomsRoommessagesRequestWithBody generates requests for POSTapiroomsRoommessages with any type of body
+func NewPOSTapiroomsRoommessagesRequestWithBody(server string, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommessagespageRequest generates requests for GETapiroomsRoommessagespage
+func NewGETapiroomsRoommessagespageRequest(server string, room string, params *GETapiroomsRoommessagespageParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/page", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Invite != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "invite", *params.Invite, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "before", *params.Before, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "after", *params.After, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoommessagesIdRequest generates requests for DELETEapiroomsRoommessagesId
+func NewDELETEapiroomsRoommessagesIdRequest(server string, room string, id string, params *DELETEapiroomsRoommessagesIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoommessagesIdRequest calls the generic PUTapiroomsRoommessagesId builder with application/json body
+func NewPUTapiroomsRoommessagesIdRequest(server string, room string, id string, params *PUTapiroomsRoommessagesIdParams, body PUTapiroomsRoommessagesIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPUTapiroomsRoommessagesIdRequestWithBody(server, room, id, params, "application/json", bodyReader)
+}
+
+// NewPUTapiroomsRoommessagesIdRequestWithBody generates requests for PUTapiroomsRoommessagesId with any type of body
+func NewPUTapiroomsRoommessagesIdRequestWithBody(server string, room string, id string, params *PUTapiroomsRoommessagesIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommessagesIdreactionsRequest generates requests for GETapiroomsRoommessagesIdreactions
+func NewGETapiroomsRoommessagesIdreactionsRequest(server string, room string, id string, params *GETapiroomsRoommessagesIdreactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s/reactions", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiroomsRoommessagesIdreactionsRequest calls the generic POSTapiroomsRoommessagesIdreactions builder with application/json body
+func NewPOSTapiroomsRoommessagesIdreactionsRequest(server string, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, body POSTapiroomsRoommessagesIdreactionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiroomsRoommessagesIdreactionsRequestWithBody(server, room, id, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiroomsRoommessagesIdreactionsRequestWithBody generates requests for POSTapiroomsRoommessagesIdreactions with any type of body
+func NewPOSTapiroomsRoommessagesIdreactionsRequestWithBody(server string, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s/reactions", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoommessagesIdreactionsEmojiRequest generates requests for DELETEapiroomsRoommessagesIdreactionsEmoji
+func NewDELETEapiroomsRoommessagesIdreactionsEmojiRequest(server string, room string, id string, emoji string, params *DELETEapiroomsRoommessagesIdreactionsEmojiParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithOptions("simple", false, "emoji", emoji, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s/reactions/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommessagesIdrevisionsRequest generates requests for GETapiroomsRoommessagesIdrevisions
+func NewGETapiroomsRoommessagesIdrevisionsRequest(server string, room string, id string, params *GETapiroomsRoommessagesIdrevisionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s/revisions", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoommessagesIdthreadRequest generates requests for GETapiroomsRoommessagesIdthread
+func NewGETapiroomsRoommessagesIdthreadRequest(server string, room string, id string, params *GETapiroomsRoommessagesIdthreadParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/messages/%s/thread", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoommoderatorsPubkeyRequest generates requests for DELETEapiroomsRoommoderatorsPubkey
+func NewDELETEapiroomsRoommoderatorsPubkeyRequest(server string, room string, pubkey string, params *DELETEapiroomsRoommoderatorsPubkeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "pubkey", pubkey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/moderators/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoommoderatorsPubkeyRequest generates requests for PUTapiroomsRoommoderatorsPubkey
+func NewPUTapiroomsRoommoderatorsPubkeyRequest(server string, room string, pubkey string, params *PUTapiroomsRoommoderatorsPubkeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "pubkey", pubkey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/moderators/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoompasswordRequest calls the generic PUTapiroomsRoompassword builder with application/json body
+func NewPUTapiroomsRoompasswordRequest(server string, room string, params *PUTapiroomsRoompasswordParams, body PUTapiroomsRoompasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPUTapiroomsRoompasswordRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPUTapiroomsRoompasswordRequestWithBody generates requests for PUTapiroomsRoompassword with any type of body
+func NewPUTapiroomsRoompasswordRequestWithBody(server string, room string, params *PUTapiroomsRoompasswordParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPUTapiroomsRoomretentionRequest calls the generic PUTapiroomsRoomretention builder with application/json body
+func NewPUTapiroomsRoomretentionRequest(server string, room string, params *PUTapiroomsRoomretentionParams, body PUTapiroomsRoomretentionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPUTapiroomsRoomretentionRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPUTapiroomsRoomretentionRequestWithBody generates requests for PUTapiroomsRoomretention with any type of body
+func NewPUTapiroomsRoomretentionRequestWithBody(server string, room string, params *PUTapiroomsRoomretentionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/retention", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoomsanctionsRequest generates requests for GETapiroomsRoomsanctions
+func NewGETapiroomsRoomsanctionsRequest(server string, room string, params *GETapiroomsRoomsanctionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/sanctions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPOSTapiroomsRoomsanctionsRequest calls the generic POSTapiroomsRoomsanctions builder with application/json body
+func NewPOSTapiroomsRoomsanctionsRequest(server string, room string, params *POSTapiroomsRoomsanctionsParams, body POSTapiroomsRoomsanctionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPOSTapiroomsRoomsanctionsRequestWithBody(server, room, params, "application/json", bodyReader)
+}
+
+// NewPOSTapiroomsRoomsanctionsRequestWithBody generates requests for POSTapiroomsRoomsanctions with any type of body
+func NewPOSTapiroomsRoomsanctionsRequestWithBody(server string, room string, params *POSTapiroomsRoomsanctionsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/sanctions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDELETEapiroomsRoomsanctionsIdRequest generates requests for DELETEapiroomsRoomsanctionsId
+func NewDELETEapiroomsRoomsanctionsIdRequest(server string, room string, id string, params *DELETEapiroomsRoomsanctionsIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/sanctions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiroomsRoomstreamRequest generates requests for GETapiroomsRoomstream
+func NewGETapiroomsRoomstreamRequest(server string, room string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "room", room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/rooms/%s/stream", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGETapisearchRequest generates requests for GETapisearch
+func NewGETapisearchRequest(server string, params *GETapisearchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/search")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "q", *params.Q, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Room != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "room", *params.Room, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Author != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "author", *params.Author, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "before", *params.Before, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Password != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "password", *params.Password, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Invite != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "invite", *params.Invite, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiserverInfoRequest generates requests for GETapiserverInfo
+func NewGETapiserverInfoRequest(server string, params *GETapiserverInfoParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/server-info")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiusersmeRequest generates requests for GETapiusersme
+func NewGETapiusersmeRequest(server string, params *GETapiusersmeParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiusersPublicKeyRequest generates requests for GETapiusersPublicKey
+func NewGETapiusersPublicKeyRequest(server string, publicKey string, params *GETapiusersPublicKeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "publicKey", publicKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Accept != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Accept", *params.Accept, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Accept", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGETapiwsRequest generates requests for GETapiws
+func NewGETapiwsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/ws")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GETWithResponse request
+	GETWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETResponse, error)
+
+	// POSTapiadminchallengeWithResponse request
+	POSTapiadminchallengeWithResponse(ctx context.Context, params *POSTapiadminchallengeParams, reqEditors ...RequestEditorFn) (*POSTapiadminchallengeResponse, error)
+
+	// GETapiadminretentionWithResponse request
+	GETapiadminretentionWithResponse(ctx context.Context, params *GETapiadminretentionParams, reqEditors ...RequestEditorFn) (*GETapiadminretentionResponse, error)
+
+	// DELETEapiadminroomsRoomWithResponse request
+	DELETEapiadminroomsRoomWithResponse(ctx context.Context, room string, params *DELETEapiadminroomsRoomParams, reqEditors ...RequestEditorFn) (*DELETEapiadminroomsRoomResponse, error)
+
+	// DELETEapiadminroomsRoommessagesIdWithResponse request
+	DELETEapiadminroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiadminroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiadminroomsRoommessagesIdResponse, error)
+
+	// POSTapiadminroomsRoompasswordWithBodyWithResponse request with any body
+	POSTapiadminroomsRoompasswordWithBodyWithResponse(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiadminroomsRoompasswordResponse, error)
+
+	POSTapiadminroomsRoompasswordWithResponse(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, body POSTapiadminroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiadminroomsRoompasswordResponse, error)
+
+	// GETapiadminsanctionsWithResponse request
+	GETapiadminsanctionsWithResponse(ctx context.Context, params *GETapiadminsanctionsParams, reqEditors ...RequestEditorFn) (*GETapiadminsanctionsResponse, error)
+
+	// POSTapiadminsanctionsWithBodyWithResponse request with any body
+	POSTapiadminsanctionsWithBodyWithResponse(ctx context.Context, params *POSTapiadminsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiadminsanctionsResponse, error)
+
+	POSTapiadminsanctionsWithResponse(ctx context.Context, params *POSTapiadminsanctionsParams, body POSTapiadminsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiadminsanctionsResponse, error)
+
+	// DELETEapiadminsanctionsIdWithResponse request
+	DELETEapiadminsanctionsIdWithResponse(ctx context.Context, id string, params *DELETEapiadminsanctionsIdParams, reqEditors ...RequestEditorFn) (*DELETEapiadminsanctionsIdResponse, error)
+
+	// GETapiadminusersWithResponse request
+	GETapiadminusersWithResponse(ctx context.Context, params *GETapiadminusersParams, reqEditors ...RequestEditorFn) (*GETapiadminusersResponse, error)
+
+	// POSTapiadminusersPublicKeyunverifyWithResponse request
+	POSTapiadminusersPublicKeyunverifyWithResponse(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyunverifyParams, reqEditors ...RequestEditorFn) (*POSTapiadminusersPublicKeyunverifyResponse, error)
+
+	// POSTapiadminusersPublicKeyverifyWithResponse request
+	POSTapiadminusersPublicKeyverifyWithResponse(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyverifyParams, reqEditors ...RequestEditorFn) (*POSTapiadminusersPublicKeyverifyResponse, error)
+
+	// GETapidmsWithResponse request
+	GETapidmsWithResponse(ctx context.Context, params *GETapidmsParams, reqEditors ...RequestEditorFn) (*GETapidmsResponse, error)
+
+	// POSTapidmsWithBodyWithResponse request with any body
+	POSTapidmsWithBodyWithResponse(ctx context.Context, params *POSTapidmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapidmsResponse, error)
+
+	POSTapidmsWithResponse(ctx context.Context, params *POSTapidmsParams, body POSTapidmsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapidmsResponse, error)
+
+	// GETapinostrWithResponse request
+	GETapinostrWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETapinostrResponse, error)
+
+	// GETapiroomsWithResponse request
+	GETapiroomsWithResponse(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*GETapiroomsResponse, error)
+
+	// POSTapiroomsWithBodyWithResponse request with any body
+	POSTapiroomsWithBodyWithResponse(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error)
+
+	POSTapiroomsWithResponse(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error)
+
+	// GETapiroomssearchWithResponse request
+	GETapiroomssearchWithResponse(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*GETapiroomssearchResponse, error)
+
+	// PUTapiroomsRoomdescriptionWithBodyWithResponse request with any body
+	PUTapiroomsRoomdescriptionWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomdescriptionResponse, error)
+
+	PUTapiroomsRoomdescriptionWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, body PUTapiroomsRoomdescriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomdescriptionResponse, error)
+
+	// GETapiroomsRoominvitesWithResponse request
+	GETapiroomsRoominvitesWithResponse(ctx context.Context, room string, params *GETapiroomsRoominvitesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoominvitesResponse, error)
+
+	// POSTapiroomsRoominvitesWithBodyWithResponse request with any body
+	POSTapiroomsRoominvitesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoominvitesResponse, error)
+
+	POSTapiroomsRoominvitesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, body POSTapiroomsRoominvitesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoominvitesResponse, error)
+
+	// DELETEapiroomsRoominvitesIdWithResponse request
+	DELETEapiroomsRoominvitesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoominvitesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoominvitesIdResponse, error)
+
+	// GETapiroomsRoommembersWithResponse request
+	GETapiroomsRoommembersWithResponse(ctx context.Context, room string, params *GETapiroomsRoommembersParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommembersResponse, error)
+
+	// DELETEapiroomsRoommembersPubkeyWithResponse request
+	DELETEapiroomsRoommembersPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommembersPubkeyResponse, error)
+
+	// PUTapiroomsRoommembersPubkeyWithResponse request
+	PUTapiroomsRoommembersPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommembersPubkeyResponse, error)
+
+	// GETapiroomsRoommessagesWithResponse request
+	GETapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesResponse, error)
+
+	// POSTapiroomsRoommessagesWithBodyWithResponse request with any body
+	POSTapiroomsRoommessagesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error)
+
+	POSTapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error)
+
+	// GETapiroomsRoommessagespageWithResponse request
+	GETapiroomsRoommessagespageWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagespageParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagespageResponse, error)
+
+	// DELETEapiroomsRoommessagesIdWithResponse request
+	DELETEapiroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommessagesIdResponse, error)
+
+	// PUTapiroomsRoommessagesIdWithBodyWithResponse request with any body
+	PUTapiroomsRoommessagesIdWithBodyWithResponse(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommessagesIdResponse, error)
+
+	PUTapiroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, body PUTapiroomsRoommessagesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommessagesIdResponse, error)
+
+	// GETapiroomsRoommessagesIdreactionsWithResponse request
+	GETapiroomsRoommessagesIdreactionsWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdreactionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdreactionsResponse, error)
+
+	// POSTapiroomsRoommessagesIdreactionsWithBodyWithResponse request with any body
+	POSTapiroomsRoommessagesIdreactionsWithBodyWithResponse(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesIdreactionsResponse, error)
+
+	POSTapiroomsRoommessagesIdreactionsWithResponse(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, body POSTapiroomsRoommessagesIdreactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesIdreactionsResponse, error)
+
+	// DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse request
+	DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse(ctx context.Context, room string, id string, emoji string, params *DELETEapiroomsRoommessagesIdreactionsEmojiParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommessagesIdreactionsEmojiResponse, error)
+
+	// GETapiroomsRoommessagesIdrevisionsWithResponse request
+	GETapiroomsRoommessagesIdrevisionsWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdrevisionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdrevisionsResponse, error)
+
+	// GETapiroomsRoommessagesIdthreadWithResponse request
+	GETapiroomsRoommessagesIdthreadWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdthreadParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdthreadResponse, error)
+
+	// DELETEapiroomsRoommoderatorsPubkeyWithResponse request
+	DELETEapiroomsRoommoderatorsPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommoderatorsPubkeyResponse, error)
+
+	// PUTapiroomsRoommoderatorsPubkeyWithResponse request
+	PUTapiroomsRoommoderatorsPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommoderatorsPubkeyResponse, error)
+
+	// PUTapiroomsRoompasswordWithBodyWithResponse request with any body
+	PUTapiroomsRoompasswordWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoompasswordResponse, error)
+
+	PUTapiroomsRoompasswordWithResponse(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, body PUTapiroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoompasswordResponse, error)
+
+	// PUTapiroomsRoomretentionWithBodyWithResponse request with any body
+	PUTapiroomsRoomretentionWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomretentionResponse, error)
+
+	PUTapiroomsRoomretentionWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, body PUTapiroomsRoomretentionJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomretentionResponse, error)
+
+	// GETapiroomsRoomsanctionsWithResponse request
+	GETapiroomsRoomsanctionsWithResponse(ctx context.Context, room string, params *GETapiroomsRoomsanctionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoomsanctionsResponse, error)
+
+	// POSTapiroomsRoomsanctionsWithBodyWithResponse request with any body
+	POSTapiroomsRoomsanctionsWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoomsanctionsResponse, error)
+
+	POSTapiroomsRoomsanctionsWithResponse(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, body POSTapiroomsRoomsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoomsanctionsResponse, error)
+
+	// DELETEapiroomsRoomsanctionsIdWithResponse request
+	DELETEapiroomsRoomsanctionsIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoomsanctionsIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoomsanctionsIdResponse, error)
+
+	// GETapiroomsRoomstreamWithResponse request
+	GETapiroomsRoomstreamWithResponse(ctx context.Context, room string, reqEditors ...RequestEditorFn) (*GETapiroomsRoomstreamResponse, error)
+
+	// GETapisearchWithResponse request
+	GETapisearchWithResponse(ctx context.Context, params *GETapisearchParams, reqEditors ...RequestEditorFn) (*GETapisearchResponse, error)
+
+	// GETapiserverInfoWithResponse request
+	GETapiserverInfoWithResponse(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*GETapiserverInfoResponse, error)
+
+	// GETapiusersmeWithResponse request
+	GETapiusersmeWithResponse(ctx context.Context, params *GETapiusersmeParams, reqEditors ...RequestEditorFn) (*GETapiusersmeResponse, error)
+
+	// GETapiusersPublicKeyWithResponse request
+	GETapiusersPublicKeyWithResponse(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*GETapiusersPublicKeyResponse, error)
+
+	// GETapiwsWithResponse request
+	GETapiwsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETapiwsResponse, error)
+}
+
+type GETResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiadminchallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminChallenge
+	XML200       *AdminChallenge
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiadminchallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiadminchallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiadminretentionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RetentionStats
+	XML200       *RetentionStats
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiadminretentionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiadminretentionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiadminroomsRoomResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiadminroomsRoomResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiadminroomsRoomResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiadminroomsRoommessagesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	XML200       *Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiadminroomsRoommessagesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiadminroomsRoommessagesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiadminroomsRoompasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Room
+	XML200       *Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiadminroomsRoompasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiadminroomsRoompasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiadminsanctionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Sanction
+	XML200       *[]Sanction
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiadminsanctionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiadminsanctionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiadminsanctionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Sanction
+	XML200       *Sanction
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiadminsanctionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiadminsanctionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiadminsanctionsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiadminsanctionsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiadminsanctionsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiadminusersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]User
+	XML200       *[]User
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiadminusersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiadminusersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiadminusersPublicKeyunverifyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	XML200       *User
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiadminusersPublicKeyunverifyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiadminusersPublicKeyunverifyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiadminusersPublicKeyverifyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	XML200       *User
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiadminusersPublicKeyverifyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiadminusersPublicKeyverifyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapidmsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]DirectMessage
+	XML200       *[]DirectMessage
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapidmsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapidmsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapidmsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DirectMessage
+	XML200       *DirectMessage
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapidmsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapidmsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapinostrResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapinostrResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapinostrResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Room
+	XML200       *[]Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiroomsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Room
+	XML200       *Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiroomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiroomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomssearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Room
+	XML200       *[]Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomssearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomssearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoomdescriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Room
+	XML200       *Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoomdescriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoomdescriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoominvitesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Invite
+	XML200       *[]Invite
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoominvitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoominvitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiroomsRoominvitesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Invite
+	XML200       *Invite
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiroomsRoominvitesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiroomsRoominvitesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoominvitesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoominvitesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoominvitesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommembersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoomMember
+	XML200       *[]RoomMember
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommembersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommembersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoommembersPubkeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoomMember
+	XML200       *[]RoomMember
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoommembersPubkeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoommembersPubkeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoommembersPubkeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoomMember
+	XML200       *[]RoomMember
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoommembersPubkeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoommembersPubkeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommessagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Message
+	XML200       *[]Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommessagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommessagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiroomsRoommessagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	XML200       *Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiroomsRoommessagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiroomsRoommessagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommessagespageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessagePage
+	XML200       *MessagePage
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommessagespageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommessagespageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoommessagesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	XML200       *Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoommessagesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoommessagesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoommessagesIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Message
+	XML200       *Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoommessagesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoommessagesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommessagesIdreactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Reaction
+	XML200       *[]Reaction
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommessagesIdreactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommessagesIdreactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiroomsRoommessagesIdreactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ReactionCount
+	XML200       *[]ReactionCount
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiroomsRoommessagesIdreactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiroomsRoommessagesIdreactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoommessagesIdreactionsEmojiResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ReactionCount
+	XML200       *[]ReactionCount
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoommessagesIdreactionsEmojiResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoommessagesIdreactionsEmojiResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommessagesIdrevisionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]MessageRevision
+	XML200       *[]MessageRevision
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommessagesIdrevisionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommessagesIdrevisionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoommessagesIdthreadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageThread
+	XML200       *MessageThread
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoommessagesIdthreadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoommessagesIdthreadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoommoderatorsPubkeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoomMember
+	XML200       *[]RoomMember
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoommoderatorsPubkeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoommoderatorsPubkeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoommoderatorsPubkeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoomMember
+	XML200       *[]RoomMember
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoommoderatorsPubkeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoommoderatorsPubkeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoompasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Room
+	XML200       *Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoompasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoompasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PUTapiroomsRoomretentionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Room
+	XML200       *Room
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r PUTapiroomsRoomretentionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PUTapiroomsRoomretentionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoomsanctionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Sanction
+	XML200       *[]Sanction
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoomsanctionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoomsanctionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type POSTapiroomsRoomsanctionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Sanction
+	XML200       *Sanction
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r POSTapiroomsRoomsanctionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r POSTapiroomsRoomsanctionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DELETEapiroomsRoomsanctionsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r DELETEapiroomsRoomsanctionsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DELETEapiroomsRoomsanctionsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiroomsRoomstreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiroomsRoomstreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiroomsRoomstreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapisearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Message
+	XML200       *[]Message
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapisearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapisearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiserverInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ServerInfoResponse
+	XML200       *ServerInfoResponse
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiserverInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiserverInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiusersmeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserWithPostCount
+	XML200       *UserWithPostCount
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiusersmeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiusersmeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiusersPublicKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	XML200       *User
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiusersPublicKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiusersPublicKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GETapiwsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UnknownInterface
+	XML200       *UnknownInterface
+	JSON400      *HTTPError
+	XML400       *HTTPError
+	JSON500      *HTTPError
+	XML500       *HTTPError
+}
+
+// Status returns HTTPResponse.Status
+func (r GETapiwsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GETapiwsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GETWithResponse request returning *GETResponse
+func (c *ClientWithResponses) GETWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETResponse, error) {
+	rsp, err := c.GET(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETResponse(rsp)
+}
+
+// POSTapiadminchallengeWithResponse request returning *POSTapiadminchallengeResponse
+func (c *ClientWithResponses) POSTapiadminchallengeWithResponse(ctx context.Context, params *POSTapiadminchallengeParams, reqEditors ...RequestEditorFn) (*POSTapiadminchallengeResponse, error) {
+	rsp, err := c.POSTapiadminchallenge(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminchallengeResponse(rsp)
+}
+
+// GETapiadminretentionWithResponse request returning *GETapiadminretentionResponse
+func (c *ClientWithResponses) GETapiadminretentionWithResponse(ctx context.Context, params *GETapiadminretentionParams, reqEditors ...RequestEditorFn) (*GETapiadminretentionResponse, error) {
+	rsp, err := c.GETapiadminretention(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiadminretentionResponse(rsp)
+}
+
+// DELETEapiadminroomsRoomWithResponse request returning *DELETEapiadminroomsRoomResponse
+func (c *ClientWithResponses) DELETEapiadminroomsRoomWithResponse(ctx context.Context, room string, params *DELETEapiadminroomsRoomParams, reqEditors ...RequestEditorFn) (*DELETEapiadminroomsRoomResponse, error) {
+	rsp, err := c.DELETEapiadminroomsRoom(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiadminroomsRoomResponse(rsp)
+}
+
+// DELETEapiadminroomsRoommessagesIdWithResponse request returning *DELETEapiadminroomsRoommessagesIdResponse
+func (c *ClientWithResponses) DELETEapiadminroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiadminroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiadminroomsRoommessagesIdResponse, error) {
+	rsp, err := c.DELETEapiadminroomsRoommessagesId(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiadminroomsRoommessagesIdResponse(rsp)
+}
+
+// POSTapiadminroomsRoompasswordWithBodyWithResponse request with arbitrary body returning *POSTapiadminroomsRoompasswordResponse
+func (c *ClientWithResponses) POSTapiadminroomsRoompasswordWithBodyWithResponse(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiadminroomsRoompasswordResponse, error) {
+	rsp, err := c.POSTapiadminroomsRoompasswordWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminroomsRoompasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiadminroomsRoompasswordWithResponse(ctx context.Context, room string, params *POSTapiadminroomsRoompasswordParams, body POSTapiadminroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiadminroomsRoompasswordResponse, error) {
+	rsp, err := c.POSTapiadminroomsRoompassword(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminroomsRoompasswordResponse(rsp)
+}
+
+// GETapiadminsanctionsWithResponse request returning *GETapiadminsanctionsResponse
+func (c *ClientWithResponses) GETapiadminsanctionsWithResponse(ctx context.Context, params *GETapiadminsanctionsParams, reqEditors ...RequestEditorFn) (*GETapiadminsanctionsResponse, error) {
+	rsp, err := c.GETapiadminsanctions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiadminsanctionsResponse(rsp)
+}
+
+// POSTapiadminsanctionsWithBodyWithResponse request with arbitrary body returning *POSTapiadminsanctionsResponse
+func (c *ClientWithResponses) POSTapiadminsanctionsWithBodyWithResponse(ctx context.Context, params *POSTapiadminsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiadminsanctionsResponse, error) {
+	rsp, err := c.POSTapiadminsanctionsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminsanctionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiadminsanctionsWithResponse(ctx context.Context, params *POSTapiadminsanctionsParams, body POSTapiadminsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiadminsanctionsResponse, error) {
+	rsp, err := c.POSTapiadminsanctions(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminsanctionsResponse(rsp)
+}
+
+// DELETEapiadminsanctionsIdWithResponse request returning *DELETEapiadminsanctionsIdResponse
+func (c *ClientWithResponses) DELETEapiadminsanctionsIdWithResponse(ctx context.Context, id string, params *DELETEapiadminsanctionsIdParams, reqEditors ...RequestEditorFn) (*DELETEapiadminsanctionsIdResponse, error) {
+	rsp, err := c.DELETEapiadminsanctionsId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiadminsanctionsIdResponse(rsp)
+}
+
+// GETapiadminusersWithResponse request returning *GETapiadminusersResponse
+func (c *ClientWithResponses) GETapiadminusersWithResponse(ctx context.Context, params *GETapiadminusersParams, reqEditors ...RequestEditorFn) (*GETapiadminusersResponse, error) {
+	rsp, err := c.GETapiadminusers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiadminusersResponse(rsp)
+}
+
+// POSTapiadminusersPublicKeyunverifyWithResponse request returning *POSTapiadminusersPublicKeyunverifyResponse
+func (c *ClientWithResponses) POSTapiadminusersPublicKeyunverifyWithResponse(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyunverifyParams, reqEditors ...RequestEditorFn) (*POSTapiadminusersPublicKeyunverifyResponse, error) {
+	rsp, err := c.POSTapiadminusersPublicKeyunverify(ctx, publicKey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminusersPublicKeyunverifyResponse(rsp)
+}
+
+// POSTapiadminusersPublicKeyverifyWithResponse request returning *POSTapiadminusersPublicKeyverifyResponse
+func (c *ClientWithResponses) POSTapiadminusersPublicKeyverifyWithResponse(ctx context.Context, publicKey string, params *POSTapiadminusersPublicKeyverifyParams, reqEditors ...RequestEditorFn) (*POSTapiadminusersPublicKeyverifyResponse, error) {
+	rsp, err := c.POSTapiadminusersPublicKeyverify(ctx, publicKey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiadminusersPublicKeyverifyResponse(rsp)
+}
+
+// GETapidmsWithResponse request returning *GETapidmsResponse
+func (c *ClientWithResponses) GETapidmsWithResponse(ctx context.Context, params *GETapidmsParams, reqEditors ...RequestEditorFn) (*GETapidmsResponse, error) {
+	rsp, err := c.GETapidms(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapidmsResponse(rsp)
+}
+
+// POSTapidmsWithBodyWithResponse request with arbitrary body returning *POSTapidmsResponse
+func (c *ClientWithResponses) POSTapidmsWithBodyWithResponse(ctx context.Context, params *POSTapidmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapidmsResponse, error) {
+	rsp, err := c.POSTapidmsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapidmsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapidmsWithResponse(ctx context.Context, params *POSTapidmsParams, body POSTapidmsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapidmsResponse, error) {
+	rsp, err := c.POSTapidms(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapidmsResponse(rsp)
+}
+
+// GETapinostrWithResponse request returning *GETapinostrResponse
+func (c *ClientWithResponses) GETapinostrWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETapinostrResponse, error) {
+	rsp, err := c.GETapinostr(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapinostrResponse(rsp)
+}
+
+// GETapiroomsWithResponse request returning *GETapiroomsResponse
+func (c *ClientWithResponses) GETapiroomsWithResponse(ctx context.Context, params *GETapiroomsParams, reqEditors ...RequestEditorFn) (*GETapiroomsResponse, error) {
+	rsp, err := c.GETapirooms(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsResponse(rsp)
+}
+
+// POSTapiroomsWithBodyWithResponse request with arbitrary body returning *POSTapiroomsResponse
+func (c *ClientWithResponses) POSTapiroomsWithBodyWithResponse(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error) {
+	rsp, err := c.POSTapiroomsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiroomsWithResponse(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error) {
+	rsp, err := c.POSTapirooms(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsResponse(rsp)
+}
+
+// GETapiroomssearchWithResponse request returning *GETapiroomssearchResponse
+func (c *ClientWithResponses) GETapiroomssearchWithResponse(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*GETapiroomssearchResponse, error) {
+	rsp, err := c.GETapiroomssearch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomssearchResponse(rsp)
+}
+
+// PUTapiroomsRoomdescriptionWithBodyWithResponse request with arbitrary body returning *PUTapiroomsRoomdescriptionResponse
+func (c *ClientWithResponses) PUTapiroomsRoomdescriptionWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomdescriptionResponse, error) {
+	rsp, err := c.PUTapiroomsRoomdescriptionWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoomdescriptionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PUTapiroomsRoomdescriptionWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomdescriptionParams, body PUTapiroomsRoomdescriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomdescriptionResponse, error) {
+	rsp, err := c.PUTapiroomsRoomdescription(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoomdescriptionResponse(rsp)
+}
+
+// GETapiroomsRoominvitesWithResponse request returning *GETapiroomsRoominvitesResponse
+func (c *ClientWithResponses) GETapiroomsRoominvitesWithResponse(ctx context.Context, room string, params *GETapiroomsRoominvitesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoominvitesResponse, error) {
+	rsp, err := c.GETapiroomsRoominvites(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoominvitesResponse(rsp)
+}
+
+// POSTapiroomsRoominvitesWithBodyWithResponse request with arbitrary body returning *POSTapiroomsRoominvitesResponse
+func (c *ClientWithResponses) POSTapiroomsRoominvitesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoominvitesResponse, error) {
+	rsp, err := c.POSTapiroomsRoominvitesWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoominvitesResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiroomsRoominvitesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoominvitesParams, body POSTapiroomsRoominvitesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoominvitesResponse, error) {
+	rsp, err := c.POSTapiroomsRoominvites(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoominvitesResponse(rsp)
+}
+
+// DELETEapiroomsRoominvitesIdWithResponse request returning *DELETEapiroomsRoominvitesIdResponse
+func (c *ClientWithResponses) DELETEapiroomsRoominvitesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoominvitesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoominvitesIdResponse, error) {
+	rsp, err := c.DELETEapiroomsRoominvitesId(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoominvitesIdResponse(rsp)
+}
+
+// GETapiroomsRoommembersWithResponse request returning *GETapiroomsRoommembersResponse
+func (c *ClientWithResponses) GETapiroomsRoommembersWithResponse(ctx context.Context, room string, params *GETapiroomsRoommembersParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommembersResponse, error) {
+	rsp, err := c.GETapiroomsRoommembers(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommembersResponse(rsp)
+}
+
+// DELETEapiroomsRoommembersPubkeyWithResponse request returning *DELETEapiroomsRoommembersPubkeyResponse
+func (c *ClientWithResponses) DELETEapiroomsRoommembersPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommembersPubkeyResponse, error) {
+	rsp, err := c.DELETEapiroomsRoommembersPubkey(ctx, room, pubkey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoommembersPubkeyResponse(rsp)
+}
+
+// PUTapiroomsRoommembersPubkeyWithResponse request returning *PUTapiroomsRoommembersPubkeyResponse
+func (c *ClientWithResponses) PUTapiroomsRoommembersPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommembersPubkeyParams, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommembersPubkeyResponse, error) {
+	rsp, err := c.PUTapiroomsRoommembersPubkey(ctx, room, pubkey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoommembersPubkeyResponse(rsp)
+}
+
+// GETapiroomsRoommessagesWithResponse request returning *GETapiroomsRoommessagesResponse
+func (c *ClientWithResponses) GETapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesResponse, error) {
+	rsp, err := c.GETapiroomsRoommessages(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommessagesResponse(rsp)
+}
+
+// POSTapiroomsRoommessagesWithBodyWithResponse request with arbitrary body returning *POSTapiroomsRoommessagesResponse
+func (c *ClientWithResponses) POSTapiroomsRoommessagesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error) {
+	rsp, err := c.POSTapiroomsRoommessagesWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoommessagesResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error) {
+	rsp, err := c.POSTapiroomsRoommessages(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoommessagesResponse(rsp)
+}
+
+// GETapiroomsRoommessagespageWithResponse request returning *GETapiroomsRoommessagespageResponse
+func (c *ClientWithResponses) GETapiroomsRoommessagespageWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagespageParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagespageResponse, error) {
+	rsp, err := c.GETapiroomsRoommessagespage(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommessagespageResponse(rsp)
+}
+
+// DELETEapiroomsRoommessagesIdWithResponse request returning *DELETEapiroomsRoommessagesIdResponse
+func (c *ClientWithResponses) DELETEapiroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoommessagesIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommessagesIdResponse, error) {
+	rsp, err := c.DELETEapiroomsRoommessagesId(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoommessagesIdResponse(rsp)
+}
+
+// PUTapiroomsRoommessagesIdWithBodyWithResponse request with arbitrary body returning *PUTapiroomsRoommessagesIdResponse
+func (c *ClientWithResponses) PUTapiroomsRoommessagesIdWithBodyWithResponse(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommessagesIdResponse, error) {
+	rsp, err := c.PUTapiroomsRoommessagesIdWithBody(ctx, room, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoommessagesIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PUTapiroomsRoommessagesIdWithResponse(ctx context.Context, room string, id string, params *PUTapiroomsRoommessagesIdParams, body PUTapiroomsRoommessagesIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommessagesIdResponse, error) {
+	rsp, err := c.PUTapiroomsRoommessagesId(ctx, room, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoommessagesIdResponse(rsp)
+}
+
+// GETapiroomsRoommessagesIdreactionsWithResponse request returning *GETapiroomsRoommessagesIdreactionsResponse
+func (c *ClientWithResponses) GETapiroomsRoommessagesIdreactionsWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdreactionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdreactionsResponse, error) {
+	rsp, err := c.GETapiroomsRoommessagesIdreactions(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommessagesIdreactionsResponse(rsp)
+}
+
+// POSTapiroomsRoommessagesIdreactionsWithBodyWithResponse request with arbitrary body returning *POSTapiroomsRoommessagesIdreactionsResponse
+func (c *ClientWithResponses) POSTapiroomsRoommessagesIdreactionsWithBodyWithResponse(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesIdreactionsResponse, error) {
+	rsp, err := c.POSTapiroomsRoommessagesIdreactionsWithBody(ctx, room, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoommessagesIdreactionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiroomsRoommessagesIdreactionsWithResponse(ctx context.Context, room string, id string, params *POSTapiroomsRoommessagesIdreactionsParams, body POSTapiroomsRoommessagesIdreactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesIdreactionsResponse, error) {
+	rsp, err := c.POSTapiroomsRoommessagesIdreactions(ctx, room, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoommessagesIdreactionsResponse(rsp)
+}
+
+// DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse request returning *DELETEapiroomsRoommessagesIdreactionsEmojiResponse
+func (c *ClientWithResponses) DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse(ctx context.Context, room string, id string, emoji string, params *DELETEapiroomsRoommessagesIdreactionsEmojiParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommessagesIdreactionsEmojiResponse, error) {
+	rsp, err := c.DELETEapiroomsRoommessagesIdreactionsEmoji(ctx, room, id, emoji, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoommessagesIdreactionsEmojiResponse(rsp)
+}
+
+// GETapiroomsRoommessagesIdrevisionsWithResponse request returning *GETapiroomsRoommessagesIdrevisionsResponse
+func (c *ClientWithResponses) GETapiroomsRoommessagesIdrevisionsWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdrevisionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdrevisionsResponse, error) {
+	rsp, err := c.GETapiroomsRoommessagesIdrevisions(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommessagesIdrevisionsResponse(rsp)
+}
+
+// GETapiroomsRoommessagesIdthreadWithResponse request returning *GETapiroomsRoommessagesIdthreadResponse
+func (c *ClientWithResponses) GETapiroomsRoommessagesIdthreadWithResponse(ctx context.Context, room string, id string, params *GETapiroomsRoommessagesIdthreadParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesIdthreadResponse, error) {
+	rsp, err := c.GETapiroomsRoommessagesIdthread(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoommessagesIdthreadResponse(rsp)
+}
+
+// DELETEapiroomsRoommoderatorsPubkeyWithResponse request returning *DELETEapiroomsRoommoderatorsPubkeyResponse
+func (c *ClientWithResponses) DELETEapiroomsRoommoderatorsPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *DELETEapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoommoderatorsPubkeyResponse, error) {
+	rsp, err := c.DELETEapiroomsRoommoderatorsPubkey(ctx, room, pubkey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoommoderatorsPubkeyResponse(rsp)
+}
+
+// PUTapiroomsRoommoderatorsPubkeyWithResponse request returning *PUTapiroomsRoommoderatorsPubkeyResponse
+func (c *ClientWithResponses) PUTapiroomsRoommoderatorsPubkeyWithResponse(ctx context.Context, room string, pubkey string, params *PUTapiroomsRoommoderatorsPubkeyParams, reqEditors ...RequestEditorFn) (*PUTapiroomsRoommoderatorsPubkeyResponse, error) {
+	rsp, err := c.PUTapiroomsRoommoderatorsPubkey(ctx, room, pubkey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoommoderatorsPubkeyResponse(rsp)
+}
+
+// PUTapiroomsRoompasswordWithBodyWithResponse request with arbitrary body returning *PUTapiroomsRoompasswordResponse
+func (c *ClientWithResponses) PUTapiroomsRoompasswordWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoompasswordResponse, error) {
+	rsp, err := c.PUTapiroomsRoompasswordWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoompasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) PUTapiroomsRoompasswordWithResponse(ctx context.Context, room string, params *PUTapiroomsRoompasswordParams, body PUTapiroomsRoompasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoompasswordResponse, error) {
+	rsp, err := c.PUTapiroomsRoompassword(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoompasswordResponse(rsp)
+}
+
+// PUTapiroomsRoomretentionWithBodyWithResponse request with arbitrary body returning *PUTapiroomsRoomretentionResponse
+func (c *ClientWithResponses) PUTapiroomsRoomretentionWithBodyWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomretentionResponse, error) {
+	rsp, err := c.PUTapiroomsRoomretentionWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoomretentionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PUTapiroomsRoomretentionWithResponse(ctx context.Context, room string, params *PUTapiroomsRoomretentionParams, body PUTapiroomsRoomretentionJSONRequestBody, reqEditors ...RequestEditorFn) (*PUTapiroomsRoomretentionResponse, error) {
+	rsp, err := c.PUTapiroomsRoomretention(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePUTapiroomsRoomretentionResponse(rsp)
+}
+
+// GETapiroomsRoomsanctionsWithResponse request returning *GETapiroomsRoomsanctionsResponse
+func (c *ClientWithResponses) GETapiroomsRoomsanctionsWithResponse(ctx context.Context, room string, params *GETapiroomsRoomsanctionsParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoomsanctionsResponse, error) {
+	rsp, err := c.GETapiroomsRoomsanctions(ctx, room, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoomsanctionsResponse(rsp)
+}
+
+// POSTapiroomsRoomsanctionsWithBodyWithResponse request with arbitrary body returning *POSTapiroomsRoomsanctionsResponse
+func (c *ClientWithResponses) POSTapiroomsRoomsanctionsWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoomsanctionsResponse, error) {
+	rsp, err := c.POSTapiroomsRoomsanctionsWithBody(ctx, room, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoomsanctionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) POSTapiroomsRoomsanctionsWithResponse(ctx context.Context, room string, params *POSTapiroomsRoomsanctionsParams, body POSTapiroomsRoomsanctionsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoomsanctionsResponse, error) {
+	rsp, err := c.POSTapiroomsRoomsanctions(ctx, room, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePOSTapiroomsRoomsanctionsResponse(rsp)
+}
+
+// DELETEapiroomsRoomsanctionsIdWithResponse request returning *DELETEapiroomsRoomsanctionsIdResponse
+func (c *ClientWithResponses) DELETEapiroomsRoomsanctionsIdWithResponse(ctx context.Context, room string, id string, params *DELETEapiroomsRoomsanctionsIdParams, reqEditors ...RequestEditorFn) (*DELETEapiroomsRoomsanctionsIdResponse, error) {
+	rsp, err := c.DELETEapiroomsRoomsanctionsId(ctx, room, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDELETEapiroomsRoomsanctionsIdResponse(rsp)
+}
+
+// GETapiroomsRoomstreamWithResponse request returning *GETapiroomsRoomstreamResponse
+func (c *ClientWithResponses) GETapiroomsRoomstreamWithResponse(ctx context.Context, room string, reqEditors ...RequestEditorFn) (*GETapiroomsRoomstreamResponse, error) {
+	rsp, err := c.GETapiroomsRoomstream(ctx, room, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiroomsRoomstreamResponse(rsp)
+}
+
+// GETapisearchWithResponse request returning *GETapisearchResponse
+func (c *ClientWithResponses) GETapisearchWithResponse(ctx context.Context, params *GETapisearchParams, reqEditors ...RequestEditorFn) (*GETapisearchResponse, error) {
+	rsp, err := c.GETapisearch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapisearchResponse(rsp)
+}
+
+// GETapiserverInfoWithResponse request returning *GETapiserverInfoResponse
+func (c *ClientWithResponses) GETapiserverInfoWithResponse(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*GETapiserverInfoResponse, error) {
+	rsp, err := c.GETapiserverInfo(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiserverInfoResponse(rsp)
+}
+
+// GETapiusersmeWithResponse request returning *GETapiusersmeResponse
+func (c *ClientWithResponses) GETapiusersmeWithResponse(ctx context.Context, params *GETapiusersmeParams, reqEditors ...RequestEditorFn) (*GETapiusersmeResponse, error) {
+	rsp, err := c.GETapiusersme(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiusersmeResponse(rsp)
+}
+
+// GETapiusersPublicKeyWithResponse request returning *GETapiusersPublicKeyResponse
+func (c *ClientWithResponses) GETapiusersPublicKeyWithResponse(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*GETapiusersPublicKeyResponse, error) {
+	rsp, err := c.GETapiusersPublicKey(ctx, publicKey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiusersPublicKeyResponse(rsp)
+}
+
+// GETapiwsWithResponse request returning *GETapiwsResponse
+func (c *ClientWithResponses) GETapiwsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GETapiwsResponse, error) {
+	rsp, err := c.GETapiws(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGETapiwsResponse(rsp)
+}
+
+// ParseGETResponse parses an HTTP response from a GETWithResponse call
+func ParseGETResponse(rsp *http.Response) (*GETResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiadminchallengeResponse parses an HTTP response from a POSTapiadminchallengeWithResponse call
+func ParsePOSTapiadminchallengeResponse(rsp *http.Response) (*POSTapiadminchallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiadminchallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminChallenge
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest AdminChallenge
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiadminretentionResponse parses an HTTP response from a GETapiadminretentionWithResponse call
+func ParseGETapiadminretentionResponse(rsp *http.Response) (*GETapiadminretentionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiadminretentionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RetentionStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest RetentionStats
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiadminroomsRoomResponse parses an HTTP response from a DELETEapiadminroomsRoomWithResponse call
+func ParseDELETEapiadminroomsRoomResponse(rsp *http.Response) (*DELETEapiadminroomsRoomResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiadminroomsRoomResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiadminroomsRoommessagesIdResponse parses an HTTP response from a DELETEapiadminroomsRoommessagesIdWithResponse call
+func ParseDELETEapiadminroomsRoommessagesIdResponse(rsp *http.Response) (*DELETEapiadminroomsRoommessagesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiadminroomsRoommessagesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Message
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiadminroomsRoompasswordResponse parses an HTTP response from a POSTapiadminroomsRoompasswordWithResponse call
+func ParsePOSTapiadminroomsRoompasswordResponse(rsp *http.Response) (*POSTapiadminroomsRoompasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiadminroomsRoompasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiadminsanctionsResponse parses an HTTP response from a GETapiadminsanctionsWithResponse call
+func ParseGETapiadminsanctionsResponse(rsp *http.Response) (*GETapiadminsanctionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiadminsanctionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Sanction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Sanction
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiadminsanctionsResponse parses an HTTP response from a POSTapiadminsanctionsWithResponse call
+func ParsePOSTapiadminsanctionsResponse(rsp *http.Response) (*POSTapiadminsanctionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiadminsanctionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Sanction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Sanction
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiadminsanctionsIdResponse parses an HTTP response from a DELETEapiadminsanctionsIdWithResponse call
+func ParseDELETEapiadminsanctionsIdResponse(rsp *http.Response) (*DELETEapiadminsanctionsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiadminsanctionsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiadminusersResponse parses an HTTP response from a GETapiadminusersWithResponse call
+func ParseGETapiadminusersResponse(rsp *http.Response) (*GETapiadminusersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiadminusersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []User
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiadminusersPublicKeyunverifyResponse parses an HTTP response from a POSTapiadminusersPublicKeyunverifyWithResponse call
+func ParsePOSTapiadminusersPublicKeyunverifyResponse(rsp *http.Response) (*POSTapiadminusersPublicKeyunverifyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiadminusersPublicKeyunverifyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest User
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiadminusersPublicKeyverifyResponse parses an HTTP response from a POSTapiadminusersPublicKeyverifyWithResponse call
+func ParsePOSTapiadminusersPublicKeyverifyResponse(rsp *http.Response) (*POSTapiadminusersPublicKeyverifyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiadminusersPublicKeyverifyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest User
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapidmsResponse parses an HTTP response from a GETapidmsWithResponse call
+func ParseGETapidmsResponse(rsp *http.Response) (*GETapidmsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapidmsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []DirectMessage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []DirectMessage
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapidmsResponse parses an HTTP response from a POSTapidmsWithResponse call
+func ParsePOSTapidmsResponse(rsp *http.Response) (*POSTapidmsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapidmsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DirectMessage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest DirectMessage
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapinostrResponse parses an HTTP response from a GETapinostrWithResponse call
+func ParseGETapinostrResponse(rsp *http.Response) (*GETapinostrResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapinostrResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsResponse parses an HTTP response from a GETapiroomsWithResponse call
+func ParseGETapiroomsResponse(rsp *http.Response) (*GETapiroomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiroomsResponse parses an HTTP response from a POSTapiroomsWithResponse call
+func ParsePOSTapiroomsResponse(rsp *http.Response) (*POSTapiroomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiroomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomssearchResponse parses an HTTP response from a GETapiroomssearchWithResponse call
+func ParseGETapiroomssearchResponse(rsp *http.Response) (*GETapiroomssearchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomssearchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePUTapiroomsRoomdescriptionResponse parses an HTTP response from a PUTapiroomsRoomdescriptionWithResponse call
+func ParsePUTapiroomsRoomdescriptionResponse(rsp *http.Response) (*PUTapiroomsRoomdescriptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PUTapiroomsRoomdescriptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoominvitesResponse parses an HTTP response from a GETapiroomsRoominvitesWithResponse call
+func ParseGETapiroomsRoominvitesResponse(rsp *http.Response) (*GETapiroomsRoominvitesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoominvitesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Invite
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Invite
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiroomsRoominvitesResponse parses an HTTP response from a POSTapiroomsRoominvitesWithResponse call
+func ParsePOSTapiroomsRoominvitesResponse(rsp *http.Response) (*POSTapiroomsRoominvitesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiroomsRoominvitesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Invite
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Invite
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiroomsRoominvitesIdResponse parses an HTTP response from a DELETEapiroomsRoominvitesIdWithResponse call
+func ParseDELETEapiroomsRoominvitesIdResponse(rsp *http.Response) (*DELETEapiroomsRoominvitesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiroomsRoominvitesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommembersResponse parses an HTTP response from a GETapiroomsRoommembersWithResponse call
+func ParseGETapiroomsRoommembersResponse(rsp *http.Response) (*GETapiroomsRoommembersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommembersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiroomsRoommembersPubkeyResponse parses an HTTP response from a DELETEapiroomsRoommembersPubkeyWithResponse call
+func ParseDELETEapiroomsRoommembersPubkeyResponse(rsp *http.Response) (*DELETEapiroomsRoommembersPubkeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiroomsRoommembersPubkeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePUTapiroomsRoommembersPubkeyResponse parses an HTTP response from a PUTapiroomsRoommembersPubkeyWithResponse call
+func ParsePUTapiroomsRoommembersPubkeyResponse(rsp *http.Response) (*PUTapiroomsRoommembersPubkeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PUTapiroomsRoommembersPubkeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommessagesResponse parses an HTTP response from a GETapiroomsRoommessagesWithResponse call
+func ParseGETapiroomsRoommessagesResponse(rsp *http.Response) (*GETapiroomsRoommessagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommessagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Message
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiroomsRoommessagesResponse parses an HTTP response from a POSTapiroomsRoommessagesWithResponse call
+func ParsePOSTapiroomsRoommessagesResponse(rsp *http.Response) (*POSTapiroomsRoommessagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiroomsRoommessagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Message
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommessagespageResponse parses an HTTP response from a GETapiroomsRoommessagespageWithResponse call
+func ParseGETapiroomsRoommessagespageResponse(rsp *http.Response) (*GETapiroomsRoommessagespageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommessagespageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessagePage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest MessagePage
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiroomsRoommessagesIdResponse parses an HTTP response from a DELETEapiroomsRoommessagesIdWithResponse call
+func ParseDELETEapiroomsRoommessagesIdResponse(rsp *http.Response) (*DELETEapiroomsRoommessagesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiroomsRoommessagesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Message
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePUTapiroomsRoommessagesIdResponse parses an HTTP response from a PUTapiroomsRoommessagesIdWithResponse call
+func ParsePUTapiroomsRoommessagesIdResponse(rsp *http.Response) (*PUTapiroomsRoommessagesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PUTapiroomsRoommessagesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Message
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Message
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommessagesIdreactionsResponse parses an HTTP response from a GETapiroomsRoommessagesIdreactionsWithResponse call
+func ParseGETapiroomsRoommessagesIdreactionsResponse(rsp *http.Response) (*GETapiroomsRoommessagesIdreactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommessagesIdreactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Reaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []Reaction
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePOSTapiroomsRoommessagesIdreactionsResponse parses an HTTP response from a POSTapiroomsRoommessagesIdreactionsWithResponse call
+func ParsePOSTapiroomsRoommessagesIdreactionsResponse(rsp *http.Response) (*POSTapiroomsRoommessagesIdreactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &POSTapiroomsRoommessagesIdreactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ReactionCount
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []ReactionCount
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiroomsRoommessagesIdreactionsEmojiResponse parses an HTTP response from a DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse call
+func ParseDELETEapiroomsRoommessagesIdreactionsEmojiResponse(rsp *http.Response) (*DELETEapiroomsRoommessagesIdreactionsEmojiResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiroomsRoommessagesIdreactionsEmojiResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ReactionCount
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []ReactionCount
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommessagesIdrevisionsResponse parses an HTTP response from a GETapiroomsRoommessagesIdrevisionsWithResponse call
+func ParseGETapiroomsRoommessagesIdrevisionsResponse(rsp *http.Response) (*GETapiroomsRoommessagesIdrevisionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommessagesIdrevisionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []MessageRevision
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []MessageRevision
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGETapiroomsRoommessagesIdthreadResponse parses an HTTP response from a GETapiroomsRoommessagesIdthreadWithResponse call
+func ParseGETapiroomsRoommessagesIdthreadResponse(rsp *http.Response) (*GETapiroomsRoommessagesIdthreadResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiroomsRoommessagesIdthreadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageThread
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest MessageThread
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDELETEapiroomsRoommoderatorsPubkeyResponse parses an HTTP response from a DELETEapiroomsRoommoderatorsPubkeyWithResponse call
+func ParseDELETEapiroomsRoommoderatorsPubkeyResponse(rsp *http.Response) (*DELETEapiroomsRoommoderatorsPubkeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DELETEapiroomsRoommoderatorsPubkeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePUTapiroomsRoommoderatorsPubkeyResponse parses an HTTP response from a PUTapiroomsRoommoderatorsPubkeyWithResponse call
+func ParsePUTapiroomsRoommoderatorsPubkeyResponse(rsp *http.Response) (*PUTapiroomsRoommoderatorsPubkeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PUTapiroomsRoommoderatorsPubkeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest []RoomMember
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
 	}
-	return ParseGETapiroomsResponse(rsp)
-}
 
-// POSTapiroomsWithBodyWithResponse request with arbitrary body returning *POSTapiroomsResponse
-func (c *ClientWithResponses) POSTapiroomsWithBodyWithResponse(ctx context.Context, params *POSTapiroomsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error) {
-	rsp, err := c.POSTapiroomsWithBody(ctx, params, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePOSTapiroomsResponse(rsp)
+	return response, nil
 }
 
-func (c *ClientWithResponses) POSTapiroomsWithResponse(ctx context.Context, params *POSTapiroomsParams, body POSTapiroomsJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsResponse, error) {
-	rsp, err := c.POSTapirooms(ctx, params, body, reqEditors...)
+// ParsePUTapiroomsRoompasswordResponse parses an HTTP response from a PUTapiroomsRoompasswordWithResponse call
+func ParsePUTapiroomsRoompasswordResponse(rsp *http.Response) (*PUTapiroomsRoompasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePOSTapiroomsResponse(rsp)
-}
 
-// GETapiroomssearchWithResponse request returning *GETapiroomssearchResponse
-func (c *ClientWithResponses) GETapiroomssearchWithResponse(ctx context.Context, params *GETapiroomssearchParams, reqEditors ...RequestEditorFn) (*GETapiroomssearchResponse, error) {
-	rsp, err := c.GETapiroomssearch(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PUTapiroomsRoompasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGETapiroomssearchResponse(rsp)
-}
 
-// GETapiroomsRoommessagesWithResponse request returning *GETapiroomsRoommessagesResponse
-func (c *ClientWithResponses) GETapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *GETapiroomsRoommessagesParams, reqEditors ...RequestEditorFn) (*GETapiroomsRoommessagesResponse, error) {
-	rsp, err := c.GETapiroomsRoommessages(ctx, room, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGETapiroomsRoommessagesResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
 
-// POSTapiroomsRoommessagesWithBodyWithResponse request with arbitrary body returning *POSTapiroomsRoommessagesResponse
-func (c *ClientWithResponses) POSTapiroomsRoommessagesWithBodyWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error) {
-	rsp, err := c.POSTapiroomsRoommessagesWithBody(ctx, room, params, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParsePOSTapiroomsRoommessagesResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) POSTapiroomsRoommessagesWithResponse(ctx context.Context, room string, params *POSTapiroomsRoommessagesParams, body POSTapiroomsRoommessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*POSTapiroomsRoommessagesResponse, error) {
-	rsp, err := c.POSTapiroomsRoommessages(ctx, room, params, body, reqEditors...)
+// ParsePUTapiroomsRoomretentionResponse parses an HTTP response from a PUTapiroomsRoomretentionWithResponse call
+func ParsePUTapiroomsRoomretentionResponse(rsp *http.Response) (*PUTapiroomsRoomretentionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePOSTapiroomsRoommessagesResponse(rsp)
-}
 
-// GETapiserverInfoWithResponse request returning *GETapiserverInfoResponse
-func (c *ClientWithResponses) GETapiserverInfoWithResponse(ctx context.Context, params *GETapiserverInfoParams, reqEditors ...RequestEditorFn) (*GETapiserverInfoResponse, error) {
-	rsp, err := c.GETapiserverInfo(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PUTapiroomsRoomretentionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGETapiserverInfoResponse(rsp)
-}
 
-// GETapiusersPublicKeyWithResponse request returning *GETapiusersPublicKeyResponse
-func (c *ClientWithResponses) GETapiusersPublicKeyWithResponse(ctx context.Context, publicKey string, params *GETapiusersPublicKeyParams, reqEditors ...RequestEditorFn) (*GETapiusersPublicKeyResponse, error) {
-	rsp, err := c.GETapiusersPublicKey(ctx, publicKey, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest Room
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
 	}
-	return ParseGETapiusersPublicKeyResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGETResponse parses an HTTP response from a GETWithResponse call
-func ParseGETResponse(rsp *http.Response) (*GETResponse, error) {
+// ParseGETapiroomsRoomsanctionsResponse parses an HTTP response from a GETapiroomsRoomsanctionsWithResponse call
+func ParseGETapiroomsRoomsanctionsResponse(rsp *http.Response) (*GETapiroomsRoomsanctionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GETResponse{
+	response := &GETapiroomsRoomsanctionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest UnknownInterface
+		var dest []Sanction
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1262,7 +9029,7 @@ func ParseGETResponse(rsp *http.Response) (*GETResponse, error) {
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest UnknownInterface
+		var dest []Sanction
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1287,22 +9054,22 @@ func ParseGETResponse(rsp *http.Response) (*GETResponse, error) {
 	return response, nil
 }
 
-// ParseGETapiroomsResponse parses an HTTP response from a GETapiroomsWithResponse call
-func ParseGETapiroomsResponse(rsp *http.Response) (*GETapiroomsResponse, error) {
+// ParsePOSTapiroomsRoomsanctionsResponse parses an HTTP response from a POSTapiroomsRoomsanctionsWithResponse call
+func ParsePOSTapiroomsRoomsanctionsResponse(rsp *http.Response) (*POSTapiroomsRoomsanctionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GETapiroomsResponse{
+	response := &POSTapiroomsRoomsanctionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Room
+		var dest Sanction
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1323,7 +9090,7 @@ func ParseGETapiroomsResponse(rsp *http.Response) (*GETapiroomsResponse, error)
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest []Room
+		var dest Sanction
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1348,22 +9115,22 @@ func ParseGETapiroomsResponse(rsp *http.Response) (*GETapiroomsResponse, error)
 	return response, nil
 }
 
-// ParsePOSTapiroomsResponse parses an HTTP response from a POSTapiroomsWithResponse call
-func ParsePOSTapiroomsResponse(rsp *http.Response) (*POSTapiroomsResponse, error) {
+// ParseDELETEapiroomsRoomsanctionsIdResponse parses an HTTP response from a DELETEapiroomsRoomsanctionsIdWithResponse call
+func ParseDELETEapiroomsRoomsanctionsIdResponse(rsp *http.Response) (*DELETEapiroomsRoomsanctionsIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &POSTapiroomsResponse{
+	response := &DELETEapiroomsRoomsanctionsIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Room
+		var dest UnknownInterface
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1384,7 +9151,7 @@ func ParsePOSTapiroomsResponse(rsp *http.Response) (*POSTapiroomsResponse, error
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest Room
+		var dest UnknownInterface
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1409,22 +9176,22 @@ func ParsePOSTapiroomsResponse(rsp *http.Response) (*POSTapiroomsResponse, error
 	return response, nil
 }
 
-// ParseGETapiroomssearchResponse parses an HTTP response from a GETapiroomssearchWithResponse call
-func ParseGETapiroomssearchResponse(rsp *http.Response) (*GETapiroomssearchResponse, error) {
+// ParseGETapiroomsRoomstreamResponse parses an HTTP response from a GETapiroomsRoomstreamWithResponse call
+func ParseGETapiroomsRoomstreamResponse(rsp *http.Response) (*GETapiroomsRoomstreamResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GETapiroomssearchResponse{
+	response := &GETapiroomsRoomstreamResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Room
+		var dest UnknownInterface
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1445,7 +9212,7 @@ func ParseGETapiroomssearchResponse(rsp *http.Response) (*GETapiroomssearchRespo
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest []Room
+		var dest UnknownInterface
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1470,15 +9237,15 @@ func ParseGETapiroomssearchResponse(rsp *http.Response) (*GETapiroomssearchRespo
 	return response, nil
 }
 
-// ParseGETapiroomsRoommessagesResponse parses an HTTP response from a GETapiroomsRoommessagesWithResponse call
-func ParseGETapiroomsRoommessagesResponse(rsp *http.Response) (*GETapiroomsRoommessagesResponse, error) {
+// ParseGETapisearchResponse parses an HTTP response from a GETapisearchWithResponse call
+func ParseGETapisearchResponse(rsp *http.Response) (*GETapisearchResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GETapiroomsRoommessagesResponse{
+	response := &GETapisearchResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1531,22 +9298,22 @@ func ParseGETapiroomsRoommessagesResponse(rsp *http.Response) (*GETapiroomsRoomm
 	return response, nil
 }
 
-// ParsePOSTapiroomsRoommessagesResponse parses an HTTP response from a POSTapiroomsRoommessagesWithResponse call
-func ParsePOSTapiroomsRoommessagesResponse(rsp *http.Response) (*POSTapiroomsRoommessagesResponse, error) {
+// ParseGETapiserverInfoResponse parses an HTTP response from a GETapiserverInfoWithResponse call
+func ParseGETapiserverInfoResponse(rsp *http.Response) (*GETapiserverInfoResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &POSTapiroomsRoommessagesResponse{
+	response := &GETapiserverInfoResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Message
+		var dest ServerInfoResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1567,7 +9334,7 @@ func ParsePOSTapiroomsRoommessagesResponse(rsp *http.Response) (*POSTapiroomsRoo
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest Message
+		var dest ServerInfoResponse
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1592,22 +9359,22 @@ func ParsePOSTapiroomsRoommessagesResponse(rsp *http.Response) (*POSTapiroomsRoo
 	return response, nil
 }
 
-// ParseGETapiserverInfoResponse parses an HTTP response from a GETapiserverInfoWithResponse call
-func ParseGETapiserverInfoResponse(rsp *http.Response) (*GETapiserverInfoResponse, error) {
+// ParseGETapiusersmeResponse parses an HTTP response from a GETapiusersmeWithResponse call
+func ParseGETapiusersmeResponse(rsp *http.Response) (*GETapiusersmeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GETapiserverInfoResponse{
+	response := &GETapiusersmeResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ServerInfoResponse
+		var dest UserWithPostCount
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1628,7 +9395,7 @@ func ParseGETapiserverInfoResponse(rsp *http.Response) (*GETapiserverInfoRespons
 		response.JSON500 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
-		var dest ServerInfoResponse
+		var dest UserWithPostCount
 		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1713,3 +9480,64 @@ func ParseGETapiusersPublicKeyResponse(rsp *http.Response) (*GETapiusersPublicKe
 
 	return response, nil
 }
+
+// ParseGETapiwsResponse parses an HTTP response from a GETapiwsWithResponse call
+func ParseGETapiwsResponse(rsp *http.Response) (*GETapiwsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GETapiwsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 200:
+		var dest UnknownInterface
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 400:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "xml") && rsp.StatusCode == 500:
+		var dest HTTPError
+		if err := xml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.XML500 = &dest
+
+	}
+
+	return response, nil
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"io/fs"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"slices"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/EwenQuim/microchat/internal/config"
 	"github.com/EwenQuim/microchat/internal/handlers"
@@ -20,6 +25,10 @@ import (
 //go:embed all:static
 var staticFiles embed.FS
 
+// shutdownTimeout bounds how long the server waits for requests in flight once
+// asked to stop; streams still open then are cut.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -59,10 +68,37 @@ func main() {
 	spaHandler := createSPAHandler(staticFS)
 	fuego.GetStd(s, "/", spaHandler)
 
-	if err := s.Run(); err != nil {
+	// Stop on SIGINT or SIGTERM; a second signal kills the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Prune the messages past their retention in the background, and relay
+	// live events between the replicas sharing the database, if any
+	var background sync.WaitGroup
+	background.Go(func() {
+		chatService.RunJanitor(ctx, cfg.Retention(), cfg.RetentionInterval)
+	})
+	background.Go(func() {
+		chatService.RunRelay(ctx)
+	})
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- s.Run() }()
+	select {
+	case err := <-serveErr:
 		slog.Error("Server failed to run", "error", err)
 		os.Exit(1)
+	case <-ctx.Done():
+	}
+	stop()
+
+	slog.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to shut down gracefully", "error", err)
 	}
+	background.Wait()
 }
 
 // createSPAHandler creates a handler that serves static files and falls back to index.html for SPA routes
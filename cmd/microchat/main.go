@@ -45,6 +45,8 @@ func main() {
 				Usage: "List messages in a room",
 				Flags: []cli.Flag{
 					&cli.StringFlag{Name: "room", Value: "general", Usage: "Chat room name"},
+					&cli.IntFlag{Name: "limit", Value: 50, Usage: "Number of messages to list"},
+					&cli.StringFlag{Name: "before", Usage: "Cursor printed by a previous list, to list older messages"},
 				},
 				Action: runList,
 			},
@@ -113,14 +115,22 @@ func runList(c *cli.Context) error {
 		return fmt.Errorf("create client: %w", err)
 	}
 	room := c.String("room")
-	resp, err := client.GETapiroomsRoommessagesWithResponse(context.Background(), room, &generated.GETapiroomsRoommessagesParams{})
+	params := &generated.GETapiroomsRoommessagespageParams{Limit: new(c.Int("limit"))}
+	if before := c.String("before"); before != "" {
+		params.Before = &before
+	}
+	resp, err := client.GETapiroomsRoommessagespageWithResponse(context.Background(), room, params)
 	if err != nil {
 		return fmt.Errorf("get messages: %w", err)
 	}
 	if resp.StatusCode() != 200 {
 		return fmt.Errorf("get messages: status %d", resp.StatusCode())
 	}
-	for _, msg := range *resp.JSON200 {
+	page := resp.JSON200
+	if page.Messages == nil {
+		return nil
+	}
+	for _, msg := range *page.Messages {
 		ts := ""
 		if msg.Timestamp != nil {
 			ts = msg.Timestamp.String()
@@ -133,8 +143,27 @@ func runList(c *cli.Context) error {
 		if msg.Content != nil {
 			content = *msg.Content
 		}
+		if msg.ReplyTo != nil && *msg.ReplyTo != "" {
+			content = "(reply) " + content
+		}
+		if msg.EditedAt != nil {
+			content += " (edited)"
+		}
+		if msg.Reactions != nil {
+			for _, reaction := range *msg.Reactions {
+				if reaction != nil && reaction.Emoji != nil && reaction.Count != nil {
+					content += fmt.Sprintf(" %s %d", *reaction.Emoji, *reaction.Count)
+				}
+			}
+		}
+		if msg.DeletedAt != nil {
+			content = "(message deleted)"
+		}
 		fmt.Printf("[%s] %s: %s\n", ts, u, content)
 	}
+	if page.PrevCursor != nil {
+		fmt.Fprintf(os.Stderr, "older messages: --before %s\n", *page.PrevCursor)
+	}
 	return nil
 }
 
@@ -2,16 +2,38 @@ package config
 
 import (
 	"cmp"
+	"log/slog"
+	"net/netip"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
 )
 
+// DefaultMessageMaxSkew is how far a signed message timestamp may drift from
+// the server clock when MESSAGE_MAX_SKEW is not set.
+const DefaultMessageMaxSkew = 5 * time.Minute
+
+// DefaultRetentionInterval is how often messages past their retention are
+// pruned when RETENTION_INTERVAL is not set.
+const DefaultRetentionInterval = time.Hour
+
 type Config struct {
 	Port                string
 	AdminPubkeys        []string
 	QuickName           string
 	Description         string
 	SuggestedServerList []string
+	MessageMaxSkew      time.Duration  // signed timestamps further from now are rejected
+	TrustedProxies      []netip.Prefix // whose X-Forwarded-Host is trusted by signed requests
+
+	// Server-default retention, which room owners may only tighten; zero
+	// keeps messages forever.
+	RetentionMaxAge      time.Duration
+	RetentionMaxMessages int           // per room
+	RetentionInterval    time.Duration // between two prunings
 }
 
 func Load() *Config {
@@ -45,11 +67,73 @@ func Load() *Config {
 		}
 	}
 
+	// Parse comma-separated list of trusted proxy addresses or CIDR ranges
+	var trustedProxies []netip.Prefix
+	for proxy := range strings.SplitSeq(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				slog.Warn("Ignoring invalid TRUSTED_PROXIES entry", "value", proxy) //nolint:gosec // G706: structured log field, not a format string
+				continue
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		trustedProxies = append(trustedProxies, prefix.Masked())
+	}
+
+	messageMaxSkew := durationEnv("MESSAGE_MAX_SKEW", DefaultMessageMaxSkew)
+	retentionMaxAge := durationEnv("RETENTION_MAX_AGE", 0)
+	retentionInterval := durationEnv("RETENTION_INTERVAL", DefaultRetentionInterval)
+
+	var retentionMaxMessages int
+	if v := os.Getenv("RETENTION_MAX_MESSAGES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			slog.Warn("Ignoring invalid RETENTION_MAX_MESSAGES, keeping every message", "value", v) //nolint:gosec // G706: structured log field, not a format string
+		} else {
+			retentionMaxMessages = n
+		}
+	}
+
 	return &Config{
 		Port:                port,
 		AdminPubkeys:        adminPubkeys,
 		QuickName:           quickName,
 		Description:         description,
 		SuggestedServerList: suggestedServerList,
+		MessageMaxSkew:      messageMaxSkew,
+		TrustedProxies:      trustedProxies,
+
+		RetentionMaxAge:      retentionMaxAge,
+		RetentionMaxMessages: retentionMaxMessages,
+		RetentionInterval:    retentionInterval,
+	}
+}
+
+// Retention returns the server-default retention policy.
+func (c *Config) Retention() models.RetentionPolicy {
+	return models.RetentionPolicy{
+		MaxAge:      int64(c.RetentionMaxAge / time.Second),
+		MaxMessages: int64(c.RetentionMaxMessages),
+	}
+}
+
+// durationEnv parses the environment variable name as a positive duration,
+// falling back to def when it is unset or invalid.
+func durationEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		slog.Warn("Ignoring invalid "+name+", using default", "value", v, "default", def) //nolint:gosec // G706: structured log field, not a format string
+		return def
 	}
+	return d
 }
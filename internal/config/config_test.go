@@ -1,7 +1,10 @@
 package config
 
 import (
+	"net/netip"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestLoad_SuggestedServerList_Empty(t *testing.T) {
@@ -36,3 +39,72 @@ func TestLoad_SuggestedServerList_MultipleURLs(t *testing.T) {
 		t.Errorf("entry[1] = %q, want %q", cfg.SuggestedServerList[1], "https://other.example.com")
 	}
 }
+
+func TestLoad_MessageMaxSkew_Default(t *testing.T) {
+	t.Setenv("MESSAGE_MAX_SKEW", "")
+	cfg := Load()
+	if cfg.MessageMaxSkew != DefaultMessageMaxSkew {
+		t.Errorf("MessageMaxSkew = %v, want %v", cfg.MessageMaxSkew, DefaultMessageMaxSkew)
+	}
+}
+
+func TestLoad_MessageMaxSkew_Custom(t *testing.T) {
+	t.Setenv("MESSAGE_MAX_SKEW", "90s")
+	cfg := Load()
+	if cfg.MessageMaxSkew != 90*time.Second {
+		t.Errorf("MessageMaxSkew = %v, want 90s", cfg.MessageMaxSkew)
+	}
+}
+
+func TestLoad_MessageMaxSkew_InvalidFallsBack(t *testing.T) {
+	t.Setenv("MESSAGE_MAX_SKEW", "soon")
+	cfg := Load()
+	if cfg.MessageMaxSkew != DefaultMessageMaxSkew {
+		t.Errorf("MessageMaxSkew = %v, want default %v", cfg.MessageMaxSkew, DefaultMessageMaxSkew)
+	}
+}
+
+func TestLoad_Retention_DefaultKeepsEverything(t *testing.T) {
+	t.Setenv("RETENTION_MAX_AGE", "")
+	t.Setenv("RETENTION_MAX_MESSAGES", "")
+	t.Setenv("RETENTION_INTERVAL", "")
+	cfg := Load()
+	if cfg.RetentionMaxAge != 0 || cfg.RetentionMaxMessages != 0 {
+		t.Errorf("retention = %v, %d messages, want none", cfg.RetentionMaxAge, cfg.RetentionMaxMessages)
+	}
+	if cfg.RetentionInterval != DefaultRetentionInterval {
+		t.Errorf("RetentionInterval = %v, want %v", cfg.RetentionInterval, DefaultRetentionInterval)
+	}
+}
+
+func TestLoad_Retention_Custom(t *testing.T) {
+	t.Setenv("RETENTION_MAX_AGE", "720h")
+	t.Setenv("RETENTION_MAX_MESSAGES", "10000")
+	t.Setenv("RETENTION_INTERVAL", "10m")
+	cfg := Load()
+	if cfg.RetentionMaxAge != 720*time.Hour || cfg.RetentionMaxMessages != 10000 || cfg.RetentionInterval != 10*time.Minute {
+		t.Errorf("retention = %v, %d messages every %v, want 720h, 10000 messages every 10m", cfg.RetentionMaxAge, cfg.RetentionMaxMessages, cfg.RetentionInterval)
+	}
+}
+
+func TestLoad_Retention_InvalidIgnored(t *testing.T) {
+	t.Setenv("RETENTION_MAX_AGE", "a month")
+	t.Setenv("RETENTION_MAX_MESSAGES", "-5")
+	cfg := Load()
+	if cfg.RetentionMaxAge != 0 || cfg.RetentionMaxMessages != 0 {
+		t.Errorf("retention = %v, %d messages, want none", cfg.RetentionMaxAge, cfg.RetentionMaxMessages)
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16, ::1, proxy.local")
+	cfg := Load()
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.1/32"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("::1/128"),
+	}
+	if !slices.Equal(cfg.TrustedProxies, want) {
+		t.Errorf("TrustedProxies = %v, want %v", cfg.TrustedProxies, want)
+	}
+}
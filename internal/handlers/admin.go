@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+
+	"github.com/go-fuego/fuego"
+)
+
+// notFoundError maps repository lookup failures to 404s.
+func notFoundError(err error) error {
+	if errors.Is(err, services.ErrRoomNotFound) || errors.Is(err, services.ErrUserNotFound) || errors.Is(err, services.ErrMessageNotFound) {
+		return fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: err.Error(), Err: err}
+	}
+	return err
+}
+
+// IssueAdminChallenge returns a single-use challenge to sign for the next
+// request of the admin named by the X-Admin-Pubkey header.
+func IssueAdminChallenge(challenges *middleware.ChallengeStore, adminPubkeys []string) func(c fuego.ContextNoBody) (*models.AdminChallenge, error) {
+	return func(c fuego.ContextNoBody) (*models.AdminChallenge, error) {
+		pubkey := c.Header(middleware.AdminPubkeyHeader)
+		if pubkey == "" {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "the " + middleware.AdminPubkeyHeader + " header must name the admin the challenge is for"}
+		}
+		if !middleware.IsAdmin(adminPubkeys, pubkey) {
+			return nil, fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "public key is not an admin"}
+		}
+		challenge, expiresAt, err := challenges.Issue(c.Context(), pubkey)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AdminChallenge{Challenge: challenge, ExpiresAt: expiresAt}, nil
+	}
+}
+
+func ListUsers(chatService *services.ChatService) func(c fuego.ContextNoBody) ([]models.User, error) {
+	return func(c fuego.ContextNoBody) ([]models.User, error) {
+		return chatService.GetAllUsers(c.Context())
+	}
+}
+
+// GetRetentionStats returns the counts of the messages pruned past their
+// retention since the server started.
+func GetRetentionStats(chatService *services.ChatService) func(c fuego.ContextNoBody) (models.RetentionStats, error) {
+	return func(c fuego.ContextNoBody) (models.RetentionStats, error) {
+		return chatService.RetentionStats(), nil
+	}
+}
+
+func VerifyUser(chatService *services.ChatService) func(c fuego.ContextNoBody) (*models.User, error) {
+	return func(c fuego.ContextNoBody) (*models.User, error) {
+		publicKey := c.PathParam("publicKey")
+		if err := chatService.VerifyUser(c.Context(), publicKey); err != nil {
+			return nil, notFoundError(err)
+		}
+		return chatService.GetUser(c.Context(), publicKey)
+	}
+}
+
+func UnverifyUser(chatService *services.ChatService) func(c fuego.ContextNoBody) (*models.User, error) {
+	return func(c fuego.ContextNoBody) (*models.User, error) {
+		publicKey := c.PathParam("publicKey")
+		if err := chatService.UnverifyUser(c.Context(), publicKey); err != nil {
+			return nil, notFoundError(err)
+		}
+		return chatService.GetUser(c.Context(), publicKey)
+	}
+}
+
+func DeleteRoom(chatService *services.ChatService) func(c fuego.ContextNoBody) (any, error) {
+	return func(c fuego.ContextNoBody) (any, error) {
+		if err := chatService.DeleteRoom(c.Context(), c.PathParam("room")); err != nil {
+			return nil, notFoundError(err)
+		}
+		c.SetStatus(http.StatusNoContent)
+		return nil, nil
+	}
+}
+
+// ResetRoomPassword replaces the password of a room. The request must be
+// signed by the owner of the room or an admin key.
+func ResetRoomPassword(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.ResetRoomPasswordRequest]) (*models.Room, error) {
+	return func(c fuego.ContextWithBody[models.ResetRoomPasswordRequest]) (*models.Room, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		room := c.PathParam("room")
+		if err := chatService.SetRoomPassword(c.Context(), actor, room, body.Password); err != nil {
+			return nil, roleError(err)
+		}
+		return &models.Room{Name: room, HasPassword: body.Password != nil && *body.Password != ""}, nil
+	}
+}
@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/go-fuego/fuego"
+)
+
+// adminRepo records the moderation calls it receives.
+type adminRepo struct {
+	stubRepo
+	users          map[string]*models.User
+	rooms          map[string]*string // room -> password
+	deletedMessage [2]string          // room, id
+}
+
+func newAdminRepo() *adminRepo {
+	return &adminRepo{
+		users: map[string]*models.User{"alice": {PublicKey: "alice"}},
+		rooms: map[string]*string{"general": nil},
+	}
+}
+
+func (r *adminRepo) GetAllUsers(_ context.Context) ([]models.User, error) {
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+func (r *adminRepo) GetUser(_ context.Context, publicKey string) (*models.User, error) {
+	user, ok := r.users[publicKey]
+	if !ok {
+		return nil, services.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *adminRepo) VerifyUser(_ context.Context, publicKey string) error {
+	user, ok := r.users[publicKey]
+	if !ok {
+		return services.ErrUserNotFound
+	}
+	user.Verified = true
+	return nil
+}
+
+func (r *adminRepo) UnverifyUser(_ context.Context, publicKey string) error {
+	user, ok := r.users[publicKey]
+	if !ok {
+		return services.ErrUserNotFound
+	}
+	user.Verified = false
+	return nil
+}
+
+func (r *adminRepo) GetRoom(_ context.Context, room string) (*models.Room, error) {
+	password, ok := r.rooms[room]
+	if !ok {
+		return nil, services.ErrRoomNotFound
+	}
+	return &models.Room{Name: room, HasPassword: password != nil}, nil
+}
+
+func (r *adminRepo) SetRoomPassword(_ context.Context, room string, password *string) error {
+	if _, ok := r.rooms[room]; !ok {
+		return services.ErrRoomNotFound
+	}
+	r.rooms[room] = password
+	return nil
+}
+
+func (r *adminRepo) DeleteRoom(_ context.Context, room string) error {
+	if _, ok := r.rooms[room]; !ok {
+		return services.ErrRoomNotFound
+	}
+	delete(r.rooms, room)
+	return nil
+}
+
+func (r *adminRepo) GetMessage(_ context.Context, room, id string) (*models.Message, error) {
+	if id != "msg-1" {
+		return nil, services.ErrMessageNotFound
+	}
+	return &models.Message{ID: id, Room: room, Pubkey: "02" + strings.Repeat("ab", 32)}, nil
+}
+
+func (r *adminRepo) DeleteMessage(_ context.Context, room, id string) (*models.Message, error) {
+	if id != "msg-1" {
+		return nil, services.ErrMessageNotFound
+	}
+	r.deletedMessage = [2]string{room, id}
+	return &models.Message{ID: id, Room: room, DeletedAt: new(time.Now())}, nil
+}
+
+func newAdminTestServer(t *testing.T, repo services.Repository) (*fuego.Server, *secp256k1.PrivateKey) {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	s := fuego.NewServer(fuego.WithoutLogger())
+	cfg := &config.Config{AdminPubkeys: []string{hex.EncodeToString(key.PubKey().SerializeCompressed())}}
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(repo), cfg)
+	return s, key
+}
+
+// requestChallenge asks for a challenge for the admin pubkey.
+func requestChallenge(s *fuego.Server, pubkey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/challenge", nil)
+	if pubkey != "" {
+		req.Header.Set(middleware.AdminPubkeyHeader, pubkey)
+	}
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+func issueChallenge(t *testing.T, s *fuego.Server, key *secp256k1.PrivateKey) string {
+	t.Helper()
+	w := requestChallenge(s, hex.EncodeToString(key.PubKey().SerializeCompressed()))
+	if w.Code != http.StatusOK {
+		t.Fatalf("challenge: status = %d; body: %s", w.Code, w.Body.String())
+	}
+	var challenge models.AdminChallenge
+	if err := json.Unmarshal(w.Body.Bytes(), &challenge); err != nil {
+		t.Fatalf("decode challenge: %v", err)
+	}
+	return challenge.Challenge
+}
+
+// signChallenge returns the admin headers for method, path and body, encoded
+// as serveAdmin does, with an ECDSA signature by key.
+func signChallenge(t *testing.T, key *secp256k1.PrivateKey, challenge, method, path string, body any) http.Header {
+	t.Helper()
+	hash, err := crypto.Challenge{Nonce: challenge, Method: method, URI: path, Payload: crypto.PayloadHash(encodeBody(body))}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	header := http.Header{}
+	header.Set(middleware.AdminPubkeyHeader, hex.EncodeToString(key.PubKey().SerializeCompressed()))
+	header.Set(middleware.AdminChallengeHeader, challenge)
+	header.Set(middleware.AdminSignatureHeader, hex.EncodeToString(ecdsa.Sign(key, hash).Serialize()))
+	return header
+}
+
+// encodeBody returns the JSON encoding of body, nothing when it is nil.
+func encodeBody(body any) []byte {
+	if body == nil {
+		return nil
+	}
+	data, _ := json.Marshal(body)
+	return data
+}
+
+func serveAdmin(s *fuego.Server, method, path string, header http.Header, body any) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(encodeBody(body))
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header = header
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+// adminRequest signs a fresh challenge for the request and serves it.
+func adminRequest(t *testing.T, s *fuego.Server, key *secp256k1.PrivateKey, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveAdmin(s, method, path, signChallenge(t, key, issueChallenge(t, s, key), method, path, body), body)
+}
+
+func TestAdmin_ListUsers(t *testing.T) {
+	s, key := newAdminTestServer(t, newAdminRepo())
+
+	w := adminRequest(t, s, key, http.MethodGet, "/api/admin/users", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var users []models.User
+	if err := json.Unmarshal(w.Body.Bytes(), &users); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(users) != 1 || users[0].PublicKey != "alice" {
+		t.Errorf("users = %+v, want alice", users)
+	}
+}
+
+func TestAdmin_RejectsUnauthenticatedRequests(t *testing.T) {
+	s, key := newAdminTestServer(t, newAdminRepo())
+	const path = "/api/admin/users"
+
+	if w := serveAdmin(s, http.MethodGet, path, http.Header{}, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("no signature: status = %d, want 401", w.Code)
+	}
+
+	other, _ := secp256k1.GeneratePrivateKey()
+	if w := serveAdmin(s, http.MethodGet, path, signChallenge(t, other, issueChallenge(t, s, key), http.MethodGet, path, nil), nil); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin key: status = %d, want 403", w.Code)
+	}
+
+	if w := serveAdmin(s, http.MethodGet, path, signChallenge(t, key, "never-issued", http.MethodGet, path, nil), nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("unknown challenge: status = %d, want 401", w.Code)
+	}
+
+	otherRoute := signChallenge(t, key, issueChallenge(t, s, key), http.MethodDelete, "/api/admin/rooms/general", nil)
+	if w := serveAdmin(s, http.MethodGet, path, otherRoute, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("signature for another route: status = %d, want 401", w.Code)
+	}
+
+	header := signChallenge(t, key, issueChallenge(t, s, key), http.MethodGet, path, nil)
+	if w := serveAdmin(s, http.MethodGet, path, header, nil); w.Code != http.StatusOK {
+		t.Fatalf("first use: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if w := serveAdmin(s, http.MethodGet, path, header, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("replayed challenge: status = %d, want 401", w.Code)
+	}
+}
+
+func TestAdmin_ChallengeCoversQueryAndBody(t *testing.T) {
+	s, key := newAdminTestServer(t, newAdminRepo())
+
+	const path = "/api/admin/users"
+	header := signChallenge(t, key, issueChallenge(t, s, key), http.MethodGet, path, nil)
+	if w := serveAdmin(s, http.MethodGet, path+"?all=1", header, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("other query: status = %d, want 401", w.Code)
+	}
+
+	signed := models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: "alice"}
+	header = signChallenge(t, key, issueChallenge(t, s, key), http.MethodPost, "/api/admin/sanctions", signed)
+	if w := serveAdmin(s, http.MethodPost, "/api/admin/sanctions", header, models.CreateSanctionRequest{Kind: models.SanctionBan, Pubkey: "alice"}); w.Code != http.StatusUnauthorized {
+		t.Errorf("other body: status = %d, want 401", w.Code)
+	}
+}
+
+func TestAdmin_Challenges(t *testing.T) {
+	s, key := newAdminTestServer(t, newAdminRepo())
+	const path = "/api/admin/users"
+
+	if w := requestChallenge(s, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("no pubkey: status = %d, want 400", w.Code)
+	}
+	other, _ := secp256k1.GeneratePrivateKey()
+	if w := requestChallenge(s, hex.EncodeToString(other.PubKey().SerializeCompressed())); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin key: status = %d, want 403", w.Code)
+	}
+
+	// A request without a valid signature does not use the challenge up.
+	challenge := issueChallenge(t, s, key)
+	forged := signChallenge(t, other, challenge, http.MethodGet, path, nil)
+	forged.Set(middleware.AdminPubkeyHeader, hex.EncodeToString(key.PubKey().SerializeCompressed()))
+	if w := serveAdmin(s, http.MethodGet, path, forged, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("forged signature: status = %d, want 401", w.Code)
+	}
+	if w := serveAdmin(s, http.MethodGet, path, signChallenge(t, key, challenge, http.MethodGet, path, nil), nil); w.Code != http.StatusOK {
+		t.Errorf("after a forged signature: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdmin_SchnorrSignature(t *testing.T) {
+	s, key := newAdminTestServer(t, newAdminRepo())
+	const path = "/api/admin/users"
+
+	challenge := issueChallenge(t, s, key)
+	hash, _ := crypto.Challenge{Nonce: challenge, Method: http.MethodGet, URI: path, Payload: crypto.PayloadHash(nil)}.Hash()
+	sig, err := schnorr.Sign(key, hash)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	header := http.Header{}
+	header.Set(middleware.AdminPubkeyHeader, hex.EncodeToString(schnorr.SerializePubKey(key.PubKey())))
+	header.Set(middleware.AdminChallengeHeader, challenge)
+	header.Set(middleware.AdminSignatureHeader, hex.EncodeToString(sig.Serialize()))
+	header.Set(middleware.AdminSigSchemeHeader, crypto.SigSchnorr)
+
+	if w := serveAdmin(s, http.MethodGet, path, header, nil); w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdmin_VerifyAndUnverifyUser(t *testing.T) {
+	repo := newAdminRepo()
+	s, key := newAdminTestServer(t, repo)
+
+	w := adminRequest(t, s, key, http.MethodPost, "/api/admin/users/alice/verify", nil)
+	if w.Code != http.StatusOK || !repo.users["alice"].Verified {
+		t.Fatalf("verify: status = %d, verified = %v; body: %s", w.Code, repo.users["alice"].Verified, w.Body.String())
+	}
+
+	w = adminRequest(t, s, key, http.MethodPost, "/api/admin/users/alice/unverify", nil)
+	if w.Code != http.StatusOK || repo.users["alice"].Verified {
+		t.Fatalf("unverify: status = %d, verified = %v; body: %s", w.Code, repo.users["alice"].Verified, w.Body.String())
+	}
+
+	if w := adminRequest(t, s, key, http.MethodPost, "/api/admin/users/bob/verify", nil); w.Code != http.StatusNotFound {
+		t.Errorf("unknown user: status = %d, want 404", w.Code)
+	}
+}
+
+func TestAdmin_DeleteRoom(t *testing.T) {
+	repo := newAdminRepo()
+	s, key := newAdminTestServer(t, repo)
+
+	if w := adminRequest(t, s, key, http.MethodDelete, "/api/admin/rooms/general", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204; body: %s", w.Code, w.Body.String())
+	}
+	if _, ok := repo.rooms["general"]; ok {
+		t.Error("room was not deleted")
+	}
+
+	if w := adminRequest(t, s, key, http.MethodDelete, "/api/admin/rooms/general", nil); w.Code != http.StatusNotFound {
+		t.Errorf("deleted room: status = %d, want 404", w.Code)
+	}
+}
+
+func TestAdmin_DeleteMessage(t *testing.T) {
+	repo := newAdminRepo()
+	s, key := newAdminTestServer(t, repo)
+
+	if w := adminRequest(t, s, key, http.MethodDelete, "/api/admin/rooms/general/messages/msg-1", nil); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if repo.deletedMessage != [2]string{"general", "msg-1"} {
+		t.Errorf("deleted = %v, want general/msg-1", repo.deletedMessage)
+	}
+
+	if w := adminRequest(t, s, key, http.MethodDelete, "/api/admin/rooms/general/messages/msg-2", nil); w.Code != http.StatusNotFound {
+		t.Errorf("unknown message: status = %d, want 404", w.Code)
+	}
+}
+
+func TestAdmin_ResetRoomPassword(t *testing.T) {
+	repo := newAdminRepo()
+	s, key := newAdminTestServer(t, repo)
+	const path = "/api/admin/rooms/general/password"
+
+	w := adminRequest(t, s, key, http.MethodPost, path, models.ResetRoomPasswordRequest{Password: new("hunter22")})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if got := repo.rooms["general"]; got == nil || *got != "hunter22" {
+		t.Errorf("password = %v, want hunter22", got)
+	}
+	var room models.Room
+	_ = json.Unmarshal(w.Body.Bytes(), &room)
+	if !room.HasPassword {
+		t.Error("expected has_password in the response")
+	}
+
+	if w := adminRequest(t, s, key, http.MethodPost, path, models.ResetRoomPasswordRequest{}); w.Code != http.StatusOK || repo.rooms["general"] != nil {
+		t.Errorf("clear: status = %d, password = %v, want a public room", w.Code, repo.rooms["general"])
+	}
+
+	if w := adminRequest(t, s, key, http.MethodPost, path, models.ResetRoomPasswordRequest{Password: new("abc")}); w.Code != http.StatusBadRequest {
+		t.Errorf("short password: status = %d, want 400", w.Code)
+	}
+
+	if w := adminRequest(t, s, key, http.MethodPost, "/api/admin/rooms/missing/password", models.ResetRoomPasswordRequest{}); w.Code != http.StatusNotFound {
+		t.Errorf("unknown room: status = %d, want 404", w.Code)
+	}
+}
+
+func TestAdmin_SignedRequest(t *testing.T) {
+	repo := newAdminRepo()
+	s, key := newAdminTestServer(t, repo)
+
+	body := models.ResetRoomPasswordRequest{Password: new("hunter22")}
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/rooms/general/password", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	signHTTPRequest(t, key, req, data)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if got := repo.rooms["general"]; got == nil || *got != "hunter22" {
+		t.Errorf("password = %v, want hunter22", got)
+	}
+
+	other, _ := secp256k1.GeneratePrivateKey()
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+	signHTTPRequest(t, other, req, nil)
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("non-admin key: status = %d, want 403", w.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+
+	"github.com/go-fuego/fuego"
+)
+
+type GetDirectMessagesQuery struct {
+	With   string `query:"with"` // only the messages exchanged with this pubkey
+	Limit  int    `query:"limit"`
+	Before string `query:"before"` // RFC3339
+}
+
+// SendDirectMessage stores an encrypted message to a pubkey. The server cannot
+// read it: it only checks that the sender signed the payload for the recipient.
+func SendDirectMessage(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.SendDirectMessageRequest]) (*models.DirectMessage, error) {
+	return func(c fuego.ContextWithBody[models.SendDirectMessageRequest]) (*models.DirectMessage, error) {
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkDirectMessage(body, messageMaxSkew(cfg)); err != nil {
+			return nil, err
+		}
+
+		ctx := services.WithClientIP(c.Context(), middleware.IPFromRequest(c.Request()))
+		dm, err := chatService.SendDirectMessage(ctx, models.DirectMessage{
+			Sender:          body.Pubkey,
+			Recipient:       body.Recipient,
+			Content:         body.Content,
+			Signature:       body.Signature,
+			SignedTimestamp: body.Timestamp,
+			Version:         body.Version,
+			Tags:            body.Tags,
+		})
+		if err != nil {
+			return nil, sendMessageError(err)
+		}
+		return dm, nil
+	}
+}
+
+// checkDirectMessage verifies that a direct message carries a NIP-44 payload
+// signed recently by its sender, with a tag binding it to the recipient.
+func checkDirectMessage(body models.SendDirectMessageRequest, maxSkew time.Duration) error {
+	if !validPubkey(body.Recipient) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "recipient must be a compressed or x-only hex public key"}
+	}
+	if err := crypto.CheckDMPayload(body.Content); err != nil {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "content must be a NIP-44 version 2 payload: " + err.Error(), Err: err}
+	}
+	if skew := time.Since(time.Unix(body.Timestamp, 0)).Abs(); skew > maxSkew {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("timestamp is outside the accepted window of ±%s from server time", maxSkew)}
+	}
+
+	event := crypto.Event{
+		Version:   body.Version,
+		Pubkey:    body.Pubkey,
+		CreatedAt: body.Timestamp,
+		Content:   body.Content,
+		Tags:      body.Tags,
+	}
+	if !event.CoversTags() {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "direct messages must be signed with event version 1 or later, which covers the tags"}
+	}
+	if !crypto.HasTag(body.Tags, crypto.RecipientTag, body.Recipient) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("tags must include [%q, %q]", crypto.RecipientTag, body.Recipient)}
+	}
+	if err := crypto.VerifyEventSignature(event, body.Signature); err != nil {
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "direct message is not signed by its pubkey: " + err.Error(), Err: err}
+	}
+	return nil
+}
+
+// GetDirectMessages returns the latest direct messages sent or received by the
+// signed caller, oldest first.
+func GetDirectMessages(chatService *services.ChatService) func(c fuego.ContextWithParams[GetDirectMessagesQuery]) ([]models.DirectMessage, error) {
+	return func(c fuego.ContextWithParams[GetDirectMessagesQuery]) ([]models.DirectMessage, error) {
+		pubkey, ok := middleware.PubkeyFromContext(c.Context())
+		if !ok {
+			return nil, fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: "request must be signed"}
+		}
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		peer := ""
+		if queryParams.With != "" {
+			if !validPubkey(queryParams.With) {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "'with' must be a compressed or x-only hex public key"}
+			}
+			peer, _ = crypto.XOnlyPubkey(queryParams.With)
+		}
+		self, err := crypto.XOnlyPubkey(pubkey)
+		if err != nil {
+			return nil, fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: err.Error(), Err: err}
+		}
+
+		params := services.MessageQueryParams{Limit: min(queryParams.Limit, maxMessageLimit)}
+		if queryParams.Before != "" {
+			t, parseErr := time.Parse(time.RFC3339, queryParams.Before)
+			if parseErr != nil {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "invalid 'before' timestamp: use RFC3339 format"}
+			}
+			params.Before = &t
+		}
+
+		return chatService.GetDirectMessages(c.Context(), self, peer, params)
+	}
+}
+
+// validPubkey reports whether s is a hex public key in compressed or x-only
+// form.
+func validPubkey(s string) bool {
+	if _, err := crypto.XOnlyPubkey(s); err != nil {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
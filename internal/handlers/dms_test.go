@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/go-fuego/fuego"
+)
+
+// signDM encrypts plaintext from sender to recipient and signs it as a
+// version 1 event tagged with the recipient.
+func signDM(t *testing.T, sender, recipient *secp256k1.PrivateKey, plaintext string) models.SendDirectMessageRequest {
+	t.Helper()
+	recipientHex := hex.EncodeToString(schnorr.SerializePubKey(recipient.PubKey()))
+	key, err := crypto.ConversationKey(sender, recipientHex)
+	if err != nil {
+		t.Fatalf("ConversationKey: %v", err)
+	}
+	payload, err := crypto.EncryptDM(key, plaintext)
+	if err != nil {
+		t.Fatalf("EncryptDM: %v", err)
+	}
+	req := models.SendDirectMessageRequest{
+		Recipient: recipientHex,
+		Content:   payload,
+		Pubkey:    hex.EncodeToString(sender.PubKey().SerializeCompressed()),
+		Timestamp: time.Now().Unix(),
+		Version:   crypto.EventV1,
+		Tags:      [][]string{{crypto.RecipientTag, recipientHex}},
+	}
+	hash, err := crypto.Event{Version: req.Version, Pubkey: req.Pubkey, CreatedAt: req.Timestamp, Content: req.Content, Tags: req.Tags}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	req.Signature = hex.EncodeToString(ecdsa.SignCompact(sender, hash, true)[1:])
+	return req
+}
+
+func postDM(t *testing.T, s *fuego.Server, body models.SendDirectMessageRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/dms", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestDirectMessages(t *testing.T) {
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(memory.NewStore()), &config.Config{})
+
+	alice, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+
+	t.Run("rejected", func(t *testing.T) {
+		notPayload := signDM(t, alice, bob, "hi")
+		notPayload.Content = "hello bob"
+		untagged := signDM(t, alice, bob, "hi")
+		untagged.Tags = nil
+		v0 := signDM(t, alice, bob, "hi")
+		v0.Version = crypto.EventV0
+		redirected := signDM(t, alice, bob, "hi")
+		redirected.Recipient = hex.EncodeToString(schnorr.SerializePubKey(carol.PubKey()))
+		tampered := signDM(t, alice, bob, "hi")
+		tampered.Content = signDM(t, alice, bob, "ho").Content
+
+		for name, tt := range map[string]struct {
+			body models.SendDirectMessageRequest
+			want int
+		}{
+			"plaintext":     {notPayload, http.StatusBadRequest},
+			"no p tag":      {untagged, http.StatusBadRequest},
+			"version 0":     {v0, http.StatusBadRequest},
+			"other tag":     {redirected, http.StatusBadRequest},
+			"tampered":      {tampered, http.StatusForbidden},
+			"bad recipient": {models.SendDirectMessageRequest{Recipient: "bob", Content: notPayload.Content, Pubkey: "x", Signature: "x", Timestamp: 1, Tags: [][]string{}}, http.StatusBadRequest},
+		} {
+			if w := postDM(t, s, tt.body); w.Code != tt.want {
+				t.Errorf("%s: status = %d, want %d; body: %s", name, w.Code, tt.want, w.Body.String())
+			}
+		}
+	})
+
+	sent := signDM(t, alice, bob, "meet at noon")
+	if w := postDM(t, s, sent); w.Code != http.StatusOK {
+		t.Fatalf("send: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if w := postDM(t, s, sent); w.Code != http.StatusConflict {
+		t.Errorf("replay: status = %d, want 409", w.Code)
+	}
+	der := sent
+	der.Signature = derSignature(t, sent.Signature)
+	if w := postDM(t, s, der); w.Code != http.StatusConflict {
+		t.Errorf("DER replay: status = %d, want 409", w.Code)
+	}
+	if w := postDM(t, s, signDM(t, carol, alice, "hello alice")); w.Code != http.StatusOK {
+		t.Fatalf("send: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	inbox := func(key *secp256k1.PrivateKey, query string) ([]models.DirectMessage, int) {
+		req := httptest.NewRequest(http.MethodGet, "/api/dms"+query, nil)
+		if key != nil {
+			signHTTPRequest(t, key, req, nil)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, req)
+		var dms []models.DirectMessage
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &dms); err != nil {
+				t.Fatalf("decode %s: %v", w.Body.String(), err)
+			}
+		}
+		return dms, w.Code
+	}
+	if _, code := inbox(nil, ""); code != http.StatusUnauthorized {
+		t.Errorf("unsigned inbox: status = %d, want 401", code)
+	}
+
+	dms, code := inbox(bob, "")
+	if code != http.StatusOK || len(dms) != 1 {
+		t.Fatalf("bob's inbox: status = %d, dms = %+v; want alice's message", code, dms)
+	}
+	key, _ := crypto.ConversationKey(bob, dms[0].Sender)
+	if plaintext, err := crypto.DecryptDM(key, dms[0].Content); err != nil || plaintext != "meet at noon" {
+		t.Errorf("bob decrypts %q, %v", plaintext, err)
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  int
+	}{
+		{"", 2}, // sent to bob and received from carol
+		{"?with=" + hex.EncodeToString(bob.PubKey().SerializeCompressed()), 1},
+		{"?with=" + hex.EncodeToString(schnorr.SerializePubKey(carol.PubKey())), 1},
+	} {
+		if dms, code := inbox(alice, tt.query); code != http.StatusOK || len(dms) != tt.want {
+			t.Errorf("alice's inbox%s: status = %d, %d messages; want %d", tt.query, code, len(dms), tt.want)
+		}
+	}
+	if _, code := inbox(alice, "?with=carol"); code != http.StatusBadRequest {
+		t.Errorf("invalid with: status = %d, want 400", code)
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+
+	"github.com/go-fuego/fuego"
+)
+
+// inviteScheme is the URL scheme of invite links, which clients open as
+// microchat://server/room?invite=token.
+const inviteScheme = "microchat"
+
+// ListInvites returns the invites of a room, without their tokens.
+func ListInvites(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextNoBody) ([]models.Invite, error) {
+	return func(c fuego.ContextNoBody) ([]models.Invite, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		invites, err := chatService.GetInvites(c.Context(), actor, c.PathParam("room"))
+		if err != nil {
+			return nil, inviteError(err)
+		}
+		return invites, nil
+	}
+}
+
+// CreateInvite mints an invite to a room. Its token and link are only
+// returned here.
+func CreateInvite(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.CreateInviteRequest]) (*models.Invite, error) {
+	return func(c fuego.ContextWithBody[models.CreateInviteRequest]) (*models.Invite, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+
+		room := c.PathParam("room")
+		invite, err := chatService.CreateInvite(c.Context(), actor, room, body)
+		if err != nil {
+			return nil, inviteError(err)
+		}
+		link := url.URL{Scheme: inviteScheme, Host: c.Request().Host, Path: "/" + room, RawQuery: url.Values{"invite": {invite.Token}}.Encode()}
+		invite.Link = link.String()
+		return invite, nil
+	}
+}
+
+// RevokeInvite deletes an invite to a room; pubkeys that redeemed it lose the
+// access it granted.
+func RevokeInvite(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextNoBody) (any, error) {
+	return func(c fuego.ContextNoBody) (any, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := chatService.RevokeInvite(c.Context(), actor, c.PathParam("room"), c.PathParam("id")); err != nil {
+			return nil, inviteError(err)
+		}
+		c.SetStatus(http.StatusNoContent)
+		return nil, nil
+	}
+}
+
+// redeemInvite checks an invite to a room presented by the signer of the
+// request, in place of the room password.
+func redeemInvite(ctx context.Context, chatService *services.ChatService, room, invite string) error {
+	pubkey, ok := middleware.PubkeyFromContext(ctx)
+	if !ok {
+		return fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: "requests with an invite must be signed"}
+	}
+	if err := chatService.RedeemInvite(ctx, room, invite, pubkey); err != nil {
+		return inviteError(err)
+	}
+	return nil
+}
+
+// inviteError maps errors from managing and redeeming invites to HTTP errors.
+func inviteError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrInviteNotFound):
+		return fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: err.Error(), Err: err}
+	case errors.Is(err, services.ErrInviteInvalid):
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: err.Error(), Err: err}
+	}
+	return roleError(err)
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/go-fuego/fuego"
+)
+
+func TestInvites(t *testing.T) {
+	store := memory.NewStore()
+	chatService := services.NewChatService(store)
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "club", Password: new("secret")}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	read := func(key *secp256k1.PrivateKey, token string, limit int) int {
+		t.Helper() // a distinct limit per signer avoids replaying a signed request
+		return roomRequest(t, s, key, http.MethodGet, fmt.Sprintf("/api/rooms/club/messages?limit=%d&invite=%s", limit, url.QueryEscape(token)), nil).Code
+	}
+	sent := 0
+	send := func(key *secp256k1.PrivateKey, token string) int {
+		t.Helper()
+		sent++ // distinct content, as a replayed signature is rejected
+		body := signMessageV1(t, key, "club", fmt.Sprintf("hello %d", sent), "someone", nil)
+		body.RoomInvite = token
+		return postMessage(t, s, "club", body).Code
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  *secp256k1.PrivateKey
+		room string
+		want int
+	}{
+		{"unsigned", nil, "club", http.StatusUnauthorized},
+		{"not the owner", bob, "club", http.StatusForbidden},
+		{"unknown room", owner, "nowhere", http.StatusNotFound},
+	} {
+		if w := roomRequest(t, s, tt.key, http.MethodPost, "/api/rooms/"+tt.room+"/invites", models.CreateInviteRequest{}); w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body: %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+
+	w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms/club/invites", models.CreateInviteRequest{Duration: 3600, MaxUses: 1})
+	if w.Code != http.StatusOK {
+		t.Fatalf("create invite: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var invite models.Invite
+	if err := json.Unmarshal(w.Body.Bytes(), &invite); err != nil || invite.Token == "" || invite.ExpiresAt == nil {
+		t.Fatalf("invite = %s, want a token and an expiry", w.Body.String())
+	}
+	if want := "microchat://example.com/club?invite=" + invite.Token; invite.Link != want {
+		t.Errorf("Link = %q, want %q", invite.Link, want)
+	}
+
+	if code := read(nil, invite.Token, 1); code != http.StatusUnauthorized {
+		t.Errorf("unsigned read: status = %d, want 401", code)
+	}
+	if code := read(bob, "forged", 1); code != http.StatusForbidden {
+		t.Errorf("unknown invite: status = %d, want 403", code)
+	}
+	if code := read(bob, invite.Token, 2); code != http.StatusOK {
+		t.Errorf("read with invite: status = %d, want 200", code)
+	}
+	if code := send(bob, invite.Token); code != http.StatusOK {
+		t.Errorf("send with invite: status = %d, want 200", code)
+	}
+	if code := read(carol, invite.Token, 1); code != http.StatusForbidden {
+		t.Errorf("read past max uses: status = %d, want 403", code)
+	}
+	if code := send(carol, invite.Token); code != http.StatusForbidden {
+		t.Errorf("send past max uses: status = %d, want 403", code)
+	}
+	if code := read(bob, invite.Token, 3); code != http.StatusOK {
+		t.Errorf("read again by a redeemer: status = %d, want 200", code)
+	}
+
+	w = roomRequest(t, s, owner, http.MethodGet, "/api/rooms/club/invites", nil)
+	var invites []models.Invite
+	if err := json.Unmarshal(w.Body.Bytes(), &invites); err != nil || len(invites) != 1 || invites[0].Uses != 1 || invites[0].Token != "" {
+		t.Errorf("list: %s, want the invite used once, without its token", w.Body.String())
+	}
+	if w := roomRequest(t, s, bob, http.MethodGet, "/api/rooms/club/invites", nil); w.Code != http.StatusForbidden {
+		t.Errorf("list by a guest: status = %d, want 403", w.Code)
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		if _, err := store.CreateInvite(context.Background(), models.Invite{
+			Room: "club", CreatedBy: hex.EncodeToString(owner.PubKey().SerializeCompressed()[1:]),
+			ExpiresAt: new(time.Now().Add(-time.Minute)),
+		}, crypto.InviteTokenHash("expired")); err != nil {
+			t.Fatalf("CreateInvite: %v", err)
+		}
+		if code := read(carol, "expired", 4); code != http.StatusForbidden {
+			t.Errorf("expired invite: status = %d, want 403", code)
+		}
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		if w := roomRequest(t, s, bob, http.MethodDelete, "/api/rooms/club/invites/"+invite.ID, nil); w.Code != http.StatusForbidden {
+			t.Errorf("revoke by a guest: status = %d, want 403", w.Code)
+		}
+		if w := roomRequest(t, s, owner, http.MethodDelete, "/api/rooms/club/invites/"+invite.ID, nil); w.Code != http.StatusNoContent {
+			t.Errorf("revoke: status = %d, want 204; body: %s", w.Code, w.Body.String())
+		}
+		if code := read(bob, invite.Token, 5); code != http.StatusForbidden {
+			t.Errorf("read with a revoked invite: status = %d, want 403", code)
+		}
+		if code := send(bob, ""); code == http.StatusOK {
+			t.Error("send without password nor invite: status = 200, want an error")
+		}
+	})
+
+	t.Run("encrypted room", func(t *testing.T) {
+		body, _ := encryptedRoomRequest(t, "vault", "hunter22")
+		if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", body); w.Code != http.StatusOK {
+			t.Fatalf("create: status = %d, want 200", w.Code)
+		}
+		w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms/vault/invites", models.CreateInviteRequest{})
+		if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "encrypted") {
+			t.Errorf("invite to an encrypted room: status = %d, want 403; body: %s", w.Code, w.Body.String())
+		}
+	})
+}
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/EwenQuim/microchat/internal/config"
 	"github.com/EwenQuim/microchat/internal/middleware"
 	"github.com/EwenQuim/microchat/internal/models"
 	"github.com/EwenQuim/microchat/internal/services"
@@ -21,10 +24,21 @@ const (
 	passwordFailDelay         = 500 * time.Millisecond // delay on password failure to slow brute-force
 )
 
+type GetRevisionsQuery struct {
+	Password string `query:"password"`
+}
+
+type GetThreadQuery struct {
+	Password string `query:"password"`
+	Limit    int    `query:"limit"` // max replies, newest kept
+}
+
 type GetMessagesQuery struct {
 	Password string `query:"password"`
+	Invite   string `query:"invite"` // invite token, in place of the password; the request must be signed
 	Limit    int    `query:"limit"`
 	Before   string `query:"before"` // RFC3339
+	After    string `query:"after"`  // id of the last message seen: the messages since it, oldest first
 }
 
 func GetMessages(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[GetMessagesQuery]) ([]models.Message, error) {
@@ -34,17 +48,21 @@ func GetMessages(chatService *services.ChatService, pwLimiter *middleware.RateLi
 		if err != nil {
 			return nil, err
 		}
-		password := queryParams.Password
 
-		err = chatService.ValidateRoomPassword(c.Context(), room, password)
-		if err != nil {
-			ip := middleware.IPFromRequest(c.Request())
-			if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
-				return nil, fuego.HTTPError{Status: http.StatusTooManyRequests, Title: "Too Many Requests", Detail: "too many failed password attempts"}
+		allowed, err := checkReadAccess(c.Context(), c.Request(), chatService, pwLimiter, room, queryParams.Password, queryParams.Invite)
+		if !allowed {
+			return []models.Message{}, err
+		}
+
+		if queryParams.After != "" {
+			if queryParams.Before != "" {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "'before' and 'after' cannot be combined"}
 			}
-			slog.ErrorContext(c, "cannot validate password", "err", err)
-			time.Sleep(passwordFailDelay) // Mitigate brute-force attacks
-			return []models.Message{}, nil
+			messages, err := chatService.GetMessagesAfter(c.Context(), room, queryParams.After, min(queryParams.Limit, maxMessageLimit))
+			if errors.Is(err, services.ErrMessageNotFound) {
+				return nil, fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: "'after' is not a message of this room: fetch the latest messages instead", Err: err}
+			}
+			return messages, err
 		}
 
 		msgParams := services.MessageQueryParams{
@@ -65,7 +83,82 @@ func GetMessages(chatService *services.ChatService, pwLimiter *middleware.RateLi
 	}
 }
 
-func SendMessage(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithBody[models.SendMessageRequest]) (*models.Message, error) {
+type GetMessagePageQuery struct {
+	Password string `query:"password"`
+	Invite   string `query:"invite"` // invite token, in place of the password; the request must be signed
+	Limit    int    `query:"limit"`
+	Before   string `query:"before"` // cursor: the page of older messages
+	After    string `query:"after"`  // cursor: the page of newer messages
+}
+
+// GetMessagePage returns a page of messages with the cursors of the pages
+// around it. Unlike the timestamps of GetMessages, cursors tell apart the
+// messages received at the same instant.
+func GetMessagePage(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[GetMessagePageQuery]) (*models.MessagePage, error) {
+	return func(c fuego.ContextWithParams[GetMessagePageQuery]) (*models.MessagePage, error) {
+		room := c.PathParam("room")
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		msgParams := services.MessageQueryParams{
+			Limit: min(queryParams.Limit, maxMessageLimit),
+		}
+		switch {
+		case queryParams.Before != "" && queryParams.After != "":
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "'before' and 'after' cannot be combined"}
+		case queryParams.Before != "":
+			cursor, err := services.ParseMessageCursor(queryParams.Before)
+			if err != nil {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "invalid 'before' cursor: pass a cursor of a previous page"}
+			}
+			msgParams.BeforeCursor = &cursor
+		case queryParams.After != "":
+			cursor, err := services.ParseMessageCursor(queryParams.After)
+			if err != nil {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "invalid 'after' cursor: pass a cursor of a previous page"}
+			}
+			msgParams.AfterCursor = &cursor
+		}
+
+		allowed, err := checkReadAccess(c.Context(), c.Request(), chatService, pwLimiter, room, queryParams.Password, queryParams.Invite)
+		if !allowed {
+			return &models.MessagePage{Messages: []models.Message{}}, err
+		}
+
+		return chatService.GetMessagePage(c.Context(), room, msgParams)
+	}
+}
+
+// checkReadAccess checks that the request may read the messages of a room,
+// with the password or invite, or as a member of a private room. A wrong
+// password is not an error: the read is answered as if the room were empty.
+func checkReadAccess(ctx context.Context, r *http.Request, chatService *services.ChatService, pwLimiter *middleware.RateLimiter, room, password, invite string) (bool, error) {
+	pubkey, _ := middleware.PubkeyFromContext(ctx)
+	if invite != "" {
+		if err := redeemInvite(ctx, chatService, room, invite); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	err := chatService.ValidateRoomAccess(ctx, room, password, pubkey)
+	if err == nil {
+		return true, nil
+	}
+	if denied := accessError(err); denied != nil {
+		return false, denied
+	}
+	ip := middleware.IPFromRequest(r)
+	if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
+		return false, fuego.HTTPError{Status: http.StatusTooManyRequests, Title: "Too Many Requests", Detail: "too many failed password attempts"}
+	}
+	slog.ErrorContext(ctx, "cannot validate password", "err", err)
+	time.Sleep(passwordFailDelay) // Mitigate brute-force attacks
+	return false, nil
+}
+
+func SendMessage(chatService *services.ChatService, pwLimiter *middleware.RateLimiter, cfg *config.Config) func(c fuego.ContextWithBody[models.SendMessageRequest]) (*models.Message, error) {
 	return func(c fuego.ContextWithBody[models.SendMessageRequest]) (*models.Message, error) {
 		room := c.PathParam("room")
 		body, err := c.Body()
@@ -73,32 +166,327 @@ func SendMessage(chatService *services.ChatService, pwLimiter *middleware.RateLi
 			return nil, err
 		}
 
-		// Get password from header or query param
-		password := body.RoomPassword
+		ip := middleware.IPFromRequest(c.Request())
+		if err := checkSendMessage(c.Context(), chatService, pwLimiter, ip, room, body, messageMaxSkew(cfg)); err != nil {
+			return nil, err
+		}
+
+		msg, err := chatService.SendMessage(services.WithClientIP(c.Context(), ip), newMessage(room, body))
+		if err != nil {
+			return nil, sendMessageError(err)
+		}
+		return msg, nil
+	}
+}
+
+// EditMessage stores a new revision of a message. The edit must be signed by
+// the message's pubkey and bound to the message by an edit tag.
+func EditMessage(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.EditMessageRequest]) (*models.Message, error) {
+	return func(c fuego.ContextWithBody[models.EditMessageRequest]) (*models.Message, error) {
+		room, id := c.PathParam("room"), c.PathParam("id")
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+
+		msg, err := chatService.GetMessage(c.Context(), room, id)
+		if err != nil {
+			return nil, notFoundError(err)
+		}
+		if err := checkEdit(*msg, body, messageMaxSkew(cfg)); err != nil {
+			return nil, err
+		}
+		if err := chatService.CheckRoomMember(c.Context(), room, msg.Pubkey); err != nil {
+			return nil, roleError(err)
+		}
+		if err := checkEncryptedContent(c.Context(), chatService, room, body.Content); err != nil {
+			return nil, err
+		}
+
+		ctx := services.WithClientIP(c.Context(), middleware.IPFromRequest(c.Request()))
+		edited, err := chatService.EditMessage(ctx, room, id, models.MessageRevision{
+			Content:         body.Content,
+			Signature:       body.Signature,
+			SignedTimestamp: body.Timestamp,
+			Version:         body.Version,
+			Tags:            body.Tags,
+			SigScheme:       cmp.Or(body.SigScheme, crypto.SigECDSA),
+		})
+		if err != nil {
+			return nil, editMessageError(err)
+		}
+		return edited, nil
+	}
+}
+
+// checkEdit verifies that an edit is signed by the author of msg, recently,
+// over the new content and tags binding it to msg.
+func checkEdit(msg models.Message, body models.EditMessageRequest, maxSkew time.Duration) error {
+	if msg.DeletedAt != nil {
+		return editMessageError(services.ErrMessageDeleted)
+	}
+	if msg.Pubkey == "" || msg.Signature == "" {
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "unsigned messages cannot be edited"}
+	}
+	if skew := time.Since(time.Unix(body.Timestamp, 0)).Abs(); skew > maxSkew {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("timestamp is outside the accepted window of ±%s from server time", maxSkew)}
+	}
+
+	event := crypto.Event{
+		Scheme:    cmp.Or(body.SigScheme, crypto.SigECDSA),
+		Version:   body.Version,
+		Pubkey:    msg.Pubkey,
+		CreatedAt: body.Timestamp,
+		Content:   body.Content,
+		Room:      msg.Room,
+		User:      msg.User,
+		Tags:      body.Tags,
+	}
+	if !event.CoversTags() {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "edits must be signed with event version 1 or later, which covers the tags"}
+	}
+	if !crypto.HasTag(body.Tags, crypto.EditTag, msg.ID) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("tags must include [%q, %q]", crypto.EditTag, msg.ID)}
+	}
+	if err := crypto.VerifyEventSignature(event, body.Signature); err != nil {
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "edit is not signed by the author of the message: " + err.Error(), Err: err}
+	}
+	return nil
+}
+
+// editMessageError maps repository errors from editing a message to HTTP errors.
+func editMessageError(err error) error {
+	if sanctioned := sanctionedError(err); sanctioned != nil {
+		return sanctioned
+	}
+	if errors.Is(err, services.ErrMessageDeleted) || errors.Is(err, services.ErrStaleRevision) {
+		return fuego.HTTPError{Status: http.StatusConflict, Title: "Conflict", Detail: err.Error(), Err: err}
+	}
+	return notFoundError(err)
+}
+
+// GetMessageRevisions returns the earlier revisions of an edited message.
+func GetMessageRevisions(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[GetRevisionsQuery]) ([]models.MessageRevision, error) {
+	return func(c fuego.ContextWithParams[GetRevisionsQuery]) ([]models.MessageRevision, error) {
+		room := c.PathParam("room")
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		if err := checkRoomPassword(c.Request(), chatService, pwLimiter, room, queryParams.Password, pubkey); err != nil {
+			return nil, err
+		}
+
+		revisions, err := chatService.GetMessageRevisions(c.Context(), room, c.PathParam("id"))
+		if err != nil {
+			return nil, notFoundError(err)
+		}
+		return revisions, nil
+	}
+}
+
+// GetThread returns a message with its most recent replies, oldest first.
+func GetThread(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[GetThreadQuery]) (*models.MessageThread, error) {
+	return func(c fuego.ContextWithParams[GetThreadQuery]) (*models.MessageThread, error) {
+		room := c.PathParam("room")
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		if err := checkRoomPassword(c.Request(), chatService, pwLimiter, room, queryParams.Password, pubkey); err != nil {
+			return nil, err
+		}
+
+		limit := min(queryParams.Limit, maxMessageLimit)
+		thread, err := chatService.GetThread(c.Context(), room, c.PathParam("id"), limit)
+		if err != nil {
+			return nil, notFoundError(err)
+		}
+		return thread, nil
+	}
+}
+
+// checkRoomPassword rejects a wrong room password with a 403, after a delay
+// and under the per-IP password limit, and an unknown room with a 404. The
+// members of a private room are checked against pubkey, the caller.
+func checkRoomPassword(r *http.Request, chatService *services.ChatService, pwLimiter *middleware.RateLimiter, room, password, pubkey string) error {
+	err := chatService.ValidateRoomAccess(r.Context(), room, password, pubkey)
+	if denied := accessError(err); denied != nil {
+		return denied
+	}
+	if errors.Is(err, services.ErrRoomNotFound) {
+		return notFoundError(err)
+	}
+	if err != nil {
+		ip := middleware.IPFromRequest(r)
+		if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
+			return fuego.HTTPError{Status: http.StatusTooManyRequests, Title: "Too Many Requests", Detail: "too many failed password attempts"}
+		}
+		time.Sleep(passwordFailDelay) // Mitigate brute-force attacks
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "invalid room password"}
+	}
+	return nil
+}
+
+// DeleteMessage replaces a message with a tombstone. The request must be signed
+// by the author of the message, the owner or a moderator of the room, or an
+// admin key.
+func DeleteMessage(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextNoBody) (*models.Message, error) {
+	return func(c fuego.ContextNoBody) (*models.Message, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		msg, err := chatService.DeleteMessage(c.Context(), actor, c.PathParam("room"), c.PathParam("id"))
+		if err != nil {
+			return nil, roleError(err)
+		}
+		return msg, nil
+	}
+}
+
+// newMessage builds the message to store from a verified send request.
+func newMessage(room string, body models.SendMessageRequest) models.Message {
+	return models.Message{
+		Room:            room,
+		User:            body.User,
+		Content:         body.Content,
+		Signature:       body.Signature,
+		Pubkey:          body.Pubkey,
+		SignedTimestamp: body.Timestamp,
+		Version:         body.Version,
+		Tags:            body.Tags,
+		SigScheme:       cmp.Or(body.SigScheme, crypto.SigECDSA),
+		ReplyTo:         body.ReplyTo,
+	}
+}
+
+// checkReplyTo requires a reply to be signed with a ["reply", id] tag matching
+// replyTo, and the message it answers to be in the same room.
+func checkReplyTo(ctx context.Context, chatService *services.ChatService, room, replyTo string, event crypto.Event) error {
+	tagged, hasTag := crypto.TagValue(event.Tags, crypto.ReplyTag)
+	if replyTo == "" && !hasTag {
+		return nil
+	}
+	if !event.CoversTags() {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "replies must be signed with event version 1 or later, which covers the tags"}
+	}
+	if replyTo == "" || tagged != replyTo {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("reply_to must be set and tags must include [%q, reply_to]", crypto.ReplyTag)}
+	}
+	_, err := chatService.GetMessage(ctx, room, replyTo)
+	if errors.Is(err, services.ErrMessageNotFound) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "reply_to is not a message of this room", Err: err}
+	}
+	return err
+}
+
+// messageMaxSkew returns the accepted drift between a signed timestamp and the
+// server clock, falling back to the default when the config leaves it unset.
+func messageMaxSkew(cfg *config.Config) time.Duration {
+	return cmp.Or(cfg.MessageMaxSkew, config.DefaultMessageMaxSkew)
+}
+
+// sendMessageError maps repository errors from saving a message to HTTP errors.
+func sendMessageError(err error) error {
+	if sanctioned := sanctionedError(err); sanctioned != nil {
+		return sanctioned
+	}
+	if errors.Is(err, services.ErrDuplicateMessage) {
+		return fuego.HTTPError{Status: http.StatusConflict, Title: "Conflict", Detail: "message already received: signed payloads cannot be replayed", Err: err}
+	}
+	return err
+}
+
+// checkEncryptedContent requires the content of a message of an encrypted room
+// to be a NIP-44 payload, so that plaintext never reaches the database.
+func checkEncryptedContent(ctx context.Context, chatService *services.ChatService, room, content string) error {
+	r, err := chatService.GetRoom(ctx, room)
+	if errors.Is(err, services.ErrRoomNotFound) {
+		return nil // auto-created as a plaintext room
+	}
+	if err != nil {
+		return err
+	}
+	if !r.Encrypted {
+		return nil
+	}
+	if err := crypto.CheckDMPayload(content); err != nil {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "messages of an encrypted room must be NIP-44 payloads encrypted with the room key: " + err.Error(), Err: err}
+	}
+	return nil
+}
+
+// checkSendMessage applies the room password, timestamp and signature rules
+// shared by every transport that accepts new messages (HTTP POST and WebSocket).
+func checkSendMessage(ctx context.Context, chatService *services.ChatService, pwLimiter *middleware.RateLimiter, ip, room string, body models.SendMessageRequest, maxSkew time.Duration) error {
+	// Get password from header or query param
+	password := body.RoomPassword
 
+	switch {
+	case body.RoomInvite != "":
+		// An invite replaces the password: it is redeemed below, once the
+		// signature proves who presents it
+	case password != "":
 		// Validate room password if provided
-		if password != "" {
-			err := chatService.ValidateRoomPassword(c.Context(), room, password)
-			if err != nil {
-				ip := middleware.IPFromRequest(c.Request())
-				if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
-					return nil, fuego.HTTPError{Status: http.StatusTooManyRequests, Title: "Too Many Requests", Detail: "too many failed password attempts"}
-				}
-				return nil, fmt.Errorf("invalid room password")
-			}
-		} else {
-			// Check if room requires password
-			err := chatService.ValidateRoomPassword(c.Context(), room, "")
-			if errors.Is(err, crypto.ErrInvalidPassword) {
-				return nil, fmt.Errorf("password required for this room")
+		err := chatService.ValidateRoomPassword(ctx, room, password)
+		if err != nil {
+			if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
+				return fuego.HTTPError{Status: http.StatusTooManyRequests, Title: "Too Many Requests", Detail: "too many failed password attempts"}
 			}
+			return fmt.Errorf("invalid room password")
+		}
+	default:
+		// Check if room requires password
+		err := chatService.ValidateRoomPassword(ctx, room, "")
+		if errors.Is(err, crypto.ErrInvalidPassword) {
+			return fmt.Errorf("password required for this room")
 		}
+	}
+
+	if err := checkEncryptedContent(ctx, chatService, room, body.Content); err != nil {
+		return err
+	}
+
+	// A signed payload is only valid close to the time it was signed, which
+	// bounds how long a captured message could be replayed.
+	if skew := time.Since(time.Unix(body.Timestamp, 0)).Abs(); skew > maxSkew {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("timestamp is outside the accepted window of ±%s from server time", maxSkew)}
+	}
 
-		// Always verify — fuego validates required fields before we get here
-		if err := crypto.VerifyMessageSignature(body.Pubkey, body.Signature, body.Content, room, body.Timestamp); err != nil {
-			return nil, fmt.Errorf("signature verification failed: %w", err)
+	// Edits and reactions have their own routes, which bind them to a message
+	for _, tag := range []string{crypto.EditTag, crypto.ReactTag} {
+		if _, ok := crypto.TagValue(body.Tags, tag); ok {
+			return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("new messages cannot carry a %q tag", tag)}
 		}
+	}
 
-		return chatService.SendMessage(c.Context(), room, body.User, body.Content, body.Signature, body.Pubkey, body.Timestamp)
+	event := crypto.Event{
+		Scheme:    body.SigScheme,
+		Version:   body.Version,
+		Pubkey:    body.Pubkey,
+		CreatedAt: body.Timestamp,
+		Content:   body.Content,
+		Room:      room,
+		User:      body.User,
+		Tags:      body.Tags,
+	}
+	// Always verify — fuego validates required fields before we get here
+	if err := crypto.VerifyEventSignature(event, body.Signature); err != nil {
+		return fmt.Errorf("signature verification failed: %w", err)
 	}
+	if body.RoomInvite != "" {
+		if err := chatService.RedeemInvite(ctx, room, body.RoomInvite, body.Pubkey); err != nil {
+			return inviteError(err)
+		}
+	} else if err := chatService.CheckRoomMember(ctx, room, body.Pubkey); err != nil {
+		return roleError(err)
+	}
+
+	return checkReplyTo(ctx, chatService, room, body.ReplyTo, event)
 }
@@ -3,33 +3,131 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/EwenQuim/microchat/internal/config"
 	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
 	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/go-fuego/fuego"
 )
 
 // stubRepo is a no-op repository for testing.
 type stubRepo struct{}
 
-func (s *stubRepo) SaveMessage(_ context.Context, _, _, _, _, _ string, _ int64) (*models.Message, error) {
+func (s *stubRepo) SaveMessage(_ context.Context, _ models.Message) (*models.Message, error) {
 	return &models.Message{}, nil
 }
 func (s *stubRepo) GetMessages(_ context.Context, _ string, _ services.MessageQueryParams) ([]models.Message, error) {
 	return nil, nil
 }
+func (s *stubRepo) FindMessages(_ context.Context, _ services.MessageFilter) ([]models.Message, error) {
+	return nil, nil
+}
+func (s *stubRepo) SearchMessages(_ context.Context, _ services.MessageSearch) ([]models.Message, error) {
+	return nil, nil
+}
 func (s *stubRepo) GetRooms(_ context.Context) ([]models.Room, error) { return nil, nil }
+func (s *stubRepo) GetSearchableRooms(_ context.Context) ([]models.Room, error) {
+	return nil, nil
+}
 func (s *stubRepo) SearchRooms(_ context.Context, _ string) ([]models.Room, error) {
 	return nil, nil
 }
-func (s *stubRepo) CreateRoom(_ context.Context, _ string, _ *string) (*models.Room, error) {
+func (s *stubRepo) CreateRoom(_ context.Context, _ string, _ *string, _, _ string, _ bool) (*models.Room, error) {
 	return nil, nil
 }
+func (s *stubRepo) GetRoom(_ context.Context, _ string) (*models.Room, error) {
+	return nil, services.ErrRoomNotFound
+}
+func (s *stubRepo) SetRoomDescription(_ context.Context, _, _ string) error { return nil }
+func (s *stubRepo) SetRoomRetention(_ context.Context, _ string, _ models.RetentionPolicy) error {
+	return nil
+}
+func (s *stubRepo) GetRetentionPolicies(_ context.Context) (map[string]models.RetentionPolicy, error) {
+	return map[string]models.RetentionPolicy{}, nil
+}
+func (s *stubRepo) PruneMessages(_ context.Context, _ string, _ time.Time, _ int) (int64, error) {
+	return 0, nil
+}
+func (s *stubRepo) Compact(_ context.Context) error { return nil }
+func (s *stubRepo) GetRoomMembers(_ context.Context, _ string) ([]models.RoomMember, error) {
+	return []models.RoomMember{}, nil
+}
+func (s *stubRepo) SetRoomRole(_ context.Context, _, _ string, _ models.RoomRole) error {
+	return nil
+}
+func (s *stubRepo) AddRoomMember(_ context.Context, _, _ string) error { return nil }
+func (s *stubRepo) RemoveRoomMember(_ context.Context, _, _ string) error {
+	return services.ErrMemberNotFound
+}
 func (s *stubRepo) ValidateRoomPassword(_ context.Context, _, _ string) error { return nil }
+func (s *stubRepo) SetRoomPassword(_ context.Context, _ string, _ *string) error {
+	return nil
+}
+func (s *stubRepo) DeleteRoom(_ context.Context, _ string) error { return nil }
+func (s *stubRepo) GetMessage(_ context.Context, _, _ string) (*models.Message, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) DeleteMessage(_ context.Context, _, _ string) (*models.Message, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) EditMessage(_ context.Context, _, _ string, _ models.MessageRevision) (*models.Message, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) GetMessageRevisions(_ context.Context, _, _ string) ([]models.MessageRevision, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) GetReplies(_ context.Context, _, _ string, _ int) ([]models.Message, error) {
+	return []models.Message{}, nil
+}
+func (s *stubRepo) AddReaction(_ context.Context, _ string, _ models.Reaction) ([]models.ReactionCount, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) RemoveReaction(_ context.Context, _, _, _, _ string) ([]models.ReactionCount, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) GetReactions(_ context.Context, _, _ string) ([]models.Reaction, error) {
+	return nil, services.ErrMessageNotFound
+}
+func (s *stubRepo) CreateSanction(_ context.Context, sanction models.Sanction) (*models.Sanction, error) {
+	return &sanction, nil
+}
+func (s *stubRepo) GetSanctions(_ context.Context, _ string) ([]models.Sanction, error) {
+	return []models.Sanction{}, nil
+}
+func (s *stubRepo) DeleteSanction(_ context.Context, _, _ string) error {
+	return services.ErrSanctionNotFound
+}
+func (s *stubRepo) CreateInvite(_ context.Context, invite models.Invite, _ string) (*models.Invite, error) {
+	return &invite, nil
+}
+func (s *stubRepo) GetInvites(_ context.Context, _ string) ([]models.Invite, error) {
+	return []models.Invite{}, nil
+}
+func (s *stubRepo) DeleteInvite(_ context.Context, _, _ string) error {
+	return services.ErrInviteNotFound
+}
+func (s *stubRepo) RedeemInvite(_ context.Context, _, _, _ string) error {
+	return services.ErrInviteInvalid
+}
+func (s *stubRepo) SaveDirectMessage(_ context.Context, dm models.DirectMessage) (*models.DirectMessage, error) {
+	return &dm, nil
+}
+func (s *stubRepo) GetDirectMessages(_ context.Context, _, _ string, _ services.MessageQueryParams) ([]models.DirectMessage, error) {
+	return []models.DirectMessage{}, nil
+}
 func (s *stubRepo) RegisterUser(_ context.Context, _ string) (*models.User, error) {
 	return nil, nil
 }
@@ -81,6 +179,132 @@ func TestGetMessages_ValidBefore_Returns200(t *testing.T) {
 	}
 }
 
+func TestGetMessages_After(t *testing.T) {
+	store := memory.NewStore()
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(store), &config.Config{})
+
+	var ids []string
+	for range 4 {
+		msg, err := store.SaveMessage(context.Background(), models.Message{Room: "test", User: "alice", Content: "hi"})
+		if err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+		ids = append(ids, msg.ID)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/rooms/test/messages?"+query, nil))
+		return w
+	}
+
+	w := get("limit=2&after=" + ids[0])
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var messages []models.Message
+	if err := json.Unmarshal(w.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	var got []string
+	for _, msg := range messages {
+		got = append(got, msg.ID)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(ids[1:3]) {
+		t.Errorf("got messages %v, want the oldest after the first: %v", got, ids[1:3])
+	}
+
+	if w := get("after=" + ids[3]); w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("after the newest: status = %d, body = %s, want 200 and []", w.Code, w.Body.String())
+	}
+	if w := get("after=unknown"); w.Code != http.StatusNotFound {
+		t.Errorf("after an unknown message: status = %d, want 404", w.Code)
+	}
+	if w := get("after=" + ids[0] + "&before=2030-01-01T00:00:00Z"); w.Code != http.StatusBadRequest {
+		t.Errorf("after and before: status = %d, want 400", w.Code)
+	}
+}
+
+func TestGetMessagePage_WalksBothWays(t *testing.T) {
+	store := memory.NewStore()
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(store), &config.Config{})
+
+	var ids []string
+	for range 5 {
+		msg, err := store.SaveMessage(context.Background(), models.Message{Room: "test", User: "alice", Content: "hi"})
+		if err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+		ids = append(ids, msg.ID)
+	}
+
+	getPage := func(query string) models.MessagePage {
+		t.Helper()
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/rooms/test/messages/page?limit=2"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+		var page models.MessagePage
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return page
+	}
+	checkPage := func(page models.MessagePage, want ...string) {
+		t.Helper()
+		var got []string
+		for _, msg := range page.Messages {
+			got = append(got, msg.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("got messages %v, want %v", got, want)
+		}
+	}
+
+	latest := getPage("")
+	checkPage(latest, ids[3:]...)
+	middle := getPage("&before=" + latest.PrevCursor)
+	checkPage(middle, ids[1:3]...)
+	oldest := getPage("&before=" + middle.PrevCursor)
+	checkPage(oldest, ids[0])
+	if oldest.PrevCursor != "" {
+		t.Errorf("got prev_cursor %q on the oldest page, want none", oldest.PrevCursor)
+	}
+
+	// And back to the latest messages
+	newer := getPage("&after=" + oldest.NextCursor)
+	checkPage(newer, ids[1:3]...)
+	if newer.PrevCursor == "" {
+		t.Error("got no prev_cursor after a cursor, want one")
+	}
+	newest := getPage("&after=" + newer.NextCursor)
+	checkPage(newest, ids[3:]...)
+	end := getPage("&after=" + newest.NextCursor)
+	checkPage(end)
+	if end.NextCursor != newest.NextCursor {
+		t.Errorf("got next_cursor %q past the newest message, want %q to keep polling", end.NextCursor, newest.NextCursor)
+	}
+}
+
+func TestGetMessagePage_InvalidCursor_Returns400(t *testing.T) {
+	s := newTestServer(t)
+
+	cursor := services.MessageCursor{Timestamp: time.Now(), ID: "id"}.String()
+	for _, query := range []string{"before=2025-01-01T00:00:00Z", "after=not-a-cursor", "before=" + cursor + "&after=" + cursor} {
+		req := httptest.NewRequest(http.MethodGet, "/api/rooms/test/messages/page?"+query, nil)
+		w := httptest.NewRecorder()
+
+		s.Mux.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400; body: %s", query, w.Code, w.Body.String())
+		}
+	}
+}
+
 func TestSendMessage_MissingSignature_Returns400(t *testing.T) {
 	s := newTestServer(t)
 
@@ -95,3 +319,511 @@ func TestSendMessage_MissingSignature_Returns400(t *testing.T) {
 		t.Errorf("status = %d, want 400; body: %s", w.Code, w.Body.String())
 	}
 }
+
+// dupRepo reports every save as a replay.
+type dupRepo struct{ stubRepo }
+
+func (r *dupRepo) SaveMessage(_ context.Context, _ models.Message) (*models.Message, error) {
+	return nil, services.ErrDuplicateMessage
+}
+
+func postMessage(t *testing.T, s *fuego.Server, room string, body models.SendMessageRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/rooms/"+room+"/messages", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestSendMessage_StaleTimestamp_Returns400(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, offset := range []time.Duration{-time.Hour, time.Hour} {
+		body := signedRequestAt(t, "test", "hello", time.Now().Add(offset).Unix())
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusBadRequest {
+			t.Errorf("offset %v: status = %d, want 400; body: %s", offset, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestSendMessage_SkewIsConfigurable(t *testing.T) {
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(&stubRepo{}), &config.Config{MessageMaxSkew: 2 * time.Hour})
+
+	body := signedRequestAt(t, "test", "hello", time.Now().Add(-time.Hour).Unix())
+	if w := postMessage(t, s, "test", body); w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 within a 2h window; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSendMessage_Replay_Returns409(t *testing.T) {
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(&dupRepo{}), &config.Config{})
+
+	if w := postMessage(t, s, "test", signedRequest(t, "test", "hello")); w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409; body: %s", w.Code, w.Body.String())
+	}
+}
+
+// derSignature re-encodes a compact hex signature as DER, which the server
+// also verifies.
+func derSignature(t *testing.T, compactHex string) string {
+	t.Helper()
+	compact, err := hex.DecodeString(compactHex)
+	if err != nil || len(compact) != 64 {
+		t.Fatalf("not a compact signature: %q", compactHex)
+	}
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(compact[:32])
+	s.SetByteSlice(compact[32:])
+	return hex.EncodeToString(ecdsa.NewSignature(&r, &s).Serialize())
+}
+
+func TestSendMessage_ReencodedReplay_Returns409(t *testing.T) {
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(memory.NewStore()), &config.Config{})
+
+	sent := signedRequest(t, "test", "hello")
+	if w := postMessage(t, s, "test", sent); w.Code != http.StatusOK {
+		t.Fatalf("send: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	der := sent
+	der.Signature = derSignature(t, sent.Signature)
+	upper := sent
+	upper.Signature = strings.ToUpper(sent.Signature)
+	for name, body := range map[string]models.SendMessageRequest{"DER": der, "upper case": upper} {
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusConflict {
+			t.Errorf("%s replay: status = %d, want 409; body: %s", name, w.Code, w.Body.String())
+		}
+	}
+}
+
+// signedRequestV1 signs a version 1 event, which also covers the user and tags.
+func signedRequestV1(t *testing.T, room, content, user string, tags [][]string) models.SendMessageRequest {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	return signMessageV1(t, key, room, content, user, tags)
+}
+
+// signMessageV1 signs a message with key as a version 1 event.
+func signMessageV1(t *testing.T, key *secp256k1.PrivateKey, room, content, user string, tags [][]string) models.SendMessageRequest {
+	t.Helper()
+	req := models.SendMessageRequest{
+		User:      user,
+		Content:   content,
+		Pubkey:    hex.EncodeToString(key.PubKey().SerializeCompressed()),
+		Timestamp: time.Now().Unix(),
+		Version:   crypto.EventV1,
+		Tags:      tags,
+	}
+	hash, err := crypto.Event{
+		Version: req.Version, Pubkey: req.Pubkey, CreatedAt: req.Timestamp,
+		Content: content, Room: room, User: user, Tags: tags,
+	}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	req.Signature = hex.EncodeToString(ecdsa.SignCompact(key, hash, true)[1:])
+	return req
+}
+
+func TestSendMessage_EventV1(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("valid", func(t *testing.T) {
+		body := signedRequestV1(t, "test", "hello", "alice", [][]string{{"t", "intro"}})
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("user changed after signing", func(t *testing.T) {
+		body := signedRequestV1(t, "test", "hello", "alice", nil)
+		body.User = "mallory"
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+
+	t.Run("tags changed after signing", func(t *testing.T) {
+		body := signedRequestV1(t, "test", "hello", "alice", [][]string{{"t", "intro"}})
+		body.Tags = [][]string{{"t", "other"}}
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		body := signedRequestV1(t, "test", "hello", "alice", nil)
+		body.Version = 7
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+}
+
+// signedRequestSchnorr signs a NIP-01 event with BIP-340 Schnorr.
+func signedRequestSchnorr(t *testing.T, room, content string, tags [][]string) models.SendMessageRequest {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	req := models.SendMessageRequest{
+		User:      "alice",
+		Content:   content,
+		Pubkey:    hex.EncodeToString(schnorr.SerializePubKey(key.PubKey())),
+		Timestamp: time.Now().Unix(),
+		Tags:      tags,
+		SigScheme: crypto.SigSchnorr,
+	}
+	id, err := crypto.Event{
+		Scheme: crypto.SigSchnorr, Pubkey: req.Pubkey, CreatedAt: req.Timestamp,
+		Content: content, Room: room, Tags: tags,
+	}.Hash() // the user is only signed through the tags
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Signature = hex.EncodeToString(sig.Serialize())
+	return req
+}
+
+func TestSendMessage_Schnorr(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("valid", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}, {crypto.NameTag, "alice"}})
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("posted to another room", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}, {crypto.NameTag, "alice"}})
+		if w := postMessage(t, s, "other", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+
+	t.Run("verified as ECDSA", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}, {crypto.NameTag, "alice"}})
+		body.SigScheme = ""
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+
+	t.Run("renamed", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}, {crypto.NameTag, "alice"}})
+		body.User = "mallory"
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the signature to be rejected", w.Code)
+		}
+	})
+
+	t.Run("without name tag", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}})
+		if w := postMessage(t, s, "test", body); w.Code == http.StatusOK {
+			t.Errorf("status = %d, want the unsigned name to be rejected", w.Code)
+		}
+		// Nostr clients send no name tag: the name then derives from the pubkey
+		body.User = crypto.NostrDisplayName(body.Pubkey)
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusOK {
+			t.Errorf("name of the pubkey: status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		body := signedRequestSchnorr(t, "test", "hello", [][]string{{crypto.RoomTag, "test"}, {crypto.NameTag, "alice"}})
+		body.SigScheme = "rsa"
+		if w := postMessage(t, s, "test", body); w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400; body: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+// deleteRepo holds a single message by author.
+type deleteRepo struct {
+	stubRepo
+	author  string
+	deleted bool
+}
+
+func (r *deleteRepo) GetMessage(_ context.Context, room, id string) (*models.Message, error) {
+	if id != "msg-1" {
+		return nil, services.ErrMessageNotFound
+	}
+	return &models.Message{ID: id, Room: room, Pubkey: r.author, Content: "oops"}, nil
+}
+
+func (r *deleteRepo) DeleteMessage(_ context.Context, room, id string) (*models.Message, error) {
+	r.deleted = true
+	return &models.Message{ID: id, Room: room, Pubkey: r.author, DeletedAt: new(time.Now())}, nil
+}
+
+func TestDeleteMessage(t *testing.T) {
+	author, _ := secp256k1.GeneratePrivateKey()
+	admin, _ := secp256k1.GeneratePrivateKey()
+	other, _ := secp256k1.GeneratePrivateKey()
+	cfg := &config.Config{AdminPubkeys: []string{hex.EncodeToString(admin.PubKey().SerializeCompressed())}}
+
+	tests := []struct {
+		name string
+		key  *secp256k1.PrivateKey
+		path string
+		want int
+	}{
+		{"unsigned", nil, "/api/rooms/general/messages/msg-1", http.StatusUnauthorized},
+		{"other key", other, "/api/rooms/general/messages/msg-1", http.StatusForbidden},
+		{"unknown message", author, "/api/rooms/general/messages/msg-2", http.StatusNotFound},
+		{"author", author, "/api/rooms/general/messages/msg-1", http.StatusOK},
+		{"admin", admin, "/api/rooms/general/messages/msg-1", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &deleteRepo{author: hex.EncodeToString(author.PubKey().SerializeCompressed())}
+			s := fuego.NewServer(fuego.WithoutLogger())
+			RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(repo), cfg)
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			if tt.key != nil {
+				signHTTPRequest(t, tt.key, req, nil)
+			}
+			w := httptest.NewRecorder()
+			s.Mux.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body: %s", w.Code, tt.want, w.Body.String())
+			}
+			if repo.deleted != (tt.want == http.StatusOK) {
+				t.Errorf("deleted = %v after status %d", repo.deleted, w.Code)
+			}
+			if tt.want == http.StatusOK {
+				var msg models.Message
+				if err := json.Unmarshal(w.Body.Bytes(), &msg); err != nil || msg.DeletedAt == nil {
+					t.Errorf("response = %s, want a tombstone", w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// signEdit signs an edit of msg with key as an ECDSA v1 event.
+func signEdit(t *testing.T, key *secp256k1.PrivateKey, msg models.Message, content string, ts int64) models.EditMessageRequest {
+	t.Helper()
+	req := models.EditMessageRequest{
+		Content:   content,
+		Timestamp: ts,
+		Version:   crypto.EventV1,
+		Tags:      [][]string{{crypto.EditTag, msg.ID}},
+	}
+	hash, err := crypto.Event{
+		Version: req.Version, Pubkey: msg.Pubkey, CreatedAt: ts,
+		Content: content, Room: msg.Room, User: msg.User, Tags: req.Tags,
+	}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	req.Signature = hex.EncodeToString(ecdsa.SignCompact(key, hash, true)[1:])
+	return req
+}
+
+// signEditSchnorr signs an edit of msg with key as a NIP-01 event carrying
+// the room and edit tags and extra.
+func signEditSchnorr(t *testing.T, key *secp256k1.PrivateKey, msg models.Message, content string, ts int64, extra ...[]string) models.EditMessageRequest {
+	t.Helper()
+	req := models.EditMessageRequest{
+		Content:   content,
+		Timestamp: ts,
+		SigScheme: crypto.SigSchnorr,
+		Tags:      append([][]string{{crypto.RoomTag, msg.Room}, {crypto.EditTag, msg.ID}}, extra...),
+	}
+	id, err := crypto.Event{
+		Scheme: crypto.SigSchnorr, Pubkey: msg.Pubkey, CreatedAt: ts,
+		Content: content, Room: msg.Room, Tags: req.Tags,
+	}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	req.Signature = hex.EncodeToString(sig.Serialize())
+	return req
+}
+
+func putEdit(t *testing.T, s *fuego.Server, msg models.Message, body models.EditMessageRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPut, "/api/rooms/"+msg.Room+"/messages/"+msg.ID, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestEditMessage(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	key, _ := secp256k1.GeneratePrivateKey()
+	now := time.Now().Unix()
+	original, err := chatService.SendMessage(context.Background(), models.Message{
+		Room: "general", User: "alice", Content: "helo", Signature: "sig",
+		Pubkey: hex.EncodeToString(key.PubKey().SerializeCompressed()), SignedTimestamp: now - 10,
+	})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		other, _ := secp256k1.GeneratePrivateKey()
+		untagged := signEdit(t, key, *original, "hello", now)
+		untagged.Tags = nil
+		v0 := signEdit(t, key, *original, "hello", now)
+		v0.Version = crypto.EventV0
+		tampered := signEdit(t, key, *original, "hello", now)
+		tampered.Content = "bye"
+
+		for name, tt := range map[string]struct {
+			body models.EditMessageRequest
+			want int
+		}{
+			"other key":      {signEdit(t, other, *original, "hello", now), http.StatusForbidden},
+			"tampered":       {tampered, http.StatusForbidden},
+			"no edit tag":    {untagged, http.StatusBadRequest},
+			"version 0":      {v0, http.StatusBadRequest},
+			"stale":          {signEdit(t, key, *original, "hello", now-20), http.StatusConflict},
+			"outside window": {signEdit(t, key, *original, "hello", now+3600), http.StatusBadRequest},
+			// The name of the message is signed with the edit
+			"schnorr, no name tag":    {signEditSchnorr(t, key, *original, "hello", now), http.StatusForbidden},
+			"schnorr, other name tag": {signEditSchnorr(t, key, *original, "hello", now, []string{crypto.NameTag, "mallory"}), http.StatusForbidden},
+		} {
+			if w := putEdit(t, s, *original, tt.body); w.Code != tt.want {
+				t.Errorf("%s: status = %d, want %d; body: %s", name, w.Code, tt.want, w.Body.String())
+			}
+		}
+	})
+
+	edit := signEdit(t, key, *original, "hello", now)
+	w := putEdit(t, s, *original, edit)
+	if w.Code != http.StatusOK {
+		t.Fatalf("edit: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var edited models.Message
+	if err := json.Unmarshal(w.Body.Bytes(), &edited); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if edited.Content != "hello" || edited.Revisions != 1 || edited.EditedAt == nil || edited.SignedTimestamp != now {
+		t.Errorf("edited = %+v, want content hello, 1 revision and the edit's signed fields", edited)
+	}
+
+	if w := putEdit(t, s, *original, edit); w.Code != http.StatusConflict {
+		t.Errorf("replayed edit: status = %d, want 409", w.Code)
+	}
+
+	schnorrEdit := signEditSchnorr(t, key, *original, "hello!", now+1, []string{crypto.NameTag, "alice"})
+	if w := putEdit(t, s, *original, schnorrEdit); w.Code != http.StatusOK {
+		t.Errorf("schnorr edit: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/general/messages/"+original.ID+"/revisions", nil)
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	var revisions []models.MessageRevision
+	if err := json.Unmarshal(w.Body.Bytes(), &revisions); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("revisions: status = %d, body: %s", w.Code, w.Body.String())
+	}
+	if len(revisions) != 2 || revisions[0].Revision != 0 || revisions[0].Content != "helo" {
+		t.Errorf("revisions = %+v, want the original content as revision 0 and the first edit", revisions)
+	}
+}
+
+func TestSendMessage_EditTag_Returns400(t *testing.T) {
+	s := newTestServer(t)
+
+	body := signedRequestV1(t, "test", "hello", "alice", [][]string{{crypto.EditTag, "some-id"}})
+	if w := postMessage(t, s, "test", body); w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSendMessage_Reply(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	parent, err := chatService.SendMessage(context.Background(), models.Message{Room: "general", User: "alice", Content: "question?"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	replyTag := [][]string{{crypto.ReplyTag, parent.ID}}
+
+	t.Run("rejected", func(t *testing.T) {
+		untagged := signedRequestV1(t, "general", "answer", "bob", nil)
+		untagged.ReplyTo = parent.ID
+		mismatched := signedRequestV1(t, "general", "answer", "bob", [][]string{{crypto.ReplyTag, "other-id"}})
+		mismatched.ReplyTo = parent.ID
+		unknown := signedRequestV1(t, "general", "answer", "bob", [][]string{{crypto.ReplyTag, "missing"}})
+		unknown.ReplyTo = "missing"
+		v0 := signedRequest(t, "general", "answer")
+		v0.ReplyTo = parent.ID
+
+		for name, body := range map[string]models.SendMessageRequest{
+			"no reply tag":   untagged,
+			"tag mismatch":   mismatched,
+			"unknown parent": unknown,
+			"version 0":      v0,
+			"tag only":       signedRequestV1(t, "general", "answer", "bob", replyTag),
+		} {
+			if w := postMessage(t, s, "general", body); w.Code != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want 400; body: %s", name, w.Code, w.Body.String())
+			}
+		}
+	})
+
+	for _, content := range []string{"first answer", "second answer"} {
+		body := signedRequestV1(t, "general", content, "bob", replyTag)
+		body.ReplyTo = parent.ID
+		if w := postMessage(t, s, "general", body); w.Code != http.StatusOK {
+			t.Fatalf("reply: status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/general/messages/"+parent.ID+"/thread?limit=1", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	var thread models.MessageThread
+	if err := json.Unmarshal(w.Body.Bytes(), &thread); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("thread: status = %d, body: %s", w.Code, w.Body.String())
+	}
+	if thread.Root.ID != parent.ID || thread.Root.Replies != 2 {
+		t.Errorf("root = %+v, want the parent with 2 replies", thread.Root)
+	}
+	if len(thread.Replies) != 1 || thread.Replies[0].Content != "second answer" || thread.Replies[0].ReplyTo != parent.ID {
+		t.Errorf("replies = %+v, want the latest reply only", thread.Replies)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/rooms/general/messages/missing/thread", nil)
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown message: status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/go-fuego/fuego"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+const (
+	maxNostrFilters     = 10  // filters accepted in a single REQ
+	maxNostrSubIDLength = 64  // NIP-01 subscription id limit
+	maxNostrLimit       = 500 // stored events returned per filter
+	defaultNostrLimit   = 100 // stored events per filter when the client sets no limit
+	maxNostrRoomLength  = 50  // same bound as CreateRoomRequest.Name
+)
+
+// nostrRelayInfo is the NIP-11 relay information document.
+type nostrRelayInfo struct {
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	SupportedNIPs []int            `json:"supported_nips"`
+	Software      string           `json:"software"`
+	Limitation    nostrRelayLimits `json:"limitation"`
+}
+
+type nostrRelayLimits struct {
+	MaxMessageLength int `json:"max_message_length"`
+	MaxSubscriptions int `json:"max_subscriptions"`
+	MaxFilters       int `json:"max_filters"`
+	MaxLimit         int `json:"max_limit"`
+	MaxSubIDLength   int `json:"max_subid_length"`
+}
+
+// NostrRelay serves GET /nostr: a NIP-01 relay over the chat rooms. Rooms map
+// to the "h" tag of kind 9 events (NIP-29 group chat messages). Published
+// events go through the same password, timestamp, signature and rate-limit
+// rules as POST /rooms/{room}/messages; subscriptions only ever see rooms
+// without a password.
+func NostrRelay(chatService *services.ChatService, rl *middleware.RateLimiter, cfg *config.Config) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/nostr+json" {
+			w.Header().Set("Content-Type", "application/nostr+json")
+			_ = json.NewEncoder(w).Encode(nostrRelayInfo{
+				Name:          cfg.QuickName,
+				Description:   cfg.Description,
+				SupportedNIPs: []int{1, 11},
+				Software:      "https://github.com/EwenQuim/microchat",
+				Limitation: nostrRelayLimits{
+					MaxMessageLength: maxWSFrameBytes,
+					MaxSubscriptions: maxWSSubscriptions,
+					MaxFilters:       maxNostrFilters,
+					MaxLimit:         maxNostrLimit,
+					MaxSubIDLength:   maxNostrSubIDLength,
+				},
+			})
+			return
+		}
+
+		serveWebSocket(w, r, func(ctx context.Context, conn *websocket.Conn) error {
+			session := &nostrSession{
+				chatService: chatService,
+				rl:          rl,
+				maxSkew:     messageMaxSkew(cfg),
+				conn:        conn,
+				ip:          middleware.IPFromRequest(r),
+				subs:        make(map[string]*nostrSubscription),
+			}
+			return session.run(ctx)
+		})
+	}
+}
+
+// nostrSession holds the state of one Nostr relay connection.
+type nostrSession struct {
+	chatService *services.ChatService
+	rl          *middleware.RateLimiter
+	maxSkew     time.Duration
+	conn        *websocket.Conn
+	ip          string
+
+	mu   sync.Mutex
+	subs map[string]*nostrSubscription
+	wg   sync.WaitGroup
+}
+
+// nostrSubscription is an open REQ: its filters and the live feed of new
+// messages matched against them.
+type nostrSubscription struct {
+	id      string
+	filters []models.NostrFilter
+	live    *services.Subscription
+}
+
+func (s *nostrSession) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.closeAll()
+		s.wg.Wait()
+	}()
+
+	s.wg.Go(func() { keepalive(ctx, s.conn) })
+
+	for {
+		_, data, err := s.conn.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		var frame []json.RawMessage
+		var frameType string
+		if json.Unmarshal(data, &frame) != nil || len(frame) == 0 || json.Unmarshal(frame[0], &frameType) != nil {
+			if err := s.write(ctx, models.NostrNotice, "invalid: expected a JSON array starting with a message type"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.handle(ctx, frameType, frame[1:]); err != nil {
+			return err
+		}
+	}
+}
+
+// handle processes one client frame. Rejections are reported to the client
+// with OK, CLOSED or NOTICE frames; only connection failures are returned.
+func (s *nostrSession) handle(ctx context.Context, frameType string, args []json.RawMessage) error {
+	switch frameType {
+	case models.NostrEvent:
+		var event models.NostrSignedEvent
+		if len(args) != 1 || json.Unmarshal(args[0], &event) != nil {
+			return s.write(ctx, models.NostrNotice, "invalid: EVENT expects a single event object")
+		}
+		accepted, reason := s.publish(ctx, event)
+		return s.write(ctx, models.NostrOK, event.ID, accepted, reason)
+
+	case models.NostrReq:
+		var subID string
+		if len(args) < 2 || json.Unmarshal(args[0], &subID) != nil {
+			return s.write(ctx, models.NostrNotice, "invalid: REQ expects a subscription id and at least one filter")
+		}
+		filters := make([]models.NostrFilter, len(args)-1)
+		for i, raw := range args[1:] {
+			if err := json.Unmarshal(raw, &filters[i]); err != nil {
+				return s.write(ctx, models.NostrClosed, subID, "invalid: malformed filter")
+			}
+		}
+		return s.subscribe(ctx, subID, filters)
+
+	case models.NostrClose:
+		var subID string
+		if len(args) != 1 || json.Unmarshal(args[0], &subID) != nil {
+			return s.write(ctx, models.NostrNotice, "invalid: CLOSE expects a subscription id")
+		}
+		s.unsubscribe(subID)
+		return nil
+
+	default:
+		return s.write(ctx, models.NostrNotice, "invalid: unknown message type "+frameType)
+	}
+}
+
+// publish validates a client event and stores it as a chat message. It returns
+// the NIP-01 OK status and machine-readable reason.
+func (s *nostrSession) publish(ctx context.Context, event models.NostrSignedEvent) (bool, string) {
+	if event.Kind != crypto.KindChatMessage {
+		return false, "invalid: only kind 9 chat messages are accepted"
+	}
+	room := nostrRoom(event.Tags)
+	if room == "" || len(room) > maxNostrRoomLength {
+		return false, `invalid: events need an ["h", room] tag naming a room of at most 50 characters`
+	}
+	if event.Content == "" || event.Sig == "" || event.CreatedAt == 0 {
+		return false, "invalid: content, sig and created_at are required"
+	}
+
+	id, err := nostrCryptoEvent(room, event.Pubkey, event.CreatedAt, event.Content, event.Tags).Hash()
+	if err != nil {
+		return false, "invalid: " + err.Error()
+	}
+	if hex.EncodeToString(id) != event.ID {
+		return false, "invalid: event id does not match the event"
+	}
+
+	if !middleware.AllowMessage(s.rl, s.ip, event.Pubkey, sendMessageBurst, sendMessageRateLimitPerMin, time.Minute) {
+		return false, "rate-limited: too many events"
+	}
+	if !s.readable(ctx, room) {
+		return false, "restricted: this room requires a password or is private"
+	}
+
+	body := models.SendMessageRequest{
+		User:      nostrUser(event),
+		Content:   event.Content,
+		Signature: event.Sig,
+		Pubkey:    event.Pubkey,
+		Timestamp: event.CreatedAt,
+		Tags:      event.Tags,
+		SigScheme: crypto.SigSchnorr,
+	}
+	body.ReplyTo, _ = crypto.TagValue(event.Tags, crypto.ReplyTag)
+	if err := checkSendMessage(ctx, s.chatService, s.rl, s.ip, room, body, s.maxSkew); err != nil {
+		var httpErr fuego.HTTPError
+		if errors.As(err, &httpErr) {
+			if httpErr.Status == http.StatusTooManyRequests {
+				return false, "rate-limited: " + httpErr.Detail
+			}
+			return false, "invalid: " + httpErr.Detail
+		}
+		return false, "invalid: " + err.Error()
+	}
+
+	if _, err := s.chatService.SendMessage(services.WithClientIP(ctx, s.ip), newMessage(room, body)); err != nil {
+		if errors.Is(err, services.ErrDuplicateMessage) {
+			return true, "duplicate: already have this event"
+		}
+		if errors.Is(err, services.ErrMuted) || errors.Is(err, services.ErrBanned) {
+			return false, "blocked: " + err.Error()
+		}
+		return false, "error: could not store the event"
+	}
+	return true, ""
+}
+
+// subscribe opens (or replaces) a subscription: stored events matching the
+// filters are sent first, followed by EOSE, then live events.
+func (s *nostrSession) subscribe(ctx context.Context, subID string, filters []models.NostrFilter) error {
+	if subID == "" || len(subID) > maxNostrSubIDLength {
+		return s.write(ctx, models.NostrClosed, subID, "invalid: subscription id must be 1 to 64 characters")
+	}
+	if len(filters) > maxNostrFilters {
+		return s.write(ctx, models.NostrClosed, subID, "invalid: too many filters")
+	}
+
+	// A REQ reusing an id replaces the previous subscription (NIP-01).
+	s.unsubscribe(subID)
+	s.mu.Lock()
+	if len(s.subs) >= maxWSSubscriptions {
+		s.mu.Unlock()
+		return s.write(ctx, models.NostrClosed, subID, "error: too many subscriptions on this connection")
+	}
+	sub := &nostrSubscription{id: subID, filters: filters, live: s.chatService.SubscribeAll()}
+	s.subs[subID] = sub
+	s.mu.Unlock()
+
+	sent := make(map[string]bool)
+	for _, filter := range filters {
+		events, err := s.storedEvents(ctx, filter)
+		if err != nil {
+			s.unsubscribe(subID)
+			return s.write(ctx, models.NostrClosed, subID, "error: could not read stored events")
+		}
+		for _, event := range events {
+			if sent[event.ID] {
+				continue
+			}
+			sent[event.ID] = true
+			if err := s.write(ctx, models.NostrEvent, subID, event); err != nil {
+				return err
+			}
+		}
+	}
+	if err := s.write(ctx, models.NostrEOSE, subID); err != nil {
+		return err
+	}
+
+	s.wg.Go(func() { s.forward(ctx, sub, sent) })
+	return nil
+}
+
+// storedEvents reads the stored messages matching filter, newest first.
+func (s *nostrSession) storedEvents(ctx context.Context, filter models.NostrFilter) ([]models.NostrSignedEvent, error) {
+	if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, crypto.KindChatMessage) {
+		return nil, nil
+	}
+	limit := defaultNostrLimit
+	if filter.Limit != nil {
+		limit = min(*filter.Limit, maxNostrLimit)
+	}
+	if len(filter.IDs) > 0 {
+		// Event ids are not stored; match them after the query.
+		limit = maxNostrLimit
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	rooms, err := s.readableRooms(ctx, filter.Rooms)
+	if err != nil || len(rooms) == 0 {
+		return nil, err
+	}
+
+	messages, err := s.chatService.FindMessages(ctx, services.MessageFilter{
+		Rooms:     rooms,
+		Authors:   filter.Authors,
+		SigScheme: crypto.SigSchnorr,
+		Since:     filter.Since,
+		Until:     filter.Until,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.NostrSignedEvent, 0, len(messages))
+	for _, msg := range messages {
+		event, err := nostrEventFromMessage(msg)
+		if err != nil {
+			continue // not representable as a valid Nostr event
+		}
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, event.ID) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// readableRooms returns the requested rooms that have no password and are not
+// private, or every such room when none is requested.
+func (s *nostrSession) readableRooms(ctx context.Context, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		open, err := s.chatService.GetOpenRooms(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rooms := make([]string, 0, len(open))
+		for _, room := range open {
+			rooms = append(rooms, room.Name)
+		}
+		return rooms, nil
+	}
+
+	rooms := make([]string, 0, len(requested))
+	for _, room := range requested {
+		if !slices.Contains(rooms, room) && s.readable(ctx, room) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+// readable reports whether room can be read without a password or a
+// membership. Rooms that do not exist yet are readable: publishing creates
+// them as public rooms.
+func (s *nostrSession) readable(ctx context.Context, room string) bool {
+	err := s.chatService.ValidateRoomAccess(ctx, room, "", "")
+	return err == nil || errors.Is(err, services.ErrRoomNotFound)
+}
+
+// forward delivers live messages matching a subscription's filters.
+func (s *nostrSession) forward(ctx context.Context, sub *nostrSubscription, sent map[string]bool) {
+	for msg := range sub.live.Messages {
+		if msg.SigScheme != crypto.SigSchnorr {
+			continue
+		}
+		event, err := nostrEventFromMessage(msg)
+		if err != nil || sent[event.ID] || !nostrMatchesAny(sub.filters, msg, event.ID) || !s.readable(ctx, msg.Room) {
+			continue
+		}
+		if err := s.write(ctx, models.NostrEvent, sub.id, event); err != nil {
+			return
+		}
+	}
+
+	// The hub dropped a slow subscriber. Closed-on-purpose subscriptions are
+	// no longer in subs.
+	s.mu.Lock()
+	dropped := s.subs[sub.id] == sub
+	if dropped {
+		delete(s.subs, sub.id)
+	}
+	s.mu.Unlock()
+	if dropped {
+		_ = s.write(ctx, models.NostrClosed, sub.id, "error: subscriber fell behind")
+	}
+}
+
+func (s *nostrSession) unsubscribe(subID string) {
+	s.mu.Lock()
+	sub := s.subs[subID]
+	delete(s.subs, subID)
+	s.mu.Unlock()
+	if sub != nil {
+		sub.live.Close()
+	}
+}
+
+func (s *nostrSession) write(ctx context.Context, frame ...any) error {
+	ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, s.conn, frame)
+}
+
+func (s *nostrSession) closeAll() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*nostrSubscription)
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.live.Close()
+	}
+}
+
+// nostrMatchesAny reports whether a live message matches one of the filters.
+func nostrMatchesAny(filters []models.NostrFilter, msg models.Message, eventID string) bool {
+	for _, filter := range filters {
+		if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, crypto.KindChatMessage) {
+			continue
+		}
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, eventID) {
+			continue
+		}
+		rooms := filter.Rooms
+		if len(rooms) == 0 {
+			rooms = []string{msg.Room}
+		}
+		match := services.MessageFilter{
+			Rooms:   rooms,
+			Authors: filter.Authors,
+			Since:   filter.Since,
+			Until:   filter.Until,
+		}
+		if match.Match(msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// nostrUser returns the display name signed in the name tag of event, or the
+// one derived from its pubkey when it has none.
+func nostrUser(event models.NostrSignedEvent) string {
+	if name, ok := crypto.TagValue(event.Tags, crypto.NameTag); ok {
+		return name
+	}
+	return crypto.NostrDisplayName(event.Pubkey)
+}
+
+// nostrRoom returns the room named by the first "h" tag.
+func nostrRoom(tags [][]string) string {
+	for _, tag := range tags {
+		if len(tag) >= 2 && tag[0] == crypto.RoomTag {
+			return tag[1]
+		}
+	}
+	return ""
+}
+
+func nostrCryptoEvent(room, pubkey string, createdAt int64, content string, tags [][]string) crypto.Event {
+	return crypto.Event{
+		Scheme:    crypto.SigSchnorr,
+		Pubkey:    pubkey,
+		CreatedAt: createdAt,
+		Content:   content,
+		Room:      room,
+		Tags:      tags,
+	}
+}
+
+// nostrEventFromMessage rebuilds the NIP-01 event of a schnorr-signed message.
+func nostrEventFromMessage(msg models.Message) (models.NostrSignedEvent, error) {
+	id, err := nostrCryptoEvent(msg.Room, msg.Pubkey, msg.SignedTimestamp, msg.Content, msg.Tags).Hash()
+	if err != nil {
+		return models.NostrSignedEvent{}, err
+	}
+	pubkey, err := crypto.XOnlyPubkey(msg.Pubkey)
+	if err != nil {
+		return models.NostrSignedEvent{}, err
+	}
+	return models.NostrSignedEvent{
+		ID:        hex.EncodeToString(id),
+		Pubkey:    pubkey,
+		CreatedAt: msg.SignedTimestamp,
+		Kind:      crypto.KindChatMessage,
+		Tags:      msg.Tags,
+		Content:   msg.Content,
+		Sig:       msg.Signature,
+	}, nil
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/go-fuego/fuego"
+)
+
+func newNostrTestServer(t *testing.T) (*httptest.Server, *services.ChatService) {
+	t.Helper()
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{QuickName: "test relay"})
+	ts := httptest.NewServer(s.Mux)
+	t.Cleanup(ts.Close)
+	return ts, chatService
+}
+
+func dialNostr(t *testing.T, ts *httptest.Server) (*websocket.Conn, context.Context) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http")+"/api/nostr", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn, ctx
+}
+
+// signNostrEvent builds a kind 9 event for room, signed with key.
+func signNostrEvent(t *testing.T, key *btcec.PrivateKey, room, content string) models.NostrSignedEvent {
+	t.Helper()
+	event := models.NostrSignedEvent{
+		Pubkey:    hex.EncodeToString(schnorr.SerializePubKey(key.PubKey())),
+		CreatedAt: time.Now().Unix(),
+		Kind:      crypto.KindChatMessage,
+		Tags:      [][]string{{crypto.RoomTag, room}},
+		Content:   content,
+	}
+	id, err := crypto.Event{Scheme: crypto.SigSchnorr, Pubkey: event.Pubkey, CreatedAt: event.CreatedAt, Content: content, Room: room, Tags: event.Tags}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	event.ID = hex.EncodeToString(id)
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	return event
+}
+
+func newNostrKey(t *testing.T) *btcec.PrivateKey {
+	t.Helper()
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	return key
+}
+
+func readNostrFrame(t *testing.T, ctx context.Context, conn *websocket.Conn) []json.RawMessage {
+	t.Helper()
+	var frame []json.RawMessage
+	if err := wsjson.Read(ctx, conn, &frame); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	if len(frame) == 0 {
+		t.Fatal("empty frame")
+	}
+	return frame
+}
+
+func nostrFrameType(t *testing.T, frame []json.RawMessage) string {
+	t.Helper()
+	var frameType string
+	if err := json.Unmarshal(frame[0], &frameType); err != nil {
+		t.Fatalf("frame type: %v", err)
+	}
+	return frameType
+}
+
+// publishNostr sends an EVENT frame and returns the OK status and reason.
+func publishNostr(t *testing.T, ctx context.Context, conn *websocket.Conn, event models.NostrSignedEvent) (bool, string) {
+	t.Helper()
+	if err := wsjson.Write(ctx, conn, []any{models.NostrEvent, event}); err != nil {
+		t.Fatalf("write EVENT: %v", err)
+	}
+	frame := readNostrFrame(t, ctx, conn)
+	if nostrFrameType(t, frame) != models.NostrOK || len(frame) != 4 {
+		t.Fatalf("got %s, want an OK frame", frame)
+	}
+	var id, reason string
+	var accepted bool
+	_ = json.Unmarshal(frame[1], &id)
+	_ = json.Unmarshal(frame[2], &accepted)
+	_ = json.Unmarshal(frame[3], &reason)
+	if id != event.ID {
+		t.Errorf("OK id = %q, want %q", id, event.ID)
+	}
+	return accepted, reason
+}
+
+func TestNostrRelay_PublishEvent(t *testing.T) {
+	ts, _ := newNostrTestServer(t)
+	conn, ctx := dialNostr(t, ts)
+	key := newNostrKey(t)
+
+	event := signNostrEvent(t, key, "general", "hello nostr")
+	if accepted, reason := publishNostr(t, ctx, conn, event); !accepted {
+		t.Fatalf("event rejected: %s", reason)
+	}
+
+	if accepted, reason := publishNostr(t, ctx, conn, event); !accepted || !strings.HasPrefix(reason, "duplicate:") {
+		t.Errorf("replayed event: accepted=%v reason=%q, want a duplicate", accepted, reason)
+	}
+
+	tampered := signNostrEvent(t, key, "general", "hello")
+	tampered.Content = "tampered"
+	if accepted, reason := publishNostr(t, ctx, conn, tampered); accepted || !strings.HasPrefix(reason, "invalid:") {
+		t.Errorf("tampered event: accepted=%v reason=%q, want invalid", accepted, reason)
+	}
+
+	wrongKind := signNostrEvent(t, key, "general", "hello")
+	wrongKind.Kind = 1
+	if accepted, _ := publishNostr(t, ctx, conn, wrongKind); accepted {
+		t.Error("expected a kind 1 event to be rejected")
+	}
+}
+
+func TestNostrRelay_ReqReturnsStoredThenLiveEvents(t *testing.T) {
+	ts, _ := newNostrTestServer(t)
+	conn, ctx := dialNostr(t, ts)
+	key := newNostrKey(t)
+
+	stored := signNostrEvent(t, key, "general", "stored")
+	if accepted, reason := publishNostr(t, ctx, conn, stored); !accepted {
+		t.Fatalf("event rejected: %s", reason)
+	}
+	other := signNostrEvent(t, key, "random", "elsewhere")
+	if accepted, reason := publishNostr(t, ctx, conn, other); !accepted {
+		t.Fatalf("event rejected: %s", reason)
+	}
+
+	filter := models.NostrFilter{Rooms: []string{"general"}, Kinds: []int{crypto.KindChatMessage}}
+	if err := wsjson.Write(ctx, conn, []any{models.NostrReq, "sub1", filter}); err != nil {
+		t.Fatalf("write REQ: %v", err)
+	}
+
+	frame := readNostrFrame(t, ctx, conn)
+	if nostrFrameType(t, frame) != models.NostrEvent {
+		t.Fatalf("got %s, want the stored EVENT", frame)
+	}
+	var got models.NostrSignedEvent
+	_ = json.Unmarshal(frame[2], &got)
+	if got.ID != stored.ID || got.Sig != stored.Sig || got.Content != "stored" {
+		t.Errorf("stored event = %+v, want %+v", got, stored)
+	}
+	if frame := readNostrFrame(t, ctx, conn); nostrFrameType(t, frame) != models.NostrEOSE {
+		t.Fatalf("got %s, want EOSE", frame)
+	}
+
+	live := signNostrEvent(t, newNostrKey(t), "general", "live")
+	publisher, _ := dialNostr(t, ts)
+	if accepted, reason := publishNostr(t, ctx, publisher, live); !accepted {
+		t.Fatalf("live event rejected: %s", reason)
+	}
+
+	frame = readNostrFrame(t, ctx, conn)
+	if nostrFrameType(t, frame) != models.NostrEvent {
+		t.Fatalf("got %s, want the live EVENT", frame)
+	}
+	_ = json.Unmarshal(frame[2], &got)
+	if got.ID != live.ID {
+		t.Errorf("live event id = %q, want %q", got.ID, live.ID)
+	}
+}
+
+func TestNostrRelay_ReqFiltersByAuthor(t *testing.T) {
+	ts, _ := newNostrTestServer(t)
+	conn, ctx := dialNostr(t, ts)
+	alice, bob := newNostrKey(t), newNostrKey(t)
+
+	for _, event := range []models.NostrSignedEvent{
+		signNostrEvent(t, alice, "general", "from alice"),
+		signNostrEvent(t, bob, "general", "from bob"),
+	} {
+		if accepted, reason := publishNostr(t, ctx, conn, event); !accepted {
+			t.Fatalf("event rejected: %s", reason)
+		}
+	}
+
+	filter := models.NostrFilter{Authors: []string{hex.EncodeToString(schnorr.SerializePubKey(bob.PubKey()))}}
+	if err := wsjson.Write(ctx, conn, []any{models.NostrReq, "bob", filter}); err != nil {
+		t.Fatalf("write REQ: %v", err)
+	}
+
+	var contents []string
+	for {
+		frame := readNostrFrame(t, ctx, conn)
+		if nostrFrameType(t, frame) == models.NostrEOSE {
+			break
+		}
+		var event models.NostrSignedEvent
+		_ = json.Unmarshal(frame[2], &event)
+		contents = append(contents, event.Content)
+	}
+	if len(contents) != 1 || contents[0] != "from bob" {
+		t.Errorf("events = %v, want only bob's", contents)
+	}
+}
+
+// TestNostrRelay_ReqCoversEveryRoom verifies a REQ without rooms reads all of
+// them, not only the rooms listed by GET /api/rooms.
+func TestNostrRelay_ReqCoversEveryRoom(t *testing.T) {
+	ts, chatService := newNostrTestServer(t)
+	conn, ctx := dialNostr(t, ts)
+
+	if accepted, reason := publishNostr(t, ctx, conn, signNostrEvent(t, newNostrKey(t), "quiet", "still here")); !accepted {
+		t.Fatalf("event rejected: %s", reason)
+	}
+	for i := range 100 { // more recently active than quiet
+		if _, err := chatService.SendMessage(ctx, models.Message{Room: fmt.Sprintf("busy%03d", i), User: "alice", Content: "hi"}); err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+
+	if err := wsjson.Write(ctx, conn, []any{models.NostrReq, "all", models.NostrFilter{}}); err != nil {
+		t.Fatalf("write REQ: %v", err)
+	}
+	var contents []string
+	for {
+		frame := readNostrFrame(t, ctx, conn)
+		if nostrFrameType(t, frame) == models.NostrEOSE {
+			break
+		}
+		var event models.NostrSignedEvent
+		_ = json.Unmarshal(frame[2], &event)
+		contents = append(contents, event.Content)
+	}
+	if len(contents) != 1 || contents[0] != "still here" {
+		t.Errorf("events = %v, want the event of quiet", contents)
+	}
+}
+
+func TestNostrRelay_PasswordRoomsStayPrivate(t *testing.T) {
+	ts, chatService := newNostrTestServer(t)
+	conn, ctx := dialNostr(t, ts)
+
+	if _, err := chatService.CreateRoom(ctx, "", "secret", new("hunter22"), "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	event := signNostrEvent(t, newNostrKey(t), "secret", "hello")
+	if accepted, reason := publishNostr(t, ctx, conn, event); accepted || !strings.HasPrefix(reason, "restricted:") {
+		t.Errorf("accepted=%v reason=%q, want restricted", accepted, reason)
+	}
+
+	if err := wsjson.Write(ctx, conn, []any{models.NostrReq, "s", models.NostrFilter{Rooms: []string{"secret"}}}); err != nil {
+		t.Fatalf("write REQ: %v", err)
+	}
+	if frame := readNostrFrame(t, ctx, conn); nostrFrameType(t, frame) != models.NostrEOSE {
+		t.Errorf("got %s, want EOSE without events", frame)
+	}
+}
+
+func TestNostrRelay_RelayInformationDocument(t *testing.T) {
+	ts, _ := newNostrTestServer(t)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/nostr", nil)
+	req.Header.Set("Accept", "application/nostr+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var info nostrRelayInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if info.Name != "test relay" || len(info.SupportedNIPs) == 0 || info.SupportedNIPs[0] != 1 {
+		t.Errorf("unexpected relay info %+v", info)
+	}
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+
+	"github.com/go-fuego/fuego"
+)
+
+// maxReactionRunes bounds an emoji, leaving room for skin tones and ZWJ
+// sequences.
+const maxReactionRunes = 8
+
+type ReactionsQuery struct {
+	Password string `query:"password"`
+}
+
+// AddReaction stores a signed reaction to a message and returns its updated
+// reaction counts. Reacting twice with the same emoji changes nothing.
+func AddReaction(chatService *services.ChatService, pwLimiter *middleware.RateLimiter, cfg *config.Config) func(c fuego.ContextWithBody[models.AddReactionRequest]) ([]models.ReactionCount, error) {
+	return func(c fuego.ContextWithBody[models.AddReactionRequest]) ([]models.ReactionCount, error) {
+		room, id := c.PathParam("room"), c.PathParam("id")
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+
+		// The access to the room is checked for the pubkey the signature proves
+		if err := checkReaction(room, id, body, messageMaxSkew(cfg)); err != nil {
+			return nil, err
+		}
+		if err := checkRoomPassword(c.Request(), chatService, pwLimiter, room, body.RoomPassword, body.Pubkey); err != nil {
+			return nil, err
+		}
+
+		// The same key may sign in compressed or x-only form: keep one reaction
+		reactions, err := chatService.GetReactions(c.Context(), room, id)
+		if err != nil {
+			return nil, reactionError(err)
+		}
+		if slices.ContainsFunc(reactions, func(r models.Reaction) bool {
+			return r.Emoji == body.Emoji && crypto.SamePubkey(r.Pubkey, body.Pubkey)
+		}) {
+			msg, err := chatService.GetMessage(c.Context(), room, id)
+			if err != nil {
+				return nil, reactionError(err)
+			}
+			return msg.Reactions, nil
+		}
+
+		ctx := services.WithClientIP(c.Context(), middleware.IPFromRequest(c.Request()))
+		counts, err := chatService.AddReaction(ctx, room, models.Reaction{
+			MessageID:       id,
+			Pubkey:          body.Pubkey,
+			Emoji:           body.Emoji,
+			Signature:       body.Signature,
+			SignedTimestamp: body.Timestamp,
+			Version:         body.Version,
+			Tags:            body.Tags,
+			SigScheme:       cmp.Or(body.SigScheme, crypto.SigECDSA),
+		})
+		if err != nil {
+			return nil, reactionError(err)
+		}
+		return counts, nil
+	}
+}
+
+// checkReaction verifies that a reaction is signed recently by its pubkey over
+// the emoji and a tag binding it to the message id.
+func checkReaction(room, id string, body models.AddReactionRequest, maxSkew time.Duration) error {
+	if utf8.RuneCountInString(body.Emoji) > maxReactionRunes || strings.ContainsFunc(body.Emoji, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("emoji must be at most %d characters without spaces", maxReactionRunes)}
+	}
+	if skew := time.Since(time.Unix(body.Timestamp, 0)).Abs(); skew > maxSkew {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("timestamp is outside the accepted window of ±%s from server time", maxSkew)}
+	}
+
+	event := crypto.Event{
+		Scheme:    cmp.Or(body.SigScheme, crypto.SigECDSA),
+		Version:   body.Version,
+		Pubkey:    body.Pubkey,
+		CreatedAt: body.Timestamp,
+		Content:   body.Emoji,
+		Room:      room,
+		Tags:      body.Tags,
+	}
+	if !event.CoversTags() {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "reactions must be signed with event version 1 or later, which covers the tags"}
+	}
+	if !crypto.HasTag(body.Tags, crypto.ReactTag, id) {
+		return fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: fmt.Sprintf("tags must include [%q, %q]", crypto.ReactTag, id)}
+	}
+	if err := crypto.VerifyEventSignature(event, body.Signature); err != nil {
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: "reaction is not signed by its pubkey: " + err.Error(), Err: err}
+	}
+	return nil
+}
+
+// RemoveReaction deletes the caller's reaction with the emoji of the path. The
+// request must be signed by the key that reacted.
+func RemoveReaction(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[ReactionsQuery]) ([]models.ReactionCount, error) {
+	return func(c fuego.ContextWithParams[ReactionsQuery]) ([]models.ReactionCount, error) {
+		pubkey, ok := middleware.PubkeyFromContext(c.Context())
+		if !ok {
+			return nil, fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: "request must be signed"}
+		}
+		room, id, emoji := c.PathParam("room"), c.PathParam("id"), c.PathParam("emoji")
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkRoomPassword(c.Request(), chatService, pwLimiter, room, queryParams.Password, pubkey); err != nil {
+			return nil, err
+		}
+
+		reactions, err := chatService.GetReactions(c.Context(), room, id)
+		if err != nil {
+			return nil, reactionError(err)
+		}
+		i := slices.IndexFunc(reactions, func(r models.Reaction) bool {
+			return r.Emoji == emoji && crypto.SamePubkey(r.Pubkey, pubkey)
+		})
+		if i == -1 {
+			return nil, reactionError(services.ErrReactionNotFound)
+		}
+
+		counts, err := chatService.RemoveReaction(c.Context(), room, id, reactions[i].Pubkey, emoji)
+		if err != nil {
+			return nil, reactionError(err)
+		}
+		return counts, nil
+	}
+}
+
+// GetReactions returns the signed reactions to a message, oldest first.
+func GetReactions(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[ReactionsQuery]) ([]models.Reaction, error) {
+	return func(c fuego.ContextWithParams[ReactionsQuery]) ([]models.Reaction, error) {
+		room := c.PathParam("room")
+		queryParams, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		if err := checkRoomPassword(c.Request(), chatService, pwLimiter, room, queryParams.Password, pubkey); err != nil {
+			return nil, err
+		}
+
+		reactions, err := chatService.GetReactions(c.Context(), room, c.PathParam("id"))
+		if err != nil {
+			return nil, reactionError(err)
+		}
+		return reactions, nil
+	}
+}
+
+// reactionError maps repository errors from reactions to HTTP errors.
+func reactionError(err error) error {
+	if sanctioned := sanctionedError(err); sanctioned != nil {
+		return sanctioned
+	}
+	if errors.Is(err, services.ErrMessageDeleted) {
+		return fuego.HTTPError{Status: http.StatusConflict, Title: "Conflict", Detail: err.Error(), Err: err}
+	}
+	if errors.Is(err, services.ErrReactionNotFound) {
+		return fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: err.Error(), Err: err}
+	}
+	return notFoundError(err)
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/go-fuego/fuego"
+)
+
+// signReaction signs a reaction to the message id with key, as an ECDSA v1
+// event with a compressed pubkey or as a Schnorr event with an x-only pubkey.
+func signReaction(t *testing.T, key *secp256k1.PrivateKey, room, id, emoji string, useSchnorr bool) models.AddReactionRequest {
+	t.Helper()
+	req := models.AddReactionRequest{
+		Emoji:     emoji,
+		Pubkey:    hex.EncodeToString(key.PubKey().SerializeCompressed()),
+		Timestamp: time.Now().Unix(),
+		Version:   crypto.EventV1,
+		Tags:      [][]string{{crypto.ReactTag, id}},
+	}
+	if useSchnorr {
+		req.Pubkey = hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))
+		req.Version = 0
+		req.SigScheme = crypto.SigSchnorr
+		req.Tags = [][]string{{crypto.RoomTag, room}, {crypto.ReactTag, id}}
+	}
+	hash, err := crypto.Event{
+		Scheme: req.SigScheme, Version: req.Version, Pubkey: req.Pubkey, CreatedAt: req.Timestamp,
+		Content: emoji, Room: room, Tags: req.Tags,
+	}.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if useSchnorr {
+		sig, err := schnorr.Sign(key, hash)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		req.Signature = hex.EncodeToString(sig.Serialize())
+	} else {
+		req.Signature = hex.EncodeToString(ecdsa.SignCompact(key, hash, true)[1:])
+	}
+	return req
+}
+
+func postReaction(t *testing.T, s *fuego.Server, room, id string, body models.AddReactionRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/rooms/"+room+"/messages/"+id+"/reactions", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+func decodeCounts(t *testing.T, w *httptest.ResponseRecorder) []models.ReactionCount {
+	t.Helper()
+	var counts []models.ReactionCount
+	if err := json.Unmarshal(w.Body.Bytes(), &counts); err != nil {
+		t.Fatalf("decode %s: %v", w.Body.String(), err)
+	}
+	return counts
+}
+
+func TestReactions(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	msg, err := chatService.SendMessage(context.Background(), models.Message{Room: "general", User: "alice", Content: "ship it?"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	alice, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+
+	t.Run("rejected", func(t *testing.T) {
+		untagged := signReaction(t, alice, "general", msg.ID, "👍", false)
+		untagged.Tags = nil
+		v0 := signReaction(t, alice, "general", msg.ID, "👍", false)
+		v0.Version = crypto.EventV0
+		tampered := signReaction(t, alice, "general", msg.ID, "👍", false)
+		tampered.Emoji = "👎"
+
+		for name, tt := range map[string]struct {
+			id   string
+			body models.AddReactionRequest
+			want int
+		}{
+			"no react tag":    {msg.ID, untagged, http.StatusBadRequest},
+			"version 0":       {msg.ID, v0, http.StatusBadRequest},
+			"other message":   {msg.ID, signReaction(t, alice, "general", "other-id", "👍", false), http.StatusBadRequest},
+			"tampered":        {msg.ID, tampered, http.StatusForbidden},
+			"not an emoji":    {msg.ID, signReaction(t, alice, "general", msg.ID, "hello world", false), http.StatusBadRequest},
+			"unknown message": {"missing", signReaction(t, alice, "general", "missing", "👍", false), http.StatusNotFound},
+		} {
+			if w := postReaction(t, s, "general", tt.id, tt.body); w.Code != tt.want {
+				t.Errorf("%s: status = %d, want %d; body: %s", name, w.Code, tt.want, w.Body.String())
+			}
+		}
+	})
+
+	for i, tt := range []struct {
+		key        *secp256k1.PrivateKey
+		useSchnorr bool
+		want       int
+	}{
+		{alice, false, 1},
+		{alice, false, 1}, // same reaction again
+		{alice, true, 1},  // same key in x-only form
+		{bob, true, 2},
+	} {
+		w := postReaction(t, s, "general", msg.ID, signReaction(t, tt.key, "general", msg.ID, "👍", tt.useSchnorr))
+		if w.Code != http.StatusOK {
+			t.Fatalf("reaction %d: status = %d, want 200; body: %s", i, w.Code, w.Body.String())
+		}
+		if counts := decodeCounts(t, w); len(counts) != 1 || counts[0] != (models.ReactionCount{Emoji: "👍", Count: tt.want}) {
+			t.Errorf("reaction %d: counts = %+v, want 👍 x%d", i, counts, tt.want)
+		}
+	}
+
+	messages, _ := chatService.GetMessages(context.Background(), "general", services.MessageQueryParams{})
+	if len(messages) != 1 || len(messages[0].Reactions) != 1 || messages[0].Reactions[0].Count != 2 {
+		t.Errorf("messages = %+v, want the reaction counts", messages)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/general/messages/"+msg.ID+"/reactions", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	var reactions []models.Reaction
+	if err := json.Unmarshal(w.Body.Bytes(), &reactions); err != nil || len(reactions) != 2 {
+		t.Fatalf("reactions: status = %d, body: %s", w.Code, w.Body.String())
+	}
+
+	remove := func(key *secp256k1.PrivateKey) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/rooms/general/messages/"+msg.ID+"/reactions/"+url.PathEscape("👍"), nil)
+		if key != nil {
+			signHTTPRequest(t, key, req, nil)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, req)
+		return w
+	}
+	if w := remove(nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned remove: status = %d, want 401", w.Code)
+	}
+	w = remove(bob)
+	if w.Code != http.StatusOK {
+		t.Fatalf("remove: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if counts := decodeCounts(t, w); len(counts) != 1 || counts[0].Count != 1 {
+		t.Errorf("counts after remove = %+v, want 👍 x1", counts)
+	}
+	carol, _ := secp256k1.GeneratePrivateKey()
+	if w := remove(carol); w.Code != http.StatusNotFound {
+		t.Errorf("remove without reacting: status = %d, want 404", w.Code)
+	}
+
+	if _, err := chatService.DeleteMessage(context.Background(), services.Actor{Admin: true}, "general", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if w := postReaction(t, s, "general", msg.ID, signReaction(t, bob, "general", msg.ID, "🎉", false)); w.Code != http.StatusConflict {
+		t.Errorf("reaction to a deleted message: status = %d, want 409", w.Code)
+	}
+}
+
+// TestReactions_SignatureCheckedBeforeAccess verifies the access to a room is
+// only checked once the reaction proves its pubkey, so a forged reaction is
+// refused for its signature, without a password attempt.
+func TestReactions_SignatureCheckedBeforeAccess(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	ctx := context.Background()
+	if _, err := chatService.CreateRoom(ctx, "", "secret", new("hunter22"), "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	msg, err := chatService.SendMessage(ctx, models.Message{Room: "secret", User: "alice", Content: "ship it?"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	alice, _ := secp256k1.GeneratePrivateKey()
+
+	forged := signReaction(t, alice, "secret", msg.ID, "👍", false)
+	forged.Emoji = "👎"
+	forged.RoomPassword = "wrong"
+	w := postReaction(t, s, "secret", msg.ID, forged)
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "not signed by its pubkey") {
+		t.Errorf("forged reaction: status = %d, want 403 for its signature; body: %s", w.Code, w.Body.String())
+	}
+
+	signed := signReaction(t, alice, "secret", msg.ID, "👍", false)
+	signed.RoomPassword = "wrong"
+	if w := postReaction(t, s, "secret", msg.ID, signed); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "invalid room password") {
+		t.Errorf("wrong password: status = %d, want 403 for the password; body: %s", w.Code, w.Body.String())
+	}
+	signed.RoomPassword = "hunter22"
+	if w := postReaction(t, s, "secret", msg.ID, signed); w.Code != http.StatusOK {
+		t.Errorf("signed reaction: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSendMessage_ReactTag_Returns400(t *testing.T) {
+	s := newTestServer(t)
+
+	body := signedRequestV1(t, "test", "👍", "alice", [][]string{{crypto.ReactTag, "some-id"}})
+	if w := postMessage(t, s, "test", body); w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400; body: %s", w.Code, w.Body.String())
+	}
+}
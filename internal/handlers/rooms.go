@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"strings"
 
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
 	"github.com/EwenQuim/microchat/internal/models"
 	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
 
 	"github.com/go-fuego/fuego"
 )
@@ -41,9 +46,12 @@ type GetRoomsQuery struct {
 	Visited string `query:"visited"`
 }
 
+// GetRooms lists the rooms. Private rooms are only listed to their members,
+// who must sign the request.
 func GetRooms(chatService *services.ChatService) func(c fuego.ContextWithParams[GetRoomsQuery]) ([]models.Room, error) {
 	return func(c fuego.ContextWithParams[GetRoomsQuery]) ([]models.Room, error) {
-		allRooms, err := chatService.GetRooms(c.Context())
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		allRooms, err := chatService.GetRooms(c.Context(), pubkey)
 		if err != nil {
 			return nil, err
 		}
@@ -62,6 +70,7 @@ type SearchRoomsQuery struct {
 	Q       string `query:"q"`
 }
 
+// SearchRooms lists the rooms whose name contains q, like GetRooms.
 func SearchRooms(chatService *services.ChatService) func(c fuego.ContextWithParams[SearchRoomsQuery]) ([]models.Room, error) {
 	return func(c fuego.ContextWithParams[SearchRoomsQuery]) ([]models.Room, error) {
 		params, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
@@ -69,7 +78,8 @@ func SearchRooms(chatService *services.ChatService) func(c fuego.ContextWithPara
 			return nil, err
 		}
 
-		allRooms, err := chatService.SearchRooms(c.Context(), params.Q)
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		allRooms, err := chatService.SearchRooms(c.Context(), params.Q, pubkey)
 		if err != nil {
 			return nil, err
 		}
@@ -78,12 +88,172 @@ func SearchRooms(chatService *services.ChatService) func(c fuego.ContextWithPara
 	}
 }
 
+// CreateRoom creates a room. A signed request makes the signer its owner, and
+// is required for a private room.
 func CreateRoom(chatService *services.ChatService) func(c fuego.ContextWithBody[models.CreateRoomRequest]) (*models.Room, error) {
 	return func(c fuego.ContextWithBody[models.CreateRoomRequest]) (*models.Room, error) {
 		body, err := c.Body()
 		if err != nil {
 			return nil, err
 		}
-		return chatService.CreateRoom(c.Context(), body.Name, body.Password)
+		keySalt := ""
+		if body.Encrypted {
+			if err := crypto.ValidateRoomKeySalt(body.KeySalt); err != nil {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "encrypted rooms need the key_salt their key derives from: " + err.Error()}
+			}
+			if body.Password == nil || !crypto.IsRoomVerifier(*body.Password) {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "the password of an encrypted room must be the verifier of its key, never the password itself"}
+			}
+			keySalt = body.KeySalt
+		}
+		owner, _ := middleware.PubkeyFromContext(c.Context())
+		if body.Private && owner == "" {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "private rooms must be created with a signed request: the signer becomes their owner"}
+		}
+		return chatService.CreateRoom(c.Context(), owner, body.Name, body.Password, keySalt, body.Private)
+	}
+}
+
+// GetRoomMembers returns the owner, the moderators and the members of a room.
+// Those of a private room are only listed to its members.
+func GetRoomMembers(chatService *services.ChatService) func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+	return func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		members, err := chatService.GetRoomMembers(c.Context(), c.PathParam("room"), pubkey)
+		if err != nil {
+			return nil, roleError(err)
+		}
+		return members, nil
+	}
+}
+
+// SetRoomMember adds the pubkey of the path to the members of a private room,
+// or removes it. The request must be signed by the owner of the room or an
+// admin key.
+func SetRoomMember(chatService *services.ChatService, cfg *config.Config, member bool) func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+	return func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		pubkey := c.PathParam("pubkey")
+		if !validPubkey(pubkey) {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "pubkey must be a compressed or x-only hex public key"}
+		}
+		var members []models.RoomMember
+		if member {
+			members, err = chatService.AddRoomMember(c.Context(), actor, c.PathParam("room"), pubkey)
+		} else {
+			members, err = chatService.RemoveRoomMember(c.Context(), actor, c.PathParam("room"), pubkey)
+		}
+		if err != nil {
+			return nil, memberError(err)
+		}
+		return members, nil
+	}
+}
+
+// SetRoomDescription replaces the description of a room. The request must be
+// signed by the owner of the room or an admin key.
+func SetRoomDescription(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.SetRoomDescriptionRequest]) (*models.Room, error) {
+	return func(c fuego.ContextWithBody[models.SetRoomDescriptionRequest]) (*models.Room, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		room, err := chatService.SetRoomDescription(c.Context(), actor, c.PathParam("room"), strings.TrimSpace(body.Description))
+		if err != nil {
+			return nil, roleError(err)
+		}
+		return room, nil
+	}
+}
+
+// SetRoomRetention replaces the retention policy of a room. The request must be
+// signed by the owner of the room or an admin key.
+func SetRoomRetention(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.RetentionPolicy]) (*models.Room, error) {
+	return func(c fuego.ContextWithBody[models.RetentionPolicy]) (*models.Room, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		room, err := chatService.SetRoomRetention(c.Context(), actor, c.PathParam("room"), body)
+		if err != nil {
+			return nil, roleError(err)
+		}
+		return room, nil
+	}
+}
+
+// SetModerator promotes the pubkey of the path to moderator of a room, or
+// demotes it. The request must be signed by the owner of the room or an admin
+// key.
+func SetModerator(chatService *services.ChatService, cfg *config.Config, moderator bool) func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+	return func(c fuego.ContextNoBody) ([]models.RoomMember, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		pubkey := c.PathParam("pubkey")
+		if !validPubkey(pubkey) {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "pubkey must be a compressed or x-only hex public key"}
+		}
+		members, err := chatService.SetModerator(c.Context(), actor, c.PathParam("room"), pubkey, moderator)
+		if err != nil {
+			return nil, roleError(err)
+		}
+		return members, nil
+	}
+}
+
+// actorFromContext returns the caller verified by RequestAuth or AdminAuth.
+func actorFromContext(ctx context.Context, cfg *config.Config) (services.Actor, error) {
+	pubkey, ok := middleware.PubkeyFromContext(ctx)
+	if !ok {
+		return services.Actor{}, fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: "request must be signed"}
+	}
+	return services.Actor{Pubkey: pubkey, Admin: middleware.IsAdmin(cfg.AdminPubkeys, pubkey)}, nil
+}
+
+// roleError maps errors from the operations restricted to room roles to HTTP
+// errors.
+func roleError(err error) error {
+	if denied := accessError(err); denied != nil {
+		return denied
+	}
+	switch {
+	case errors.Is(err, services.ErrPermissionDenied):
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: err.Error(), Err: err}
+	case errors.Is(err, services.ErrRoomEncrypted), errors.Is(err, services.ErrRoomNotPrivate):
+		return fuego.HTTPError{Status: http.StatusConflict, Title: "Conflict", Detail: err.Error(), Err: err}
+	}
+	return notFoundError(err)
+}
+
+// memberError maps errors from managing the members of a room to HTTP errors.
+func memberError(err error) error {
+	if errors.Is(err, services.ErrMemberNotFound) {
+		return fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: err.Error(), Err: err}
+	}
+	return roleError(err)
+}
+
+// accessError returns a 401 for an unsigned read of a private room and a 403
+// for a caller missing from its members, and nil for other errors.
+func accessError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrSignatureRequired):
+		return fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: err.Error(), Err: err}
+	case errors.Is(err, services.ErrNotMember):
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: err.Error(), Err: err}
 	}
+	return nil
 }
@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/go-fuego/fuego"
+)
+
+func postRoom(t *testing.T, s *fuego.Server, body models.CreateRoomRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	return roomRequest(t, s, nil, http.MethodPost, "/api/rooms", body)
+}
+
+// roomRequest sends body as JSON, signed by key unless it is nil.
+func roomRequest(t *testing.T, s *fuego.Server, key *secp256k1.PrivateKey, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	if key != nil {
+		signHTTPRequest(t, key, req, data)
+	}
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	return w
+}
+
+// encryptedRoomRequest creates the encrypted room name the way clients do:
+// with a salt of their own and the verifier of the key as password. It
+// returns the request and the room key.
+func encryptedRoomRequest(t *testing.T, name, password string) (models.CreateRoomRequest, []byte) {
+	t.Helper()
+	salt, err := crypto.NewRoomKeySalt()
+	if err != nil {
+		t.Fatalf("NewRoomKeySalt: %v", err)
+	}
+	key, err := crypto.RoomKey(password, salt)
+	if err != nil {
+		t.Fatalf("RoomKey: %v", err)
+	}
+	return models.CreateRoomRequest{Name: name, Password: new(crypto.RoomVerifier(key)), Encrypted: true, KeySalt: salt}, key
+}
+
+func TestEncryptedRoom(t *testing.T) {
+	repo := memory.NewStore()
+	chatService := services.NewChatService(repo)
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	body, key := encryptedRoomRequest(t, "vault", "hunter22")
+	verifier := *body.Password
+	for name, rejected := range map[string]models.CreateRoomRequest{
+		"without password":   {Name: "vault", Encrypted: true, KeySalt: body.KeySalt},
+		"with the password":  {Name: "vault", Password: new("hunter22"), Encrypted: true, KeySalt: body.KeySalt},
+		"without salt":       {Name: "vault", Password: body.Password, Encrypted: true},
+		"with a short salt":  {Name: "vault", Password: body.Password, Encrypted: true, KeySalt: "AAAA"},
+		"with an upper case": {Name: "vault", Password: new(strings.ToUpper(verifier)), Encrypted: true, KeySalt: body.KeySalt},
+	} {
+		if w := postRoom(t, s, rejected); w.Code != http.StatusBadRequest {
+			t.Errorf("encrypted %s: status = %d, want 400", name, w.Code)
+		}
+	}
+	w := postRoom(t, s, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var room models.Room
+	if err := json.Unmarshal(w.Body.Bytes(), &room); err != nil || !room.Encrypted || room.KeySalt != body.KeySalt {
+		t.Fatalf("room = %+v, want an encrypted room with the key salt of the request", room)
+	}
+
+	payload, _ := crypto.EncryptDM(key, "the eagle has landed")
+
+	plaintext := signedRequestV1(t, "vault", "the eagle has landed", "alice", nil)
+	plaintext.RoomPassword = verifier
+	if w := postMessage(t, s, "vault", plaintext); w.Code != http.StatusBadRequest {
+		t.Errorf("plaintext: status = %d, want 400", w.Code)
+	}
+	// Members authenticate with the verifier, the password itself is unknown
+	encrypted := signedRequestV1(t, "vault", payload, "alice", nil)
+	encrypted.RoomPassword = "hunter22"
+	if w := postMessage(t, s, "vault", encrypted); w.Code == http.StatusOK {
+		t.Error("with the password: status = 200, want the password refused")
+	}
+	encrypted = signedRequestV1(t, "vault", payload, "alice", nil)
+	encrypted.RoomPassword = verifier
+	if w := postMessage(t, s, "vault", encrypted); w.Code != http.StatusOK {
+		t.Fatalf("encrypted: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if w := postMessage(t, s, "general", signedRequestV1(t, "general", "hello", "alice", nil)); w.Code != http.StatusOK {
+		t.Errorf("plaintext room: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	stored, _ := chatService.GetMessages(context.Background(), "vault", services.MessageQueryParams{})
+	if len(stored) != 1 || stored[0].Content != payload {
+		t.Fatalf("stored = %+v, want only the ciphertext", stored)
+	}
+	if got, err := crypto.DecryptDM(key, stored[0].Content); err != nil || got != "the eagle has landed" {
+		t.Errorf("DecryptDM = %q, %v", got, err)
+	}
+
+	author, _ := secp256k1.GeneratePrivateKey()
+	now := time.Now().Unix()
+	original, err := chatService.SendMessage(context.Background(), models.Message{
+		Room: "vault", User: "alice", Content: payload, Signature: "sig",
+		Pubkey: hex.EncodeToString(author.PubKey().SerializeCompressed()), SignedTimestamp: now - 10,
+	})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if w := putEdit(t, s, *original, signEdit(t, author, *original, "in clear", now)); w.Code != http.StatusBadRequest {
+		t.Errorf("plaintext edit: status = %d, want 400", w.Code)
+	}
+	newPayload, _ := crypto.EncryptDM(key, "the eagle has left")
+	if w := putEdit(t, s, *original, signEdit(t, author, *original, newPayload, now)); w.Code != http.StatusOK {
+		t.Errorf("encrypted edit: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	admin, adminKey := newAdminTestServer(t, repo)
+	if w := adminRequest(t, admin, adminKey, http.MethodPost, "/api/admin/rooms/vault/password", models.ResetRoomPasswordRequest{Password: new("hunter23")}); w.Code != http.StatusConflict {
+		t.Errorf("password change: status = %d, want 409", w.Code)
+	}
+}
+
+func TestRoomRoles(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+	xOnly := func(key *secp256k1.PrivateKey) string {
+		return hex.EncodeToString(key.PubKey().SerializeCompressed()[1:])
+	}
+	members := func(room string) []models.RoomMember {
+		t.Helper()
+		w := roomRequest(t, s, nil, http.MethodGet, "/api/rooms/"+room+"/members", nil)
+		var members []models.RoomMember
+		if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		return members
+	}
+
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "club"}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if got := members("club"); len(got) != 1 || got[0] != (models.RoomMember{Pubkey: xOnly(owner), Role: models.RoleOwner}) {
+		t.Fatalf("members = %+v, want the signer as owner", got)
+	}
+	if w := postRoom(t, s, models.CreateRoomRequest{Name: "open"}); w.Code != http.StatusOK || len(members("open")) != 0 {
+		t.Errorf("unsigned create: status = %d, members = %+v; want an unowned room", w.Code, members("open"))
+	}
+
+	for _, tt := range []struct {
+		name   string
+		key    *secp256k1.PrivateKey
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{"unsigned description", nil, http.MethodPut, "/api/rooms/club/description", models.SetRoomDescriptionRequest{Description: "hi"}, http.StatusUnauthorized},
+		{"stranger description", bob, http.MethodPut, "/api/rooms/club/description", models.SetRoomDescriptionRequest{Description: "hi"}, http.StatusForbidden},
+		{"stranger password", bob, http.MethodPut, "/api/rooms/club/password", models.ResetRoomPasswordRequest{Password: new("hunter22")}, http.StatusForbidden},
+		{"stranger promotion", bob, http.MethodPut, "/api/rooms/club/moderators/" + xOnly(bob), nil, http.StatusForbidden},
+		{"owner description", owner, http.MethodPut, "/api/rooms/club/description", models.SetRoomDescriptionRequest{Description: "Members only"}, http.StatusOK},
+		{"owner password", owner, http.MethodPut, "/api/rooms/club/password", models.ResetRoomPasswordRequest{Password: new("hunter22")}, http.StatusOK},
+		{"owner promotion", owner, http.MethodPut, "/api/rooms/club/moderators/" + xOnly(bob), nil, http.StatusOK},
+		{"owner demotes itself", owner, http.MethodDelete, "/api/rooms/club/moderators/" + xOnly(owner), nil, http.StatusForbidden},
+		{"moderator demotes owner", bob, http.MethodDelete, "/api/rooms/club/moderators/" + xOnly(owner), nil, http.StatusForbidden},
+		{"invalid pubkey", owner, http.MethodPut, "/api/rooms/club/moderators/bob", nil, http.StatusBadRequest},
+		{"unknown room", owner, http.MethodPut, "/api/rooms/nowhere/description", models.SetRoomDescriptionRequest{}, http.StatusNotFound},
+	} {
+		if w := roomRequest(t, s, tt.key, tt.method, tt.path, tt.body); w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body: %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+	room, err := chatService.GetRoom(context.Background(), "club")
+	if err != nil || room.Description != "Members only" || !room.HasPassword {
+		t.Errorf("room = %+v, %v; want the owner's description and password", room, err)
+	}
+	if got := members("club"); len(got) != 2 || got[1] != (models.RoomMember{Pubkey: xOnly(bob), Role: models.RoleModerator}) {
+		t.Fatalf("members = %+v, want bob as moderator", got)
+	}
+
+	msg, err := chatService.SendMessage(context.Background(), models.Message{
+		Room: "club", User: "carol", Content: "spam", Signature: "sig",
+		Pubkey: hex.EncodeToString(carol.PubKey().SerializeCompressed()),
+	})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	stranger, _ := secp256k1.GeneratePrivateKey()
+	if w := roomRequest(t, s, stranger, http.MethodDelete, "/api/rooms/club/messages/"+msg.ID, nil); w.Code != http.StatusForbidden {
+		t.Errorf("stranger delete: status = %d, want 403", w.Code)
+	}
+	if w := roomRequest(t, s, bob, http.MethodDelete, "/api/rooms/club/messages/"+msg.ID, nil); w.Code != http.StatusOK {
+		t.Errorf("moderator delete: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	if w := roomRequest(t, s, owner, http.MethodDelete, "/api/rooms/club/moderators/"+xOnly(bob), nil); w.Code != http.StatusOK || len(members("club")) != 1 {
+		t.Errorf("demotion: status = %d, members = %+v; want only the owner", w.Code, members("club"))
+	}
+}
+
+func TestRoomRetention(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "club"}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  *secp256k1.PrivateKey
+		body models.RetentionPolicy
+		want int
+	}{
+		{"unsigned", nil, models.RetentionPolicy{MaxMessages: 2}, http.StatusUnauthorized},
+		{"stranger", bob, models.RetentionPolicy{MaxMessages: 2}, http.StatusForbidden},
+		{"negative", owner, models.RetentionPolicy{MaxAge: -1}, http.StatusBadRequest},
+		{"owner", owner, models.RetentionPolicy{MaxAge: 3600, MaxMessages: 2}, http.StatusOK},
+	} {
+		if w := roomRequest(t, s, tt.key, http.MethodPut, "/api/rooms/club/retention", tt.body); w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body: %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+
+	var first *models.Message
+	for range 4 {
+		msg, err := chatService.SendMessage(context.Background(), models.Message{Room: "club", User: "alice", Content: "hi"})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+		first = cmp.Or(first, msg)
+		time.Sleep(time.Millisecond)
+	}
+	// The room keeps 2 messages, within the server default of 3
+	pruned, err := chatService.PruneMessages(context.Background(), models.RetentionPolicy{MaxMessages: 3}, time.Now())
+	if err != nil || pruned != 2 {
+		t.Fatalf("PruneMessages = %d, %v; want 2 pruned", pruned, err)
+	}
+	if msgs, _ := chatService.GetMessages(context.Background(), "club", services.MessageQueryParams{}); len(msgs) != 2 {
+		t.Errorf("got %d messages after pruning, want 2", len(msgs))
+	}
+	// A stream resuming from a pruned message does not replay the others
+	sub, backlog, err := chatService.Subscribe(context.Background(), "club", first.ID)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	sub.Close()
+	if len(backlog) != 0 {
+		t.Errorf("resume from a pruned message: got %d messages, want none", len(backlog))
+	}
+	if stats := chatService.RetentionStats(); stats.Runs != 1 || stats.PrunedMessages != 2 || stats.LastRunAt == nil {
+		t.Errorf("stats = %+v, want 1 run pruning 2 messages", stats)
+	}
+}
+
+func TestPrivateRoom(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+	pubkey := func(key *secp256k1.PrivateKey) string {
+		return hex.EncodeToString(key.PubKey().SerializeCompressed())
+	}
+	requests := 0
+	distinct := func(path string) string {
+		requests++ // a distinct URL per request, as a replayed signed request is rejected
+		if strings.Contains(path, "?") {
+			return fmt.Sprintf("%s&n=%d", path, requests)
+		}
+		return fmt.Sprintf("%s?n=%d", path, requests)
+	}
+	request := func(key *secp256k1.PrivateKey, method, path string) int {
+		t.Helper()
+		return roomRequest(t, s, key, method, distinct(path), nil).Code
+	}
+	listed := func(key *secp256k1.PrivateKey, path string) bool {
+		t.Helper()
+		w := roomRequest(t, s, key, http.MethodGet, distinct(path), nil)
+		var rooms []models.Room
+		if err := json.Unmarshal(w.Body.Bytes(), &rooms); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		for _, room := range rooms {
+			if room.Name == "cellar" {
+				return room.Private
+			}
+		}
+		return false
+	}
+	sent := 0
+	send := func(key *secp256k1.PrivateKey) int {
+		t.Helper()
+		sent++ // distinct content, as a replayed signature is rejected
+		return postMessage(t, s, "cellar", signMessageV1(t, key, "cellar", fmt.Sprintf("hello %d", sent), "someone", nil)).Code
+	}
+
+	if w := postRoom(t, s, models.CreateRoomRequest{Name: "cellar", Private: true}); w.Code != http.StatusBadRequest {
+		t.Errorf("unsigned private room: status = %d, want 400", w.Code)
+	}
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "cellar", Private: true}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+
+	if !listed(owner, "/api/rooms") || !listed(owner, "/api/rooms/search?q=cell") {
+		t.Error("the owner does not see the private room")
+	}
+	if listed(nil, "/api/rooms") || listed(bob, "/api/rooms") || listed(bob, "/api/rooms/search?q=cell") {
+		t.Error("a stranger sees the private room")
+	}
+	for _, tt := range []struct {
+		name string
+		key  *secp256k1.PrivateKey
+		path string
+		want int
+	}{
+		{"unsigned read", nil, "/api/rooms/cellar/messages", http.StatusUnauthorized},
+		{"stranger read", bob, "/api/rooms/cellar/messages", http.StatusForbidden},
+		{"owner read", owner, "/api/rooms/cellar/messages", http.StatusOK},
+		{"unsigned members", nil, "/api/rooms/cellar/members", http.StatusUnauthorized},
+		{"stranger members", bob, "/api/rooms/cellar/members", http.StatusForbidden},
+		{"unsigned stream", nil, "/api/rooms/cellar/stream", http.StatusUnauthorized},
+	} {
+		if code := request(tt.key, http.MethodGet, tt.path); code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, code, tt.want)
+		}
+	}
+	if code := send(bob); code != http.StatusForbidden {
+		t.Errorf("stranger send: status = %d, want 403", code)
+	}
+
+	if code := request(bob, http.MethodPut, "/api/rooms/cellar/members/"+pubkey(carol)); code != http.StatusForbidden {
+		t.Errorf("add by a stranger: status = %d, want 403", code)
+	}
+	if code := request(owner, http.MethodPut, "/api/rooms/cellar/members/"+pubkey(bob)); code != http.StatusOK {
+		t.Fatalf("add: status = %d, want 200", code)
+	}
+	w := roomRequest(t, s, bob, http.MethodGet, "/api/rooms/cellar/members", nil)
+	var members []models.RoomMember
+	if err := json.Unmarshal(w.Body.Bytes(), &members); err != nil || len(members) != 2 || members[1].Role != models.RoleMember {
+		t.Errorf("members = %s, want the owner and bob", w.Body.String())
+	}
+	if !listed(bob, "/api/rooms") {
+		t.Error("a member does not see the private room")
+	}
+	if code := request(bob, http.MethodGet, "/api/rooms/cellar/messages"); code != http.StatusOK {
+		t.Errorf("member read: status = %d, want 200", code)
+	}
+	if code := send(bob); code != http.StatusOK {
+		t.Errorf("member send: status = %d, want 200", code)
+	}
+
+	if code := request(owner, http.MethodDelete, "/api/rooms/cellar/members/"+pubkey(owner)); code != http.StatusForbidden {
+		t.Errorf("remove the owner: status = %d, want 403", code)
+	}
+	if code := request(owner, http.MethodDelete, "/api/rooms/cellar/members/"+pubkey(bob)); code != http.StatusOK {
+		t.Errorf("remove: status = %d, want 200", code)
+	}
+	if code := request(owner, http.MethodDelete, "/api/rooms/cellar/members/"+pubkey(bob)); code != http.StatusNotFound {
+		t.Errorf("remove again: status = %d, want 404", code)
+	}
+	if code := request(bob, http.MethodGet, "/api/rooms/cellar/messages"); code != http.StatusForbidden {
+		t.Errorf("removed member read: status = %d, want 403", code)
+	}
+
+	w = roomRequest(t, s, owner, http.MethodPost, "/api/rooms/cellar/invites", models.CreateInviteRequest{})
+	var invite models.Invite
+	if err := json.Unmarshal(w.Body.Bytes(), &invite); err != nil || invite.Token == "" {
+		t.Fatalf("invite = %s", w.Body.String())
+	}
+	if code := request(carol, http.MethodGet, "/api/rooms/cellar/messages?invite="+invite.Token); code != http.StatusOK {
+		t.Errorf("read with an invite: status = %d, want 200", code)
+	}
+	if code := request(carol, http.MethodGet, "/api/rooms/cellar/messages"); code != http.StatusOK {
+		t.Errorf("read after the invite: status = %d, want 200", code)
+	}
+
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "hall"}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if code := request(owner, http.MethodPut, "/api/rooms/hall/members/"+pubkey(bob)); code != http.StatusConflict {
+		t.Errorf("add to a public room: status = %d, want 409", code)
+	}
+}
@@ -8,32 +8,52 @@ import (
 	"github.com/EwenQuim/microchat/internal/services"
 	"github.com/go-fuego/fuego"
 	"github.com/go-fuego/fuego/option"
+	"github.com/go-fuego/fuego/param"
 	"github.com/jub0bs/cors"
 )
 
 // Rate limits per route, keyed by their window duration.
 const (
-	roomsRateLimitPerMin       = 120 // GET /rooms and GET /rooms/search
-	createRoomRateLimitPerHour = 10  // POST /rooms
-	getMessagesRateLimitPerMin = 60  // GET /rooms/{room}/messages
-	sendMessageBurst           = 20  // POST /rooms/{room}/messages burst allowance
-	sendMessageRateLimitPerMin = 30  // POST /rooms/{room}/messages sustained
+	roomsRateLimitPerMin          = 120 // GET /rooms and GET /rooms/search
+	createRoomRateLimitPerHour    = 10  // POST /rooms
+	manageRoomRateLimitPerMin     = 30  // PUT /rooms/{room}/description and password, moderators, sanctions and invites
+	getMessagesRateLimitPerMin    = 60  // GET /rooms/{room}/messages
+	sendMessageBurst              = 20  // POST /rooms/{room}/messages burst allowance
+	sendMessageRateLimitPerMin    = 30  // POST /rooms/{room}/messages sustained
+	editMessageRateLimitPerMin    = 30  // PUT /rooms/{room}/messages/{id}
+	deleteMessageRateLimitPerMin  = 30  // DELETE /rooms/{room}/messages/{id}
+	reactRateLimitPerMin          = 60  // POST and DELETE /rooms/{room}/messages/{id}/reactions
+	searchRateLimitPerMin         = 30  // GET /search
+	sendDMRateLimitPerMin         = 30  // POST /dms
+	streamRateLimitPerMin         = 30  // GET /rooms/{room}/stream (new connections)
+	wsRateLimitPerMin             = 30  // GET /ws (new connections)
+	nostrRateLimitPerMin          = 30  // GET /nostr (new connections and NIP-11 requests)
+	adminChallengeRateLimitPerMin = 30  // POST /admin/challenge
 )
 
+// adminChallengeTTL is how long an admin has to sign and use a challenge.
+const adminChallengeTTL = 2 * time.Minute
+
 func RegisterChatRoutes(s *fuego.Server, chatService *services.ChatService, cfg *config.Config) {
 	corsMw, err := cors.NewMiddleware(cors.Config{
 		Origins:        []string{"*"},
-		Methods:        []string{"GET", "POST"},
-		RequestHeaders: []string{"Content-Type"},
+		Methods:        []string{"GET", "POST", "PUT", "DELETE"},
+		RequestHeaders: []string{"Content-Type", "Authorization", middleware.AdminPubkeyHeader, middleware.AdminChallengeHeader, middleware.AdminSignatureHeader, middleware.AdminSigSchemeHeader},
 	})
 	if err != nil {
 		panic(err)
 	}
 	fuego.Use(s, corsMw.Wrap)
 
+	// Replicas sharing a repository also share the state of the middlewares
+	shared, _ := chatService.Repository().(middleware.SharedStore)
+
 	// Rate limiters: one per window duration.
-	minuteRL := middleware.NewRateLimiter(time.Minute)
-	hourRL := middleware.NewRateLimiter(time.Hour)
+	minuteRL := middleware.NewRateLimiter(time.Minute, shared)
+	hourRL := middleware.NewRateLimiter(time.Hour, shared)
+
+	// Signed requests (NIP-98) identify the caller of privileged routes
+	requestAuth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, shared, cfg.TrustedProxies...)
 
 	// Server info
 	fuego.Get(s, "/server-info", GetServerInfo(cfg))
@@ -43,23 +63,179 @@ func RegisterChatRoutes(s *fuego.Server, chatService *services.ChatService, cfg
 
 	fuego.Get(chatGroup, "", GetRooms(chatService),
 		option.Middleware(middleware.IPRateLimit(minuteRL, roomsRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
 	)
 	fuego.Get(chatGroup, "/search", SearchRooms(chatService),
 		option.Middleware(middleware.IPRateLimit(minuteRL, roomsRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
 	)
 	fuego.Post(chatGroup, "", CreateRoom(chatService),
 		option.RequestContentType("application/json"),
 		option.Middleware(middleware.IPRateLimit(hourRL, createRoomRateLimitPerHour, time.Hour)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Get(chatGroup, "/{room}/members", GetRoomMembers(chatService),
+		option.Middleware(middleware.IPRateLimit(minuteRL, roomsRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Put(chatGroup, "/{room}/members/{pubkey}", SetRoomMember(chatService, cfg, true),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Delete(chatGroup, "/{room}/members/{pubkey}", SetRoomMember(chatService, cfg, false),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Put(chatGroup, "/{room}/description", SetRoomDescription(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Put(chatGroup, "/{room}/retention", SetRoomRetention(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Put(chatGroup, "/{room}/password", ResetRoomPassword(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Put(chatGroup, "/{room}/moderators/{pubkey}", SetModerator(chatService, cfg, true),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Delete(chatGroup, "/{room}/moderators/{pubkey}", SetModerator(chatService, cfg, false),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Get(chatGroup, "/{room}/sanctions", ListSanctions(chatService, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Post(chatGroup, "/{room}/sanctions", CreateSanction(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Delete(chatGroup, "/{room}/sanctions/{id}", LiftSanction(chatService, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Get(chatGroup, "/{room}/invites", ListInvites(chatService, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Post(chatGroup, "/{room}/invites", CreateInvite(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Delete(chatGroup, "/{room}/invites/{id}", RevokeInvite(chatService, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, manageRoomRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
 	)
 	fuego.Get(chatGroup, "/{room}/messages", GetMessages(chatService, minuteRL),
 		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Get(chatGroup, "/{room}/messages/page", GetMessagePage(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
 	)
-	fuego.Post(chatGroup, "/{room}/messages", SendMessage(chatService, minuteRL),
+	fuego.Post(chatGroup, "/{room}/messages", SendMessage(chatService, minuteRL, cfg),
 		option.RequestContentType("application/json"),
 		option.Middleware(middleware.MessageRateLimit(minuteRL, sendMessageBurst, sendMessageRateLimitPerMin, time.Minute)),
 	)
+	fuego.Put(chatGroup, "/{room}/messages/{id}", EditMessage(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, editMessageRateLimitPerMin, time.Minute)),
+	)
+	fuego.Get(chatGroup, "/{room}/messages/{id}/revisions", GetMessageRevisions(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Get(chatGroup, "/{room}/messages/{id}/thread", GetThread(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Delete(chatGroup, "/{room}/messages/{id}", DeleteMessage(chatService, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, deleteMessageRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Get(chatGroup, "/{room}/messages/{id}/reactions", GetReactions(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+	fuego.Post(chatGroup, "/{room}/messages/{id}/reactions", AddReaction(chatService, minuteRL, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, reactRateLimitPerMin, time.Minute)),
+	)
+	fuego.Delete(chatGroup, "/{room}/messages/{id}/reactions/{emoji}", RemoveReaction(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, reactRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.GetStd(chatGroup, "/{room}/stream", StreamMessages(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, streamRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+
+	// Full-text search of messages, across rooms or in one
+	fuego.Get(s, "/search", SearchMessages(chatService, minuteRL),
+		option.Middleware(middleware.IPRateLimit(minuteRL, searchRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Optional()),
+	)
+
+	// Direct messages: end-to-end encrypted, the server only stores ciphertext
+	dmGroup := fuego.Group(s, "/dms", option.TagInfo("dm", "end-to-end encrypted direct messages between pubkeys"))
+
+	fuego.Get(dmGroup, "", GetDirectMessages(chatService),
+		option.Middleware(middleware.IPRateLimit(minuteRL, getMessagesRateLimitPerMin, time.Minute)),
+		option.Middleware(requestAuth.Required()),
+	)
+	fuego.Post(dmGroup, "", SendDirectMessage(chatService, cfg),
+		option.RequestContentType("application/json"),
+		option.Middleware(middleware.IPRateLimit(minuteRL, sendDMRateLimitPerMin, time.Minute)),
+	)
+
+	// WebSocket transport: multi-room subscriptions and signed sends
+	fuego.GetStd(s, "/ws", WebSocket(chatService, minuteRL, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, wsRateLimitPerMin, time.Minute)),
+	)
+
+	// Nostr relay (NIP-01) bridge: rooms map to the "h" tag of kind 9 events
+	fuego.GetStd(s, "/nostr", NostrRelay(chatService, minuteRL, cfg),
+		option.Middleware(middleware.IPRateLimit(minuteRL, nostrRateLimitPerMin, time.Minute)),
+	)
 
 	// User routes
 	userGroup := fuego.Group(s, "/users", option.TagInfo("user", "routes relative to users"))
+	fuego.Get(userGroup, "/me", GetCurrentUser(chatService),
+		option.Middleware(requestAuth.Required()),
+	)
 	fuego.Get(userGroup, "/{publicKey}", GetUser(chatService))
+
+	// Admin routes: each request is signed by a key listed in ADMIN_PUBKEYS
+	adminChallenges := middleware.NewChallengeStore(adminChallengeTTL, shared)
+	adminAuth := option.Middleware(middleware.AdminAuth(adminChallenges, requestAuth, cfg.AdminPubkeys))
+	adminGroup := fuego.Group(s, "/admin", option.TagInfo("admin", "moderation routes, authenticated by a signed challenge"))
+
+	fuego.Post(adminGroup, "/challenge", IssueAdminChallenge(adminChallenges, cfg.AdminPubkeys),
+		option.Header(middleware.AdminPubkeyHeader, "admin public key the challenge is for", param.Required()),
+		option.Middleware(middleware.IPRateLimit(minuteRL, adminChallengeRateLimitPerMin, time.Minute)),
+	)
+	fuego.Get(adminGroup, "/users", ListUsers(chatService), adminAuth)
+	fuego.Post(adminGroup, "/users/{publicKey}/verify", VerifyUser(chatService), adminAuth)
+	fuego.Post(adminGroup, "/users/{publicKey}/unverify", UnverifyUser(chatService), adminAuth)
+	fuego.Delete(adminGroup, "/rooms/{room}", DeleteRoom(chatService), adminAuth)
+	fuego.Delete(adminGroup, "/rooms/{room}/messages/{id}", DeleteMessage(chatService, cfg), adminAuth)
+	fuego.Post(adminGroup, "/rooms/{room}/password", ResetRoomPassword(chatService, cfg), adminAuth,
+		option.RequestContentType("application/json"),
+	)
+	fuego.Get(adminGroup, "/retention", GetRetentionStats(chatService), adminAuth)
+	fuego.Get(adminGroup, "/sanctions", ListSanctions(chatService, cfg), adminAuth)
+	fuego.Post(adminGroup, "/sanctions", CreateSanction(chatService, cfg), adminAuth,
+		option.RequestContentType("application/json"),
+	)
+	fuego.Delete(adminGroup, "/sanctions/{id}", LiftSanction(chatService, cfg), adminAuth)
 }
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+
+	"github.com/go-fuego/fuego"
+)
+
+// The sanction routes serve both the sanctions of a room, managed by its owner
+// and moderators, and the server-wide ones under /admin, where the path has no
+// room.
+
+// ListSanctions returns the active mutes and bans of a room, or the
+// server-wide ones.
+func ListSanctions(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextNoBody) ([]models.Sanction, error) {
+	return func(c fuego.ContextNoBody) ([]models.Sanction, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		sanctions, err := chatService.GetSanctions(c.Context(), actor, c.PathParam("room"))
+		if err != nil {
+			return nil, sanctionError(err)
+		}
+		return sanctions, nil
+	}
+}
+
+// CreateSanction mutes or bans a pubkey or an IP address.
+func CreateSanction(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextWithBody[models.CreateSanctionRequest]) (*models.Sanction, error) {
+	return func(c fuego.ContextWithBody[models.CreateSanctionRequest]) (*models.Sanction, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.Body()
+		if err != nil {
+			return nil, err
+		}
+		if body.Pubkey == "" && body.IP == "" {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "a pubkey or an ip is required"}
+		}
+		if body.Pubkey != "" && !validPubkey(body.Pubkey) {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "pubkey must be a compressed or x-only hex public key"}
+		}
+
+		sanction, err := chatService.Sanction(c.Context(), actor, c.PathParam("room"), body)
+		if err != nil {
+			return nil, sanctionError(err)
+		}
+		return sanction, nil
+	}
+}
+
+// LiftSanction removes a mute or a ban.
+func LiftSanction(chatService *services.ChatService, cfg *config.Config) func(c fuego.ContextNoBody) (any, error) {
+	return func(c fuego.ContextNoBody) (any, error) {
+		actor, err := actorFromContext(c.Context(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := chatService.LiftSanction(c.Context(), actor, c.PathParam("room"), c.PathParam("id")); err != nil {
+			return nil, sanctionError(err)
+		}
+		c.SetStatus(http.StatusNoContent)
+		return nil, nil
+	}
+}
+
+// sanctionError maps errors from managing sanctions to HTTP errors.
+func sanctionError(err error) error {
+	if errors.Is(err, services.ErrSanctionNotFound) {
+		return fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: err.Error(), Err: err}
+	}
+	return roleError(err)
+}
+
+// sanctionedError returns a 403 for a muted or banned sender, and nil for
+// other errors.
+func sanctionedError(err error) error {
+	if errors.Is(err, services.ErrMuted) || errors.Is(err, services.ErrBanned) {
+		return fuego.HTTPError{Status: http.StatusForbidden, Title: "Forbidden", Detail: err.Error(), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/go-fuego/fuego"
+)
+
+func TestSanctions(t *testing.T) {
+	store := memory.NewStore()
+	chatService := services.NewChatService(store)
+	admin, _ := secp256k1.GeneratePrivateKey()
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{
+		AdminPubkeys: []string{hex.EncodeToString(admin.PubKey().SerializeCompressed())},
+	})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+	dave, _ := secp256k1.GeneratePrivateKey()
+	pubkey := func(key *secp256k1.PrivateKey) string {
+		return hex.EncodeToString(key.PubKey().SerializeCompressed())
+	}
+	sent := 0
+	send := func(key *secp256k1.PrivateKey, room, ip string) int {
+		t.Helper()
+		sent++ // distinct content, as a replayed signature is rejected
+		data, _ := json.Marshal(signMessageV1(t, key, room, fmt.Sprintf("hello %d", sent), "someone", nil))
+		req := httptest.NewRequest(http.MethodPost, "/api/rooms/"+room+"/messages", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		if ip != "" {
+			req.Header.Set("X-Forwarded-For", ip)
+		}
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, req)
+		return w.Code
+	}
+	sanction := func(key *secp256k1.PrivateKey, room string, body models.CreateSanctionRequest) models.Sanction {
+		t.Helper()
+		w := roomRequest(t, s, key, http.MethodPost, "/api/rooms/"+room+"/sanctions", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("sanction: status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+		var created models.Sanction
+		if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		return created
+	}
+
+	if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", models.CreateRoomRequest{Name: "club"}); w.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	if w := roomRequest(t, s, owner, http.MethodPut, "/api/rooms/club/moderators/"+pubkey(bob), nil); w.Code != http.StatusOK {
+		t.Fatalf("promotion: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	msg, err := chatService.SendMessage(context.Background(), models.Message{Room: "club", User: "owner", Content: "welcome", Signature: "sig"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  *secp256k1.PrivateKey
+		room string
+		body models.CreateSanctionRequest
+		want int
+	}{
+		{"unsigned", nil, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: pubkey(carol)}, http.StatusUnauthorized},
+		{"stranger", dave, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: pubkey(carol)}, http.StatusForbidden},
+		{"moderator mutes owner", bob, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: pubkey(owner)}, http.StatusForbidden},
+		{"no target", bob, "club", models.CreateSanctionRequest{Kind: models.SanctionMute}, http.StatusBadRequest},
+		{"invalid pubkey", bob, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: "carol"}, http.StatusBadRequest},
+		{"invalid ip", bob, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, IP: "nowhere"}, http.StatusBadRequest},
+		{"invalid kind", bob, "club", models.CreateSanctionRequest{Kind: "kick", Pubkey: pubkey(carol)}, http.StatusBadRequest},
+		{"unknown room", bob, "nowhere", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: pubkey(carol)}, http.StatusNotFound},
+	} {
+		if w := roomRequest(t, s, tt.key, http.MethodPost, "/api/rooms/"+tt.room+"/sanctions", tt.body); w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body: %s", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+
+	t.Run("mute", func(t *testing.T) {
+		mute := sanction(bob, "club", models.CreateSanctionRequest{Kind: models.SanctionMute, Pubkey: pubkey(carol), Reason: "spam"})
+		if code := send(carol, "club", ""); code != http.StatusForbidden {
+			t.Errorf("muted send: status = %d, want 403", code)
+		}
+		if code := send(carol, "lobby", ""); code != http.StatusOK {
+			t.Errorf("send in another room: status = %d, want 200", code)
+		}
+		if w := postReaction(t, s, "club", msg.ID, signReaction(t, carol, "club", msg.ID, "👍", false)); w.Code != http.StatusOK {
+			t.Errorf("muted reaction: status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+
+		w := roomRequest(t, s, owner, http.MethodGet, "/api/rooms/club/sanctions", nil)
+		var sanctions []models.Sanction
+		if err := json.Unmarshal(w.Body.Bytes(), &sanctions); err != nil || len(sanctions) != 1 || sanctions[0].ID != mute.ID {
+			t.Errorf("list: %s, want the mute", w.Body.String())
+		}
+		if w := roomRequest(t, s, dave, http.MethodGet, "/api/rooms/club/sanctions", nil); w.Code != http.StatusForbidden {
+			t.Errorf("stranger list: status = %d, want 403", w.Code)
+		}
+
+		if w := roomRequest(t, s, bob, http.MethodDelete, "/api/rooms/club/sanctions/"+mute.ID, nil); w.Code != http.StatusNoContent {
+			t.Errorf("lift: status = %d, want 204; body: %s", w.Code, w.Body.String())
+		}
+		if w := roomRequest(t, s, owner, http.MethodDelete, "/api/rooms/club/sanctions/"+mute.ID, nil); w.Code != http.StatusNotFound {
+			t.Errorf("lift again: status = %d, want 404", w.Code)
+		}
+		if code := send(carol, "club", ""); code != http.StatusOK {
+			t.Errorf("send after lift: status = %d, want 200", code)
+		}
+	})
+
+	t.Run("ban by ip", func(t *testing.T) {
+		ban := sanction(owner, "club", models.CreateSanctionRequest{Kind: models.SanctionBan, IP: "203.0.113.7"})
+		if code := send(dave, "club", "203.0.113.7"); code != http.StatusForbidden {
+			t.Errorf("banned ip: status = %d, want 403", code)
+		}
+		data, _ := json.Marshal(signReaction(t, dave, "club", msg.ID, "👍", false))
+		req := httptest.NewRequest(http.MethodPost, "/api/rooms/club/messages/"+msg.ID+"/reactions", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		w := httptest.NewRecorder()
+		s.Mux.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("banned reaction: status = %d, want 403; body: %s", w.Code, w.Body.String())
+		}
+		if code := send(dave, "club", "198.51.100.1"); code != http.StatusOK {
+			t.Errorf("other ip: status = %d, want 200", code)
+		}
+		if w := roomRequest(t, s, owner, http.MethodDelete, "/api/rooms/club/sanctions/"+ban.ID, nil); w.Code != http.StatusNoContent {
+			t.Errorf("lift: status = %d, want 204", w.Code)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		if _, err := store.CreateSanction(context.Background(), models.Sanction{
+			Kind: models.SanctionMute, Room: "club", Pubkey: hex.EncodeToString(carol.PubKey().SerializeCompressed()[1:]),
+			ExpiresAt: new(time.Now().Add(-time.Minute)),
+		}); err != nil {
+			t.Fatalf("CreateSanction: %v", err)
+		}
+		if code := send(carol, "club", ""); code != http.StatusOK {
+			t.Errorf("expired mute: status = %d, want 200", code)
+		}
+	})
+
+	t.Run("server-wide ban", func(t *testing.T) {
+		w := adminRequest(t, s, admin, http.MethodPost, "/api/admin/sanctions", models.CreateSanctionRequest{Kind: models.SanctionBan, Pubkey: pubkey(dave)})
+		if w.Code != http.StatusOK {
+			t.Fatalf("admin ban: status = %d, want 200; body: %s", w.Code, w.Body.String())
+		}
+		var ban models.Sanction
+		if err := json.Unmarshal(w.Body.Bytes(), &ban); err != nil || ban.Room != "" {
+			t.Fatalf("ban = %s, want a server-wide sanction", w.Body.String())
+		}
+		if code := send(dave, "lobby", ""); code != http.StatusForbidden {
+			t.Errorf("banned send: status = %d, want 403", code)
+		}
+		if w := postDM(t, s, signDM(t, dave, carol, "hi")); w.Code != http.StatusForbidden {
+			t.Errorf("banned dm: status = %d, want 403; body: %s", w.Code, w.Body.String())
+		}
+		if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms/club/sanctions", models.CreateSanctionRequest{Kind: models.SanctionBan, Pubkey: pubkey(dave)}); w.Code != http.StatusOK {
+			t.Errorf("room ban on top: status = %d, want 200", w.Code)
+		}
+		if w := roomRequest(t, s, owner, http.MethodGet, "/api/admin/sanctions", nil); w.Code == http.StatusOK {
+			t.Errorf("owner lists server-wide sanctions: status = 200, want an error")
+		}
+
+		if w := adminRequest(t, s, admin, http.MethodDelete, "/api/admin/sanctions/"+ban.ID, nil); w.Code != http.StatusNoContent {
+			t.Errorf("admin lift: status = %d, want 204; body: %s", w.Code, w.Body.String())
+		}
+		if code := send(dave, "lobby", ""); code != http.StatusOK {
+			t.Errorf("send after lift: status = %d, want 200", code)
+		}
+		if code := send(dave, "club", ""); code != http.StatusForbidden {
+			t.Errorf("room ban still applies: status = %d, want 403", code)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+
+	"github.com/go-fuego/fuego"
+)
+
+type SearchMessagesQuery struct {
+	Q        string `query:"q"`        // words, each matching the start of a word
+	Room     string `query:"room"`     // search only this room
+	Author   string `query:"author"`   // hex pubkey of the author
+	Before   string `query:"before"`   // RFC3339
+	Password string `query:"password"` // password of room
+	Invite   string `query:"invite"`   // invite token to room, in place of the password; the request must be signed
+	Limit    int    `query:"limit"`
+}
+
+// SearchMessages lists the messages matching q, newest first. With room, it
+// searches that room, given its password or invite as GetMessages does;
+// without it, every room the caller may read without a password. Encrypted
+// rooms cannot be searched.
+func SearchMessages(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(c fuego.ContextWithParams[SearchMessagesQuery]) ([]models.Message, error) {
+	return func(c fuego.ContextWithParams[SearchMessagesQuery]) ([]models.Message, error) {
+		params, err := c.Params() //nolint:staticcheck // no replacement available yet in fuego
+		if err != nil {
+			return nil, err
+		}
+		if len(services.SearchTerms(params.Q)) == 0 {
+			return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "q must contain at least one word"}
+		}
+
+		search := services.MessageSearch{Query: params.Q, Limit: min(params.Limit, maxMessageLimit)}
+		if params.Author != "" {
+			if !validPubkey(params.Author) {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "author must be a hex public key"}
+			}
+			search.Author, _ = crypto.XOnlyPubkey(strings.ToLower(params.Author))
+		}
+		if params.Before != "" {
+			t, err := time.Parse(time.RFC3339, params.Before)
+			if err != nil {
+				return nil, fuego.HTTPError{Status: http.StatusBadRequest, Title: "Bad Request", Detail: "invalid 'before' timestamp: use RFC3339 format"}
+			}
+			search.Before = &t
+		}
+
+		pubkey, _ := middleware.PubkeyFromContext(c.Context())
+		if params.Room != "" {
+			if params.Invite != "" {
+				err = redeemInvite(c.Context(), chatService, params.Room, params.Invite)
+			} else {
+				err = checkRoomPassword(c.Request(), chatService, pwLimiter, params.Room, params.Password, pubkey)
+			}
+			if err != nil {
+				return nil, err
+			}
+			search.Rooms = []string{params.Room}
+		}
+
+		messages, err := chatService.SearchMessages(c.Context(), search, pubkey)
+		if errors.Is(err, services.ErrSearchEncrypted) {
+			return nil, fuego.HTTPError{Status: http.StatusConflict, Title: "Conflict", Detail: err.Error(), Err: err}
+		}
+		return messages, err
+	}
+}
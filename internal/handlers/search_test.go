@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/go-fuego/fuego"
+)
+
+func TestSearchMessages(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	owner, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	crypt, _ := encryptedRoomRequest(t, "crypt", "secret1")
+	for _, room := range []models.CreateRoomRequest{
+		{Name: "vault", Password: new("secret1")},
+		crypt,
+		{Name: "cellar", Private: true},
+	} {
+		if w := roomRequest(t, s, owner, http.MethodPost, "/api/rooms", room); w.Code != http.StatusOK {
+			t.Fatalf("create %s: status = %d; body: %s", room.Name, w.Code, w.Body.String())
+		}
+	}
+	for _, post := range []struct {
+		key           *secp256k1.PrivateKey
+		room, content string
+	}{
+		{owner, "general", "hello from the lobby"},
+		{bob, "general", "Hello, world"},
+		{owner, "vault", "hello from the vault"},
+		{owner, "cellar", "hello from the cellar"},
+	} {
+		body := signMessageV1(t, post.key, post.room, post.content, "someone", nil)
+		if post.room == "vault" {
+			body.RoomPassword = "secret1"
+		}
+		if w := postMessage(t, s, post.room, body); w.Code != http.StatusOK {
+			t.Fatalf("post to %s: status = %d; body: %s", post.room, w.Code, w.Body.String())
+		}
+	}
+
+	requests := 0
+	search := func(key *secp256k1.PrivateKey, query url.Values) (int, []string) {
+		t.Helper()
+		requests++ // a distinct URL per request, as a replayed signed request is rejected
+		query.Set("n", fmt.Sprint(requests))
+		w := roomRequest(t, s, key, http.MethodGet, "/api/search?"+query.Encode(), nil)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var messages []models.Message
+		if err := json.Unmarshal(w.Body.Bytes(), &messages); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		contents := []string{}
+		for _, msg := range messages {
+			contents = append(contents, msg.Content)
+		}
+		return w.Code, contents
+	}
+
+	if _, got := search(nil, url.Values{"q": {"hel"}}); !slices.Equal(got, []string{"Hello, world", "hello from the lobby"}) {
+		t.Errorf("unsigned search = %q, want the public room only, newest first", got)
+	}
+	if _, got := search(owner, url.Values{"q": {"hello from"}}); !slices.Equal(got, []string{"hello from the cellar", "hello from the lobby"}) {
+		t.Errorf("member search = %q, want the private room too", got)
+	}
+	author := hex.EncodeToString(bob.PubKey().SerializeCompressed())
+	if _, got := search(nil, url.Values{"q": {"hello"}, "author": {author}}); !slices.Equal(got, []string{"Hello, world"}) {
+		t.Errorf("search by author = %q, want bob's message", got)
+	}
+	if _, got := search(nil, url.Values{"q": {"hello"}, "room": {"vault"}, "password": {"secret1"}}); !slices.Equal(got, []string{"hello from the vault"}) {
+		t.Errorf("search in the password room = %q, want its message", got)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		key   *secp256k1.PrivateKey
+		query url.Values
+		want  int
+	}{
+		{"no words", nil, url.Values{"q": {" ?! "}}, http.StatusBadRequest},
+		{"bad author", nil, url.Values{"q": {"hello"}, "author": {"bob"}}, http.StatusBadRequest},
+		{"bad before", nil, url.Values{"q": {"hello"}, "before": {"yesterday"}}, http.StatusBadRequest},
+		{"wrong password", nil, url.Values{"q": {"hello"}, "room": {"vault"}, "password": {"guess"}}, http.StatusForbidden},
+		{"encrypted room", nil, url.Values{"q": {"hello"}, "room": {"crypt"}, "password": {*crypt.Password}}, http.StatusConflict},
+		{"unsigned private room", nil, url.Values{"q": {"hello"}, "room": {"cellar"}}, http.StatusUnauthorized},
+		{"stranger in private room", bob, url.Values{"q": {"hello"}, "room": {"cellar"}}, http.StatusForbidden},
+		{"unknown room", nil, url.Values{"q": {"hello"}, "room": {"nowhere"}}, http.StatusNotFound},
+	} {
+		if code, _ := search(tt.key, tt.query); code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, code, tt.want)
+		}
+	}
+}
+
+func TestSearchMessages_RoomsBeyondListing(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	ctx := context.Background()
+
+	// 100 more recently active rooms push the first one out of GetRooms
+	if _, err := chatService.SendMessage(ctx, models.Message{Room: "quiet", User: "alice", Content: "needle"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	for i := range 100 {
+		if _, err := chatService.SendMessage(ctx, models.Message{Room: fmt.Sprintf("busy-%03d", i), User: "alice", Content: "hay"}); err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+	rooms, err := chatService.GetRooms(ctx, "")
+	if err != nil || slices.ContainsFunc(rooms, func(room models.Room) bool { return room.Name == "quiet" }) {
+		t.Fatalf("GetRooms = %d rooms, %v; want the quiet room left out", len(rooms), err)
+	}
+
+	found, err := chatService.SearchMessages(ctx, services.MessageSearch{Query: "needle"}, "")
+	if err != nil || len(found) != 1 || found[0].Room != "quiet" {
+		t.Errorf("SearchMessages = %+v, %v; want the message of the quiet room", found, err)
+	}
+}
@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/pkg/crypto"
 	"github.com/go-fuego/fuego"
 )
 
 type ServerInfoResponse struct {
-	SuggestedQuickname string   `json:"suggested_quickname"`
-	Description        string   `json:"description"`
-	SuggestedServers   []string `json:"suggested_servers,omitempty"`
+	SuggestedQuickname string                 `json:"suggested_quickname"`
+	Description        string                 `json:"description"`
+	SuggestedServers   []string               `json:"suggested_servers,omitempty"`
+	SignatureSchemes   []string               `json:"signature_schemes"`  // accepted values of SendMessageRequest.sig_scheme
+	Retention          models.RetentionPolicy `json:"retention,omitzero"` // server default, which rooms may only tighten
 }
 
 func GetServerInfo(cfg *config.Config) func(ctx fuego.ContextNoBody) (ServerInfoResponse, error) {
@@ -17,6 +21,8 @@ func GetServerInfo(cfg *config.Config) func(ctx fuego.ContextNoBody) (ServerInfo
 			SuggestedQuickname: cfg.QuickName,
 			Description:        cfg.Description,
 			SuggestedServers:   cfg.SuggestedServerList,
+			SignatureSchemes:   crypto.SignatureSchemes(),
+			Retention:          cfg.Retention(),
 		}, nil
 	}
 }
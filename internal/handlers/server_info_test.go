@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/pkg/crypto"
 	"github.com/go-fuego/fuego"
 )
 
@@ -46,3 +48,13 @@ func TestGetServerInfo_WithSuggestedServers(t *testing.T) {
 		t.Errorf("SuggestedServers[1] = %q, want %q", resp.SuggestedServers[1], "https://other.example.com")
 	}
 }
+
+func TestGetServerInfo_AdvertisesSignatureSchemes(t *testing.T) {
+	resp, err := GetServerInfo(&config.Config{})(fuego.NewMockContextNoBody())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(resp.SignatureSchemes, crypto.SigSchnorr) {
+		t.Errorf("SignatureSchemes = %v, want it to include %q", resp.SignatureSchemes, crypto.SigSchnorr)
+	}
+}
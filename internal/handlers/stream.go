@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+)
+
+// streamHeartbeatInterval keeps idle SSE connections open through proxies.
+const streamHeartbeatInterval = 25 * time.Second
+
+// StreamMessages serves GET /rooms/{room}/stream as Server-Sent Events. Each
+// message saved in the room is pushed as a "message" event whose id is the
+// message ID, so clients can resume with the Last-Event-ID header (or the
+// last_event_id query parameter for EventSource polyfills). Private rooms
+// need a request signed by a member.
+func StreamMessages(chatService *services.ChatService, pwLimiter *middleware.RateLimiter) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		room := r.PathValue("room")
+		password := r.URL.Query().Get("password")
+
+		pubkey, _ := middleware.PubkeyFromContext(r.Context())
+		if err := chatService.ValidateRoomAccess(r.Context(), room, password, pubkey); err != nil {
+			switch {
+			case errors.Is(err, services.ErrSignatureRequired):
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			case errors.Is(err, services.ErrNotMember):
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			ip := middleware.IPFromRequest(r)
+			if !pwLimiter.Allow("pw:"+ip, maxPasswordAttemptsPerMin, time.Minute) {
+				http.Error(w, "too many failed password attempts", http.StatusTooManyRequests)
+				return
+			}
+			slog.ErrorContext(r.Context(), "cannot validate password", "err", err)
+			time.Sleep(passwordFailDelay) // Mitigate brute-force attacks
+			http.Error(w, "cannot access room", http.StatusUnauthorized)
+			return
+		}
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("last_event_id")
+		}
+
+		sub, backlog, err := chatService.Subscribe(r.Context(), room, lastEventID)
+		if err != nil {
+			http.Error(w, "cannot subscribe to room", http.StatusInternalServerError)
+			return
+		}
+		defer sub.Close()
+
+		// The server-wide write timeout would otherwise cut long-lived streams.
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		// Backlog read from the repository may overlap with live deliveries.
+		sent := make(map[deliveryKey]bool, len(backlog))
+		for _, msg := range backlog {
+			if err := writeMessageEvent(w, msg); err != nil {
+				return
+			}
+			sent[deliveryKeyOf(msg)] = true
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+			case msg, ok := <-sub.Messages:
+				if !ok {
+					// Dropped as a slow consumer, or the access to the room
+					// changed: the client reconnects with Last-Event-ID and
+					// passes the access checks again.
+					return
+				}
+				if sent[deliveryKeyOf(msg)] {
+					continue
+				}
+				if err := writeMessageEvent(w, msg); err != nil {
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// deliveryKey identifies a message as delivered to a stream: its edits and
+// its deletion are published again under the same ID.
+type deliveryKey struct {
+	id              string
+	edited, deleted int64
+}
+
+func deliveryKeyOf(msg models.Message) deliveryKey {
+	key := deliveryKey{id: msg.ID}
+	if msg.EditedAt != nil {
+		key.edited = msg.EditedAt.UnixNano()
+	}
+	if msg.DeletedAt != nil {
+		key.deleted = msg.DeletedAt.UnixNano()
+	}
+	return key
+}
+
+// writeMessageEvent writes msg as a single SSE "message" event.
+func writeMessageEvent(w http.ResponseWriter, msg models.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", msg.ID, data)
+	return err
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/go-fuego/fuego"
+)
+
+// idRepo is a stubRepo whose saved messages carry an ID and room, so they can
+// be told apart on the stream.
+type idRepo struct {
+	stubRepo
+	n int
+}
+
+func (r *idRepo) SaveMessage(_ context.Context, msg models.Message) (*models.Message, error) {
+	r.n++
+	msg.ID = strings.Repeat("m", r.n)
+	return &msg, nil
+}
+
+func TestStreamMessages_PushesSentMessages(t *testing.T) {
+	chatService := services.NewChatService(&idRepo{})
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+	ts := httptest.NewServer(s.Mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/rooms/general/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	if _, err := chatService.SendMessage(ctx, models.Message{Room: "general", User: "alice", Content: "hello"}); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var event []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" && len(event) > 0 {
+			break
+		}
+		if line != "" {
+			event = append(event, line)
+		}
+	}
+
+	got := strings.Join(event, "\n")
+	if !strings.Contains(got, "id: m") || !strings.Contains(got, "event: message") || !strings.Contains(got, `"content":"hello"`) {
+		t.Errorf("unexpected event:\n%s", got)
+	}
+}
+
+// lockedRepo rejects every room password.
+type lockedRepo struct{ stubRepo }
+
+func (lockedRepo) ValidateRoomPassword(_ context.Context, _, _ string) error {
+	return crypto.ErrInvalidPassword
+}
+
+func TestStreamMessages_WrongPassword_Returns401(t *testing.T) {
+	chatService := services.NewChatService(&lockedRepo{})
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rooms/secret/stream?password=nope", nil)
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401; body: %s", w.Code, w.Body.String())
+	}
+}
+
+// readEvent returns the data of the next event of an SSE stream.
+func readEvent(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" && data != "" {
+			return data
+		}
+		if value, ok := strings.CutPrefix(line, "data: "); ok {
+			data = value
+		}
+	}
+}
+
+func TestStreamMessages_ResumedBacklogGetsEditsAndDeletions(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+	ts := httptest.NewServer(s.Mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var msgs []*models.Message
+	for _, content := range []string{"first", "second", "third"} {
+		msg, err := chatService.SendMessage(ctx, models.Message{Room: "general", User: "alice", Content: content, SignedTimestamp: 1})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/rooms/general/stream", nil)
+	req.Header.Set("Last-Event-ID", msgs[0].ID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	for _, want := range []string{`"content":"second"`, `"content":"third"`} {
+		if got := readEvent(t, reader); !strings.Contains(got, want) {
+			t.Fatalf("backlog event = %s, want %s", got, want)
+		}
+	}
+
+	// Both were in the backlog, yet their updates are delivered
+	if _, err := chatService.EditMessage(ctx, "general", msgs[1].ID, models.MessageRevision{Content: "edited", SignedTimestamp: 2}); err != nil {
+		t.Fatalf("EditMessage: %v", err)
+	}
+	if got := readEvent(t, reader); !strings.Contains(got, `"content":"edited"`) {
+		t.Errorf("event = %s, want the edit", got)
+	}
+	if _, err := chatService.DeleteMessage(ctx, services.Actor{Admin: true}, "general", msgs[2].ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if got := readEvent(t, reader); !strings.Contains(got, msgs[2].ID) || !strings.Contains(got, `"deleted_at"`) {
+		t.Errorf("event = %s, want the tombstone of the third message", got)
+	}
+}
+
+func TestStreamMessages_PasswordChangeEndsStream(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+	ts := httptest.NewServer(s.Mux)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := chatService.CreateRoom(ctx, "", "secret", new("old"), "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/rooms/secret/stream?password=old", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	if err := chatService.SetRoomPassword(ctx, services.Actor{Admin: true}, "secret", new("new")); err != nil {
+		t.Fatalf("SetRoomPassword: %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+
+	// Reconnecting checks the password again
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/rooms/secret/stream?password=old", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("reconnect: status = %d, want 401", resp.StatusCode)
+	}
+}
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/EwenQuim/microchat/internal/middleware"
 	"github.com/EwenQuim/microchat/internal/models"
 	"github.com/EwenQuim/microchat/internal/services"
 
@@ -13,3 +17,28 @@ func GetUser(chatService *services.ChatService) func(c fuego.ContextNoBody) (*mo
 		return chatService.GetUser(c.Context(), publicKey)
 	}
 }
+
+// GetCurrentUser returns the user who signed the request. Users are stored
+// under their compressed key, so an x-only signer is looked up under both
+// compressed forms.
+func GetCurrentUser(chatService *services.ChatService) func(c fuego.ContextNoBody) (*models.UserWithPostCount, error) {
+	return func(c fuego.ContextNoBody) (*models.UserWithPostCount, error) {
+		pubkey, ok := middleware.PubkeyFromContext(c.Context())
+		if !ok {
+			return nil, fuego.HTTPError{Status: http.StatusUnauthorized, Title: "Unauthorized", Detail: "request must be signed"}
+		}
+
+		candidates := []string{pubkey}
+		if len(pubkey) == 64 {
+			candidates = []string{"02" + pubkey, "03" + pubkey, pubkey}
+		}
+		for _, candidate := range candidates {
+			user, err := chatService.GetUserWithPostCount(c.Context(), candidate)
+			if errors.Is(err, services.ErrUserNotFound) {
+				continue
+			}
+			return user, err
+		}
+		return nil, fuego.HTTPError{Status: http.StatusNotFound, Title: "Not Found", Detail: "no messages were posted with this key yet", Err: services.ErrUserNotFound}
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/go-fuego/fuego"
+)
+
+// signHTTPRequest sets a NIP-98 Authorization header on r, signed by key.
+func signHTTPRequest(t *testing.T, key *secp256k1.PrivateKey, r *http.Request, body []byte) {
+	t.Helper()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	event := crypto.NewHTTPAuthEvent(pubkey, r.Method, "http://"+r.Host+r.URL.RequestURI(), body, time.Now().Unix())
+	id, err := event.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	event.ID = hex.EncodeToString(id)
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	header, err := event.AuthorizationHeader()
+	if err != nil {
+		t.Fatalf("AuthorizationHeader: %v", err)
+	}
+	r.Header.Set("Authorization", header)
+}
+
+// userRepo knows a single user.
+type userRepo struct {
+	stubRepo
+	publicKey string
+}
+
+func (r *userRepo) GetUserWithPostCount(_ context.Context, publicKey string) (*models.UserWithPostCount, error) {
+	if publicKey != r.publicKey {
+		return nil, services.ErrUserNotFound
+	}
+	return &models.UserWithPostCount{PublicKey: publicKey, PostCount: 3}, nil
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	key, _ := secp256k1.GeneratePrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), services.NewChatService(&userRepo{publicKey: pubkey}), &config.Config{})
+
+	w := httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users/me", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous: status = %d, want 401", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+	signHTTPRequest(t, key, req, nil)
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("signed: status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var user models.UserWithPostCount
+	if err := json.Unmarshal(w.Body.Bytes(), &user); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if user.PublicKey != pubkey || user.PostCount != 3 {
+		t.Errorf("user = %+v, want %s with 3 posts", user, pubkey)
+	}
+
+	stranger, _ := secp256k1.GeneratePrivateKey()
+	req = httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+	signHTTPRequest(t, stranger, req, nil)
+	w = httptest.NewRecorder()
+	s.Mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown key: status = %d, want 404", w.Code)
+	}
+}
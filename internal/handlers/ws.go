@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+const (
+	maxWSSubscriptions = 20               // rooms a single WebSocket may follow at once
+	maxWSFrameBytes    = 64 << 10         // largest client frame accepted
+	wsPingInterval     = 30 * time.Second // keepalive for idle connections
+	wsWriteTimeout     = 10 * time.Second // per-frame write deadline
+)
+
+// WebSocket serves GET /ws: a single bidirectional connection that can follow
+// several rooms and post signed messages. Sends are held to the same password,
+// signature and rate-limit rules as POST /rooms/{room}/messages.
+func WebSocket(chatService *services.ChatService, rl *middleware.RateLimiter, cfg *config.Config) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveWebSocket(w, r, func(ctx context.Context, conn *websocket.Conn) error {
+			session := &wsSession{
+				chatService: chatService,
+				rl:          rl,
+				maxSkew:     messageMaxSkew(cfg),
+				conn:        conn,
+				ip:          middleware.IPFromRequest(r),
+				subs:        make(map[string]*services.Subscription),
+			}
+			return session.run(ctx)
+		})
+	}
+}
+
+// serveWebSocket upgrades the request and runs a session on the connection,
+// closing it according to how the session ended.
+func serveWebSocket(w http.ResponseWriter, r *http.Request, run func(ctx context.Context, conn *websocket.Conn) error) {
+	// The server-wide timeouts would otherwise cut long-lived connections.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	// CORS is open to any origin for the HTTP API; mirror that here.
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "cannot accept websocket", "err", err)
+		return
+	}
+	conn.SetReadLimit(maxWSFrameBytes)
+
+	err = run(r.Context(), conn)
+
+	status := websocket.CloseStatus(err)
+	if status == websocket.StatusNormalClosure || status == websocket.StatusGoingAway {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+		return
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		slog.InfoContext(r.Context(), "websocket closed", "err", err)
+	}
+	_ = conn.CloseNow()
+}
+
+// wsSession holds the state of one WebSocket connection.
+type wsSession struct {
+	chatService *services.ChatService
+	rl          *middleware.RateLimiter
+	maxSkew     time.Duration
+	conn        *websocket.Conn
+	ip          string
+
+	mu   sync.Mutex
+	subs map[string]*services.Subscription
+	wg   sync.WaitGroup
+}
+
+func (s *wsSession) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.closeAll()
+		s.wg.Wait()
+	}()
+
+	s.wg.Go(func() { keepalive(ctx, s.conn) })
+
+	for {
+		_, data, err := s.conn.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		var frame models.WSClientFrame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			// Malformed frame: report it and keep the connection open.
+			if err := s.write(ctx, models.WSServerFrame{Type: models.WSError, Error: "invalid frame: " + err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.handle(ctx, frame); err != nil {
+			return err
+		}
+	}
+}
+
+// handle processes one client frame. Rejections are reported to the client as
+// error frames; only connection failures are returned.
+func (s *wsSession) handle(ctx context.Context, frame models.WSClientFrame) error {
+	reply := models.WSServerFrame{Room: frame.Room, Ref: frame.Ref}
+
+	if frame.Room == "" {
+		reply.Type = models.WSError
+		reply.Error = "room is required"
+		return s.write(ctx, reply)
+	}
+
+	switch frame.Type {
+	case models.WSSubscribe:
+		if err := s.subscribe(ctx, frame); err != nil {
+			reply.Type = models.WSError
+			reply.Error = err.Error()
+			return s.write(ctx, reply)
+		}
+		return nil
+
+	case models.WSUnsubscribe:
+		s.mu.Lock()
+		sub := s.subs[frame.Room]
+		delete(s.subs, frame.Room)
+		s.mu.Unlock()
+		if sub != nil {
+			sub.Close()
+		}
+		reply.Type = models.WSUnsubscribed
+		return s.write(ctx, reply)
+
+	case models.WSSend:
+		msg, err := s.send(ctx, frame)
+		if err != nil {
+			reply.Type = models.WSError
+			reply.Error = err.Error()
+			return s.write(ctx, reply)
+		}
+		reply.Type = models.WSAck
+		reply.Message = msg
+		return s.write(ctx, reply)
+
+	default:
+		reply.Type = models.WSError
+		reply.Error = "unknown frame type: " + string(frame.Type)
+		return s.write(ctx, reply)
+	}
+}
+
+func (s *wsSession) subscribe(ctx context.Context, frame models.WSClientFrame) error {
+	s.mu.Lock()
+	_, exists := s.subs[frame.Room]
+	count := len(s.subs)
+	s.mu.Unlock()
+	if exists {
+		return errors.New("already subscribed to this room")
+	}
+	if count >= maxWSSubscriptions {
+		return errors.New("too many subscriptions on this connection")
+	}
+
+	if err := s.chatService.ValidateRoomAccess(ctx, frame.Room, frame.Password, ""); err != nil {
+		if errors.Is(err, services.ErrSignatureRequired) {
+			return errors.New("private room: read it with signed requests")
+		}
+		if !s.rl.Allow("pw:"+s.ip, maxPasswordAttemptsPerMin, time.Minute) {
+			return errors.New("too many failed password attempts")
+		}
+		time.Sleep(passwordFailDelay) // Mitigate brute-force attacks
+		return errors.New("cannot access room")
+	}
+
+	sub, backlog, err := s.chatService.Subscribe(ctx, frame.Room, frame.LastEventID)
+	if err != nil {
+		return errors.New("cannot subscribe to room")
+	}
+
+	s.mu.Lock()
+	s.subs[frame.Room] = sub
+	s.mu.Unlock()
+
+	if err := s.write(ctx, models.WSServerFrame{Type: models.WSSubscribed, Room: frame.Room, Ref: frame.Ref}); err != nil {
+		return err
+	}
+
+	s.wg.Go(func() { s.forward(ctx, sub, backlog) })
+	return nil
+}
+
+// forward delivers a subscription's backlog and live messages to the client.
+func (s *wsSession) forward(ctx context.Context, sub *services.Subscription, backlog []models.Message) {
+	// Backlog read from the repository may overlap with live deliveries.
+	sent := make(map[deliveryKey]bool, len(backlog))
+	for _, msg := range backlog {
+		if err := s.write(ctx, models.WSServerFrame{Type: models.WSMessage, Room: sub.Room, Message: &msg}); err != nil {
+			return
+		}
+		sent[deliveryKeyOf(msg)] = true
+	}
+
+	for msg := range sub.Messages {
+		if sent[deliveryKeyOf(msg)] {
+			continue
+		}
+		if err := s.write(ctx, models.WSServerFrame{Type: models.WSMessage, Room: sub.Room, Message: &msg}); err != nil {
+			return
+		}
+	}
+
+	// The hub dropped a slow subscriber or the access to the room changed:
+	// tell the client so it can resubscribe with last_event_id, which checks
+	// the access again. Closed-on-purpose subscriptions are no longer in subs.
+	s.mu.Lock()
+	dropped := s.subs[sub.Room] == sub
+	if dropped {
+		delete(s.subs, sub.Room)
+	}
+	s.mu.Unlock()
+	if dropped {
+		_ = s.write(ctx, models.WSServerFrame{Type: models.WSUnsubscribed, Room: sub.Room, Error: cmp.Or(sub.Err(), services.ErrSubscriberBehind).Error()})
+	}
+}
+
+func (s *wsSession) send(ctx context.Context, frame models.WSClientFrame) (*models.Message, error) {
+	if frame.Message == nil {
+		return nil, errors.New("message is required")
+	}
+	body := *frame.Message
+	if body.User == "" || body.Content == "" || body.Signature == "" || body.Pubkey == "" || body.Timestamp == 0 {
+		return nil, errors.New("user, content, signature, pubkey and timestamp are required")
+	}
+
+	if !middleware.AllowMessage(s.rl, s.ip, body.Pubkey, sendMessageBurst, sendMessageRateLimitPerMin, time.Minute) {
+		return nil, errors.New("too many requests")
+	}
+
+	if err := checkSendMessage(ctx, s.chatService, s.rl, s.ip, frame.Room, body, s.maxSkew); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chatService.SendMessage(services.WithClientIP(ctx, s.ip), newMessage(frame.Room, body))
+	if err != nil {
+		return nil, sendMessageError(err)
+	}
+	return msg, nil
+}
+
+// keepalive pings the connection until ctx ends, dropping it when a ping fails.
+func keepalive(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+			err := conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				_ = conn.CloseNow()
+				return
+			}
+		}
+	}
+}
+
+func (s *wsSession) write(ctx context.Context, frame models.WSServerFrame) error {
+	ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, s.conn, frame)
+}
+
+func (s *wsSession) closeAll() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*services.Subscription)
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/config"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/go-fuego/fuego"
+)
+
+// signedRequest builds a SendMessageRequest signed the way clients sign messages.
+func signedRequest(t *testing.T, room, content string) models.SendMessageRequest {
+	t.Helper()
+	return signedRequestAt(t, room, content, time.Now().Unix())
+}
+
+// signedRequestAt is signedRequest with an explicit signed timestamp.
+func signedRequestAt(t *testing.T, room, content string, ts int64) models.SendMessageRequest {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	event, _ := json.Marshal([]any{0, pubkey, ts, content, room})
+	hash := sha256.Sum256(event)
+	sig := ecdsa.SignCompact(key, hash[:], true)[1:] // drop the recovery byte
+	return models.SendMessageRequest{
+		User:      "alice",
+		Content:   content,
+		Signature: hex.EncodeToString(sig),
+		Pubkey:    pubkey,
+		Timestamp: ts,
+	}
+}
+
+func dialTestWS(t *testing.T) (*websocket.Conn, context.Context) {
+	t.Helper()
+	return dialWS(t, services.NewChatService(&idRepo{}))
+}
+
+// dialWS connects to the WebSocket endpoint of a server backed by chatService.
+func dialWS(t *testing.T, chatService *services.ChatService) (*websocket.Conn, context.Context) {
+	t.Helper()
+	s := fuego.NewServer(fuego.WithoutLogger())
+	RegisterChatRoutes(fuego.Group(s, "/api"), chatService, &config.Config{})
+	ts := httptest.NewServer(s.Mux)
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http")+"/api/ws", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn, ctx
+}
+
+func readFrame(t *testing.T, ctx context.Context, conn *websocket.Conn) models.WSServerFrame {
+	t.Helper()
+	var frame models.WSServerFrame
+	if err := wsjson.Read(ctx, conn, &frame); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	return frame
+}
+
+func TestWebSocket_SubscribeAndSend(t *testing.T) {
+	conn, ctx := dialTestWS(t)
+
+	for _, room := range []string{"general", "random"} {
+		if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSubscribe, Room: room}); err != nil {
+			t.Fatalf("write subscribe: %v", err)
+		}
+		if f := readFrame(t, ctx, conn); f.Type != models.WSSubscribed || f.Room != room {
+			t.Fatalf("got %+v, want subscribed to %s", f, room)
+		}
+	}
+
+	req := signedRequest(t, "random", "hello")
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSend, Room: "random", Ref: "r1", Message: &req}); err != nil {
+		t.Fatalf("write send: %v", err)
+	}
+
+	var gotAck, gotMessage bool
+	for !gotAck || !gotMessage {
+		f := readFrame(t, ctx, conn)
+		switch f.Type {
+		case models.WSAck:
+			if f.Ref != "r1" {
+				t.Errorf("ack ref = %q, want r1", f.Ref)
+			}
+			gotAck = true
+		case models.WSMessage:
+			if f.Room != "random" || f.Message == nil || f.Message.Content != "hello" {
+				t.Errorf("unexpected message frame %+v", f)
+			}
+			gotMessage = true
+		default:
+			t.Fatalf("unexpected frame %+v", f)
+		}
+	}
+}
+
+func TestWebSocket_SendWithBadSignature_ReturnsError(t *testing.T) {
+	conn, ctx := dialTestWS(t)
+
+	req := signedRequest(t, "general", "hello")
+	req.Content = "tampered"
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSend, Room: "general", Ref: "r1", Message: &req}); err != nil {
+		t.Fatalf("write send: %v", err)
+	}
+
+	f := readFrame(t, ctx, conn)
+	if f.Type != models.WSError || f.Ref != "r1" || !strings.Contains(f.Error, "signature") {
+		t.Errorf("got %+v, want signature error for r1", f)
+	}
+}
+
+func TestWebSocket_MalformedFrame_KeepsConnection(t *testing.T) {
+	conn, ctx := dialTestWS(t)
+
+	if err := conn.Write(ctx, websocket.MessageText, []byte("{not json")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSError {
+		t.Fatalf("got %+v, want error frame", f)
+	}
+
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSubscribe, Room: "general"}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSSubscribed {
+		t.Errorf("got %+v, want subscribed after a malformed frame", f)
+	}
+}
+
+func TestWebSocket_ResumedBacklogGetsEditsAndDeletions(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	conn, ctx := dialWS(t, chatService)
+	var msgs []*models.Message
+	for _, content := range []string{"first", "second", "third"} {
+		msg, err := chatService.SendMessage(ctx, models.Message{Room: "general", User: "alice", Content: content, SignedTimestamp: 1})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSubscribe, Room: "general", LastEventID: msgs[0].ID}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSSubscribed {
+		t.Fatalf("got %+v, want subscribed", f)
+	}
+	for _, want := range msgs[1:] {
+		if f := readFrame(t, ctx, conn); f.Message == nil || f.Message.ID != want.ID {
+			t.Fatalf("got %+v, want backlog message %s", f, want.ID)
+		}
+	}
+
+	// Both were in the backlog, yet their updates are delivered
+	if _, err := chatService.EditMessage(ctx, "general", msgs[1].ID, models.MessageRevision{Content: "edited", SignedTimestamp: 2}); err != nil {
+		t.Fatalf("EditMessage: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Message == nil || f.Message.Content != "edited" {
+		t.Errorf("got %+v, want the edit", f)
+	}
+	if _, err := chatService.DeleteMessage(ctx, services.Actor{Admin: true}, "general", msgs[2].ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Message == nil || f.Message.ID != msgs[2].ID || f.Message.DeletedAt == nil {
+		t.Errorf("got %+v, want the tombstone of the third message", f)
+	}
+}
+
+func TestWebSocket_PasswordChangeEndsSubscription(t *testing.T) {
+	chatService := services.NewChatService(memory.NewStore())
+	conn, ctx := dialWS(t, chatService)
+	if _, err := chatService.CreateRoom(ctx, "", "secret", new("old"), "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSubscribe, Room: "secret", Password: "old"}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSSubscribed {
+		t.Fatalf("got %+v, want subscribed", f)
+	}
+
+	if err := chatService.SetRoomPassword(ctx, services.Actor{Admin: true}, "secret", new("new")); err != nil {
+		t.Fatalf("SetRoomPassword: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSUnsubscribed || f.Room != "secret" || f.Error != services.ErrAccessChanged.Error() {
+		t.Fatalf("got %+v, want unsubscribed from secret as its access changed", f)
+	}
+
+	// Resubscribing checks the password again
+	if err := wsjson.Write(ctx, conn, models.WSClientFrame{Type: models.WSSubscribe, Room: "secret", Password: "old"}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	if f := readFrame(t, ctx, conn); f.Type != models.WSError {
+		t.Errorf("got %+v, want an error", f)
+	}
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// Headers carrying the signed challenge of an admin request.
+const (
+	AdminPubkeyHeader    = "X-Admin-Pubkey"
+	AdminChallengeHeader = "X-Admin-Challenge"
+	AdminSignatureHeader = "X-Admin-Signature"
+	AdminSigSchemeHeader = "X-Admin-Sig-Scheme" // "ecdsa" (default) or "schnorr"
+)
+
+// maxPendingChallenges bounds the unexpired, unused challenges of each admin.
+const maxPendingChallenges = 16
+
+// ChallengeStore issues single-use challenges, each bound to the admin pubkey
+// it was issued for, that expire after a TTL.
+type ChallengeStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	pending map[string][]pendingChallenge // x-only admin pubkey -> its challenges, oldest first
+	shared  SharedStore                   // keeps the challenges instead of pending, when set
+}
+
+type pendingChallenge struct {
+	challenge string
+	expiry    time.Time
+}
+
+// NewChallengeStore returns a ChallengeStore keeping its challenges in shared
+// when it is not nil, so that replicas sharing it accept the challenges
+// issued by each other, and in memory otherwise.
+func NewChallengeStore(ttl time.Duration, shared SharedStore) *ChallengeStore {
+	return &ChallengeStore{
+		ttl:     ttl,
+		pending: make(map[string][]pendingChallenge),
+		shared:  shared,
+	}
+}
+
+// Issue returns a new random challenge for pubkey, which the caller checked
+// is an admin, and its expiry. Past maxPendingChallenges, the oldest challenge
+// of pubkey is dropped: anyone may request challenges for an admin, and must
+// not be able to lock it out by doing so.
+func (cs *ChallengeStore) Issue(ctx context.Context, pubkey string) (string, time.Time, error) {
+	key, err := crypto.XOnlyPubkey(strings.ToLower(pubkey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	if cs.shared != nil {
+		challenge, expiry := rand.Text(), now.Add(cs.ttl)
+		if err := cs.shared.SaveAdminChallenge(ctx, key, challenge, expiry, maxPendingChallenges); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to save admin challenge: %w", err)
+		}
+		return challenge, expiry, nil
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	pending := slices.DeleteFunc(cs.pending[key], func(p pendingChallenge) bool { return now.After(p.expiry) })
+	if len(pending) >= maxPendingChallenges {
+		pending = slices.Delete(pending, 0, len(pending)-maxPendingChallenges+1)
+	}
+
+	challenge := rand.Text()
+	expiry := now.Add(cs.ttl)
+	cs.pending[key] = append(pending, pendingChallenge{challenge: challenge, expiry: expiry})
+	return challenge, expiry, nil
+}
+
+// Consume reports whether challenge was issued for pubkey and has not
+// expired, and invalidates it.
+func (cs *ChallengeStore) Consume(ctx context.Context, pubkey, challenge string) (bool, error) {
+	key, err := crypto.XOnlyPubkey(strings.ToLower(pubkey))
+	if err != nil {
+		return false, nil
+	}
+
+	if cs.shared != nil {
+		valid, err := cs.shared.ConsumeAdminChallenge(ctx, key, challenge, time.Now())
+		if err != nil {
+			return false, fmt.Errorf("failed to consume admin challenge: %w", err)
+		}
+		return valid, nil
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	pending := cs.pending[key]
+	i := slices.IndexFunc(pending, func(p pendingChallenge) bool { return p.challenge == challenge })
+	if i == -1 {
+		return false, nil
+	}
+	expiry := pending[i].expiry
+	if pending = slices.Delete(pending, i, i+1); len(pending) == 0 {
+		delete(cs.pending, key)
+	} else {
+		cs.pending[key] = pending
+	}
+	return !time.Now().After(expiry), nil
+}
+
+// AdminAuth returns middleware that only lets through requests signed by one
+// of adminPubkeys, and puts the admin pubkey in the request context. A request
+// is signed either with a NIP-98 Authorization header, checked by auth, or
+// with a challenge issued by cs for its pubkey and a signature over
+// crypto.Challenge{challenge, method, URI, payload hash}; each challenge is
+// accepted once, and only used up by a valid signature.
+func AdminAuth(cs *ChallengeStore, auth *RequestAuth, adminPubkeys []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var pubkey string
+			if r.Header.Get("Authorization") != "" {
+				var err error
+				if pubkey, err = auth.Verify(r); err != nil {
+					rejectAuth(w, r, err)
+					return
+				}
+				if !IsAdmin(adminPubkeys, pubkey) {
+					writeError(w, http.StatusForbidden, "public key is not an admin")
+					return
+				}
+			} else {
+				pubkey = strings.ToLower(r.Header.Get(AdminPubkeyHeader))
+				challenge := r.Header.Get(AdminChallengeHeader)
+				signature := r.Header.Get(AdminSignatureHeader)
+				if pubkey == "" || challenge == "" || signature == "" {
+					unauthorized(w, errors.New("missing admin challenge signature"))
+					return
+				}
+				if !IsAdmin(adminPubkeys, pubkey) {
+					writeError(w, http.StatusForbidden, "public key is not an admin")
+					return
+				}
+
+				body, err := readSignedBody(r)
+				if err != nil {
+					unauthorized(w, err)
+					return
+				}
+				signed := crypto.Challenge{Nonce: challenge, Method: r.Method, URI: r.URL.RequestURI(), Payload: crypto.PayloadHash(body)}
+				if err := crypto.VerifyChallengeSignature(r.Header.Get(AdminSigSchemeHeader), pubkey, signed, signature); err != nil {
+					unauthorized(w, errors.New("invalid challenge signature"))
+					return
+				}
+				valid, err := cs.Consume(r.Context(), pubkey, challenge)
+				if err != nil {
+					slog.ErrorContext(r.Context(), "Failed to check an admin challenge", "error", err)
+					writeError(w, http.StatusServiceUnavailable, "cannot check the admin challenge")
+					return
+				}
+				if !valid {
+					unauthorized(w, errors.New("unknown or expired challenge"))
+					return
+				}
+			}
+
+			slog.InfoContext(r.Context(), "admin request", "pubkey", pubkey, "method", r.Method, "path", r.URL.Path) //nolint:gosec // G706: structured log field, not a format string
+			next.ServeHTTP(w, r.WithContext(WithPubkey(r.Context(), pubkey)))
+		})
+	}
+}
+
+// IsAdmin reports whether pubkey is one of adminPubkeys. Keys are compared in
+// x-only form, so an admin configured with a compressed key can also sign
+// with schnorr.
+func IsAdmin(adminPubkeys []string, pubkey string) bool {
+	return slices.ContainsFunc(adminPubkeys, func(admin string) bool {
+		return crypto.SamePubkey(admin, pubkey)
+	})
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	adminA = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	adminB = "c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"
+)
+
+func TestChallengeStore_SingleUse(t *testing.T) {
+	cs := NewChallengeStore(time.Minute, nil)
+	challenge, expiry, err := cs.Issue(t.Context(), "02"+adminA)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if challenge == "" || !expiry.After(time.Now()) {
+		t.Fatalf("Issue() = %q, %v", challenge, expiry)
+	}
+
+	if consume(t, cs, adminB, challenge) {
+		t.Fatal("challenge should only be accepted for the admin it was issued for")
+	}
+	if !consume(t, cs, adminA, challenge) {
+		t.Fatal("fresh challenge should be accepted, with the x-only key too")
+	}
+	if consume(t, cs, adminA, challenge) {
+		t.Error("challenge should only be accepted once")
+	}
+	if consume(t, cs, adminA, "never-issued") {
+		t.Error("unknown challenge should be rejected")
+	}
+	if _, _, err := cs.Issue(t.Context(), "not a key"); err == nil {
+		t.Error("expected an error for an invalid pubkey")
+	}
+}
+
+func TestChallengeStore_Expiry(t *testing.T) {
+	cs := NewChallengeStore(10*time.Millisecond, nil)
+	challenge, _, _ := cs.Issue(t.Context(), adminA)
+	time.Sleep(20 * time.Millisecond)
+	if consume(t, cs, adminA, challenge) {
+		t.Error("expired challenge should be rejected")
+	}
+}
+
+func TestChallengeStore_BoundsPendingChallengesPerAdmin(t *testing.T) {
+	cs := NewChallengeStore(time.Minute, nil)
+	first, _, _ := cs.Issue(t.Context(), adminA)
+	other, _, _ := cs.Issue(t.Context(), adminB)
+	var last string
+	for range maxPendingChallenges {
+		var err error
+		if last, _, err = cs.Issue(t.Context(), adminA); err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+	}
+	if len(cs.pending[adminA]) != maxPendingChallenges {
+		t.Errorf("pending = %d, want %d", len(cs.pending[adminA]), maxPendingChallenges)
+	}
+	if consume(t, cs, adminA, first) {
+		t.Error("the oldest challenge should be dropped past the limit")
+	}
+	if !consume(t, cs, adminA, last) {
+		t.Error("the latest challenge should be accepted")
+	}
+	if !consume(t, cs, adminB, other) {
+		t.Error("the challenges of another admin should be kept")
+	}
+}
+
+func consume(t *testing.T, cs *ChallengeStore, pubkey, challenge string) bool {
+	t.Helper()
+	valid, err := cs.Consume(t.Context(), pubkey, challenge)
+	if err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	return valid
+}
+
+func TestIsAdmin(t *testing.T) {
+	xOnly := adminA
+	admins := []string{"02" + xOnly}
+
+	for pubkey, want := range map[string]bool{
+		"02" + xOnly:            true,
+		xOnly:                   true,
+		"03" + xOnly:            true,
+		"02" + "11" + xOnly[2:]: false,
+		"":                      false,
+	} {
+		if got := IsAdmin(admins, pubkey); got != want {
+			t.Errorf("IsAdmin(%q) = %v, want %v", pubkey, got, want)
+		}
+	}
+}
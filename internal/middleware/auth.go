@@ -0,0 +1,287 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// DefaultSignedRequestMaxSkew is the accepted drift between the created_at of
+// a signed request and the server clock.
+const DefaultSignedRequestMaxSkew = time.Minute
+
+// maxSignedBodyBytes bounds the request body buffered to check its hash.
+const maxSignedBodyBytes = 1 << 20
+
+// ErrNoAuthorization is returned by RequestAuth.Verify for a request without
+// a signed Authorization header.
+var ErrNoAuthorization = errors.New("missing signed Authorization header")
+
+// ErrAuthUnavailable is returned, wrapped, by RequestAuth.Verify when the
+// shared store recording the used events failed, and the request could not be
+// checked.
+var ErrAuthUnavailable = errors.New("cannot check the authorization")
+
+type pubkeyContextKey struct{}
+
+// WithPubkey returns a copy of ctx carrying the verified pubkey of the caller.
+func WithPubkey(ctx context.Context, pubkey string) context.Context {
+	return context.WithValue(ctx, pubkeyContextKey{}, pubkey)
+}
+
+// PubkeyFromContext returns the pubkey verified by RequestAuth or AdminAuth,
+// as it was sent by the caller (lowercase, compressed or x-only hex).
+func PubkeyFromContext(ctx context.Context) (string, bool) {
+	pubkey, ok := ctx.Value(pubkeyContextKey{}).(string)
+	return pubkey, ok && pubkey != ""
+}
+
+// RequestAuth authenticates requests carrying a NIP-98 Authorization header:
+// a kind 27235 event signed with BIP-340 over the request URL, method, body
+// hash and a recent timestamp. Each event is accepted once.
+type RequestAuth struct {
+	maxSkew        time.Duration
+	trustedProxies []netip.Prefix // whose X-Forwarded-Host names the signed host
+
+	mu     sync.Mutex
+	seen   map[string]time.Time // event id -> when it can be forgotten
+	shared SharedStore          // records the event ids instead of seen, when set
+}
+
+// seenCleanupInterval is how often RequestAuth forgets the ids of events too
+// old to pass the timestamp check again.
+const seenCleanupInterval = time.Minute
+
+// NewRequestAuth returns a RequestAuth accepting events created within maxSkew
+// of the server clock, and starts a background goroutine forgetting the ids
+// of expired events. The ids are recorded in shared when it is not nil, so
+// that replicas sharing it accept each event once between them. The
+// X-Forwarded-Host header is only honoured on requests from trustedProxies:
+// anyone else could otherwise replay an event signed for another server.
+func NewRequestAuth(maxSkew time.Duration, shared SharedStore, trustedProxies ...netip.Prefix) *RequestAuth {
+	a := &RequestAuth{
+		maxSkew:        maxSkew,
+		trustedProxies: trustedProxies,
+		seen:           make(map[string]time.Time),
+		shared:         shared,
+	}
+	go a.cleanup(seenCleanupInterval)
+	return a
+}
+
+// Verify checks the Authorization header of r and returns the signing pubkey.
+// A request body is buffered so the handler can still read it.
+func (a *RequestAuth) Verify(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", ErrNoAuthorization
+	}
+	encoded, ok := strings.CutPrefix(header, crypto.HTTPAuthScheme+" ")
+	if !ok {
+		return "", fmt.Errorf("authorization scheme must be %q", crypto.HTTPAuthScheme)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", fmt.Errorf("authorization event is not valid base64: %w", err)
+	}
+	var event crypto.NostrEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", fmt.Errorf("authorization event is not valid JSON: %w", err)
+	}
+
+	if event.Kind != crypto.KindHTTPAuth {
+		return "", fmt.Errorf("authorization event must be of kind %d", crypto.KindHTTPAuth)
+	}
+	if skew := time.Since(time.Unix(event.CreatedAt, 0)).Abs(); skew > a.maxSkew {
+		return "", fmt.Errorf("authorization event is outside the accepted window of ±%s from server time", a.maxSkew)
+	}
+	if method, _ := crypto.TagValue(event.Tags, crypto.MethodTag); method != r.Method {
+		return "", fmt.Errorf("authorization event is not for method %s", r.Method)
+	}
+	if signedURL, _ := crypto.TagValue(event.Tags, crypto.URLTag); !a.matchesRequestURL(signedURL, r) {
+		return "", fmt.Errorf("authorization event is not for this URL")
+	}
+	if err := checkPayload(event.Tags, r); err != nil {
+		return "", err
+	}
+	if err := event.Verify(); err != nil {
+		return "", fmt.Errorf("invalid authorization event: %w", err)
+	}
+	firstUse, err := a.firstUse(r.Context(), event.ID, time.Unix(event.CreatedAt, 0))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAuthUnavailable, err)
+	}
+	if !firstUse {
+		return "", fmt.Errorf("authorization event was already used")
+	}
+
+	return strings.ToLower(event.Pubkey), nil
+}
+
+// matchesRequestURL compares the signed URL with the host, path and query of
+// r. The scheme is not compared as TLS is often terminated by a proxy.
+func (a *RequestAuth) matchesRequestURL(signedURL string, r *http.Request) bool {
+	u, err := url.Parse(signedURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := r.Host
+	if fwd := r.Header.Get("X-Forwarded-Host"); fwd != "" && a.fromTrustedProxy(r) {
+		host = strings.TrimSpace(strings.SplitN(fwd, ",", 2)[0])
+	}
+	return strings.EqualFold(u.Host, host) && u.RequestURI() == r.URL.RequestURI()
+}
+
+// fromTrustedProxy reports whether r was sent by one of the trusted proxies.
+func (a *RequestAuth) fromTrustedProxy(r *http.Request) bool {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	return slices.ContainsFunc(a.trustedProxies, func(proxy netip.Prefix) bool { return proxy.Contains(addr) })
+}
+
+// checkPayload requires the payload tag to match the hash of a non-empty body.
+func checkPayload(tags [][]string, r *http.Request) error {
+	body, err := readSignedBody(r)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if payload, _ := crypto.TagValue(tags, crypto.PayloadTag); payload != crypto.PayloadHash(body) {
+		return fmt.Errorf("authorization event does not match the request body")
+	}
+	return nil
+}
+
+// readSignedBody reads the body of r, up to maxSignedBodyBytes, to check its
+// hash, and puts it back for the handler.
+func readSignedBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodyBytes+1))
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read request body: %w", err)
+	}
+	if len(body) > maxSignedBodyBytes {
+		return nil, fmt.Errorf("request body is too large to be signed")
+	}
+	return body, nil
+}
+
+// firstUse records id and reports whether it had not been seen. An id is
+// remembered until its timestamp leaves the accepted window, and forgotten
+// by cleanup after that.
+func (a *RequestAuth) firstUse(ctx context.Context, id string, createdAt time.Time) (bool, error) {
+	forgetAt := createdAt.Add(a.maxSkew)
+	if a.shared != nil {
+		return a.shared.UseAuthEvent(ctx, id, forgetAt)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, seen := a.seen[id]; seen {
+		return false, nil
+	}
+	a.seen[id] = forgetAt
+	return true, nil
+}
+
+func (a *RequestAuth) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		a.forgetExpired(now)
+	}
+}
+
+// forgetExpired drops the ids of the events whose timestamp left the
+// accepted window by now: Verify rejects them before looking them up.
+func (a *RequestAuth) forgetExpired(now time.Time) {
+	if a.shared != nil {
+		if err := a.shared.ForgetAuthEvents(context.Background(), now); err != nil {
+			slog.Error("Failed to forget used authorization events", "error", err)
+		}
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for id, forgetAt := range a.seen {
+		if now.After(forgetAt) {
+			delete(a.seen, id)
+		}
+	}
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", crypto.HTTPAuthScheme)
+	writeError(w, http.StatusUnauthorized, err.Error())
+}
+
+// rejectAuth answers a request that RequestAuth.Verify rejected with err: 401,
+// or 503 when it could not check it.
+func rejectAuth(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrAuthUnavailable) {
+		slog.ErrorContext(r.Context(), "Failed to check a signed request", "error", err)
+		writeError(w, http.StatusServiceUnavailable, ErrAuthUnavailable.Error())
+		return
+	}
+	unauthorized(w, err)
+}
+
+// Required returns middleware that rejects requests without a valid signed
+// Authorization header and puts the verified pubkey in the request context.
+func (a *RequestAuth) Required() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pubkey, err := a.Verify(r)
+			if err != nil {
+				rejectAuth(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPubkey(r.Context(), pubkey)))
+		})
+	}
+}
+
+// Optional returns middleware that puts the verified pubkey in the request
+// context when the request is signed. Anonymous requests go through; an
+// invalid signature is still rejected.
+func (a *RequestAuth) Optional() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pubkey, err := a.Verify(r)
+			switch {
+			case errors.Is(err, ErrNoAuthorization):
+				next.ServeHTTP(w, r)
+			case err != nil:
+				rejectAuth(w, r, err)
+			default:
+				next.ServeHTTP(w, r.WithContext(WithPubkey(r.Context(), pubkey)))
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// signRequest returns the Authorization header for event, signed by key.
+func signRequest(t *testing.T, key *btcec.PrivateKey, event crypto.NostrEvent) string {
+	t.Helper()
+	id, err := event.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	event.ID = hex.EncodeToString(id)
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	header, err := event.AuthorizationHeader()
+	if err != nil {
+		t.Fatalf("AuthorizationHeader: %v", err)
+	}
+	return header
+}
+
+func newSignedRequest(method, target string, body []byte, authorization string) *http.Request {
+	r := httptest.NewRequest(method, target, bytes.NewReader(body))
+	r.Header.Set("Authorization", authorization)
+	return r
+}
+
+func TestRequestAuth_Verify(t *testing.T) {
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	const target = "http://example.com/api/users/me?x=1"
+	body := []byte(`{"hello":"world"}`)
+	now := time.Now().Unix()
+
+	valid := crypto.NewHTTPAuthEvent(pubkey, http.MethodPost, target, body, now)
+
+	t.Run("valid", func(t *testing.T) {
+		auth := NewRequestAuth(time.Minute, nil)
+		r := newSignedRequest(http.MethodPost, target, body, signRequest(t, key, valid))
+		got, err := auth.Verify(r)
+		if err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+		if got != pubkey {
+			t.Errorf("pubkey = %q, want %q", got, pubkey)
+		}
+		if rest, _ := io.ReadAll(r.Body); !bytes.Equal(rest, body) {
+			t.Errorf("body = %q, want it to still be readable", rest)
+		}
+
+		replay := newSignedRequest(http.MethodPost, target, body, signRequest(t, key, valid))
+		if _, err := auth.Verify(replay); err == nil {
+			t.Error("expected a replayed event to be rejected")
+		}
+	})
+
+	wrongKind := valid
+	wrongKind.Kind = crypto.KindChatMessage
+	tests := map[string]*http.Request{
+		"missing header":  newSignedRequest(http.MethodPost, target, body, ""),
+		"other scheme":    newSignedRequest(http.MethodPost, target, body, "Bearer abc"),
+		"not base64":      newSignedRequest(http.MethodPost, target, body, "Nostr !!!"),
+		"wrong kind":      newSignedRequest(http.MethodPost, target, body, signRequest(t, key, wrongKind)),
+		"other method":    newSignedRequest(http.MethodPut, target, body, signRequest(t, key, valid)),
+		"other path":      newSignedRequest(http.MethodPost, "http://example.com/api/admin/users?x=1", body, signRequest(t, key, valid)),
+		"other query":     newSignedRequest(http.MethodPost, "http://example.com/api/users/me?x=2", body, signRequest(t, key, valid)),
+		"other host":      newSignedRequest(http.MethodPost, "http://evil.com/api/users/me?x=1", body, signRequest(t, key, valid)),
+		"other body":      newSignedRequest(http.MethodPost, target, []byte(`{}`), signRequest(t, key, valid)),
+		"stale":           newSignedRequest(http.MethodPost, target, body, signRequest(t, key, crypto.NewHTTPAuthEvent(pubkey, http.MethodPost, target, body, now-3600))),
+		"missing payload": newSignedRequest(http.MethodPost, target, body, signRequest(t, key, crypto.NewHTTPAuthEvent(pubkey, http.MethodPost, target, nil, now))),
+	}
+	for name, r := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRequestAuth(time.Minute, nil).Verify(r); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	t.Run("forged signature", func(t *testing.T) {
+		other, _ := btcec.NewPrivateKey()
+		r := newSignedRequest(http.MethodPost, target, body, signRequest(t, other, valid))
+		if _, err := NewRequestAuth(time.Minute, nil).Verify(r); err == nil {
+			t.Error("expected a signature by another key to be rejected")
+		}
+	})
+}
+
+func TestRequestAuth_ForwardedHost(t *testing.T) {
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	event := crypto.NewHTTPAuthEvent(pubkey, http.MethodGet, "https://chat.example.com/api/users/me", nil, time.Now().Unix())
+
+	forwarded := func() *http.Request {
+		r := newSignedRequest(http.MethodGet, "http://10.0.0.1:8080/api/users/me", nil, signRequest(t, key, event))
+		r.Header.Set("X-Forwarded-Host", "chat.example.com")
+		return r
+	}
+
+	// httptest requests come from 192.0.2.1
+	if _, err := NewRequestAuth(time.Minute, nil, netip.MustParsePrefix("192.0.2.0/24")).Verify(forwarded()); err != nil {
+		t.Errorf("trusted proxy: Verify: %v", err)
+	}
+	// Anyone else could replay an event signed for another server
+	if _, err := NewRequestAuth(time.Minute, nil).Verify(forwarded()); err == nil {
+		t.Error("untrusted client: expected the forwarded host to be ignored")
+	}
+	if _, err := NewRequestAuth(time.Minute, nil, netip.MustParsePrefix("10.0.0.0/8")).Verify(forwarded()); err == nil {
+		t.Error("other proxy: expected the forwarded host to be ignored")
+	}
+}
+
+func TestRequestAuth_Middleware(t *testing.T) {
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	auth := NewRequestAuth(time.Minute, nil)
+
+	var gotPubkey string
+	var gotOK bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPubkey, gotOK = PubkeyFromContext(r.Context())
+	})
+
+	serve := func(mw func(http.Handler) http.Handler, authorization string) int {
+		gotPubkey, gotOK = "", false
+		w := httptest.NewRecorder()
+		mw(next).ServeHTTP(w, newSignedRequest(http.MethodGet, "http://example.com/", nil, authorization))
+		return w.Code
+	}
+	signed := func() string {
+		return signRequest(t, key, crypto.NewHTTPAuthEvent(pubkey, http.MethodGet, "http://example.com/", nil, time.Now().Unix()))
+	}
+
+	if code := serve(auth.Required(), ""); code != http.StatusUnauthorized {
+		t.Errorf("required, anonymous: status = %d, want 401", code)
+	}
+	if code := serve(auth.Required(), signed()); code != http.StatusOK || !gotOK || gotPubkey != pubkey {
+		t.Errorf("required, signed: status = %d, pubkey = %q", code, gotPubkey)
+	}
+	if code := serve(auth.Optional(), ""); code != http.StatusOK || gotOK {
+		t.Errorf("optional, anonymous: status = %d, pubkey = %q", code, gotPubkey)
+	}
+	if code := serve(auth.Optional(), "Nostr e30="); code != http.StatusUnauthorized {
+		t.Errorf("optional, invalid: status = %d, want 401", code)
+	}
+}
+
+func TestRequestAuth_ForgetExpired(t *testing.T) {
+	auth := NewRequestAuth(time.Minute, nil)
+	now := time.Now()
+	firstUse := func(id string, createdAt time.Time) bool {
+		ok, err := auth.firstUse(t.Context(), id, createdAt)
+		if err != nil {
+			t.Fatalf("firstUse: %v", err)
+		}
+		return ok
+	}
+	if !firstUse("old", now.Add(-2*time.Minute)) || !firstUse("recent", now) {
+		t.Fatal("fresh ids should be accepted")
+	}
+
+	auth.forgetExpired(now)
+	if _, ok := auth.seen["old"]; ok {
+		t.Error("an id past the accepted window should be forgotten")
+	}
+	if firstUse("recent", now) {
+		t.Error("an id within the accepted window should be remembered")
+	}
+}
@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -25,21 +27,33 @@ type windowEntry struct {
 //	rate ≈ prevCount*(1 - elapsed/window) + currCount
 type RateLimiter struct {
 	entries sync.Map
+	shared  SharedStore // counts the requests instead of entries, when set
 }
 
 // NewRateLimiter creates a RateLimiter and starts a background goroutine that
-// evicts stale entries every cleanupInterval.
-func NewRateLimiter(cleanupInterval time.Duration) *RateLimiter {
-	rl := &RateLimiter{}
+// evicts stale entries every cleanupInterval. The requests are counted in
+// shared when it is not nil, so that replicas sharing it enforce the limits
+// together.
+func NewRateLimiter(cleanupInterval time.Duration, shared SharedStore) *RateLimiter {
+	rl := &RateLimiter{shared: shared}
 	go rl.cleanup(cleanupInterval)
 	return rl
 }
 
 // Allow returns true if the request identified by key is within the rate limit,
 // and increments the counter. Returns false (without incrementing) when the
-// estimated rate equals or exceeds limit.
+// estimated rate equals or exceeds limit. When the shared store fails, the
+// request is counted in memory instead.
 func (rl *RateLimiter) Allow(key string, limit int, window time.Duration) bool {
 	now := time.Now()
+	if rl.shared != nil {
+		allowed, err := rl.shared.HitRateLimit(context.Background(), key, limit, window, now)
+		if err == nil {
+			return allowed
+		}
+		slog.Error("Failed to count a request in the shared rate limits", "error", err)
+	}
+
 	v, _ := rl.entries.LoadOrStore(key, &windowEntry{windowStart: now})
 	entry := v.(*windowEntry)
 
@@ -77,8 +91,12 @@ func (rl *RateLimiter) Allow(key string, limit int, window time.Duration) bool {
 func (rl *RateLimiter) cleanup(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
-	for range ticker.C {
-		now := time.Now()
+	for now := range ticker.C {
+		if rl.shared != nil {
+			if err := rl.shared.ForgetRateLimits(context.Background(), now); err != nil {
+				slog.Error("Failed to forget shared rate limits", "error", err)
+			}
+		}
 		rl.entries.Range(func(k, v any) bool {
 			entry := v.(*windowEntry)
 			entry.mu.Lock()
@@ -107,9 +125,13 @@ func IPFromRequest(r *http.Request) string {
 
 func tooManyRequests(w http.ResponseWriter, window time.Duration) {
 	w.Header().Set("Retry-After", strconv.Itoa(int(window.Seconds())))
+	writeError(w, http.StatusTooManyRequests, "too many requests")
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusTooManyRequests)
-	_ = json.NewEncoder(w).Encode(map[string]string{"error": "too many requests"})
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
 // IPRateLimit returns middleware that limits requests by client IP.
@@ -133,7 +155,7 @@ func MessageRateLimit(rl *RateLimiter, ipLimit, pubkeyLimit int, window time.Dur
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ip := IPFromRequest(r)
-			if !rl.Allow("ip:"+ip, ipLimit, window) {
+			if !AllowMessage(rl, ip, "", ipLimit, pubkeyLimit, window) {
 				tooManyRequests(w, window)
 				return
 			}
@@ -160,3 +182,17 @@ func MessageRateLimit(rl *RateLimiter, ipLimit, pubkeyLimit int, window time.Dur
 		})
 	}
 }
+
+// AllowMessage applies the message-sending limits of MessageRateLimit outside
+// of an HTTP request, e.g. for frames received over a WebSocket. It shares the
+// same counters, so switching transport does not reset a sender's budget.
+// An empty pubkey only checks the IP limit.
+func AllowMessage(rl *RateLimiter, ip, pubkey string, ipLimit, pubkeyLimit int, window time.Duration) bool {
+	if !rl.Allow("ip:"+ip, ipLimit, window) {
+		return false
+	}
+	if pubkey != "" && pubkeyLimit > 0 {
+		return rl.Allow("pubkey:"+pubkey, pubkeyLimit, window)
+	}
+	return true
+}
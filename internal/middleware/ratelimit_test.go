@@ -35,7 +35,7 @@ func TestIPFromRequest(t *testing.T) {
 }
 
 func TestAllow_UnderLimit(t *testing.T) {
-	rl := NewRateLimiter(time.Minute)
+	rl := NewRateLimiter(time.Minute, nil)
 	for i := range 5 {
 		if !rl.Allow("key", 10, time.Minute) {
 			t.Fatalf("request %d should be allowed (under limit)", i+1)
@@ -44,7 +44,7 @@ func TestAllow_UnderLimit(t *testing.T) {
 }
 
 func TestAllow_AtLimit(t *testing.T) {
-	rl := NewRateLimiter(time.Minute)
+	rl := NewRateLimiter(time.Minute, nil)
 	for range 10 {
 		rl.Allow("key", 10, time.Minute)
 	}
@@ -54,7 +54,7 @@ func TestAllow_AtLimit(t *testing.T) {
 }
 
 func TestAllow_SlidingWindow(t *testing.T) {
-	rl := NewRateLimiter(time.Minute)
+	rl := NewRateLimiter(time.Minute, nil)
 	window := 200 * time.Millisecond
 	limit := 10
 
@@ -87,7 +87,7 @@ func TestAllow_SlidingWindow(t *testing.T) {
 }
 
 func TestAllow_DifferentKeys(t *testing.T) {
-	rl := NewRateLimiter(time.Minute)
+	rl := NewRateLimiter(time.Minute, nil)
 
 	// Exhaust key "a".
 	for range 10 {
@@ -105,7 +105,7 @@ func TestAllow_DifferentKeys(t *testing.T) {
 
 func TestCleanup(t *testing.T) {
 	interval := 50 * time.Millisecond
-	rl := NewRateLimiter(interval)
+	rl := NewRateLimiter(interval, nil)
 
 	rl.Allow("key", 10, interval)
 
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"time"
+)
+
+// SharedStore keeps the state of the middlewares in a database that several
+// server replicas share, instead of the memory of each: an admin challenge
+// issued by one replica is then accepted by the others, a NIP-98 event is
+// accepted once by all of them, and rate limits count the requests sent to
+// any of them.
+type SharedStore interface {
+	// SaveAdminChallenge stores challenge for the x-only pubkey until expiry,
+	// and drops the expired challenges, and those of pubkey past its latest
+	// keep ones.
+	SaveAdminChallenge(ctx context.Context, pubkey, challenge string, expiry time.Time, keep int) error
+	// ConsumeAdminChallenge deletes challenge of the x-only pubkey, and
+	// reports whether it was stored and had not expired at now.
+	ConsumeAdminChallenge(ctx context.Context, pubkey, challenge string, now time.Time) (bool, error)
+	// UseAuthEvent records the id of a NIP-98 event until forgetAt, and
+	// reports whether it was not recorded yet.
+	UseAuthEvent(ctx context.Context, id string, forgetAt time.Time) (bool, error)
+	// ForgetAuthEvents forgets the event ids recorded until before now.
+	ForgetAuthEvents(ctx context.Context, now time.Time) error
+	// HitRateLimit counts a request for key unless its rate over window
+	// reached limit, and reports whether it counted it.
+	HitRateLimit(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (bool, error)
+	// ForgetRateLimits forgets the counts no longer weighing on a rate at now.
+	ForgetRateLimits(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// fakeShared is a SharedStore in memory, standing for the database shared by
+// the replicas of a test. Once err is set, every call fails with it.
+type fakeShared struct {
+	mu         sync.Mutex
+	err        error
+	challenges map[string]string // challenge -> pubkey
+	events     map[string]bool
+	hits       map[string]int
+}
+
+func newFakeShared() *fakeShared {
+	return &fakeShared{challenges: make(map[string]string), events: make(map[string]bool), hits: make(map[string]int)}
+}
+
+func (f *fakeShared) SaveAdminChallenge(_ context.Context, pubkey, challenge string, _ time.Time, _ int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.challenges[challenge] = pubkey
+	return f.err
+}
+
+func (f *fakeShared) ConsumeAdminChallenge(_ context.Context, pubkey, challenge string, _ time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	issuedFor, ok := f.challenges[challenge]
+	if ok && issuedFor == pubkey {
+		delete(f.challenges, challenge)
+	}
+	return ok && issuedFor == pubkey, nil
+}
+
+func (f *fakeShared) UseAuthEvent(_ context.Context, id string, _ time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	used := f.events[id]
+	f.events[id] = true
+	return !used, nil
+}
+
+func (f *fakeShared) ForgetAuthEvents(context.Context, time.Time) error { return f.err }
+
+func (f *fakeShared) HitRateLimit(_ context.Context, key string, limit int, _ time.Duration, _ time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.hits[key] >= limit {
+		return false, nil
+	}
+	f.hits[key]++
+	return true, nil
+}
+
+func (f *fakeShared) ForgetRateLimits(context.Context, time.Time) error { return f.err }
+
+func TestSharedStore_ChallengesAcrossReplicas(t *testing.T) {
+	shared := newFakeShared()
+	issuer, checker := NewChallengeStore(time.Minute, shared), NewChallengeStore(time.Minute, shared)
+
+	challenge, _, err := issuer.Issue(t.Context(), "02"+adminA)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if shared.challenges[challenge] != adminA {
+		t.Errorf("challenge stored for %q, want the x-only key", shared.challenges[challenge])
+	}
+	if consume(t, checker, adminB, challenge) {
+		t.Error("challenge should only be accepted for the admin it was issued for")
+	}
+	if !consume(t, checker, adminA, challenge) {
+		t.Error("a challenge issued by one replica should be accepted by another")
+	}
+	if consume(t, issuer, adminA, challenge) {
+		t.Error("challenge should only be accepted once, on any replica")
+	}
+}
+
+func TestSharedStore_AuthEventsAcrossReplicas(t *testing.T) {
+	shared := newFakeShared()
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	authorization := signRequest(t, key, crypto.NewHTTPAuthEvent(pubkey, http.MethodGet, "http://example.com/", nil, time.Now().Unix()))
+
+	if _, err := NewRequestAuth(time.Minute, shared).Verify(newSignedRequest(http.MethodGet, "http://example.com/", nil, authorization)); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if _, err := NewRequestAuth(time.Minute, shared).Verify(newSignedRequest(http.MethodGet, "http://example.com/", nil, authorization)); err == nil {
+		t.Error("an event accepted by one replica should be rejected by another")
+	}
+}
+
+func TestSharedStore_RateLimitsAcrossReplicas(t *testing.T) {
+	shared := newFakeShared()
+	a, b := NewRateLimiter(time.Minute, shared), NewRateLimiter(time.Minute, shared)
+
+	if !a.Allow("k", 2, time.Minute) || !b.Allow("k", 2, time.Minute) {
+		t.Fatal("requests under the limit should be allowed")
+	}
+	if a.Allow("k", 2, time.Minute) {
+		t.Error("the requests sent to every replica should count against the limit")
+	}
+}
+
+func TestSharedStore_Unavailable(t *testing.T) {
+	shared := newFakeShared()
+	shared.err = errors.New("database is down")
+
+	rl := NewRateLimiter(time.Minute, shared)
+	if !rl.Allow("k", 1, time.Minute) || rl.Allow("k", 1, time.Minute) {
+		t.Error("the rate limiter should count in memory when the shared store fails")
+	}
+
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	authorization := signRequest(t, key, crypto.NewHTTPAuthEvent(pubkey, http.MethodGet, "http://example.com/", nil, time.Now().Unix()))
+	w := httptest.NewRecorder()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	NewRequestAuth(time.Minute, shared).Required()(next).ServeHTTP(w, newSignedRequest(http.MethodGet, "http://example.com/", nil, authorization))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("signed request: status = %d, want 503", w.Code)
+	}
+
+	if _, _, err := NewChallengeStore(time.Minute, shared).Issue(t.Context(), adminA); err == nil {
+		t.Error("Issue: expected the error of the shared store")
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AdminChallenge is a single-use nonce an admin signs to authenticate one
+// request to /api/admin.
+type AdminChallenge struct {
+	Challenge string    `json:"challenge"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ResetRoomPasswordRequest replaces a room password. Omitting the password
+// makes the room public.
+type ResetRoomPasswordRequest struct {
+	Password *string `json:"password,omitempty" validate:"omitempty,min=4,max=72"`
+}
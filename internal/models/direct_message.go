@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// DirectMessage is a message between two pubkeys, end-to-end encrypted with
+// their NIP-44 conversation key: the server only stores the ciphertext. It is
+// signed like a version 1 message whose content is the ciphertext, with an
+// empty room and user and a ["p", recipient] tag.
+type DirectMessage struct {
+	ID              string     `json:"id"`
+	Sender          string     `json:"sender"`    // Public key that signed the message (hex-encoded)
+	Recipient       string     `json:"recipient"` // Public key the message is encrypted to (hex-encoded)
+	Content         string     `json:"content"`   // NIP-44 version 2 payload (base64)
+	Timestamp       time.Time  `json:"timestamp"`
+	Signature       string     `json:"signature"`
+	SignedTimestamp int64      `json:"signed_timestamp"`
+	Version         int        `json:"version"`
+	Tags            [][]string `json:"tags"`
+}
+
+// SendDirectMessageRequest sends an encrypted message to Recipient. It must be
+// signed with event version 1 so that the signature covers the recipient tag.
+type SendDirectMessageRequest struct {
+	Recipient string     `json:"recipient" validate:"required"`
+	Content   string     `json:"content" validate:"required,max=87472"` // NIP-44 version 2 payload (base64)
+	Pubkey    string     `json:"pubkey" validate:"required"`
+	Signature string     `json:"signature" validate:"required"`
+	Timestamp int64      `json:"timestamp" validate:"required"` // Unix timestamp signed with the message
+	Version   int        `json:"version"`                       // Event hash format signed; must be 1
+	Tags      [][]string `json:"tags" validate:"required"`      // Must include ["p", recipient]
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Invite grants access to a room in place of its password. It is redeemed by
+// the pubkeys that use it, up to MaxUses of them when set, until ExpiresAt.
+type Invite struct {
+	ID        string     `json:"id"`
+	Room      string     `json:"room"`
+	Token     string     `json:"token,omitempty"` // only returned when the invite is created
+	Link      string     `json:"link,omitempty"`  // microchat://host/room?invite=token, only returned when the invite is created
+	CreatedBy string     `json:"created_by"`      // x-only hex pubkey of the owner
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxUses   int        `json:"max_uses,omitempty"` // 0 for unlimited
+	Uses      int        `json:"uses"`               // pubkeys that redeemed the invite
+}
+
+// Expired reports whether the invite no longer grants access at now.
+func (i Invite) Expired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+// UsedUp reports whether the invite was redeemed by MaxUses pubkeys already.
+func (i Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// CreateInviteRequest mints an invite valid for Duration seconds and MaxUses
+// pubkeys, without limit when they are 0.
+type CreateInviteRequest struct {
+	Duration int64 `json:"duration,omitempty" validate:"min=0"`
+	MaxUses  int   `json:"max_uses,omitempty" validate:"min=0"`
+}
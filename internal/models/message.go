@@ -5,21 +5,74 @@ import (
 )
 
 type Message struct {
-	ID              string    `json:"id"`
-	Room            string    `json:"room"`
-	User            string    `json:"user"`
-	Content         string    `json:"content"`
-	Timestamp       time.Time `json:"timestamp"`
-	Signature       string    `json:"signature,omitempty"`        // Cryptographic signature (hex-encoded)
-	Pubkey          string    `json:"pubkey,omitempty"`           // Public key used for signing (hex-encoded)
-	SignedTimestamp int64     `json:"signed_timestamp,omitempty"` // Unix timestamp that was signed
+	ID              string          `json:"id"`
+	Room            string          `json:"room"`
+	User            string          `json:"user"`
+	Content         string          `json:"content"`
+	Timestamp       time.Time       `json:"timestamp"`
+	Signature       string          `json:"signature,omitempty"`        // Cryptographic signature (hex-encoded)
+	Pubkey          string          `json:"pubkey,omitempty"`           // Public key used for signing (hex-encoded)
+	SignedTimestamp int64           `json:"signed_timestamp,omitempty"` // Unix timestamp that was signed
+	Version         int             `json:"version,omitempty"`          // Event hash format that was signed (0 = legacy, 1 = covers user and tags)
+	Tags            [][]string      `json:"tags,omitempty"`             // Signed tags (version 1+)
+	SigScheme       string          `json:"sig_scheme,omitempty"`       // "ecdsa" (default) or "schnorr" (BIP-340 over the NIP-01 event id)
+	DeletedAt       *time.Time      `json:"deleted_at,omitempty"`       // Set once the message is deleted; its content is then cleared
+	EditedAt        *time.Time      `json:"edited_at,omitempty"`        // Set once the content was edited; the signed fields are those of the latest revision
+	Revisions       int             `json:"revisions,omitempty"`        // Number of edits; earlier contents are kept in the revision history
+	ReplyTo         string          `json:"reply_to,omitempty"`         // ID of the message this one replies to, signed as a ["reply", id] tag
+	Replies         int             `json:"replies,omitempty"`          // Number of replies to this message
+	Reactions       []ReactionCount `json:"reactions,omitempty"`        // Reactions by emoji, in the order they were first used
+}
+
+// MessageRevision is an earlier signed content of an edited message. Room,
+// user and pubkey are those of the message.
+type MessageRevision struct {
+	Revision        int        `json:"revision"` // 0 is the original message
+	Content         string     `json:"content"`
+	Signature       string     `json:"signature,omitempty"`
+	SignedTimestamp int64      `json:"signed_timestamp,omitempty"`
+	Version         int        `json:"version,omitempty"`
+	Tags            [][]string `json:"tags,omitempty"`
+	SigScheme       string     `json:"sig_scheme,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"` // When the server received this revision
 }
 
 type SendMessageRequest struct {
-	User         string `json:"user" validate:"required"`
-	Content      string `json:"content" validate:"required"`
-	Signature    string `json:"signature" validate:"required"`
-	Pubkey       string `json:"pubkey" validate:"required"`
-	Timestamp    int64  `json:"timestamp" validate:"required"`
-	RoomPassword string `json:"room_password,omitempty"`
+	User         string     `json:"user" validate:"required"`
+	Content      string     `json:"content" validate:"required"`
+	Signature    string     `json:"signature" validate:"required"`
+	Pubkey       string     `json:"pubkey" validate:"required"`
+	Timestamp    int64      `json:"timestamp" validate:"required"`
+	RoomPassword string     `json:"room_password,omitempty"`
+	RoomInvite   string     `json:"room_invite,omitempty"`                                         // Invite token, in place of the room password
+	Version      int        `json:"version,omitempty"`                                             // Event hash format signed: 0 (legacy) or 1 (covers user and tags)
+	Tags         [][]string `json:"tags,omitempty"`                                                // Signed tags (version 1+)
+	SigScheme    string     `json:"sig_scheme,omitempty" validate:"omitempty,oneof=ecdsa schnorr"` // "ecdsa" (default) or "schnorr" (BIP-340 over the NIP-01 event id, tags must include ["h", room])
+	ReplyTo      string     `json:"reply_to,omitempty"`                                            // ID of a message of the room to reply to; tags must then include ["reply", id]
+}
+
+// MessagePage is a page of the messages of a room, oldest first. Its cursors
+// are opaque: they are passed back as the before or after parameter.
+type MessagePage struct {
+	Messages   []Message `json:"messages"`
+	PrevCursor string    `json:"prev_cursor,omitempty"` // Fetches the older messages; omitted when none remain
+	NextCursor string    `json:"next_cursor,omitempty"` // Fetches the newer messages, even those not received yet; omitted for an empty room
+}
+
+// MessageThread is a message and its replies, oldest first.
+type MessageThread struct {
+	Root    Message   `json:"root"`
+	Replies []Message `json:"replies"`
+}
+
+// EditMessageRequest replaces the content of a message. It must be signed by
+// the message's pubkey, as the message would be with the new content and
+// timestamp, and its tags must include ["edit", message id].
+type EditMessageRequest struct {
+	Content   string     `json:"content" validate:"required"`
+	Signature string     `json:"signature" validate:"required"`
+	Timestamp int64      `json:"timestamp" validate:"required"`                                 // Must be later than the signed timestamp of the current revision
+	Version   int        `json:"version,omitempty"`                                             // Event hash format signed; edits need 1 (covers the tags) unless signed with schnorr
+	Tags      [][]string `json:"tags" validate:"required"`                                      // Signed tags, including ["edit", message id]
+	SigScheme string     `json:"sig_scheme,omitempty" validate:"omitempty,oneof=ecdsa schnorr"` // "ecdsa" (default) or "schnorr"
 }
@@ -0,0 +1,36 @@
+package models
+
+// Nostr relay message types (NIP-01), the first element of every frame
+// exchanged over /api/nostr.
+const (
+	NostrEvent  = "EVENT"  // client: publish an event; relay: deliver an event to a subscription
+	NostrReq    = "REQ"    // client: open a subscription
+	NostrClose  = "CLOSE"  // client: end a subscription
+	NostrOK     = "OK"     // relay: result of an EVENT
+	NostrEOSE   = "EOSE"   // relay: end of stored events for a subscription
+	NostrClosed = "CLOSED" // relay: a subscription was refused or ended
+	NostrNotice = "NOTICE" // relay: human-readable message
+)
+
+// NostrSignedEvent is a NIP-01 event.
+type NostrSignedEvent struct {
+	ID        string     `json:"id"`
+	Pubkey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// NostrFilter is a NIP-01 subscription filter. Rooms are selected with the
+// "#h" tag filter.
+type NostrFilter struct {
+	IDs     []string `json:"ids,omitempty"`
+	Authors []string `json:"authors,omitempty"`
+	Kinds   []int    `json:"kinds,omitempty"`
+	Rooms   []string `json:"#h,omitempty"`
+	Since   int64    `json:"since,omitempty"`
+	Until   int64    `json:"until,omitempty"`
+	Limit   *int     `json:"limit,omitempty"` // nil = relay default; 0 = live events only
+}
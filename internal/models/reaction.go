@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Reaction is an emoji a pubkey attached to a message. It is signed like a
+// message of the room whose content is the emoji, with an empty user and a
+// ["react", message id] tag.
+type Reaction struct {
+	MessageID       string     `json:"message_id"`
+	Pubkey          string     `json:"pubkey"`
+	Emoji           string     `json:"emoji"`
+	Signature       string     `json:"signature"`
+	SignedTimestamp int64      `json:"signed_timestamp"`
+	Version         int        `json:"version,omitempty"`
+	Tags            [][]string `json:"tags,omitempty"`
+	SigScheme       string     `json:"sig_scheme,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ReactionCount is the number of pubkeys that reacted to a message with Emoji.
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// AddReactionRequest reacts to a message. It must be signed with event
+// version 1 or sig_scheme "schnorr" so that the signature covers the tags.
+type AddReactionRequest struct {
+	Emoji        string     `json:"emoji" validate:"required,max=32"`
+	Pubkey       string     `json:"pubkey" validate:"required"`
+	Signature    string     `json:"signature" validate:"required"`
+	Timestamp    int64      `json:"timestamp" validate:"required"`                                 // Unix timestamp signed with the reaction
+	Version      int        `json:"version,omitempty"`                                             // Event hash format signed; must cover the tags
+	Tags         [][]string `json:"tags" validate:"required"`                                      // Must include ["react", message id]
+	SigScheme    string     `json:"sig_scheme,omitempty" validate:"omitempty,oneof=ecdsa schnorr"` // "ecdsa" (default) or "schnorr"
+	RoomPassword string     `json:"room_password,omitempty"`
+}
@@ -2,16 +2,22 @@ package models
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-fuego/fuego"
 )
 
 type Room struct {
-	Name                 string  `json:"name"`
-	HasPassword          bool    `json:"has_password"`
-	LastMessageContent   *string `json:"last_message_content,omitempty"`
-	LastMessageUser      *string `json:"last_message_user,omitempty"`
-	LastMessageTimestamp *string `json:"last_message_timestamp,omitempty"`
+	Name                 string          `json:"name"`
+	Description          string          `json:"description,omitempty"` // set by the owner
+	HasPassword          bool            `json:"has_password"`
+	Encrypted            bool            `json:"encrypted"`          // messages are end-to-end encrypted with a key derived from the password
+	KeySalt              string          `json:"key_salt,omitempty"` // base64 salt of the key of an encrypted room
+	Private              bool            `json:"private"`            // only its members can read and write it
+	Retention            RetentionPolicy `json:"retention,omitzero"` // set by the owner, within the server default
+	LastMessageContent   *string         `json:"last_message_content,omitempty"`
+	LastMessageUser      *string         `json:"last_message_user,omitempty"`
+	LastMessageTimestamp *string         `json:"last_message_timestamp,omitempty"`
 }
 
 var _ fuego.OutTransformer = (*Room)(nil)
@@ -28,6 +34,68 @@ func (r *Room) OutTransform(context.Context) error {
 }
 
 type CreateRoomRequest struct {
-	Name     string  `json:"name" validate:"required,min=1,max=50"`
-	Password *string `json:"password,omitempty" validate:"omitempty,min=4,max=72"`
+	Name      string  `json:"name" validate:"required,min=1,max=50"`
+	Password  *string `json:"password,omitempty" validate:"omitempty,min=4,max=72"` // for an encrypted room, the verifier of its key, never the password itself
+	Encrypted bool    `json:"encrypted,omitempty"`                                  // end-to-end encrypted, requires key_salt and the verifier as password
+	KeySalt   string  `json:"key_salt,omitempty"`                                   // base64 salt the key of an encrypted room derives from, chosen by the client
+	Private   bool    `json:"private,omitempty"`                                    // members only, requires a signed request
+}
+
+// SetRoomDescriptionRequest replaces the description of a room. An empty
+// description removes it.
+type SetRoomDescriptionRequest struct {
+	Description string `json:"description" validate:"max=280"`
+}
+
+// RetentionPolicy bounds the history a room keeps: the messages older than
+// MaxAge seconds, and those before the latest MaxMessages, are pruned. Zero
+// fields set no bound.
+type RetentionPolicy struct {
+	MaxAge      int64 `json:"max_age,omitempty" validate:"gte=0"`
+	MaxMessages int64 `json:"max_messages,omitempty" validate:"gte=0"`
+}
+
+// Within returns the stricter of both policies, bound by each field set in
+// either.
+func (p RetentionPolicy) Within(other RetentionPolicy) RetentionPolicy {
+	stricter := func(a, b int64) int64 {
+		if a == 0 || b == 0 {
+			return max(a, b)
+		}
+		return min(a, b)
+	}
+	return RetentionPolicy{
+		MaxAge:      stricter(p.MaxAge, other.MaxAge),
+		MaxMessages: stricter(p.MaxMessages, other.MaxMessages),
+	}
+}
+
+// RetentionStats counts the messages pruned since the server started.
+type RetentionStats struct {
+	Runs           int64      `json:"runs"`
+	PrunedMessages int64      `json:"pruned_messages"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	LastPruned     int64      `json:"last_pruned"` // messages pruned by the last run
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// RoomRole is a role held by a pubkey in a room.
+type RoomRole string
+
+const (
+	// RoleOwner is held by the signer of the room creation: the owner
+	// manages the password, description and moderators of the room.
+	RoleOwner RoomRole = "owner"
+	// RoleModerator is granted by the owner and lets a pubkey delete the
+	// messages of the room.
+	RoleModerator RoomRole = "moderator"
+	// RoleMember is held by the pubkeys the owner added to the allowlist of a
+	// private room: it lets them read and write the room.
+	RoleMember RoomRole = "member"
+)
+
+// RoomMember is a pubkey holding a role in a room.
+type RoomMember struct {
+	Pubkey string   `json:"pubkey"` // x-only hex
+	Role   RoomRole `json:"role"`
 }
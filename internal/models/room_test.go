@@ -42,3 +42,18 @@ func TestOutTransform_PublicKeepsLastMessage(t *testing.T) {
 		t.Fatal("public room must keep its last message fields")
 	}
 }
+
+func TestRetentionPolicy_Within(t *testing.T) {
+	for _, tt := range []struct {
+		room, server, want RetentionPolicy
+	}{
+		{RetentionPolicy{}, RetentionPolicy{}, RetentionPolicy{}},
+		{RetentionPolicy{MaxAge: 60}, RetentionPolicy{}, RetentionPolicy{MaxAge: 60}},
+		{RetentionPolicy{}, RetentionPolicy{MaxMessages: 10}, RetentionPolicy{MaxMessages: 10}},
+		{RetentionPolicy{MaxAge: 60, MaxMessages: 100}, RetentionPolicy{MaxAge: 3600, MaxMessages: 10}, RetentionPolicy{MaxAge: 60, MaxMessages: 10}},
+	} {
+		if got := tt.room.Within(tt.server); got != tt.want {
+			t.Errorf("%+v.Within(%+v) = %+v, want %+v", tt.room, tt.server, got, tt.want)
+		}
+	}
+}
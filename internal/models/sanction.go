@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// SanctionKind is the effect of a sanction.
+type SanctionKind string
+
+const (
+	// SanctionMute stops new messages.
+	SanctionMute SanctionKind = "mute"
+	// SanctionBan stops new messages, edits and reactions.
+	SanctionBan SanctionKind = "ban"
+)
+
+// Sanction mutes or bans a pubkey or an IP address in a room, or on the whole
+// server when Room is empty. It applies until ExpiresAt, or until it is lifted
+// when ExpiresAt is nil.
+type Sanction struct {
+	ID        string       `json:"id"`
+	Kind      SanctionKind `json:"kind"`
+	Room      string       `json:"room,omitempty"`   // empty for a server-wide sanction
+	Pubkey    string       `json:"pubkey,omitempty"` // x-only hex
+	IP        string       `json:"ip,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+	CreatedBy string       `json:"created_by"` // x-only hex pubkey of the moderator or admin
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+}
+
+// Active reports whether the sanction still applies at now.
+func (s Sanction) Active(now time.Time) bool {
+	return s.ExpiresAt == nil || now.Before(*s.ExpiresAt)
+}
+
+// CreateSanctionRequest mutes or bans a pubkey or an IP address, for Duration
+// seconds or until lifted when it is 0.
+type CreateSanctionRequest struct {
+	Kind     SanctionKind `json:"kind" validate:"required,oneof=mute ban"`
+	Pubkey   string       `json:"pubkey,omitempty"`
+	IP       string       `json:"ip,omitempty" validate:"omitempty,ip"`
+	Reason   string       `json:"reason,omitempty" validate:"max=280"`
+	Duration int64        `json:"duration,omitempty" validate:"min=0"`
+}
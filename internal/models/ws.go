@@ -0,0 +1,39 @@
+package models
+
+// WSFrameType identifies the kind of a frame exchanged over /api/ws.
+type WSFrameType string
+
+// Client → server frame types.
+const (
+	WSSubscribe   WSFrameType = "subscribe"   // start receiving a room's messages
+	WSUnsubscribe WSFrameType = "unsubscribe" // stop receiving a room's messages
+	WSSend        WSFrameType = "send"        // post a signed message to a room
+)
+
+// Server → client frame types.
+const (
+	WSSubscribed   WSFrameType = "subscribed"   // subscription accepted
+	WSUnsubscribed WSFrameType = "unsubscribed" // subscription ended
+	WSMessage      WSFrameType = "message"      // a message was saved in a subscribed room
+	WSAck          WSFrameType = "ack"          // a send frame was accepted and saved
+	WSError        WSFrameType = "error"        // a frame was rejected
+)
+
+// WSClientFrame is a frame sent by a client over the WebSocket transport.
+type WSClientFrame struct {
+	Type        WSFrameType         `json:"type"`
+	Room        string              `json:"room"`
+	Ref         string              `json:"ref,omitempty"`           // echoed back in the matching ack or error
+	Password    string              `json:"password,omitempty"`      // subscribe: room password
+	LastEventID string              `json:"last_event_id,omitempty"` // subscribe: resume after this message ID
+	Message     *SendMessageRequest `json:"message,omitempty"`       // send: the signed message
+}
+
+// WSServerFrame is a frame sent by the server over the WebSocket transport.
+type WSServerFrame struct {
+	Type    WSFrameType `json:"type"`
+	Room    string      `json:"room,omitempty"`
+	Ref     string      `json:"ref,omitempty"`
+	Message *Message    `json:"message,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
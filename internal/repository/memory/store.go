@@ -1,8 +1,10 @@
 package memory
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +17,12 @@ import (
 
 type roomMetadata struct {
 	PasswordHash *string
+	KeySalt      string // set for encrypted rooms
+	Description  string
+	Members      []models.RoomMember // owner first, then moderators in promotion order
+	Private      bool
+	Allowlist    []string // x-only hex pubkeys added to the member list, oldest first
+	Retention    models.RetentionPolicy
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -24,6 +32,24 @@ type Store struct {
 	messages map[string][]models.Message
 	users    map[string]*models.User // username -> User
 	rooms    map[string]*roomMetadata
+	seenSigs map[string]struct{} // pubkey + ":" + signature of every signed message
+
+	revisions map[string][]models.MessageRevision // message id -> earlier revisions, oldest first
+	reactions map[string][]models.Reaction        // message id -> reactions, oldest first
+
+	directMessages []models.DirectMessage // oldest first
+	seenDMSigs     map[string]struct{}    // sender + ":" + signature of every direct message
+
+	sanctions []models.Sanction // oldest first
+	invites   []*inviteRecord   // oldest first
+}
+
+// inviteRecord is an invite with the hash of its token and the pubkeys that
+// redeemed it.
+type inviteRecord struct {
+	invite    models.Invite
+	tokenHash string
+	redeemers map[string]struct{}
 }
 
 // Ensure Store implements the Repository interface
@@ -34,13 +60,29 @@ func NewStore() *Store {
 		messages: make(map[string][]models.Message),
 		users:    make(map[string]*models.User),
 		rooms:    make(map[string]*roomMetadata),
+		seenSigs: make(map[string]struct{}),
+
+		revisions: make(map[string][]models.MessageRevision),
+		reactions: make(map[string][]models.Reaction),
+
+		seenDMSigs: make(map[string]struct{}),
 	}
 }
 
-func (s *Store) SaveMessage(ctx context.Context, room, user, content, signature, pubkey string, signedTimestamp int64) (*models.Message, error) {
+func (s *Store) SaveMessage(ctx context.Context, msg models.Message) (*models.Message, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	room, pubkey := msg.Room, msg.Pubkey
+
+	// Reject replays of an already stored signed message
+	sigKey := pubkey + ":" + msg.Signature
+	if msg.Signature != "" {
+		if _, seen := s.seenSigs[sigKey]; seen {
+			return nil, services.ErrDuplicateMessage
+		}
+	}
+
 	// Automatically create room if it doesn't exist (public rooms only)
 	if _, exists := s.rooms[room]; !exists {
 		now := time.Now()
@@ -65,18 +107,23 @@ func (s *Store) SaveMessage(ctx context.Context, room, user, content, signature,
 		}
 	}
 
-	msg := models.Message{
-		ID:              uuid.New().String(),
-		Room:            room,
-		User:            user,
-		Content:         content,
-		Timestamp:       time.Now(),
-		Signature:       signature,
-		Pubkey:          pubkey,
-		SignedTimestamp: signedTimestamp,
-	}
+	msg.ID = uuid.New().String()
+	msg.Timestamp = time.Now()
 
-	s.messages[room] = append(s.messages[room], msg)
+	if msg.ReplyTo != "" {
+		for i := range s.messages[room] {
+			if s.messages[room][i].ID == msg.ReplyTo {
+				s.messages[room][i].Replies++
+				break
+			}
+		}
+	}
+	// Keep the history sorted, as the wall clock may go backwards
+	i, _ := slices.BinarySearchFunc(s.messages[room], msg, services.CompareMessages)
+	s.messages[room] = slices.Insert(s.messages[room], i, msg)
+	if msg.Signature != "" {
+		s.seenSigs[sigKey] = struct{}{}
+	}
 	return &msg, nil
 }
 
@@ -88,83 +135,210 @@ func (s *Store) GetMessages(ctx context.Context, room string, params services.Me
 	if limit <= 0 {
 		limit = 50
 	}
-	before := time.Now().Add(time.Second)
-	if params.Before != nil {
-		before = *params.Before
+	// The history of a room is sorted: the cursor is found by binary search
+	all := s.messages[room]
+	var page []models.Message
+	switch {
+	case params.AfterCursor != nil:
+		start, found := slices.BinarySearchFunc(all, *params.AfterCursor, compareToCursor)
+		if found {
+			start++
+		}
+		page = all[start:min(start+limit, len(all))]
+	case params.BeforeCursor != nil:
+		end, _ := slices.BinarySearchFunc(all, *params.BeforeCursor, compareToCursor)
+		page = all[max(end-limit, 0):end]
+	default:
+		before := time.Now().Add(time.Second)
+		if params.Before != nil {
+			before = *params.Before
+		}
+		end, _ := slices.BinarySearchFunc(all, before, func(msg models.Message, t time.Time) int { return msg.Timestamp.Compare(t) })
+		page = all[max(end-limit, 0):end]
 	}
 
-	all, exists := s.messages[room]
-	if !exists {
-		return []models.Message{}, nil
+	filtered := append(make([]models.Message, 0, len(page)), page...)
+	for i := range filtered {
+		filtered[i].Reactions = s.reactionCounts(filtered[i].ID)
 	}
 
-	// Collect messages with timestamp < before
-	filtered := make([]models.Message, 0, len(all))
-	for _, msg := range all {
-		if msg.Timestamp.Before(before) {
-			filtered = append(filtered, msg)
+	return filtered, nil
+}
+
+// compareToCursor places msg relative to the cursor in a sorted history.
+func compareToCursor(msg models.Message, cursor services.MessageCursor) int {
+	return cursor.Compare(msg)
+}
+
+func (s *Store) GetMessage(ctx context.Context, room, id string) (*models.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, msg := range s.messages[room] {
+		if msg.ID == id {
+			msg.Reactions = s.reactionCounts(id)
+			return &msg, nil
 		}
 	}
+	return nil, services.ErrMessageNotFound
+}
 
-	// Return the last `limit` entries (most recent before cursor), in ASC order
-	if len(filtered) > limit {
-		filtered = filtered[len(filtered)-limit:]
+func (s *Store) GetReplies(ctx context.Context, room, id string, limit int) ([]models.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = 50
 	}
+	replies := []models.Message{}
+	for _, msg := range s.messages[room] {
+		if msg.ReplyTo == id {
+			msg.Reactions = s.reactionCounts(msg.ID)
+			replies = append(replies, msg)
+		}
+	}
+	if len(replies) > limit {
+		replies = replies[len(replies)-limit:]
+	}
+	return replies, nil
+}
 
-	return filtered, nil
+func (s *Store) FindMessages(ctx context.Context, filter services.MessageFilter) ([]models.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	found := []models.Message{}
+	for _, room := range filter.Rooms {
+		for _, msg := range s.messages[room] {
+			if filter.Match(msg) {
+				found = append(found, msg)
+			}
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b models.Message) int {
+		return cmp.Compare(b.SignedTimestamp, a.SignedTimestamp)
+	})
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
 }
 
-func (s *Store) GetRooms(ctx context.Context) ([]models.Room, error) {
+// SearchMessages scans the messages of the searched rooms.
+func (s *Store) SearchMessages(ctx context.Context, search services.MessageSearch) ([]models.Message, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rooms := make([]models.Room, 0, len(s.rooms))
-	for name, metadata := range s.rooms {
-		rooms = append(rooms, models.Room{
-			Name:        name,
-			HasPassword: metadata.PasswordHash != nil,
-		})
+	limit := search.Limit
+	if limit <= 0 {
+		limit = 50
 	}
 
-	return rooms, nil
+	found := []models.Message{}
+	for _, room := range search.Rooms {
+		for _, msg := range s.messages[room] {
+			if search.Match(msg) {
+				found = append(found, msg)
+			}
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b models.Message) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+// maxRoomsListed bounds GetRooms and SearchRooms, as the SQL stores do.
+const maxRoomsListed = 100
+
+func (s *Store) GetRooms(ctx context.Context) ([]models.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.listRooms(""), nil
 }
 
 func (s *Store) SearchRooms(ctx context.Context, query string) ([]models.Room, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rooms := make([]models.Room, 0)
+	return s.listRooms(query), nil
+}
+
+// GetSearchableRooms returns every room without a password, by name.
+func (s *Store) GetSearchableRooms(ctx context.Context) ([]models.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rooms := make([]models.Room, 0, len(s.rooms))
 	for name, metadata := range s.rooms {
-		// Simple case-insensitive substring search
-		if query == "" || containsCaseInsensitive(name, query) {
-			var lastMessage *models.Message
-			if messages, exists := s.messages[name]; exists {
-				messageCount := len(messages)
-				if messageCount > 0 {
-					lastMessage = &messages[messageCount-1]
-				}
-			}
+		if metadata.PasswordHash == nil && metadata.KeySalt == "" {
+			rooms = append(rooms, models.Room{Name: name, Private: metadata.Private})
+		}
+	}
+	slices.SortFunc(rooms, func(a, b models.Room) int { return strings.Compare(a.Name, b.Name) })
+	return rooms, nil
+}
 
-			room := models.Room{
-				Name:        name,
-				HasPassword: metadata.PasswordHash != nil,
-			}
+// listRooms returns the rooms whose name contains query, case-insensitively,
+// the most recently active first, then those without messages by name.
+func (s *Store) listRooms(query string) []models.Room {
+	type listedRoom struct {
+		room         models.Room
+		lastActivity time.Time // zero for rooms without messages
+	}
 
-			if lastMessage != nil {
-				room.LastMessageContent = &lastMessage.Content
-				room.LastMessageUser = &lastMessage.User
-				timestamp := lastMessage.Timestamp.Format(time.RFC3339)
-				room.LastMessageTimestamp = &timestamp
-			}
+	listed := make([]listedRoom, 0, len(s.rooms))
+	for name, metadata := range s.rooms {
+		// Simple case-insensitive substring search
+		if query != "" && !containsCaseInsensitive(name, query) {
+			continue
+		}
+
+		room := models.Room{
+			Name:        name,
+			Description: metadata.Description,
+			HasPassword: metadata.PasswordHash != nil,
+			Encrypted:   metadata.KeySalt != "",
+			KeySalt:     metadata.KeySalt,
+			Private:     metadata.Private,
+		}
 
-			rooms = append(rooms, room)
+		var lastActivity time.Time
+		if messages := s.messages[name]; len(messages) > 0 {
+			lastMessage := messages[len(messages)-1]
+			room.LastMessageContent = &lastMessage.Content
+			room.LastMessageUser = &lastMessage.User
+			timestamp := lastMessage.Timestamp.Format(time.RFC3339)
+			room.LastMessageTimestamp = &timestamp
+			lastActivity = lastMessage.Timestamp
 		}
+
+		listed = append(listed, listedRoom{room: room, lastActivity: lastActivity})
 	}
 
-	return rooms, nil
+	slices.SortFunc(listed, func(a, b listedRoom) int {
+		return cmp.Or(b.lastActivity.Compare(a.lastActivity), cmp.Compare(a.room.Name, b.room.Name))
+	})
+
+	rooms := make([]models.Room, 0, min(len(listed), maxRoomsListed))
+	for _, l := range listed[:min(len(listed), maxRoomsListed)] {
+		rooms = append(rooms, l.room)
+	}
+	return rooms
 }
 
-func (s *Store) CreateRoom(ctx context.Context, name string, password *string) (*models.Room, error) {
+func (s *Store) CreateRoom(ctx context.Context, name string, password *string, keySalt, owner string, private bool) (*models.Room, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -172,16 +346,46 @@ func (s *Store) CreateRoom(ctx context.Context, name string, password *string) (
 		return nil, fmt.Errorf("room already exists")
 	}
 
+	if password != nil && *password == "" {
+		password = nil
+	}
 	now := time.Now()
 	s.rooms[name] = &roomMetadata{
 		PasswordHash: password, // In-memory store doesn't hash for simplicity
+		KeySalt:      keySalt,
+		Private:      private,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
+	if owner != "" {
+		s.rooms[name].Members = []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}}
+	}
 	s.messages[name] = []models.Message{}
 	return &models.Room{
 		Name:        name,
-		HasPassword: password != nil && *password != "",
+		HasPassword: password != nil,
+		Encrypted:   keySalt != "",
+		KeySalt:     keySalt,
+		Private:     private,
+	}, nil
+}
+
+func (s *Store) GetRoom(ctx context.Context, name string) (*models.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metadata, exists := s.rooms[name]
+	if !exists {
+		return nil, services.ErrRoomNotFound
+	}
+	return &models.Room{
+		Name:        name,
+		Description: metadata.Description,
+		HasPassword: metadata.PasswordHash != nil,
+		Encrypted:   metadata.KeySalt != "",
+		KeySalt:     metadata.KeySalt,
+		Private:     metadata.Private,
+		Retention:   metadata.Retention,
 	}, nil
 }
 
@@ -190,10 +394,8 @@ func (s *Store) RegisterUser(ctx context.Context, publicKey string) (*models.Use
 	defer s.mu.Unlock()
 
 	// Check if public key is already registered
-	for _, user := range s.users {
-		if user.PublicKey == publicKey {
-			return nil, fmt.Errorf("public key already registered to user %s", publicKey)
-		}
+	if _, exists := s.users[publicKey]; exists {
+		return nil, fmt.Errorf("public key already registered to user %s", publicKey)
 	}
 
 	now := time.Now()
@@ -203,8 +405,10 @@ func (s *Store) RegisterUser(ctx context.Context, publicKey string) (*models.Use
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+	s.users[publicKey] = user
 
-	return user, nil
+	copied := *user
+	return &copied, nil
 }
 
 func (s *Store) GetUser(ctx context.Context, publicKey string) (*models.User, error) {
@@ -213,7 +417,7 @@ func (s *Store) GetUser(ctx context.Context, publicKey string) (*models.User, er
 
 	user, exists := s.users[publicKey]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, services.ErrUserNotFound
 	}
 
 	return user, nil
@@ -229,7 +433,7 @@ func (s *Store) GetUserByPublicKey(ctx context.Context, publicKey string) (*mode
 		}
 	}
 
-	return nil, fmt.Errorf("user not found")
+	return nil, services.ErrUserNotFound
 }
 
 func (s *Store) GetAllUsers(ctx context.Context) ([]models.User, error) {
@@ -250,7 +454,7 @@ func (s *Store) VerifyUser(ctx context.Context, publicKey string) error {
 
 	user, exists := s.users[publicKey]
 	if !exists {
-		return fmt.Errorf("user not found")
+		return services.ErrUserNotFound
 	}
 
 	user.Verified = true
@@ -264,7 +468,7 @@ func (s *Store) UnverifyUser(ctx context.Context, publicKey string) error {
 
 	user, exists := s.users[publicKey]
 	if !exists {
-		return fmt.Errorf("user not found")
+		return services.ErrUserNotFound
 	}
 
 	user.Verified = false
@@ -278,7 +482,7 @@ func (s *Store) GetUserWithPostCount(ctx context.Context, publicKey string) (*mo
 
 	user, exists := s.users[publicKey]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, services.ErrUserNotFound
 	}
 
 	// Count posts by this user
@@ -306,7 +510,7 @@ func (s *Store) ValidateRoomPassword(ctx context.Context, roomName, password str
 
 	room, exists := s.rooms[roomName]
 	if !exists {
-		return fmt.Errorf("room not found")
+		return services.ErrRoomNotFound
 	}
 
 	// If password_hash is nil, room is public
@@ -322,6 +526,461 @@ func (s *Store) ValidateRoomPassword(ctx context.Context, roomName, password str
 	return nil
 }
 
+func (s *Store) SetRoomPassword(ctx context.Context, roomName string, password *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+
+	if password != nil && *password == "" {
+		password = nil
+	}
+	room.PasswordHash = password // In-memory store doesn't hash for simplicity
+	room.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) SetRoomDescription(ctx context.Context, roomName, description string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	room.Description = description
+	room.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) SetRoomRetention(ctx context.Context, roomName string, policy models.RetentionPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	room.Retention = policy
+	room.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) GetRetentionPolicies(ctx context.Context) (map[string]models.RetentionPolicy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policies := make(map[string]models.RetentionPolicy, len(s.rooms))
+	for name, room := range s.rooms {
+		policies[name] = room.Retention
+	}
+	return policies, nil
+}
+
+func (s *Store) PruneMessages(ctx context.Context, roomName string, before time.Time, keep int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The history of a room is sorted: the pruned messages are its start
+	messages := s.messages[roomName]
+	pruned, _ := slices.BinarySearchFunc(messages, before, func(msg models.Message, t time.Time) int { return msg.Timestamp.Compare(t) })
+	if keep > 0 {
+		pruned = max(pruned, len(messages)-keep)
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	// seenSigs keeps the signatures of pruned messages so they cannot be replayed
+	for _, msg := range messages[:pruned] {
+		delete(s.revisions, msg.ID)
+		delete(s.reactions, msg.ID)
+	}
+	s.messages[roomName] = slices.Delete(messages, 0, pruned)
+	return int64(pruned), nil
+}
+
+// Compact does nothing: the memory of pruned messages is garbage collected.
+func (s *Store) Compact(ctx context.Context) error {
+	return nil
+}
+
+func (s *Store) GetRoomMembers(ctx context.Context, roomName string) ([]models.RoomMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return nil, services.ErrRoomNotFound
+	}
+	members := slices.Clone(room.Members)
+	for _, pubkey := range room.Allowlist {
+		if !slices.ContainsFunc(room.Members, func(member models.RoomMember) bool { return member.Pubkey == pubkey }) {
+			members = append(members, models.RoomMember{Pubkey: pubkey, Role: models.RoleMember})
+		}
+	}
+	return members, nil
+}
+
+func (s *Store) AddRoomMember(ctx context.Context, roomName, pubkey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	if !slices.Contains(room.Allowlist, pubkey) {
+		room.Allowlist = append(room.Allowlist, pubkey)
+		room.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (s *Store) RemoveRoomMember(ctx context.Context, roomName, pubkey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	i := slices.Index(room.Allowlist, pubkey)
+	if i == -1 {
+		return services.ErrMemberNotFound
+	}
+	room.Allowlist = slices.Delete(room.Allowlist, i, i+1)
+	room.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, roomName, pubkey string, role models.RoomRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomName]
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	i := slices.IndexFunc(room.Members, func(member models.RoomMember) bool { return member.Pubkey == pubkey })
+	switch {
+	case role == "" && i != -1:
+		room.Members = slices.Delete(room.Members, i, i+1)
+	case role != "" && i != -1:
+		room.Members[i].Role = role
+	case role != "":
+		room.Members = append(room.Members, models.RoomMember{Pubkey: pubkey, Role: role})
+	}
+	room.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) DeleteRoom(ctx context.Context, roomName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.rooms[roomName]; !exists {
+		return services.ErrRoomNotFound
+	}
+
+	// seenSigs keeps the signatures of deleted messages so they cannot be replayed
+	for _, msg := range s.messages[roomName] {
+		delete(s.revisions, msg.ID)
+		delete(s.reactions, msg.ID)
+	}
+	delete(s.rooms, roomName)
+	delete(s.messages, roomName)
+	s.sanctions = slices.DeleteFunc(s.sanctions, func(sanction models.Sanction) bool { return sanction.Room == roomName })
+	s.invites = slices.DeleteFunc(s.invites, func(record *inviteRecord) bool { return record.invite.Room == roomName })
+	return nil
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, roomName, id string) (*models.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := s.messages[roomName]
+	i := slices.IndexFunc(messages, func(msg models.Message) bool { return msg.ID == id })
+	if i == -1 {
+		return nil, services.ErrMessageNotFound
+	}
+
+	// seenSigs keeps the signature so the deleted message cannot be replayed
+	if messages[i].DeletedAt == nil {
+		messages[i].Content = ""
+		messages[i].DeletedAt = new(time.Now())
+		delete(s.revisions, id) // the history would still show the content
+		delete(s.reactions, id)
+	}
+	tombstone := messages[i]
+	return &tombstone, nil
+}
+
+func (s *Store) EditMessage(ctx context.Context, roomName, id string, edit models.MessageRevision) (*models.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := s.messages[roomName]
+	i := slices.IndexFunc(messages, func(msg models.Message) bool { return msg.ID == id })
+	if i == -1 {
+		return nil, services.ErrMessageNotFound
+	}
+	msg := &messages[i]
+	if msg.DeletedAt != nil {
+		return nil, services.ErrMessageDeleted
+	}
+	if edit.SignedTimestamp <= msg.SignedTimestamp {
+		return nil, services.ErrStaleRevision
+	}
+
+	receivedAt := msg.Timestamp
+	if msg.EditedAt != nil {
+		receivedAt = *msg.EditedAt
+	}
+	s.revisions[id] = append(s.revisions[id], models.MessageRevision{
+		Revision:        msg.Revisions,
+		Content:         msg.Content,
+		Signature:       msg.Signature,
+		SignedTimestamp: msg.SignedTimestamp,
+		Version:         msg.Version,
+		Tags:            msg.Tags,
+		SigScheme:       msg.SigScheme,
+		CreatedAt:       receivedAt,
+	})
+	msg.Content = edit.Content
+	msg.Signature = edit.Signature
+	msg.SignedTimestamp = edit.SignedTimestamp
+	msg.Version = edit.Version
+	msg.Tags = edit.Tags
+	msg.SigScheme = edit.SigScheme
+	msg.EditedAt = new(time.Now())
+	msg.Revisions++
+
+	edited := *msg
+	edited.Reactions = s.reactionCounts(id)
+	return &edited, nil
+}
+
+func (s *Store) GetMessageRevisions(ctx context.Context, roomName, id string) ([]models.MessageRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !slices.ContainsFunc(s.messages[roomName], func(msg models.Message) bool { return msg.ID == id }) {
+		return nil, services.ErrMessageNotFound
+	}
+	return append([]models.MessageRevision{}, s.revisions[id]...), nil
+}
+
+func (s *Store) AddReaction(ctx context.Context, roomName string, reaction models.Reaction) ([]models.ReactionCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.messages[roomName], func(msg models.Message) bool { return msg.ID == reaction.MessageID })
+	if i == -1 {
+		return nil, services.ErrMessageNotFound
+	}
+	if s.messages[roomName][i].DeletedAt != nil {
+		return nil, services.ErrMessageDeleted
+	}
+
+	id := reaction.MessageID
+	if !slices.ContainsFunc(s.reactions[id], func(r models.Reaction) bool {
+		return r.Pubkey == reaction.Pubkey && r.Emoji == reaction.Emoji
+	}) {
+		reaction.CreatedAt = time.Now()
+		s.reactions[id] = append(s.reactions[id], reaction)
+	}
+	return s.reactionCounts(id), nil
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, roomName, messageID, pubkey, emoji string) ([]models.ReactionCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.ContainsFunc(s.messages[roomName], func(msg models.Message) bool { return msg.ID == messageID }) {
+		return nil, services.ErrMessageNotFound
+	}
+	reactions := s.reactions[messageID]
+	i := slices.IndexFunc(reactions, func(r models.Reaction) bool { return r.Pubkey == pubkey && r.Emoji == emoji })
+	if i == -1 {
+		return nil, services.ErrReactionNotFound
+	}
+	s.reactions[messageID] = slices.Delete(reactions, i, i+1)
+	return append([]models.ReactionCount{}, s.reactionCounts(messageID)...), nil
+}
+
+func (s *Store) GetReactions(ctx context.Context, roomName, messageID string) ([]models.Reaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !slices.ContainsFunc(s.messages[roomName], func(msg models.Message) bool { return msg.ID == messageID }) {
+		return nil, services.ErrMessageNotFound
+	}
+	return append([]models.Reaction{}, s.reactions[messageID]...), nil
+}
+
+// reactionCounts aggregates the reactions to a message by emoji, in the order
+// each emoji was first used. The caller must hold the lock.
+func (s *Store) reactionCounts(messageID string) []models.ReactionCount {
+	var counts []models.ReactionCount
+	for _, reaction := range s.reactions[messageID] {
+		i := slices.IndexFunc(counts, func(c models.ReactionCount) bool { return c.Emoji == reaction.Emoji })
+		if i == -1 {
+			counts = append(counts, models.ReactionCount{Emoji: reaction.Emoji})
+			i = len(counts) - 1
+		}
+		counts[i].Count++
+	}
+	return counts
+}
+
+func (s *Store) SaveDirectMessage(ctx context.Context, dm models.DirectMessage) (*models.DirectMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sigKey := dm.Sender + ":" + dm.Signature
+	if _, seen := s.seenDMSigs[sigKey]; seen {
+		return nil, services.ErrDuplicateMessage
+	}
+	dm.ID = uuid.New().String()
+	dm.Timestamp = time.Now()
+	s.directMessages = append(s.directMessages, dm)
+	s.seenDMSigs[sigKey] = struct{}{}
+	return &dm, nil
+}
+
+func (s *Store) GetDirectMessages(ctx context.Context, pubkey, peer string, params services.MessageQueryParams) ([]models.DirectMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	before := time.Now().Add(time.Second)
+	if params.Before != nil {
+		before = *params.Before
+	}
+
+	filtered := []models.DirectMessage{}
+	for _, dm := range s.directMessages {
+		sent, received := crypto.SamePubkey(dm.Sender, pubkey), crypto.SamePubkey(dm.Recipient, pubkey)
+		if !sent && !received || !dm.Timestamp.Before(before) {
+			continue
+		}
+		if peer != "" && !(sent && crypto.SamePubkey(dm.Recipient, peer)) && !(received && crypto.SamePubkey(dm.Sender, peer)) {
+			continue
+		}
+		filtered = append(filtered, dm)
+	}
+	if len(filtered) > limit {
+		filtered = filtered[len(filtered)-limit:]
+	}
+	return filtered, nil
+}
+
+func (s *Store) CreateSanction(ctx context.Context, sanction models.Sanction) (*models.Sanction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sanction.ID = uuid.New().String()
+	sanction.CreatedAt = time.Now()
+	s.sanctions = append(s.sanctions, sanction)
+	return &sanction, nil
+}
+
+func (s *Store) GetSanctions(ctx context.Context, room string) ([]models.Sanction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sanctions := []models.Sanction{}
+	for _, sanction := range s.sanctions {
+		if sanction.Room == room {
+			sanctions = append(sanctions, sanction)
+		}
+	}
+	return sanctions, nil
+}
+
+func (s *Store) DeleteSanction(ctx context.Context, room, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.sanctions, func(sanction models.Sanction) bool { return sanction.ID == id && sanction.Room == room })
+	if i == -1 {
+		return services.ErrSanctionNotFound
+	}
+	s.sanctions = slices.Delete(s.sanctions, i, i+1)
+	return nil
+}
+
+func (s *Store) CreateInvite(ctx context.Context, invite models.Invite, tokenHash string) (*models.Invite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.rooms[invite.Room]; !exists {
+		return nil, services.ErrRoomNotFound
+	}
+	invite.ID = uuid.New().String()
+	invite.CreatedAt = time.Now()
+	s.invites = append(s.invites, &inviteRecord{invite: invite, tokenHash: tokenHash, redeemers: make(map[string]struct{})})
+	return &invite, nil
+}
+
+func (s *Store) GetInvites(ctx context.Context, room string) ([]models.Invite, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	invites := []models.Invite{}
+	for _, record := range s.invites {
+		if record.invite.Room == room {
+			invite := record.invite
+			invite.Uses = len(record.redeemers)
+			invites = append(invites, invite)
+		}
+	}
+	return invites, nil
+}
+
+func (s *Store) DeleteInvite(ctx context.Context, room, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.invites, func(record *inviteRecord) bool { return record.invite.ID == id && record.invite.Room == room })
+	if i == -1 {
+		return services.ErrInviteNotFound
+	}
+	s.invites = slices.Delete(s.invites, i, i+1)
+	return nil
+}
+
+func (s *Store) RedeemInvite(ctx context.Context, room, tokenHash, pubkey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.invites, func(record *inviteRecord) bool { return record.tokenHash == tokenHash && record.invite.Room == room })
+	if i == -1 {
+		return services.ErrInviteInvalid
+	}
+	record := s.invites[i]
+	invite := record.invite
+	invite.Uses = len(record.redeemers)
+	if invite.Expired(time.Now()) {
+		return services.ErrInviteInvalid
+	}
+	if _, redeemed := record.redeemers[pubkey]; redeemed {
+		return nil
+	}
+	if invite.UsedUp() {
+		return services.ErrInviteInvalid
+	}
+	record.redeemers[pubkey] = struct{}{}
+	return nil
+}
+
 func containsCaseInsensitive(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
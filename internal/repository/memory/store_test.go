@@ -2,9 +2,15 @@ package memory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/repositorytest"
 	"github.com/EwenQuim/microchat/internal/services"
 )
 
@@ -12,7 +18,7 @@ import (
 func saveAt(t *testing.T, s *Store, room string, ts time.Time) {
 	t.Helper()
 	ctx := context.Background()
-	_, err := s.SaveMessage(ctx, room, "user", "content", "", "", 0)
+	_, err := s.SaveMessage(ctx, models.Message{Room: room, User: "user", Content: "content"})
 	if err != nil {
 		t.Fatalf("SaveMessage: %v", err)
 	}
@@ -22,6 +28,12 @@ func saveAt(t *testing.T, s *Store, room string, ts time.Time) {
 	s.mu.Unlock()
 }
 
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) services.Repository {
+		return NewStore()
+	})
+}
+
 func TestGetMessages_DefaultLimit50(t *testing.T) {
 	s := NewStore()
 	ctx := context.Background()
@@ -114,3 +126,509 @@ func TestGetMessages_EmptyRoom(t *testing.T) {
 		t.Errorf("got %d messages for empty room, want 0", len(msgs))
 	}
 }
+
+func TestSaveMessage_DuplicateSignatureRejected(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	if _, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "hi", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1}); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	// Same signature replayed into another room
+	if _, err := s.SaveMessage(ctx, models.Message{Room: "other", User: "alice", Content: "hi", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1}); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replay err = %v, want ErrDuplicateMessage", err)
+	}
+	// Unsigned messages are never considered duplicates
+	for range 2 {
+		if _, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "hi"}); err != nil {
+			t.Errorf("unsigned save: %v", err)
+		}
+	}
+}
+
+func TestFindMessages_FiltersAndOrders(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	xOnly := strings.Repeat("ab", 32)
+	for i, msg := range []models.Message{
+		{Room: "general", Pubkey: "02" + xOnly, SignedTimestamp: 10, SigScheme: "schnorr"},
+		{Room: "general", Pubkey: "pk2", SignedTimestamp: 20, SigScheme: "ecdsa"},
+		{Room: "random", Pubkey: xOnly, SignedTimestamp: 30, SigScheme: "schnorr"},
+		{Room: "other", Pubkey: xOnly, SignedTimestamp: 40, SigScheme: "schnorr"},
+		{Room: "general", SignedTimestamp: 50}, // unsigned
+	} {
+		if msg.Pubkey != "" {
+			msg.Signature = fmt.Sprintf("sig%d", i)
+		}
+		if _, err := s.SaveMessage(ctx, msg); err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		filter services.MessageFilter
+		want   []int64 // signed timestamps, newest first
+	}{
+		{"rooms", services.MessageFilter{Rooms: []string{"general", "random"}}, []int64{30, 20, 10}},
+		{"author in either key form", services.MessageFilter{Rooms: []string{"general", "random"}, Authors: []string{xOnly}}, []int64{30, 10}},
+		{"scheme", services.MessageFilter{Rooms: []string{"general"}, SigScheme: "ecdsa"}, []int64{20}},
+		{"since and until", services.MessageFilter{Rooms: []string{"general", "random", "other"}, Since: 20, Until: 30}, []int64{30, 20}},
+		{"limit keeps the newest", services.MessageFilter{Rooms: []string{"general", "random", "other"}, Limit: 2}, []int64{40, 30}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs, err := s.FindMessages(ctx, tc.filter)
+			if err != nil {
+				t.Fatalf("FindMessages: %v", err)
+			}
+			got := make([]int64, len(msgs))
+			for i, msg := range msgs {
+				got[i] = msg.SignedTimestamp
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeleteMessage_Tombstone(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	signed := models.Message{Room: "room", User: "alice", Content: "spam", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1}
+	saved, err := s.SaveMessage(ctx, signed)
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	deleted, err := s.DeleteMessage(ctx, "room", saved.ID)
+	if err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if deleted.Content != "" || deleted.DeletedAt == nil {
+		t.Errorf("tombstone = %+v, want empty content and DeletedAt set", deleted)
+	}
+	again, err := s.DeleteMessage(ctx, "room", saved.ID)
+	if err != nil {
+		t.Fatalf("second DeleteMessage: %v", err)
+	}
+	if !again.DeletedAt.Equal(*deleted.DeletedAt) {
+		t.Errorf("second delete moved DeletedAt from %v to %v", deleted.DeletedAt, again.DeletedAt)
+	}
+	if _, err := s.DeleteMessage(ctx, "room", "missing"); !errors.Is(err, services.ErrMessageNotFound) {
+		t.Errorf("delete of a missing message err = %v, want ErrMessageNotFound", err)
+	}
+
+	msgs, _ := s.GetMessages(ctx, "room", services.MessageQueryParams{})
+	if len(msgs) != 1 || msgs[0].DeletedAt == nil || msgs[0].Content != "" {
+		t.Errorf("GetMessages after delete = %+v, want the tombstone", msgs)
+	}
+	found, _ := s.FindMessages(ctx, services.MessageFilter{Rooms: []string{"room"}})
+	if len(found) != 0 {
+		t.Errorf("FindMessages returned %d deleted messages, want 0", len(found))
+	}
+	if _, err := s.SaveMessage(ctx, signed); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replay of a deleted message err = %v, want ErrDuplicateMessage", err)
+	}
+}
+
+func TestEditMessage_KeepsRevisions(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	saved, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "helo", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	edited, err := s.EditMessage(ctx, "room", saved.ID, models.MessageRevision{Content: "hello", Signature: "sig2", SignedTimestamp: 2})
+	if err != nil {
+		t.Fatalf("EditMessage: %v", err)
+	}
+	if edited.Content != "hello" || edited.Signature != "sig2" || edited.Revisions != 1 || edited.EditedAt == nil {
+		t.Errorf("edited = %+v, want the new revision", edited)
+	}
+	if _, err := s.EditMessage(ctx, "room", saved.ID, models.MessageRevision{Content: "hi", Signature: "sig3", SignedTimestamp: 2}); !errors.Is(err, services.ErrStaleRevision) {
+		t.Errorf("edit with the same timestamp err = %v, want ErrStaleRevision", err)
+	}
+
+	revisions, err := s.GetMessageRevisions(ctx, "room", saved.ID)
+	if err != nil {
+		t.Fatalf("GetMessageRevisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Content != "helo" || revisions[0].Signature != "sig1" {
+		t.Errorf("revisions = %+v, want the original", revisions)
+	}
+
+	if _, err := s.DeleteMessage(ctx, "room", saved.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if revisions, _ := s.GetMessageRevisions(ctx, "room", saved.ID); len(revisions) != 0 {
+		t.Errorf("got %d revisions after delete, want 0", len(revisions))
+	}
+	if _, err := s.EditMessage(ctx, "room", saved.ID, models.MessageRevision{Content: "back", SignedTimestamp: 3}); !errors.Is(err, services.ErrMessageDeleted) {
+		t.Errorf("edit of a tombstone err = %v, want ErrMessageDeleted", err)
+	}
+	if _, err := s.GetMessageRevisions(ctx, "room", "missing"); !errors.Is(err, services.ErrMessageNotFound) {
+		t.Errorf("revisions of a missing message err = %v, want ErrMessageNotFound", err)
+	}
+}
+
+func TestDeleteRoom(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	saveAt(t, s, "room", time.Now())
+
+	if err := s.DeleteRoom(ctx, "room"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if rooms, _ := s.GetRooms(ctx); len(rooms) != 0 {
+		t.Errorf("rooms = %v, want none", rooms)
+	}
+	if err := s.DeleteRoom(ctx, "room"); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("err = %v, want ErrRoomNotFound", err)
+	}
+}
+
+func TestSetRoomPassword(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	if _, err := s.CreateRoom(ctx, "room", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if err := s.SetRoomPassword(ctx, "room", new("hunter22")); err != nil {
+		t.Fatalf("SetRoomPassword: %v", err)
+	}
+	if err := s.ValidateRoomPassword(ctx, "room", "wrong"); err == nil {
+		t.Error("expected the new password to be required")
+	}
+	if err := s.SetRoomPassword(ctx, "room", new("")); err != nil {
+		t.Fatalf("SetRoomPassword: %v", err)
+	}
+	if err := s.ValidateRoomPassword(ctx, "room", ""); err != nil {
+		t.Errorf("expected an empty password to make the room public: %v", err)
+	}
+	if err := s.SetRoomPassword(ctx, "missing", nil); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("err = %v, want ErrRoomNotFound", err)
+	}
+}
+
+func TestGetRoom_Encrypted(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	if _, err := s.CreateRoom(ctx, "secret", new("hunter22"), "c2FsdA==", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if _, err := s.CreateRoom(ctx, "public", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	room, err := s.GetRoom(ctx, "secret")
+	if err != nil || !room.Encrypted || room.KeySalt != "c2FsdA==" || !room.HasPassword {
+		t.Errorf("GetRoom(secret) = %+v, %v; want an encrypted room with its salt", room, err)
+	}
+	if room, err := s.GetRoom(ctx, "public"); err != nil || room.Encrypted || room.KeySalt != "" {
+		t.Errorf("GetRoom(public) = %+v, %v; want a plain room", room, err)
+	}
+	if _, err := s.GetRoom(ctx, "missing"); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("err = %v, want ErrRoomNotFound", err)
+	}
+	rooms, _ := s.GetRooms(ctx)
+	for _, room := range rooms {
+		if room.Encrypted != (room.Name == "secret") {
+			t.Errorf("GetRooms: %+v", room)
+		}
+	}
+}
+
+func TestRoomRoles(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	owner, mod := strings.Repeat("aa", 32), strings.Repeat("bb", 32)
+	if _, err := s.CreateRoom(ctx, "room", nil, "", owner, false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if err := s.SetRoomRole(ctx, "room", mod, models.RoleModerator); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	members, err := s.GetRoomMembers(ctx, "room")
+	want := []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: mod, Role: models.RoleModerator}}
+	if err != nil || !slices.Equal(members, want) {
+		t.Errorf("GetRoomMembers = %+v, %v; want %+v", members, err, want)
+	}
+	if err := s.SetRoomRole(ctx, "room", mod, ""); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	if members, _ := s.GetRoomMembers(ctx, "room"); len(members) != 1 {
+		t.Errorf("GetRoomMembers = %+v, want only the owner", members)
+	}
+
+	if err := s.SetRoomDescription(ctx, "room", "About things"); err != nil {
+		t.Fatalf("SetRoomDescription: %v", err)
+	}
+	if room, _ := s.GetRoom(ctx, "room"); room.Description != "About things" {
+		t.Errorf("Description = %q", room.Description)
+	}
+	if _, err := s.GetRoomMembers(ctx, "missing"); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("err = %v, want ErrRoomNotFound", err)
+	}
+}
+
+func TestRoomMemberList(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	owner, mod, member := strings.Repeat("aa", 32), strings.Repeat("bb", 32), strings.Repeat("cc", 32)
+	if _, err := s.CreateRoom(ctx, "room", nil, "", owner, true); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if room, _ := s.GetRoom(ctx, "room"); !room.Private {
+		t.Errorf("GetRoom = %+v, want a private room", room)
+	}
+
+	for _, pubkey := range []string{member, mod, member} {
+		if err := s.AddRoomMember(ctx, "room", pubkey); err != nil {
+			t.Fatalf("AddRoomMember: %v", err)
+		}
+	}
+	if err := s.SetRoomRole(ctx, "room", mod, models.RoleModerator); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	members, err := s.GetRoomMembers(ctx, "room")
+	want := []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: mod, Role: models.RoleModerator}, {Pubkey: member, Role: models.RoleMember}}
+	if err != nil || !slices.Equal(members, want) {
+		t.Errorf("GetRoomMembers = %+v, %v; want %+v", members, err, want)
+	}
+
+	if err := s.RemoveRoomMember(ctx, "room", member); err != nil {
+		t.Fatalf("RemoveRoomMember: %v", err)
+	}
+	if err := s.RemoveRoomMember(ctx, "room", member); !errors.Is(err, services.ErrMemberNotFound) {
+		t.Errorf("err = %v, want ErrMemberNotFound", err)
+	}
+	if err := s.AddRoomMember(ctx, "missing", member); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("err = %v, want ErrRoomNotFound", err)
+	}
+}
+
+func TestSanctions_ScopedByRoom(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	if _, err := s.CreateRoom(ctx, "room", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	inRoom, err := s.CreateSanction(ctx, models.Sanction{Kind: models.SanctionMute, Room: "room", Pubkey: strings.Repeat("aa", 32)})
+	if err != nil || inRoom.ID == "" || inRoom.CreatedAt.IsZero() {
+		t.Fatalf("CreateSanction = %+v, %v", inRoom, err)
+	}
+	global, _ := s.CreateSanction(ctx, models.Sanction{Kind: models.SanctionBan, IP: "203.0.113.7"})
+
+	if got, _ := s.GetSanctions(ctx, "room"); len(got) != 1 || got[0].ID != inRoom.ID {
+		t.Errorf("GetSanctions(room) = %+v, want the room mute", got)
+	}
+	if got, _ := s.GetSanctions(ctx, ""); len(got) != 1 || got[0].ID != global.ID {
+		t.Errorf("GetSanctions(server) = %+v, want the ban", got)
+	}
+	if err := s.DeleteSanction(ctx, "room", global.ID); !errors.Is(err, services.ErrSanctionNotFound) {
+		t.Errorf("DeleteSanction from another scope: err = %v, want ErrSanctionNotFound", err)
+	}
+	if err := s.DeleteRoom(ctx, "room"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if got, _ := s.GetSanctions(ctx, "room"); len(got) != 0 {
+		t.Errorf("GetSanctions after DeleteRoom = %+v, want none", got)
+	}
+}
+
+func TestSaveMessage_Replies(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	parent, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "question?"})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	for _, content := range []string{"a", "b", "c"} {
+		if _, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "bob", Content: content, ReplyTo: parent.ID}); err != nil {
+			t.Fatalf("SaveMessage reply: %v", err)
+		}
+	}
+
+	got, err := s.GetMessage(ctx, "room", parent.ID)
+	if err != nil {
+		t.Fatalf("GetMessage: %v", err)
+	}
+	if got.Replies != 3 {
+		t.Errorf("Replies = %d, want 3", got.Replies)
+	}
+
+	replies, err := s.GetReplies(ctx, "room", parent.ID, 2)
+	if err != nil {
+		t.Fatalf("GetReplies: %v", err)
+	}
+	if len(replies) != 2 || replies[0].Content != "b" || replies[1].Content != "c" {
+		t.Errorf("replies = %+v, want the last two oldest first", replies)
+	}
+	if replies, _ := s.GetReplies(ctx, "other", parent.ID, 0); replies == nil || len(replies) != 0 {
+		t.Errorf("replies in another room = %v, want an empty slice", replies)
+	}
+}
+
+func TestSearchMessages(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	alice := strings.Repeat("aa", 32)
+
+	for _, msg := range []models.Message{
+		{Room: "general", User: "alice", Content: "Hello, brave new world!", Pubkey: "02" + alice},
+		{Room: "general", User: "bob", Content: "worldwide hellos"},
+		{Room: "general", User: "bob", Content: "shell world"},
+		{Room: "other", User: "bob", Content: "hello there"},
+	} {
+		if _, err := s.SaveMessage(ctx, msg); err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+	}
+	search := func(search services.MessageSearch) []string {
+		t.Helper()
+		found, err := s.SearchMessages(ctx, search)
+		if err != nil {
+			t.Fatalf("SearchMessages: %v", err)
+		}
+		contents := []string{}
+		for _, msg := range found {
+			contents = append(contents, msg.Content)
+		}
+		return contents
+	}
+
+	if got := search(services.MessageSearch{Query: "HEL wor", Rooms: []string{"general"}}); !slices.Equal(got, []string{"worldwide hellos", "Hello, brave new world!"}) {
+		t.Errorf("search = %q, want the two messages with words starting with hel and wor, newest first", got)
+	}
+	if got := search(services.MessageSearch{Query: "hello", Rooms: []string{"general", "other"}, Author: alice}); !slices.Equal(got, []string{"Hello, brave new world!"}) {
+		t.Errorf("search by author = %q, want alice's message", got)
+	}
+	if got := search(services.MessageSearch{Query: "hello", Rooms: []string{"general", "other"}, Limit: 1}); !slices.Equal(got, []string{"hello there"}) {
+		t.Errorf("search with limit = %q, want the newest message", got)
+	}
+	if got := search(services.MessageSearch{Query: " ,;", Rooms: []string{"general"}}); len(got) != 0 {
+		t.Errorf("search without terms = %q, want nothing", got)
+	}
+}
+
+func TestReactions_CountsAndRemoval(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+
+	msg, err := s.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "hi"})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	for _, r := range []struct{ pubkey, emoji string }{{"pk1", "👍"}, {"pk2", "🎉"}, {"pk2", "👍"}, {"pk1", "👍"}} {
+		if _, err := s.AddReaction(ctx, "room", models.Reaction{MessageID: msg.ID, Pubkey: r.pubkey, Emoji: r.emoji}); err != nil {
+			t.Fatalf("AddReaction: %v", err)
+		}
+	}
+
+	got, _ := s.GetMessage(ctx, "room", msg.ID)
+	want := []models.ReactionCount{{Emoji: "👍", Count: 2}, {Emoji: "🎉", Count: 1}}
+	if !slices.Equal(got.Reactions, want) {
+		t.Errorf("Reactions = %+v, want %+v", got.Reactions, want)
+	}
+
+	counts, err := s.RemoveReaction(ctx, "room", msg.ID, "pk2", "🎉")
+	if err != nil || !slices.Equal(counts, want[:1]) {
+		t.Errorf("RemoveReaction = %+v, %v; want %+v", counts, err, want[:1])
+	}
+	if _, err := s.RemoveReaction(ctx, "room", msg.ID, "pk2", "🎉"); !errors.Is(err, services.ErrReactionNotFound) {
+		t.Errorf("second RemoveReaction err = %v, want ErrReactionNotFound", err)
+	}
+
+	if _, err := s.DeleteMessage(ctx, "room", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if reactions, _ := s.GetReactions(ctx, "room", msg.ID); len(reactions) != 0 {
+		t.Errorf("got %d reactions after delete, want 0", len(reactions))
+	}
+	if _, err := s.AddReaction(ctx, "room", models.Reaction{MessageID: msg.ID, Pubkey: "pk1", Emoji: "👍"}); !errors.Is(err, services.ErrMessageDeleted) {
+		t.Errorf("reaction to a tombstone err = %v, want ErrMessageDeleted", err)
+	}
+}
+
+func TestGetDirectMessages_MatchesKeyForms(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	alice, bob, carol := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+
+	for i, dm := range []models.DirectMessage{
+		{Sender: "02" + alice, Recipient: bob, Signature: "s1"},
+		{Sender: bob, Recipient: "03" + alice, Signature: "s2"},
+		{Sender: carol, Recipient: bob, Signature: "s3"},
+	} {
+		if _, err := s.SaveDirectMessage(ctx, dm); err != nil {
+			t.Fatalf("SaveDirectMessage %d: %v", i, err)
+		}
+	}
+	if _, err := s.SaveDirectMessage(ctx, models.DirectMessage{Sender: carol, Recipient: alice, Signature: "s3"}); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replayed signature: err = %v, want ErrDuplicateMessage", err)
+	}
+
+	for _, tt := range []struct {
+		pubkey, peer string
+		want         []string
+	}{
+		{alice, "", []string{"s1", "s2"}},
+		{bob, "", []string{"s1", "s2", "s3"}},
+		{bob, carol, []string{"s3"}},
+		{carol, alice, nil},
+	} {
+		dms, err := s.GetDirectMessages(ctx, tt.pubkey, tt.peer, services.MessageQueryParams{})
+		if err != nil {
+			t.Fatalf("GetDirectMessages: %v", err)
+		}
+		var got []string
+		for _, dm := range dms {
+			got = append(got, dm.Signature)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("GetDirectMessages(%.1s…, %.1s…) = %v, want %v", tt.pubkey, tt.peer, got, tt.want)
+		}
+	}
+}
+
+func TestRedeemInvite_CountsDistinctPubkeys(t *testing.T) {
+	s := NewStore()
+	ctx := context.Background()
+	if _, err := s.CreateRoom(ctx, "room", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	invite, err := s.CreateInvite(ctx, models.Invite{Room: "room", MaxUses: 1}, "hash")
+	if err != nil || invite.ID == "" || invite.CreatedAt.IsZero() {
+		t.Fatalf("CreateInvite = %+v, %v", invite, err)
+	}
+	alice, bob := strings.Repeat("aa", 32), strings.Repeat("bb", 32)
+
+	if err := s.RedeemInvite(ctx, "other", "hash", alice); !errors.Is(err, services.ErrInviteInvalid) {
+		t.Errorf("RedeemInvite in another room: err = %v, want ErrInviteInvalid", err)
+	}
+	for _, pubkey := range []string{alice, alice} {
+		if err := s.RedeemInvite(ctx, "room", "hash", pubkey); err != nil {
+			t.Errorf("RedeemInvite(alice): %v", err)
+		}
+	}
+	if err := s.RedeemInvite(ctx, "room", "hash", bob); !errors.Is(err, services.ErrInviteInvalid) {
+		t.Errorf("RedeemInvite past max uses: err = %v, want ErrInviteInvalid", err)
+	}
+	if got, _ := s.GetInvites(ctx, "room"); len(got) != 1 || got[0].Uses != 1 {
+		t.Errorf("GetInvites = %+v, want one invite used once", got)
+	}
+
+	if err := s.DeleteRoom(ctx, "room"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if err := s.DeleteInvite(ctx, "room", invite.ID); !errors.Is(err, services.ErrInviteNotFound) {
+		t.Errorf("DeleteInvite after DeleteRoom: err = %v, want ErrInviteNotFound", err)
+	}
+}
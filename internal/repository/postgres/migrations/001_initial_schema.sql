@@ -0,0 +1,171 @@
+-- +goose Up
+-- The PostgreSQL schema starts at the state the SQLite migrations reached,
+-- so it has no history of its own.
+CREATE TABLE IF NOT EXISTS users (
+    public_key TEXT PRIMARY KEY,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_verified ON users(verified);
+
+CREATE TABLE IF NOT EXISTS rooms (
+    name TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    password_hash TEXT,
+    -- Salt of the key of end-to-end encrypted rooms; NULL for plaintext rooms
+    key_salt TEXT,
+    description TEXT NOT NULL DEFAULT '',
+    -- Private rooms are only readable by their owner, moderators and members
+    private BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_rooms_password ON rooms(password_hash) WHERE password_hash IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS messages (
+    id TEXT PRIMARY KEY,
+    room TEXT NOT NULL,
+    "user" TEXT NOT NULL,
+    content TEXT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    signature TEXT,
+    pubkey TEXT REFERENCES users(public_key) ON DELETE SET NULL,
+    signed_timestamp BIGINT,
+    -- Event hash format the signature covers (0 = legacy, 1 = includes user and tags)
+    event_version BIGINT NOT NULL DEFAULT 0,
+    -- Signed tags as a JSON array of string arrays; NULL when the message has none
+    tags TEXT,
+    -- Signature scheme: 'ecdsa' over the microchat event hash, or 'schnorr' (BIP-340) over the NIP-01 event id
+    sig_scheme TEXT NOT NULL DEFAULT 'ecdsa',
+    -- Deleted messages are kept as tombstones: content cleared, deleted_at set
+    deleted_at TIMESTAMPTZ,
+    edited_at TIMESTAMPTZ,
+    revisions BIGINT NOT NULL DEFAULT 0,
+    -- ID of the message a reply answers, and the number of replies a message received
+    reply_to TEXT,
+    replies BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_timestamp ON messages(timestamp);
+CREATE INDEX IF NOT EXISTS idx_messages_pubkey ON messages(pubkey);
+CREATE INDEX IF NOT EXISTS idx_messages_room_timestamp ON messages(room, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_messages_room_reply_to ON messages(room, reply_to, timestamp DESC);
+-- Full-text index of the contents: the 'simple' configuration lowercases words
+-- without stemming them. Searches must use the same expression.
+CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN (to_tsvector('simple', content));
+-- Reject replayed signed messages: a (pubkey, signature) pair may be stored once.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_pubkey_signature ON messages(pubkey, signature);
+
+-- Edited messages keep their latest revision in messages and the earlier ones here
+CREATE TABLE IF NOT EXISTS message_revisions (
+    message_id TEXT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    content TEXT NOT NULL,
+    signature TEXT,
+    signed_timestamp BIGINT,
+    event_version BIGINT NOT NULL DEFAULT 0,
+    tags TEXT,
+    sig_scheme TEXT NOT NULL DEFAULT 'ecdsa',
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (message_id, revision)
+);
+
+-- Signed emoji reactions, one per (message, pubkey, emoji)
+CREATE TABLE IF NOT EXISTS reactions (
+    message_id TEXT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    pubkey TEXT NOT NULL,
+    emoji TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    signed_timestamp BIGINT NOT NULL,
+    event_version BIGINT NOT NULL DEFAULT 0,
+    tags TEXT,
+    sig_scheme TEXT NOT NULL DEFAULT 'ecdsa',
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (message_id, pubkey, emoji)
+);
+
+-- End-to-end encrypted direct messages: the content is an opaque NIP-44 payload
+CREATE TABLE IF NOT EXISTS direct_messages (
+    id TEXT PRIMARY KEY,
+    sender TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    content TEXT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    signature TEXT NOT NULL,
+    signed_timestamp BIGINT NOT NULL,
+    event_version BIGINT NOT NULL,
+    tags TEXT,
+    UNIQUE (sender, signature)
+);
+
+CREATE INDEX IF NOT EXISTS idx_direct_messages_sender ON direct_messages(sender, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_direct_messages_recipient ON direct_messages(recipient, timestamp DESC);
+
+-- Owner and moderators of each room, pubkeys in x-only hex
+CREATE TABLE IF NOT EXISTS room_roles (
+    room_name TEXT NOT NULL REFERENCES rooms(name) ON DELETE CASCADE,
+    pubkey TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (room_name, pubkey)
+);
+
+-- Member lists of private rooms, pubkeys in x-only hex
+CREATE TABLE IF NOT EXISTS room_members (
+    room_name TEXT NOT NULL REFERENCES rooms(name) ON DELETE CASCADE,
+    pubkey TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (room_name, pubkey)
+);
+
+-- Mutes and bans of a pubkey or IP address, in a room or server-wide (empty room)
+CREATE TABLE IF NOT EXISTS sanctions (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    room TEXT NOT NULL DEFAULT '',
+    pubkey TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanctions_room ON sanctions(room);
+
+-- Invites granting access to a room in place of its password; only the hash
+-- of their token is stored
+CREATE TABLE IF NOT EXISTS room_invites (
+    id TEXT PRIMARY KEY,
+    room TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ,
+    max_uses BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_invites_room ON room_invites(room);
+
+-- Pubkeys that redeemed an invite
+CREATE TABLE IF NOT EXISTS room_invite_uses (
+    invite_id TEXT NOT NULL,
+    pubkey TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (invite_id, pubkey)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_invite_uses;
+DROP TABLE IF EXISTS room_invites;
+DROP TABLE IF EXISTS sanctions;
+DROP TABLE IF EXISTS room_members;
+DROP TABLE IF EXISTS room_roles;
+DROP TABLE IF EXISTS direct_messages;
+DROP TABLE IF EXISTS reactions;
+DROP TABLE IF EXISTS message_revisions;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS users;
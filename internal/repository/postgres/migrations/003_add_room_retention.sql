@@ -0,0 +1,10 @@
+-- +goose Up
+-- Retention policy set by the owner of a room: the messages older than
+-- retention_max_age seconds, and those before the latest
+-- retention_max_messages, are pruned. 0 sets no bound.
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS retention_max_age BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS retention_max_messages BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE rooms DROP COLUMN IF EXISTS retention_max_messages;
+ALTER TABLE rooms DROP COLUMN IF EXISTS retention_max_age;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- State that a single server keeps in memory, shared here by the replicas of
+-- the server using this database.
+
+-- Single-use challenges issued to admins, each bound to the x-only pubkey of
+-- the admin it was issued for.
+CREATE TABLE IF NOT EXISTS admin_challenges (
+    challenge TEXT PRIMARY KEY,
+    pubkey TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_challenges_pubkey ON admin_challenges(pubkey, expires_at);
+CREATE INDEX IF NOT EXISTS idx_admin_challenges_expires_at ON admin_challenges(expires_at);
+
+-- Ids of the NIP-98 events accepted, kept until their timestamp leaves the
+-- accepted window, so each event is accepted once across replicas.
+CREATE TABLE IF NOT EXISTS used_auth_events (
+    id TEXT PRIMARY KEY,
+    forget_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_auth_events_forget_at ON used_auth_events(forget_at);
+
+-- Requests counted by the rate limiters, per key and window, in buckets of
+-- one window aligned on the window duration.
+CREATE TABLE IF NOT EXISTS rate_limit_hits (
+    key TEXT NOT NULL,
+    window_ms BIGINT NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    hits BIGINT NOT NULL,
+    forget_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (key, window_ms, window_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_hits_forget_at ON rate_limit_hits(forget_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_limit_hits;
+DROP TABLE IF EXISTS used_auth_events;
+DROP TABLE IF EXISTS admin_challenges;
@@ -0,0 +1,444 @@
+-- name: CreateMessage :one
+INSERT INTO messages (id, room, "user", content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, reply_to)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: IncrementReplies :exec
+UPDATE messages SET replies = replies + 1 WHERE room = $1 AND id = $2;
+
+-- name: GetReplies :many
+SELECT * FROM messages
+WHERE room = $1 AND reply_to = $2
+ORDER BY timestamp DESC
+LIMIT $3;
+
+-- name: MessageSignatureExists :one
+SELECT COUNT(*) > 0 as signature_exists FROM messages WHERE pubkey = $1 AND signature = $2;
+
+-- name: GetMessagesByRoomPaginated :many
+SELECT * FROM messages
+WHERE room = $1
+  AND timestamp < $2
+ORDER BY timestamp DESC
+LIMIT $3;
+
+-- name: GetMessagesBeforeCursor :many
+-- The cursor is at the stored timestamp of its message, which compares exactly,
+-- or at the timestamp it carries once the message is gone.
+SELECT m.* FROM messages m
+WHERE m.room = sqlc.arg(room)
+  AND (m.timestamp, m.id) < (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = sqlc.arg(room) AND c.id = sqlc.arg(cursor_id)), sqlc.arg(cursor_timestamp)::timestamptz),
+    sqlc.arg(cursor_id)
+  )
+ORDER BY m.timestamp DESC, m.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetMessagesAfterCursor :many
+SELECT m.* FROM messages m
+WHERE m.room = sqlc.arg(room)
+  AND (m.timestamp, m.id) > (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = sqlc.arg(room) AND c.id = sqlc.arg(cursor_id)), sqlc.arg(cursor_timestamp)::timestamptz),
+    sqlc.arg(cursor_id)
+  )
+ORDER BY m.timestamp, m.id
+LIMIT sqlc.arg('limit');
+
+-- name: FindMessagesInRoom :many
+SELECT * FROM messages
+WHERE room = sqlc.arg(room)
+  AND (sqlc.arg(author)::text = '' OR pubkey = sqlc.arg(author)::text OR substr(pubkey, 3) = sqlc.arg(author)::text)
+  AND (sqlc.arg(sig_scheme)::text = '' OR sig_scheme = sqlc.arg(sig_scheme)::text)
+  AND signature IS NOT NULL
+  AND deleted_at IS NULL
+  AND signed_timestamp >= sqlc.arg(since)
+  AND signed_timestamp <= sqlc.arg(until)
+ORDER BY signed_timestamp DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetMessage :one
+SELECT * FROM messages WHERE room = $1 AND id = $2;
+
+-- name: TombstoneMessage :exec
+UPDATE messages
+SET content = '', deleted_at = $1
+WHERE room = $2 AND id = $3 AND deleted_at IS NULL;
+
+-- name: ReviseMessage :execrows
+UPDATE messages
+SET content = sqlc.arg(content),
+    signature = sqlc.arg(signature),
+    signed_timestamp = sqlc.arg(signed_timestamp),
+    event_version = sqlc.arg(event_version),
+    tags = sqlc.arg(tags),
+    sig_scheme = sqlc.arg(sig_scheme),
+    edited_at = sqlc.arg(edited_at),
+    revisions = revisions + 1
+WHERE room = sqlc.arg(room) AND id = sqlc.arg(id)
+  AND deleted_at IS NULL
+  AND messages.signed_timestamp < sqlc.arg(signed_timestamp);
+
+-- name: ArchiveMessageRevision :exec
+INSERT INTO message_revisions (message_id, revision, content, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+SELECT id, revisions, content, signature, signed_timestamp, event_version, tags, sig_scheme, COALESCE(edited_at, timestamp)
+FROM messages
+WHERE room = $1 AND id = $2;
+
+-- name: GetMessageRevisions :many
+SELECT * FROM message_revisions
+WHERE message_id = $1
+ORDER BY revision;
+
+-- name: DeleteMessageRevisions :exec
+DELETE FROM message_revisions WHERE message_id = $1;
+
+-- name: DeleteMessageRevisionsByRoom :exec
+DELETE FROM message_revisions
+WHERE message_id IN (SELECT id FROM messages WHERE room = $1);
+
+-- name: CreateReaction :exec
+INSERT INTO reactions (message_id, pubkey, emoji, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (message_id, pubkey, emoji) DO NOTHING;
+
+-- name: DeleteReaction :execrows
+DELETE FROM reactions WHERE message_id = $1 AND pubkey = $2 AND emoji = $3;
+
+-- name: GetReactions :many
+SELECT * FROM reactions
+WHERE message_id = $1
+ORDER BY created_at;
+
+-- name: CountReactions :many
+SELECT emoji, COUNT(*) AS count FROM reactions
+WHERE message_id = $1
+GROUP BY emoji
+ORDER BY MIN(created_at), emoji;
+
+-- name: DeleteMessageReactions :exec
+DELETE FROM reactions WHERE message_id = $1;
+
+-- name: DeleteReactionsByRoom :exec
+DELETE FROM reactions
+WHERE message_id IN (SELECT id FROM messages WHERE room = $1);
+
+-- name: DeleteMessagesByRoom :exec
+DELETE FROM messages WHERE room = $1;
+
+-- name: PruneMessages :execrows
+-- Deletes the messages of a room received before a time, and those older than
+-- its latest keep messages: the message at offset keep is the newest pruned.
+DELETE FROM messages m
+WHERE m.room = sqlc.arg(room)
+  AND (
+    m.timestamp < sqlc.arg(before)::timestamptz
+    OR (m.timestamp, m.id) <= (
+      SELECT k.timestamp, k.id FROM messages k
+      WHERE k.room = sqlc.arg(room)
+      ORDER BY k.timestamp DESC, k.id DESC
+      LIMIT 1 OFFSET sqlc.arg(keep)::bigint
+    )
+  );
+
+-- name: GetRoomsWithLasMessage :many
+SELECT
+    r.name,
+    r.description,
+    (r.password_hash IS NOT NULL)::boolean as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
+    COALESCE(last_msg.content, '') as last_message_content,
+    COALESCE(last_msg."user", '') as last_message_user,
+    COALESCE(last_msg.timestamp, 'epoch'::timestamptz) as last_message_timestamp
+FROM rooms r
+LEFT JOIN (
+    SELECT room, content, "user", timestamp
+    FROM (
+        SELECT m.room, m.content, m."user", m.timestamp,
+               ROW_NUMBER() OVER (PARTITION BY m.room ORDER BY m.timestamp DESC) as rn
+        FROM messages m
+    ) ranked
+    WHERE rn = 1
+) last_msg ON last_msg.room = r.name
+ORDER BY last_msg.timestamp DESC NULLS LAST, r.name ASC
+LIMIT 100;
+
+-- name: GetMessageCountByRoom :one
+SELECT COUNT(*) as count
+FROM messages
+WHERE room = $1;
+
+-- name: CreateUser :one
+INSERT INTO users (public_key, verified, created_at, updated_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: EnsureUser :exec
+INSERT INTO users (public_key, verified, created_at, updated_at)
+VALUES ($1, FALSE, $2, $2)
+ON CONFLICT (public_key) DO NOTHING;
+
+-- name: GetUserByPublicKey :one
+SELECT * FROM users
+WHERE public_key = $1;
+
+-- name: UserExistsByPublicKey :one
+SELECT COUNT(*) > 0 as user_exists FROM users WHERE public_key = $1;
+
+-- name: GetAllUsers :many
+SELECT * FROM users
+ORDER BY created_at, public_key
+LIMIT 100;
+
+-- name: UpdateUserVerified :execrows
+UPDATE users
+SET verified = $1, updated_at = $2
+WHERE public_key = $3;
+
+-- name: GetUserVerified :one
+SELECT verified FROM users
+WHERE public_key = $1;
+
+-- name: GetUserWithPostCount :one
+SELECT
+    u.*,
+    (SELECT COUNT(*) FROM messages WHERE pubkey = u.public_key) as post_count
+FROM users u
+WHERE u.public_key = $1;
+
+-- name: CreateRoom :one
+INSERT INTO rooms (name, password_hash, key_salt, private, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: EnsureRoom :exec
+INSERT INTO rooms (name, created_at, updated_at)
+VALUES ($1, $2, $2)
+ON CONFLICT (name) DO NOTHING;
+
+-- name: GetRoomByName :one
+SELECT * FROM rooms
+WHERE name = $1;
+
+-- name: RoomExists :one
+SELECT COUNT(*) > 0 as room_exists FROM rooms WHERE name = $1;
+
+-- name: SearchRoomsByName :many
+SELECT
+    r.name,
+    r.description,
+    (r.password_hash IS NOT NULL)::boolean as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
+    COALESCE(last_msg.content, '') as last_message_content,
+    COALESCE(last_msg."user", '') as last_message_user,
+    COALESCE(last_msg.timestamp, 'epoch'::timestamptz) as last_message_timestamp
+FROM rooms r
+LEFT JOIN (
+    SELECT room, content, "user", timestamp
+    FROM (
+        SELECT m.room, m.content, m."user", m.timestamp,
+               ROW_NUMBER() OVER (PARTITION BY m.room ORDER BY m.timestamp DESC) as rn
+        FROM messages m
+    ) ranked
+    WHERE rn = 1
+) last_msg ON last_msg.room = r.name
+WHERE r.name ILIKE '%' || sqlc.arg(query)::text || '%'
+ORDER BY last_msg.timestamp DESC NULLS LAST, r.name ASC
+LIMIT 100;
+
+-- name: GetSearchableRooms :many
+SELECT name, private FROM rooms
+WHERE password_hash IS NULL AND COALESCE(key_salt, '') = ''
+ORDER BY name ASC;
+
+-- name: GetRoomPasswordHash :one
+SELECT password_hash FROM rooms WHERE name = $1;
+
+-- name: UpdateRoomPassword :execrows
+UPDATE rooms
+SET password_hash = $1, updated_at = $2
+WHERE name = $3;
+
+-- name: DeleteRoom :execrows
+DELETE FROM rooms WHERE name = $1;
+
+-- name: UpdateRoomDescription :execrows
+UPDATE rooms
+SET description = $1, updated_at = $2
+WHERE name = $3;
+
+-- name: UpdateRoomRetention :execrows
+UPDATE rooms
+SET retention_max_age = $1, retention_max_messages = $2, updated_at = $3
+WHERE name = $4;
+
+-- name: GetRoomRetentions :many
+SELECT name, retention_max_age, retention_max_messages FROM rooms;
+
+-- name: GetRoomRoles :many
+SELECT pubkey, role FROM room_roles
+WHERE room_name = $1
+ORDER BY role = 'owner' DESC, created_at ASC, pubkey ASC;
+
+-- name: UpsertRoomRole :exec
+INSERT INTO room_roles (room_name, pubkey, role, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (room_name, pubkey) DO UPDATE SET role = excluded.role;
+
+-- name: DeleteRoomRole :exec
+DELETE FROM room_roles WHERE room_name = $1 AND pubkey = $2;
+
+-- name: DeleteRoomRolesByRoom :exec
+DELETE FROM room_roles WHERE room_name = $1;
+
+-- name: CreateDirectMessage :one
+INSERT INTO direct_messages (id, sender, recipient, content, timestamp, signature, signed_timestamp, event_version, tags)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetDirectMessages :many
+SELECT * FROM direct_messages
+WHERE ((sender = sqlc.arg(pubkey)::text OR substr(sender, 3) = sqlc.arg(pubkey)::text)
+       AND (sqlc.arg(peer)::text = '' OR recipient = sqlc.arg(peer)::text OR substr(recipient, 3) = sqlc.arg(peer)::text)
+    OR (recipient = sqlc.arg(pubkey)::text OR substr(recipient, 3) = sqlc.arg(pubkey)::text)
+       AND (sqlc.arg(peer)::text = '' OR sender = sqlc.arg(peer)::text OR substr(sender, 3) = sqlc.arg(peer)::text))
+  AND timestamp < sqlc.arg(before)
+ORDER BY timestamp DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CreateSanction :one
+INSERT INTO sanctions (id, kind, room, pubkey, ip, reason, created_by, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetSanctions :many
+SELECT * FROM sanctions
+WHERE room = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: DeleteSanction :execrows
+DELETE FROM sanctions WHERE room = $1 AND id = $2;
+
+-- name: DeleteSanctionsByRoom :exec
+DELETE FROM sanctions WHERE room = $1;
+
+-- name: CreateInvite :one
+INSERT INTO room_invites (id, room, token_hash, created_by, created_at, expires_at, max_uses)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetInvites :many
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetInviteByTokenHash :one
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = $1 AND token_hash = $2;
+
+-- name: LockInvite :one
+SELECT id FROM room_invites
+WHERE room = $1 AND token_hash = $2
+FOR UPDATE;
+
+-- name: InviteRedeemedBy :one
+SELECT COUNT(*) > 0 as redeemed FROM room_invite_uses WHERE invite_id = $1 AND pubkey = $2;
+
+-- name: CreateInviteUse :exec
+INSERT INTO room_invite_uses (invite_id, pubkey, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (invite_id, pubkey) DO NOTHING;
+
+-- name: DeleteInvite :execrows
+DELETE FROM room_invites WHERE room = $1 AND id = $2;
+
+-- name: DeleteInviteUses :exec
+DELETE FROM room_invite_uses WHERE invite_id = $1;
+
+-- name: DeleteInvitesByRoom :exec
+DELETE FROM room_invites WHERE room = $1;
+
+-- name: DeleteInviteUsesByRoom :exec
+DELETE FROM room_invite_uses WHERE invite_id IN (SELECT id FROM room_invites WHERE room = $1);
+
+-- name: GetRoomMemberList :many
+SELECT pubkey FROM room_members
+WHERE room_name = $1
+ORDER BY created_at ASC, pubkey ASC;
+
+-- name: CreateRoomMember :exec
+INSERT INTO room_members (room_name, pubkey, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (room_name, pubkey) DO NOTHING;
+
+-- name: DeleteRoomMember :execrows
+DELETE FROM room_members WHERE room_name = $1 AND pubkey = $2;
+
+-- name: DeleteRoomMembersByRoom :exec
+DELETE FROM room_members WHERE room_name = $1;
+
+-- name: SearchMessages :many
+SELECT * FROM messages m
+WHERE to_tsvector('simple', m.content) @@ to_tsquery('simple', sqlc.arg(query)::text)
+  AND m.room IN (SELECT jsonb_array_elements_text(sqlc.arg(rooms)::jsonb))
+  AND (sqlc.arg(author)::text = '' OR m.pubkey = sqlc.arg(author)::text OR substr(m.pubkey, 3) = sqlc.arg(author)::text)
+  AND m.deleted_at IS NULL
+  AND m.timestamp < sqlc.arg(before)
+ORDER BY m.timestamp DESC
+LIMIT sqlc.arg('limit');
+
+-- name: NotifyHub :exec
+SELECT pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
+
+-- name: CreateAdminChallenge :exec
+INSERT INTO admin_challenges (challenge, pubkey, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: DeleteStaleAdminChallenges :exec
+-- Drops the expired challenges, and those of pubkey older than its latest
+-- keep ones.
+DELETE FROM admin_challenges a
+WHERE a.expires_at < sqlc.arg(now)::timestamptz
+   OR (a.pubkey = sqlc.arg(pubkey)::text AND a.challenge NOT IN (
+        SELECT c.challenge FROM admin_challenges c
+        WHERE c.pubkey = sqlc.arg(pubkey)::text
+        ORDER BY c.expires_at DESC
+        LIMIT sqlc.arg(keep)::bigint
+   ));
+
+-- name: ConsumeAdminChallenge :one
+DELETE FROM admin_challenges
+WHERE pubkey = $1 AND challenge = $2
+RETURNING expires_at;
+
+-- name: CreateUsedAuthEvent :execrows
+INSERT INTO used_auth_events (id, forget_at)
+VALUES ($1, $2)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: DeleteUsedAuthEvents :exec
+DELETE FROM used_auth_events WHERE forget_at < $1;
+
+-- name: HitRateLimit :execrows
+-- Counts a request in the current bucket of key unless the estimated rate,
+-- the previous bucket weighted by the share of it still in the window plus
+-- the current bucket, reached the limit. It affects no row when it did.
+INSERT INTO rate_limit_hits AS h (key, window_ms, window_start, hits, forget_at)
+SELECT sqlc.arg(key)::text, sqlc.arg(window_ms)::bigint, sqlc.arg(window_start)::timestamptz, 1, sqlc.arg(forget_at)::timestamptz
+WHERE sqlc.arg(previous_weight)::float8 * COALESCE((
+    SELECT p.hits FROM rate_limit_hits p
+    WHERE p.key = sqlc.arg(key)::text AND p.window_ms = sqlc.arg(window_ms)::bigint AND p.window_start = sqlc.arg(previous_start)::timestamptz
+), 0) < sqlc.arg(hit_limit)::float8
+ON CONFLICT (key, window_ms, window_start) DO UPDATE SET hits = h.hits + 1
+WHERE h.hits + sqlc.arg(previous_weight)::float8 * COALESCE((
+    SELECT p.hits FROM rate_limit_hits p
+    WHERE p.key = h.key AND p.window_ms = h.window_ms AND p.window_start = sqlc.arg(previous_start)::timestamptz
+), 0) < sqlc.arg(hit_limit)::float8;
+
+-- name: DeleteRateLimitHits :exec
+DELETE FROM rate_limit_hits WHERE forget_at < $1;
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/internal/repository/postgres/sqlc"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// hubChannel is the channel of the NOTIFY relaying the hub events of each
+// replica to the others.
+const hubChannel = "microchat_hub"
+
+// Ensure Store can be shared by several server replicas
+var (
+	_ services.Broadcaster   = (*Store)(nil)
+	_ middleware.SharedStore = (*Store)(nil)
+)
+
+// hubNotification is the payload of a NOTIFY on hubChannel. Origin tells a
+// replica its own events apart, which it already applied to its hub.
+type hubNotification struct {
+	Origin string `json:"origin"`
+	services.HubEvent
+}
+
+func (s *Store) Broadcast(ctx context.Context, event services.HubEvent) error {
+	payload, err := json.Marshal(hubNotification{Origin: s.origin, HubEvent: event})
+	if err != nil {
+		return fmt.Errorf("failed to encode hub event: %w", err)
+	}
+	err = s.queries.NotifyHub(ctx, sqlc.NotifyHubParams{Channel: hubChannel, Payload: string(payload)})
+	if err != nil {
+		return fmt.Errorf("failed to notify hub event: %w", err)
+	}
+	return nil
+}
+
+// Listen holds a connection of the pool listening to hubChannel, closed
+// rather than put back in the pool when it returns.
+func (s *Store) Listen(ctx context.Context, ready func(), handle func(services.HubEvent)) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	var listenErr error
+	_ = conn.Raw(func(driverConn any) error {
+		listenErr = s.listen(ctx, driverConn.(*stdlib.Conn).Conn(), ready, handle)
+		return driver.ErrBadConn // the connection still listens
+	})
+	return listenErr
+}
+
+func (s *Store) listen(ctx context.Context, conn *pgx.Conn, ready func(), handle func(services.HubEvent)) error {
+	if _, err := conn.Exec(ctx, "LISTEN "+hubChannel); err != nil {
+		return fmt.Errorf("failed to listen to hub events: %w", err)
+	}
+	ready()
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for hub events: %w", err)
+		}
+		var n hubNotification
+		if err := json.Unmarshal([]byte(notification.Payload), &n); err != nil || n.Origin == s.origin {
+			continue
+		}
+		handle(n.HubEvent)
+	}
+}
+
+func (s *Store) SaveAdminChallenge(ctx context.Context, pubkey, challenge string, expiry time.Time, keep int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	err = queries.CreateAdminChallenge(ctx, sqlc.CreateAdminChallengeParams{Challenge: challenge, Pubkey: pubkey, ExpiresAt: expiry})
+	if err != nil {
+		return fmt.Errorf("failed to save admin challenge: %w", err)
+	}
+	err = queries.DeleteStaleAdminChallenges(ctx, sqlc.DeleteStaleAdminChallengesParams{Now: time.Now(), Pubkey: pubkey, Keep: int64(keep)})
+	if err != nil {
+		return fmt.Errorf("failed to delete stale admin challenges: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) ConsumeAdminChallenge(ctx context.Context, pubkey, challenge string, now time.Time) (bool, error) {
+	expiry, err := s.queries.ConsumeAdminChallenge(ctx, sqlc.ConsumeAdminChallengeParams{Pubkey: pubkey, Challenge: challenge})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to consume admin challenge: %w", err)
+	}
+	return !now.After(expiry), nil
+}
+
+func (s *Store) UseAuthEvent(ctx context.Context, id string, forgetAt time.Time) (bool, error) {
+	n, err := s.queries.CreateUsedAuthEvent(ctx, sqlc.CreateUsedAuthEventParams{ID: id, ForgetAt: forgetAt})
+	if err != nil {
+		return false, fmt.Errorf("failed to record used auth event: %w", err)
+	}
+	return n == 1, nil
+}
+
+func (s *Store) ForgetAuthEvents(ctx context.Context, now time.Time) error {
+	if err := s.queries.DeleteUsedAuthEvents(ctx, now); err != nil {
+		return fmt.Errorf("failed to delete used auth events: %w", err)
+	}
+	return nil
+}
+
+// HitRateLimit counts the requests in buckets of one window aligned on the
+// window duration, and estimates the rate as RateLimiter does: the previous
+// bucket weighted by the share of it still in the window, plus the current
+// one.
+func (s *Store) HitRateLimit(ctx context.Context, key string, limit int, window time.Duration, now time.Time) (bool, error) {
+	start := now.Truncate(window)
+	n, err := s.queries.HitRateLimit(ctx, sqlc.HitRateLimitParams{
+		Key:            key,
+		WindowMs:       window.Milliseconds(),
+		WindowStart:    start,
+		ForgetAt:       start.Add(2 * window),
+		PreviousWeight: 1 - float64(now.Sub(start))/float64(window),
+		PreviousStart:  start.Add(-window),
+		HitLimit:       float64(limit),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count rate limit hit: %w", err)
+	}
+	return n == 1, nil
+}
+
+func (s *Store) ForgetRateLimits(ctx context.Context, now time.Time) error {
+	if err := s.queries.DeleteRateLimitHits(ctx, now); err != nil {
+		return fmt.Errorf("failed to delete rate limit hits: %w", err)
+	}
+	return nil
+}
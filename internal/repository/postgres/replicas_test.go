@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/services"
+)
+
+// TestSharedStore runs against the database of TestConformance, whose shared
+// tables it empties first.
+func TestSharedStore(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.Exec(`TRUNCATE admin_challenges, used_auth_events, rate_limit_hits`); err != nil {
+		t.Fatalf("failed to empty tables: %v", err)
+	}
+	store := NewStore(db)
+	ctx := t.Context()
+	now := time.Now()
+
+	t.Run("admin challenges", func(t *testing.T) {
+		for i, challenge := range []string{"c1", "c2", "c3"} {
+			expiry := now.Add(time.Duration(i+1) * time.Minute)
+			if err := store.SaveAdminChallenge(ctx, "admin", challenge, expiry, 2); err != nil {
+				t.Fatalf("SaveAdminChallenge: %v", err)
+			}
+		}
+		if err := store.SaveAdminChallenge(ctx, "admin", "expired", now.Add(-time.Second), 16); err != nil {
+			t.Fatalf("SaveAdminChallenge: %v", err)
+		}
+		for _, tc := range []struct {
+			pubkey, challenge string
+			want              bool
+		}{
+			{"admin", "c1", false},      // dropped past the latest 2
+			{"other", "c3", false},      // issued for another admin
+			{"admin", "c3", true},       // valid
+			{"admin", "c3", false},      // used up
+			{"admin", "expired", false}, // expired
+		} {
+			got, err := store.ConsumeAdminChallenge(ctx, tc.pubkey, tc.challenge, now)
+			if err != nil {
+				t.Fatalf("ConsumeAdminChallenge: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ConsumeAdminChallenge(%q, %q) = %v, want %v", tc.pubkey, tc.challenge, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("auth events", func(t *testing.T) {
+		use := func(id string) bool {
+			t.Helper()
+			first, err := store.UseAuthEvent(ctx, id, now.Add(time.Minute))
+			if err != nil {
+				t.Fatalf("UseAuthEvent: %v", err)
+			}
+			return first
+		}
+		if !use("event") || use("event") {
+			t.Fatal("an event should be accepted once")
+		}
+		if err := store.ForgetAuthEvents(ctx, now.Add(2*time.Minute)); err != nil {
+			t.Fatalf("ForgetAuthEvents: %v", err)
+		}
+		if !use("event") {
+			t.Error("a forgotten event should be accepted again")
+		}
+	})
+
+	t.Run("rate limits", func(t *testing.T) {
+		start := now.Truncate(time.Minute)
+		hit := func(key string, window time.Duration, at time.Time) bool {
+			t.Helper()
+			allowed, err := store.HitRateLimit(ctx, key, 2, window, at)
+			if err != nil {
+				t.Fatalf("HitRateLimit: %v", err)
+			}
+			return allowed
+		}
+		at := start.Add(time.Second)
+		if !hit("k", time.Minute, at) || !hit("k", time.Minute, at) || hit("k", time.Minute, at) {
+			t.Error("2 requests should be allowed in a window, and not a third")
+		}
+		if !hit("other", time.Minute, at) || !hit("k", time.Hour, at) {
+			t.Error("other keys and windows should be counted apart")
+		}
+		// Halfway through the next window, the 2 previous requests weigh 1
+		at = start.Add(90 * time.Second)
+		if !hit("k", time.Minute, at) || hit("k", time.Minute, at) {
+			t.Error("the previous window should weigh by the share of it still in the window")
+		}
+		if err := store.ForgetRateLimits(ctx, start.Add(3*time.Minute)); err != nil {
+			t.Fatalf("ForgetRateLimits: %v", err)
+		}
+		var rows int
+		if err := db.QueryRow(`SELECT count(*) FROM rate_limit_hits WHERE window_ms = 60000`).Scan(&rows); err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		if rows != 0 {
+			t.Errorf("%d minute buckets left, want them forgotten", rows)
+		}
+	})
+}
+
+// TestListen checks that the hub events broadcast by a store reach the other
+// stores of the database, and not itself.
+func TestListen(t *testing.T) {
+	db := openTestDB(t)
+	a, b := NewStore(db), NewStore(db)
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var listeners sync.WaitGroup
+	listen := func(store *Store) <-chan services.HubEvent {
+		ready := make(chan struct{})
+		events := make(chan services.HubEvent, 4)
+		listeners.Go(func() {
+			err := store.Listen(ctx, func() { close(ready) }, func(event services.HubEvent) { events <- event })
+			if ctx.Err() == nil {
+				t.Errorf("Listen: %v", err)
+			}
+		})
+		select {
+		case <-ready:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Listen not ready")
+		}
+		return events
+	}
+	aEvents, bEvents := listen(a), listen(b)
+
+	receive := func(events <-chan services.HubEvent) services.HubEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no hub event received")
+			return services.HubEvent{}
+		}
+	}
+	fromA := services.HubEvent{Kind: services.HubEventMessage, Room: "general", MessageID: "1"}
+	fromB := services.HubEvent{Kind: services.HubEventCloseRoom, Room: "general"}
+	if err := a.Broadcast(ctx, fromA); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	if got := receive(bEvents); got != fromA {
+		t.Errorf("b received %+v, want %+v", got, fromA)
+	}
+	if err := b.Broadcast(ctx, fromB); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	// Notifications arrive in order: a would have received its own first
+	if got := receive(aEvents); got != fromB {
+		t.Errorf("a received %+v, want only the event of b", got)
+	}
+
+	cancel()
+	listeners.Wait()
+	if err := db.PingContext(t.Context()); err != nil {
+		t.Errorf("the pool should still work once the listeners stopped: %v", err)
+	}
+}
@@ -0,0 +1,1405 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/postgres/sqlc"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Store struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+	origin  string // tells the hub events of this replica apart
+}
+
+// Ensure Store implements the Repository interface
+var _ services.Repository = (*Store)(nil)
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		db:      db,
+		queries: sqlc.New(db),
+		origin:  rand.Text(),
+	}
+}
+
+func (s *Store) SaveMessage(ctx context.Context, msg models.Message) (*models.Message, error) {
+	room, pubkey, signature := msg.Room, msg.Pubkey, msg.Signature
+
+	// Reject replays before any side effect; the unique index on
+	// (pubkey, signature) still catches concurrent duplicates below.
+	if signature != "" {
+		seen, err := s.queries.MessageSignatureExists(ctx, sqlc.MessageSignatureExistsParams{
+			Pubkey:    sql.NullString{String: pubkey, Valid: pubkey != ""},
+			Signature: sql.NullString{String: signature, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check message signature: %w", err)
+		}
+		if seen {
+			return nil, services.ErrDuplicateMessage
+		}
+	}
+
+	// Automatically create the room (always public) and an unverified user;
+	// another request or replica may be creating them at the same time.
+	now := time.Now()
+	if err := s.queries.EnsureRoom(ctx, sqlc.EnsureRoomParams{Name: room, CreatedAt: now}); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+	if pubkey != "" {
+		if err := s.queries.EnsureUser(ctx, sqlc.EnsureUserParams{PublicKey: pubkey, CreatedAt: now}); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	tags, err := encodeTags(msg.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+	timestamp := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	sqlcMsg, err := queries.CreateMessage(ctx, sqlc.CreateMessageParams{
+		ID:        msgID,
+		Room:      room,
+		User:      msg.User,
+		Content:   msg.Content,
+		Timestamp: timestamp,
+		Signature: sql.NullString{
+			String: signature,
+			Valid:  signature != "",
+		},
+		Pubkey: sql.NullString{
+			String: pubkey,
+			Valid:  pubkey != "",
+		},
+		SignedTimestamp: sql.NullInt64{
+			Int64: msg.SignedTimestamp,
+			Valid: msg.SignedTimestamp != 0,
+		},
+		EventVersion: int64(msg.Version),
+		Tags:         tags,
+		SigScheme:    msg.SigScheme,
+		ReplyTo: sql.NullString{
+			String: msg.ReplyTo,
+			Valid:  msg.ReplyTo != "",
+		},
+	})
+	if isUniqueViolation(err) {
+		return nil, services.ErrDuplicateMessage
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save message: %w", err)
+	}
+
+	if msg.ReplyTo != "" {
+		err = queries.IncrementReplies(ctx, sqlc.IncrementRepliesParams{Room: room, ID: msg.ReplyTo})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count reply: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return sqlcMessageToModel(sqlcMsg), nil
+}
+
+func (s *Store) GetMessages(ctx context.Context, room string, params services.MessageQueryParams) ([]models.Message, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	before := time.Now().Add(time.Second)
+	if params.Before != nil {
+		before = *params.Before
+	}
+
+	var sqlcMessages []sqlc.Message
+	var err error
+	switch {
+	case params.AfterCursor != nil:
+		sqlcMessages, err = s.queries.GetMessagesAfterCursor(ctx, sqlc.GetMessagesAfterCursorParams{
+			Room:            room,
+			CursorID:        params.AfterCursor.ID,
+			CursorTimestamp: params.AfterCursor.Timestamp,
+			Limit:           int32(limit),
+		})
+	case params.BeforeCursor != nil:
+		sqlcMessages, err = s.queries.GetMessagesBeforeCursor(ctx, sqlc.GetMessagesBeforeCursorParams{
+			Room:            room,
+			CursorID:        params.BeforeCursor.ID,
+			CursorTimestamp: params.BeforeCursor.Timestamp,
+			Limit:           int32(limit),
+		})
+	default:
+		sqlcMessages, err = s.queries.GetMessagesByRoomPaginated(ctx, sqlc.GetMessagesByRoomPaginatedParams{
+			Room:      room,
+			Timestamp: before,
+			Limit:     int32(limit),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	messages := make([]models.Message, len(sqlcMessages))
+	for i, msg := range sqlcMessages {
+		messages[i] = *sqlcMessageToModel(msg)
+	}
+	// Results come DESC from DB but after a cursor; reverse to return ASC to callers
+	if params.AfterCursor == nil {
+		slices.Reverse(messages)
+	}
+
+	if err := s.attachReactions(ctx, messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (s *Store) FindMessages(ctx context.Context, filter services.MessageFilter) ([]models.Message, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	until := filter.Until
+	if until == 0 {
+		until = math.MaxInt64
+	}
+	authors := filter.Authors
+	if len(authors) == 0 {
+		authors = []string{""} // any author
+	}
+
+	// One indexed query per (room, author); each is limited, then merged.
+	found := []models.Message{}
+	for _, room := range filter.Rooms {
+		for _, author := range authors {
+			rows, err := s.queries.FindMessagesInRoom(ctx, sqlc.FindMessagesInRoomParams{
+				Room:      room,
+				Author:    author,
+				SigScheme: filter.SigScheme,
+				Since:     sql.NullInt64{Int64: filter.Since, Valid: true},
+				Until:     sql.NullInt64{Int64: until, Valid: true},
+				Limit:     int32(limit),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to find messages: %w", err)
+			}
+			for _, row := range rows {
+				found = append(found, *sqlcMessageToModel(row))
+			}
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b models.Message) int {
+		return cmp.Compare(b.SignedTimestamp, a.SignedTimestamp)
+	})
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+// SearchMessages queries the full-text index, each term of the search as a
+// prefix, in the searched rooms passed as a JSON array.
+func (s *Store) SearchMessages(ctx context.Context, search services.MessageSearch) ([]models.Message, error) {
+	terms := services.SearchTerms(search.Query)
+	if len(terms) == 0 || len(search.Rooms) == 0 {
+		return []models.Message{}, nil
+	}
+	// Terms are letters and digits only, so they need no quoting in a tsquery.
+	match := make([]string, len(terms))
+	for i, term := range terms {
+		match[i] = term + ":*"
+	}
+	rooms, err := json.Marshal(search.Rooms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rooms: %w", err)
+	}
+	limit := search.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	before := time.Now().Add(time.Second)
+	if search.Before != nil {
+		before = *search.Before
+	}
+
+	rows, err := s.queries.SearchMessages(ctx, sqlc.SearchMessagesParams{
+		Query:  strings.Join(match, " & "),
+		Rooms:  rooms,
+		Author: search.Author,
+		Before: before,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	found := make([]models.Message, len(rows))
+	for i, row := range rows {
+		found[i] = *sqlcMessageToModel(row)
+	}
+	return found, nil
+}
+
+func (s *Store) GetRooms(ctx context.Context) ([]models.Room, error) {
+	rows, err := s.queries.GetRoomsWithLasMessage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+
+	rooms := make([]models.Room, 0, len(rows))
+	for _, row := range rows {
+		rooms = append(rooms, sqlcRoomRowToModel(row))
+	}
+
+	return rooms, nil
+}
+
+// GetSearchableRooms returns every room without a password, by name.
+func (s *Store) GetSearchableRooms(ctx context.Context) ([]models.Room, error) {
+	rows, err := s.queries.GetSearchableRooms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get searchable rooms: %w", err)
+	}
+
+	rooms := make([]models.Room, len(rows))
+	for i, row := range rows {
+		rooms[i] = models.Room{Name: row.Name, Private: row.Private}
+	}
+	return rooms, nil
+}
+
+func (s *Store) SearchRooms(ctx context.Context, query string) ([]models.Room, error) {
+	rows, err := s.queries.SearchRoomsByName(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search rooms: %w", err)
+	}
+
+	rooms := make([]models.Room, 0, len(rows))
+	for _, row := range rows {
+		rooms = append(rooms, sqlcRoomRowToModel(sqlc.GetRoomsWithLasMessageRow(row)))
+	}
+
+	return rooms, nil
+}
+
+// sqlcRoomRowToModel converts a room listed with its last message. A room
+// without messages has an empty content and user and the epoch as timestamp.
+func sqlcRoomRowToModel(row sqlc.GetRoomsWithLasMessageRow) models.Room {
+	room := models.Room{
+		Name:        row.Name,
+		Description: row.Description,
+		HasPassword: row.HasPassword,
+		Encrypted:   row.KeySalt != "",
+		KeySalt:     row.KeySalt,
+		Private:     row.Private,
+	}
+
+	// Only set last message fields if they exist (room has messages)
+	if row.LastMessageContent != "" {
+		room.LastMessageContent = &row.LastMessageContent
+	}
+	if row.LastMessageUser != "" {
+		room.LastMessageUser = &row.LastMessageUser
+	}
+	if row.LastMessageTimestamp.Unix() != 0 {
+		room.LastMessageTimestamp = new(row.LastMessageTimestamp.Format(time.RFC3339))
+	}
+
+	return room
+}
+
+func (s *Store) CreateRoom(ctx context.Context, name string, password *string, keySalt, owner string, private bool) (*models.Room, error) {
+	// Check if room already exists
+	exists, err := s.queries.RoomExists(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check room existence: %w", err)
+	}
+
+	if exists {
+		return nil, fmt.Errorf("room already exists")
+	}
+
+	var passwordHash sql.NullString
+	hasPassword := false
+
+	if password != nil && *password != "" {
+		// Hash the password
+		hash, err := crypto.HashPassword(*password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		passwordHash = sql.NullString{String: hash, Valid: true}
+		hasPassword = true
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	now := time.Now()
+	_, err = queries.CreateRoom(ctx, sqlc.CreateRoomParams{
+		Name:         name,
+		PasswordHash: passwordHash,
+		KeySalt:      sql.NullString{String: keySalt, Valid: keySalt != ""},
+		Private:      private,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if isUniqueViolation(err) { // created meanwhile, by another request or replica
+		return nil, fmt.Errorf("room already exists")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+	if owner != "" {
+		err = queries.UpsertRoomRole(ctx, sqlc.UpsertRoomRoleParams{
+			RoomName:  name,
+			Pubkey:    owner,
+			Role:      string(models.RoleOwner),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record room owner: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	return &models.Room{
+		Name:        name,
+		HasPassword: hasPassword,
+		Encrypted:   keySalt != "",
+		KeySalt:     keySalt,
+		Private:     private,
+	}, nil
+}
+
+func (s *Store) GetRoom(ctx context.Context, name string) (*models.Room, error) {
+	row, err := s.queries.GetRoomByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	return &models.Room{
+		Name:        row.Name,
+		Description: row.Description,
+		HasPassword: row.PasswordHash.Valid,
+		Encrypted:   row.KeySalt.Valid,
+		KeySalt:     row.KeySalt.String,
+		Private:     row.Private,
+		Retention:   models.RetentionPolicy{MaxAge: row.RetentionMaxAge, MaxMessages: row.RetentionMaxMessages},
+	}, nil
+}
+
+func (s *Store) RegisterUser(ctx context.Context, publicKey string) (*models.User, error) {
+
+	// Check if public key is already registered
+	exists, err := s.queries.UserExistsByPublicKey(ctx, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	if exists {
+		return nil, fmt.Errorf("public key already registered to user %s", publicKey)
+	}
+
+	now := time.Now()
+	sqlcUser, err := s.queries.CreateUser(ctx, sqlc.CreateUserParams{
+		PublicKey: publicKey,
+		Verified:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("public key already registered to user %s", publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	return sqlcUserToModel(sqlcUser), nil
+}
+
+func (s *Store) GetUser(ctx context.Context, publicKey string) (*models.User, error) {
+
+	sqlcUser, err := s.queries.GetUserByPublicKey(ctx, publicKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return sqlcUserToModel(sqlcUser), nil
+}
+
+func (s *Store) GetUserByPublicKey(ctx context.Context, publicKey string) (*models.User, error) {
+	return s.GetUser(ctx, publicKey)
+}
+
+func (s *Store) GetAllUsers(ctx context.Context) ([]models.User, error) {
+
+	sqlcUsers, err := s.queries.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all users: %w", err)
+	}
+
+	users := make([]models.User, 0, len(sqlcUsers))
+	for _, user := range sqlcUsers {
+		users = append(users, *sqlcUserToModel(user))
+	}
+
+	return users, nil
+}
+
+func (s *Store) VerifyUser(ctx context.Context, publicKey string) error {
+
+	updated, err := s.queries.UpdateUserVerified(ctx, sqlc.UpdateUserVerifiedParams{
+		Verified:  true,
+		UpdatedAt: time.Now(),
+		PublicKey: publicKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify user: %w", err)
+	}
+	if updated == 0 {
+		return services.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) UnverifyUser(ctx context.Context, publicKey string) error {
+
+	updated, err := s.queries.UpdateUserVerified(ctx, sqlc.UpdateUserVerifiedParams{
+		Verified:  false,
+		UpdatedAt: time.Now(),
+		PublicKey: publicKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unverify user: %w", err)
+	}
+	if updated == 0 {
+		return services.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a unique_violation (23505).
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// Helper functions to convert between sqlc and models types
+// encodeTags stores signed tags as a JSON array; no tags are stored as NULL.
+func encodeTags(tags [][]string) (sql.NullString, error) {
+	if len(tags) == 0 {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode tags: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func sqlcMessageToModel(msg sqlc.Message) *models.Message {
+	m := &models.Message{
+		ID:              msg.ID,
+		Room:            msg.Room,
+		User:            msg.User,
+		Content:         msg.Content,
+		Timestamp:       msg.Timestamp,
+		Signature:       msg.Signature.String,
+		Pubkey:          msg.Pubkey.String,
+		SignedTimestamp: msg.SignedTimestamp.Int64,
+		Version:         int(msg.EventVersion),
+		SigScheme:       msg.SigScheme,
+	}
+	if msg.Tags.Valid {
+		// Tags are written by SaveMessage; a decoding failure leaves them empty
+		// and the signature then fails to verify client-side, which is visible.
+		_ = json.Unmarshal([]byte(msg.Tags.String), &m.Tags)
+	}
+	if msg.DeletedAt.Valid {
+		m.DeletedAt = &msg.DeletedAt.Time
+	}
+	if msg.EditedAt.Valid {
+		m.EditedAt = &msg.EditedAt.Time
+	}
+	m.Revisions = int(msg.Revisions)
+	m.ReplyTo = msg.ReplyTo.String
+	m.Replies = int(msg.Replies)
+	return m
+}
+
+func sqlcReactionToModel(reaction sqlc.Reaction) models.Reaction {
+	r := models.Reaction{
+		MessageID:       reaction.MessageID,
+		Pubkey:          reaction.Pubkey,
+		Emoji:           reaction.Emoji,
+		Signature:       reaction.Signature,
+		SignedTimestamp: reaction.SignedTimestamp,
+		Version:         int(reaction.EventVersion),
+		SigScheme:       reaction.SigScheme,
+		CreatedAt:       reaction.CreatedAt,
+	}
+	if reaction.Tags.Valid {
+		_ = json.Unmarshal([]byte(reaction.Tags.String), &r.Tags) // see sqlcMessageToModel
+	}
+	return r
+}
+
+func sqlcDirectMessageToModel(dm sqlc.DirectMessage) models.DirectMessage {
+	d := models.DirectMessage{
+		ID:              dm.ID,
+		Sender:          dm.Sender,
+		Recipient:       dm.Recipient,
+		Content:         dm.Content,
+		Timestamp:       dm.Timestamp,
+		Signature:       dm.Signature,
+		SignedTimestamp: dm.SignedTimestamp,
+		Version:         int(dm.EventVersion),
+	}
+	if dm.Tags.Valid {
+		_ = json.Unmarshal([]byte(dm.Tags.String), &d.Tags) // see sqlcMessageToModel
+	}
+	return d
+}
+
+func sqlcRevisionToModel(rev sqlc.MessageRevision) models.MessageRevision {
+	r := models.MessageRevision{
+		Revision:        int(rev.Revision),
+		Content:         rev.Content,
+		Signature:       rev.Signature.String,
+		SignedTimestamp: rev.SignedTimestamp.Int64,
+		Version:         int(rev.EventVersion),
+		SigScheme:       rev.SigScheme,
+		CreatedAt:       rev.CreatedAt,
+	}
+	if rev.Tags.Valid {
+		_ = json.Unmarshal([]byte(rev.Tags.String), &r.Tags) // see sqlcMessageToModel
+	}
+	return r
+}
+
+func sqlcUserToModel(user sqlc.User) *models.User {
+	return &models.User{
+		PublicKey: user.PublicKey,
+		Verified:  user.Verified,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+func (s *Store) GetUserWithPostCount(ctx context.Context, publicKey string) (*models.UserWithPostCount, error) {
+	row, err := s.queries.GetUserWithPostCount(ctx, publicKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user with post count: %w", err)
+	}
+
+	return &models.UserWithPostCount{
+		PublicKey: row.PublicKey,
+		Verified:  row.Verified,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+		PostCount: row.PostCount,
+	}, nil
+}
+
+func (s *Store) ValidateRoomPassword(ctx context.Context, roomName, password string) error {
+	passwordHash, err := s.queries.GetRoomPasswordHash(ctx, roomName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return services.ErrRoomNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get room password hash: %w", err)
+	}
+
+	// If password_hash is NULL, room is public
+	if !passwordHash.Valid {
+		return nil
+	}
+
+	// Verify password
+	return crypto.VerifyPassword(password, passwordHash.String)
+}
+
+func (s *Store) SetRoomPassword(ctx context.Context, roomName string, password *string) error {
+	var passwordHash sql.NullString
+	if password != nil && *password != "" {
+		hash, err := crypto.HashPassword(*password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		passwordHash = sql.NullString{String: hash, Valid: true}
+	}
+
+	updated, err := s.queries.UpdateRoomPassword(ctx, sqlc.UpdateRoomPasswordParams{
+		PasswordHash: passwordHash,
+		UpdatedAt:    time.Now(),
+		Name:         roomName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update room password: %w", err)
+	}
+	if updated == 0 {
+		return services.ErrRoomNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) SetRoomDescription(ctx context.Context, roomName, description string) error {
+	updated, err := s.queries.UpdateRoomDescription(ctx, sqlc.UpdateRoomDescriptionParams{
+		Description: description,
+		UpdatedAt:   time.Now(),
+		Name:        roomName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update room description: %w", err)
+	}
+	if updated == 0 {
+		return services.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (s *Store) SetRoomRetention(ctx context.Context, roomName string, policy models.RetentionPolicy) error {
+	updated, err := s.queries.UpdateRoomRetention(ctx, sqlc.UpdateRoomRetentionParams{
+		RetentionMaxAge:      policy.MaxAge,
+		RetentionMaxMessages: policy.MaxMessages,
+		UpdatedAt:            time.Now(),
+		Name:                 roomName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update room retention: %w", err)
+	}
+	if updated == 0 {
+		return services.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (s *Store) GetRetentionPolicies(ctx context.Context) (map[string]models.RetentionPolicy, error) {
+	rows, err := s.queries.GetRoomRetentions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room retentions: %w", err)
+	}
+	policies := make(map[string]models.RetentionPolicy, len(rows))
+	for _, row := range rows {
+		policies[row.Name] = models.RetentionPolicy{MaxAge: row.RetentionMaxAge, MaxMessages: row.RetentionMaxMessages}
+	}
+	return policies, nil
+}
+
+// PruneMessages deletes the messages past the bounds; their revisions and
+// reactions follow by ON DELETE CASCADE.
+func (s *Store) PruneMessages(ctx context.Context, roomName string, before time.Time, keep int) (int64, error) {
+	if keep <= 0 {
+		keep = math.MaxInt64 // no message is that far from the latest
+	}
+	pruned, err := s.queries.PruneMessages(ctx, sqlc.PruneMessagesParams{
+		Room:   roomName,
+		Before: before,
+		Keep:   int64(keep),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune messages: %w", err)
+	}
+	return pruned, nil
+}
+
+// Compact does nothing: autovacuum reclaims the rows of pruned messages.
+func (s *Store) Compact(ctx context.Context) error {
+	return nil
+}
+
+func (s *Store) GetRoomMembers(ctx context.Context, roomName string) ([]models.RoomMember, error) {
+	if err := s.requireRoom(ctx, roomName); err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.GetRoomRoles(ctx, roomName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room roles: %w", err)
+	}
+	listed, err := s.queries.GetRoomMemberList(ctx, roomName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room member list: %w", err)
+	}
+	members := make([]models.RoomMember, 0, len(rows)+len(listed))
+	for _, row := range rows {
+		members = append(members, models.RoomMember{Pubkey: row.Pubkey, Role: models.RoomRole(row.Role)})
+	}
+	for _, pubkey := range listed {
+		if !slices.ContainsFunc(rows, func(row sqlc.GetRoomRolesRow) bool { return row.Pubkey == pubkey }) {
+			members = append(members, models.RoomMember{Pubkey: pubkey, Role: models.RoleMember})
+		}
+	}
+	return members, nil
+}
+
+func (s *Store) AddRoomMember(ctx context.Context, roomName, pubkey string) error {
+	if err := s.requireRoom(ctx, roomName); err != nil {
+		return err
+	}
+	err := s.queries.CreateRoomMember(ctx, sqlc.CreateRoomMemberParams{
+		RoomName:  roomName,
+		Pubkey:    pubkey,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add room member: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) RemoveRoomMember(ctx context.Context, roomName, pubkey string) error {
+	if err := s.requireRoom(ctx, roomName); err != nil {
+		return err
+	}
+	deleted, err := s.queries.DeleteRoomMember(ctx, sqlc.DeleteRoomMemberParams{RoomName: roomName, Pubkey: pubkey})
+	if err != nil {
+		return fmt.Errorf("failed to remove room member: %w", err)
+	}
+	if deleted == 0 {
+		return services.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, roomName, pubkey string, role models.RoomRole) error {
+	if err := s.requireRoom(ctx, roomName); err != nil {
+		return err
+	}
+	if role == "" {
+		if err := s.queries.DeleteRoomRole(ctx, sqlc.DeleteRoomRoleParams{RoomName: roomName, Pubkey: pubkey}); err != nil {
+			return fmt.Errorf("failed to revoke room role: %w", err)
+		}
+		return nil
+	}
+	err := s.queries.UpsertRoomRole(ctx, sqlc.UpsertRoomRoleParams{
+		RoomName:  roomName,
+		Pubkey:    pubkey,
+		Role:      string(role),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to grant room role: %w", err)
+	}
+	return nil
+}
+
+// requireRoom returns ErrRoomNotFound unless the room exists.
+func (s *Store) requireRoom(ctx context.Context, roomName string) error {
+	exists, err := s.queries.RoomExists(ctx, roomName)
+	if err != nil {
+		return fmt.Errorf("failed to check room existence: %w", err)
+	}
+	if !exists {
+		return services.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteRoom(ctx context.Context, roomName string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	deleted, err := queries.DeleteRoom(ctx, roomName)
+	if err != nil {
+		return fmt.Errorf("failed to delete room: %w", err)
+	}
+	if deleted == 0 {
+		return services.ErrRoomNotFound
+	}
+	if err := queries.DeleteMessageRevisionsByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room message revisions: %w", err)
+	}
+	if err := queries.DeleteReactionsByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room reactions: %w", err)
+	}
+	if err := queries.DeleteMessagesByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room messages: %w", err)
+	}
+	if err := queries.DeleteRoomRolesByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room roles: %w", err)
+	}
+	if err := queries.DeleteRoomMembersByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room members: %w", err)
+	}
+	if err := queries.DeleteSanctionsByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room sanctions: %w", err)
+	}
+	if err := queries.DeleteInviteUsesByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room invite uses: %w", err)
+	}
+	if err := queries.DeleteInvitesByRoom(ctx, roomName); err != nil {
+		return fmt.Errorf("failed to delete room invites: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (s *Store) GetMessage(ctx context.Context, roomName, id string) (*models.Message, error) {
+	msg, err := s.queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	m := sqlcMessageToModel(msg)
+	if m.Reactions, err = countReactions(ctx, s.queries, id); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, roomName, id string) (*models.Message, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	err = queries.TombstoneMessage(ctx, sqlc.TombstoneMessageParams{
+		DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		Room:      roomName,
+		ID:        id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete message: %w", err)
+	}
+	// Deleting twice keeps the first tombstone.
+	msg, err := queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	// The history would still show the content
+	if err := queries.DeleteMessageRevisions(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to delete message revisions: %w", err)
+	}
+	if err := queries.DeleteMessageReactions(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to delete message reactions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return sqlcMessageToModel(msg), nil
+}
+
+func (s *Store) EditMessage(ctx context.Context, roomName, id string, edit models.MessageRevision) (*models.Message, error) {
+	tags, err := encodeTags(edit.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	current, err := queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if current.DeletedAt.Valid {
+		return nil, services.ErrMessageDeleted
+	}
+
+	if err := queries.ArchiveMessageRevision(ctx, sqlc.ArchiveMessageRevisionParams{Room: roomName, ID: id}); err != nil {
+		return nil, fmt.Errorf("failed to archive message revision: %w", err)
+	}
+	revised, err := queries.ReviseMessage(ctx, sqlc.ReviseMessageParams{
+		Content:         edit.Content,
+		Signature:       sql.NullString{String: edit.Signature, Valid: edit.Signature != ""},
+		SignedTimestamp: sql.NullInt64{Int64: edit.SignedTimestamp, Valid: edit.SignedTimestamp != 0},
+		EventVersion:    int64(edit.Version),
+		Tags:            tags,
+		SigScheme:       edit.SigScheme,
+		EditedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		Room:            roomName,
+		ID:              id,
+	})
+	if isUniqueViolation(err) {
+		return nil, services.ErrStaleRevision
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+	// The update only applies to a revision signed after the current one
+	if revised == 0 {
+		return nil, services.ErrStaleRevision
+	}
+
+	edited, err := queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	reactions, err := countReactions(ctx, queries, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	m := sqlcMessageToModel(edited)
+	m.Reactions = reactions
+	return m, nil
+}
+
+func (s *Store) GetMessageRevisions(ctx context.Context, roomName, id string) ([]models.MessageRevision, error) {
+	if _, err := s.GetMessage(ctx, roomName, id); err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.GetMessageRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message revisions: %w", err)
+	}
+
+	revisions := make([]models.MessageRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, sqlcRevisionToModel(row))
+	}
+	return revisions, nil
+}
+
+func (s *Store) GetReplies(ctx context.Context, roomName, id string, limit int) ([]models.Message, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.queries.GetReplies(ctx, sqlc.GetRepliesParams{
+		Room:    roomName,
+		ReplyTo: sql.NullString{String: id, Valid: true},
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replies: %w", err)
+	}
+
+	// Rows come newest first; return them oldest first like GetMessages.
+	replies := make([]models.Message, len(rows))
+	for i, row := range rows {
+		replies[len(rows)-1-i] = *sqlcMessageToModel(row)
+	}
+	if err := s.attachReactions(ctx, replies); err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
+
+func (s *Store) AddReaction(ctx context.Context, roomName string, reaction models.Reaction) ([]models.ReactionCount, error) {
+	tags, err := encodeTags(reaction.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	msg, err := queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: reaction.MessageID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if msg.DeletedAt.Valid {
+		return nil, services.ErrMessageDeleted
+	}
+
+	err = queries.CreateReaction(ctx, sqlc.CreateReactionParams{
+		MessageID:       reaction.MessageID,
+		Pubkey:          reaction.Pubkey,
+		Emoji:           reaction.Emoji,
+		Signature:       reaction.Signature,
+		SignedTimestamp: reaction.SignedTimestamp,
+		EventVersion:    int64(reaction.Version),
+		Tags:            tags,
+		SigScheme:       reaction.SigScheme,
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save reaction: %w", err)
+	}
+	counts, err := countReactions(ctx, queries, reaction.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, roomName, messageID, pubkey, emoji string) ([]models.ReactionCount, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	if _, err := queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: messageID}); errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	removed, err := queries.DeleteReaction(ctx, sqlc.DeleteReactionParams{MessageID: messageID, Pubkey: pubkey, Emoji: emoji})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	if removed == 0 {
+		return nil, services.ErrReactionNotFound
+	}
+	counts, err := countReactions(ctx, queries, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if counts == nil {
+		counts = []models.ReactionCount{}
+	}
+	return counts, nil
+}
+
+func (s *Store) GetReactions(ctx context.Context, roomName, messageID string) ([]models.Reaction, error) {
+	if _, err := s.queries.GetMessage(ctx, sqlc.GetMessageParams{Room: roomName, ID: messageID}); errors.Is(err, sql.ErrNoRows) {
+		return nil, services.ErrMessageNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	rows, err := s.queries.GetReactions(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+
+	reactions := make([]models.Reaction, 0, len(rows))
+	for _, row := range rows {
+		reactions = append(reactions, sqlcReactionToModel(row))
+	}
+	return reactions, nil
+}
+
+// countReactions returns the reaction counts of a message, nil when it has none.
+func countReactions(ctx context.Context, queries *sqlc.Queries, messageID string) ([]models.ReactionCount, error) {
+	rows, err := queries.CountReactions(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	var counts []models.ReactionCount
+	for _, row := range rows {
+		counts = append(counts, models.ReactionCount{Emoji: row.Emoji, Count: int(row.Count)})
+	}
+	return counts, nil
+}
+
+// attachReactions sets the reaction counts of messages.
+func (s *Store) attachReactions(ctx context.Context, messages []models.Message) error {
+	for i := range messages {
+		counts, err := countReactions(ctx, s.queries, messages[i].ID)
+		if err != nil {
+			return err
+		}
+		messages[i].Reactions = counts
+	}
+	return nil
+}
+
+func (s *Store) SaveDirectMessage(ctx context.Context, dm models.DirectMessage) (*models.DirectMessage, error) {
+	tags, err := encodeTags(dm.Tags)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.queries.CreateDirectMessage(ctx, sqlc.CreateDirectMessageParams{
+		ID:              uuid.New().String(),
+		Sender:          dm.Sender,
+		Recipient:       dm.Recipient,
+		Content:         dm.Content,
+		Timestamp:       time.Now(),
+		Signature:       dm.Signature,
+		SignedTimestamp: dm.SignedTimestamp,
+		EventVersion:    int64(dm.Version),
+		Tags:            tags,
+	})
+	if isUniqueViolation(err) {
+		return nil, services.ErrDuplicateMessage
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save direct message: %w", err)
+	}
+	saved := sqlcDirectMessageToModel(row)
+	return &saved, nil
+}
+
+func (s *Store) GetDirectMessages(ctx context.Context, pubkey, peer string, params services.MessageQueryParams) ([]models.DirectMessage, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	before := time.Now().Add(time.Second)
+	if params.Before != nil {
+		before = *params.Before
+	}
+
+	rows, err := s.queries.GetDirectMessages(ctx, sqlc.GetDirectMessagesParams{
+		Pubkey: pubkey,
+		Peer:   peer,
+		Before: before,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get direct messages: %w", err)
+	}
+
+	// Results come DESC from DB; reverse to return ASC to callers
+	dms := make([]models.DirectMessage, len(rows))
+	for i, row := range rows {
+		dms[len(rows)-1-i] = sqlcDirectMessageToModel(row)
+	}
+	return dms, nil
+}
+
+func (s *Store) CreateSanction(ctx context.Context, sanction models.Sanction) (*models.Sanction, error) {
+	params := sqlc.CreateSanctionParams{
+		ID:        uuid.New().String(),
+		Kind:      string(sanction.Kind),
+		Room:      sanction.Room,
+		Pubkey:    sanction.Pubkey,
+		Ip:        sanction.IP,
+		Reason:    sanction.Reason,
+		CreatedBy: sanction.CreatedBy,
+		CreatedAt: time.Now(),
+	}
+	if sanction.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *sanction.ExpiresAt, Valid: true}
+	}
+	row, err := s.queries.CreateSanction(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sanction: %w", err)
+	}
+	return sqlcSanctionToModel(row), nil
+}
+
+func (s *Store) GetSanctions(ctx context.Context, room string) ([]models.Sanction, error) {
+	rows, err := s.queries.GetSanctions(ctx, room)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sanctions: %w", err)
+	}
+	sanctions := make([]models.Sanction, 0, len(rows))
+	for _, row := range rows {
+		sanctions = append(sanctions, *sqlcSanctionToModel(row))
+	}
+	return sanctions, nil
+}
+
+func (s *Store) DeleteSanction(ctx context.Context, room, id string) error {
+	deleted, err := s.queries.DeleteSanction(ctx, sqlc.DeleteSanctionParams{Room: room, ID: id})
+	if err != nil {
+		return fmt.Errorf("failed to delete sanction: %w", err)
+	}
+	if deleted == 0 {
+		return services.ErrSanctionNotFound
+	}
+	return nil
+}
+
+func sqlcSanctionToModel(row sqlc.Sanction) *models.Sanction {
+	sanction := &models.Sanction{
+		ID:        row.ID,
+		Kind:      models.SanctionKind(row.Kind),
+		Room:      row.Room,
+		Pubkey:    row.Pubkey,
+		IP:        row.Ip,
+		Reason:    row.Reason,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.ExpiresAt.Valid {
+		sanction.ExpiresAt = &row.ExpiresAt.Time
+	}
+	return sanction
+}
+
+func (s *Store) CreateInvite(ctx context.Context, invite models.Invite, tokenHash string) (*models.Invite, error) {
+	params := sqlc.CreateInviteParams{
+		ID:        uuid.New().String(),
+		Room:      invite.Room,
+		TokenHash: tokenHash,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: time.Now(),
+		MaxUses:   int64(invite.MaxUses),
+	}
+	if invite.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *invite.ExpiresAt, Valid: true}
+	}
+	row, err := s.queries.CreateInvite(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+	return sqlcInviteToModel(sqlc.GetInvitesRow{
+		ID:        row.ID,
+		Room:      row.Room,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+		ExpiresAt: row.ExpiresAt,
+		MaxUses:   row.MaxUses,
+	}), nil
+}
+
+func (s *Store) GetInvites(ctx context.Context, room string) ([]models.Invite, error) {
+	rows, err := s.queries.GetInvites(ctx, room)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invites: %w", err)
+	}
+	invites := make([]models.Invite, 0, len(rows))
+	for _, row := range rows {
+		invites = append(invites, *sqlcInviteToModel(row))
+	}
+	return invites, nil
+}
+
+func (s *Store) DeleteInvite(ctx context.Context, room, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	deleted, err := queries.DeleteInvite(ctx, sqlc.DeleteInviteParams{Room: room, ID: id})
+	if err != nil {
+		return fmt.Errorf("failed to delete invite: %w", err)
+	}
+	if deleted == 0 {
+		return services.ErrInviteNotFound
+	}
+	if err := queries.DeleteInviteUses(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete invite uses: %w", err)
+	}
+	return tx.Commit()
+}
+
+func (s *Store) RedeemInvite(ctx context.Context, room, tokenHash, pubkey string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	queries := s.queries.WithTx(tx)
+	// Lock the invite first, so that its uses read below include those of
+	// concurrent redemptions, which wait for this transaction.
+	if _, err := queries.LockInvite(ctx, sqlc.LockInviteParams{Room: room, TokenHash: tokenHash}); errors.Is(err, sql.ErrNoRows) {
+		return services.ErrInviteInvalid
+	} else if err != nil {
+		return fmt.Errorf("failed to lock invite: %w", err)
+	}
+	row, err := queries.GetInviteByTokenHash(ctx, sqlc.GetInviteByTokenHashParams{Room: room, TokenHash: tokenHash})
+	if errors.Is(err, sql.ErrNoRows) {
+		return services.ErrInviteInvalid
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get invite: %w", err)
+	}
+	invite := sqlcInviteToModel(sqlc.GetInvitesRow(row))
+	if invite.Expired(time.Now()) {
+		return services.ErrInviteInvalid
+	}
+	redeemed, err := queries.InviteRedeemedBy(ctx, sqlc.InviteRedeemedByParams{InviteID: invite.ID, Pubkey: pubkey})
+	if err != nil {
+		return fmt.Errorf("failed to check invite use: %w", err)
+	}
+	if redeemed {
+		return nil
+	}
+	if invite.UsedUp() {
+		return services.ErrInviteInvalid
+	}
+	if err := queries.CreateInviteUse(ctx, sqlc.CreateInviteUseParams{InviteID: invite.ID, Pubkey: pubkey, CreatedAt: time.Now()}); err != nil {
+		return fmt.Errorf("failed to record invite use: %w", err)
+	}
+	return tx.Commit()
+}
+
+func sqlcInviteToModel(row sqlc.GetInvitesRow) *models.Invite {
+	invite := &models.Invite{
+		ID:        row.ID,
+		Room:      row.Room,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+		MaxUses:   int(row.MaxUses),
+		Uses:      int(row.Uses),
+	}
+	if row.ExpiresAt.Valid {
+		invite.ExpiresAt = &row.ExpiresAt.Time
+	}
+	return invite
+}
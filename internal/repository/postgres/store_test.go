@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"bytes"
+	"database/sql"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+
+	"github.com/EwenQuim/microchat/internal/repository/repositorytest"
+	"github.com/EwenQuim/microchat/internal/services"
+)
+
+// TestConformance runs against the database of MICROCHAT_TEST_DATABASE_URL,
+// whose tables it empties before each test, or else against an embedded
+// Postgres it starts for the test.
+func TestConformance(t *testing.T) {
+	db := openTestDB(t)
+	repositorytest.Run(t, func(t *testing.T) services.Repository {
+		_, err := db.Exec(`TRUNCATE users, rooms, messages, message_revisions, reactions, direct_messages,
+			room_roles, room_members, sanctions, room_invites, room_invite_uses CASCADE`)
+		if err != nil {
+			t.Fatalf("failed to empty tables: %v", err)
+		}
+		return NewStore(db)
+	})
+}
+
+// openTestDB returns the database of MICROCHAT_TEST_DATABASE_URL, or else of
+// an embedded Postgres started for the test, migrated and closed at the end
+// of the test.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	databaseURL := os.Getenv("MICROCHAT_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = startEmbeddedPostgres(t)
+	}
+	db, err := InitDB(databaseURL)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(db) })
+	return db
+}
+
+// startEmbeddedPostgres starts a Postgres on a free port, stopped at the end
+// of the test, and returns its URL. Its binaries are downloaded once into
+// ~/.embedded-postgres-go. When it cannot start, as without network or as
+// root, the test is skipped, except in CI where it fails.
+func startEmbeddedPostgres(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	port := uint32(listener.Addr().(*net.TCPAddr).Port)
+	_ = listener.Close()
+
+	var logs bytes.Buffer
+	runtimePath := t.TempDir()
+	config := embeddedpostgres.DefaultConfig().
+		Port(port).
+		Database("microchat").
+		RuntimePath(runtimePath).
+		DataPath(filepath.Join(runtimePath, "data")).
+		Logger(&logs)
+	database := embeddedpostgres.NewDatabase(config)
+	if err := database.Start(); err != nil {
+		if os.Getenv("CI") != "" {
+			t.Fatalf("failed to start embedded Postgres: %v\n%s", err, logs.String())
+		}
+		t.Skipf("failed to start embedded Postgres, set MICROCHAT_TEST_DATABASE_URL to use another one: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := database.Stop(); err != nil {
+			t.Errorf("failed to stop embedded Postgres: %v", err)
+		}
+	})
+	return config.GetConnectionURL() + "?sslmode=disable"
+}
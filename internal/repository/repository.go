@@ -7,14 +7,27 @@ import (
 	"os"
 
 	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/repository/postgres"
 	"github.com/EwenQuim/microchat/internal/repository/sqlite"
 	"github.com/EwenQuim/microchat/internal/services"
 )
 
-// NewRepository creates a new repository based on the DB_PATH environment variable.
-// If DB_PATH is ":memory:" or empty, it uses in-memory storage.
-// Otherwise, it uses SQLite with the specified path.
+// NewRepository creates a new repository based on the DATABASE_URL and DB_PATH
+// environment variables. If DATABASE_URL is set, it uses PostgreSQL, which
+// several server replicas can share.
+// Otherwise, if DB_PATH is ":memory:" or empty, it uses in-memory storage,
+// and SQLite with the specified path if not.
 func NewRepository() (services.Repository, error) {
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		slog.Info("Using PostgreSQL database") // the URL may hold a password
+		db, err := postgres.InitDB(databaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize PostgreSQL database: %w", err)
+		}
+
+		return postgres.NewStore(db), nil
+	}
+
 	dbPath := cmp.Or(os.Getenv("DB_PATH"), ":memory:")
 
 	if dbPath == ":memory:" {
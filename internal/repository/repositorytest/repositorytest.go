@@ -0,0 +1,1328 @@
+// Package repositorytest provides a conformance suite for implementations of
+// services.Repository, so that every storage backend behaves the same way.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/services"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// Run runs the conformance suite against the repositories returned by
+// newRepo, which must return an empty repository on each call.
+func Run(t *testing.T, newRepo func(t *testing.T) services.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo services.Repository)
+	}{
+		{"GetMessages_DefaultLimit", testGetMessagesDefaultLimit},
+		{"GetMessages_Limit", testGetMessagesLimit},
+		{"GetMessages_BeforeIsExclusive", testGetMessagesBeforeIsExclusive},
+		{"GetMessages_BeforeInOtherZone", testGetMessagesBeforeInOtherZone},
+		{"GetMessages_EmptyRoom", testGetMessagesEmptyRoom},
+		{"GetMessages_Cursors", testGetMessagesCursors},
+		{"GetMessages_CursorOfMissingMessage", testGetMessagesCursorOfMissingMessage},
+		{"SaveMessage_AssignsIDAndTimestamp", testSaveMessageAssignsIDAndTimestamp},
+		{"SaveMessage_CreatesRoom", testSaveMessageCreatesRoom},
+		{"SaveMessage_RegistersUser", testSaveMessageRegistersUser},
+		{"SaveMessage_DuplicateSignature", testSaveMessageDuplicateSignature},
+		{"CreateRoom_Duplicate", testCreateRoomDuplicate},
+		{"RoomPassword", testRoomPassword},
+		{"RoomPassword_Empty", testRoomPasswordEmpty},
+		{"SetRoomPassword", testSetRoomPassword},
+		{"GetRooms_LastMessageFirst", testGetRoomsLastMessageFirst},
+		{"GetRooms_Limit", testGetRoomsLimit},
+		{"SearchRooms", testSearchRooms},
+		{"GetSearchableRooms", testGetSearchableRooms},
+		{"SearchMessages", testSearchMessages},
+		{"SearchMessages_Edited", testSearchMessagesEdited},
+		{"SearchMessages_Deleted", testSearchMessagesDeleted},
+		{"SearchMessages_Pruned", testSearchMessagesPruned},
+		{"SearchMessages_BeforeInOtherZone", testSearchMessagesBeforeInOtherZone},
+		{"DeleteMessage", testDeleteMessage},
+		{"EditMessage", testEditMessage},
+		{"GetMessageRevisions", testGetMessageRevisions},
+		{"Reactions", testReactions},
+		{"DirectMessages", testDirectMessages},
+		{"Sanctions", testSanctions},
+		{"Invites", testInvites},
+		{"RedeemInvite_MaxUses", testRedeemInviteMaxUses},
+		{"RoomMembers", testRoomMembers},
+		{"RoomRoles", testRoomRoles},
+		{"RegisterUser", testRegisterUser},
+		{"VerifyUser", testVerifyUser},
+		{"PostCount", testPostCount},
+		{"RoomRetention", testRoomRetention},
+		{"PruneMessages_Keep", testPruneMessagesKeep},
+		{"PruneMessages_Before", testPruneMessagesBefore},
+		{"PruneMessages_StricterBoundWins", testPruneMessagesStricterBoundWins},
+		{"PruneMessages_NoBound", testPruneMessagesNoBound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+// save stores a message in room and returns it. Timestamps are assigned by
+// the repository, so it waits a little after saving: consecutive messages
+// then never share a timestamp, even at microsecond precision.
+func save(t *testing.T, repo services.Repository, room string) *models.Message {
+	t.Helper()
+	msg, err := repo.SaveMessage(context.Background(), models.Message{Room: room, User: "user", Content: "content"})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	return msg
+}
+
+// saveContent stores a message with content in room and returns it.
+func saveContent(t *testing.T, repo services.Repository, room, content string) *models.Message {
+	t.Helper()
+	msg, err := repo.SaveMessage(context.Background(), models.Message{Room: room, User: "user", Content: content, SignedTimestamp: 1})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	return msg
+}
+
+// saveN stores n messages in room and returns them, oldest first.
+func saveN(t *testing.T, repo services.Repository, room string, n int) []*models.Message {
+	t.Helper()
+	msgs := make([]*models.Message, n)
+	for i := range msgs {
+		msgs[i] = save(t, repo, room)
+	}
+	return msgs
+}
+
+func getMessages(t *testing.T, repo services.Repository, room string, params services.MessageQueryParams) []models.Message {
+	t.Helper()
+	msgs, err := repo.GetMessages(context.Background(), room, params)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	return msgs
+}
+
+// checkIDs fails unless got holds the messages of want, in the same order.
+func checkIDs(t *testing.T, got []models.Message, want []*models.Message) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].ID != want[i].ID {
+			t.Errorf("message %d: got ID %s, want %s", i, got[i].ID, want[i].ID)
+		}
+	}
+}
+
+func testGetMessagesDefaultLimit(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 55)
+
+	// The latest 50, oldest first
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs[5:])
+}
+
+func testGetMessagesLimit(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+	saveN(t, repo, "other", 2)
+
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Limit: 2}), msgs[3:])
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Limit: 10}), msgs)
+}
+
+func testGetMessagesBeforeIsExclusive(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+
+	before := msgs[3].Timestamp
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Limit: 2, Before: &before}), msgs[1:3])
+
+	before = msgs[0].Timestamp
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Before: &before}), nil)
+}
+
+func testGetMessagesBeforeInOtherZone(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 3)
+
+	// Cursors parsed from a request are not in the zone of the server
+	for _, zone := range []*time.Location{time.UTC, time.FixedZone("UTC+5", 5*60*60), time.FixedZone("UTC-5", -5*60*60)} {
+		before := msgs[2].Timestamp.In(zone)
+		checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Before: &before}), msgs[:2])
+	}
+}
+
+func testGetMessagesEmptyRoom(t *testing.T, repo services.Repository) {
+	msgs := getMessages(t, repo, "noroom", services.MessageQueryParams{})
+	if msgs == nil || len(msgs) != 0 {
+		t.Errorf("got %v, want an empty slice", msgs)
+	}
+}
+
+func testGetMessagesCursors(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+	saveN(t, repo, "other", 2)
+
+	// Cursors go through their encoding, as they do through clients
+	cursor := func(msg *models.Message) *services.MessageCursor {
+		t.Helper()
+		parsed, err := services.ParseMessageCursor(services.CursorOf(*msg).String())
+		if err != nil {
+			t.Fatalf("ParseMessageCursor: %v", err)
+		}
+		return &parsed
+	}
+
+	// The messages closest to the cursor, which is excluded, oldest first
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Limit: 2, BeforeCursor: cursor(msgs[3])}), msgs[1:3])
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{Limit: 2, AfterCursor: cursor(msgs[1])}), msgs[2:4])
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{BeforeCursor: cursor(msgs[4])}), msgs[:4])
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{AfterCursor: cursor(msgs[0])}), msgs[1:])
+
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{BeforeCursor: cursor(msgs[0])}), nil)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{AfterCursor: cursor(msgs[4])}), nil)
+}
+
+func testGetMessagesCursorOfMissingMessage(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 4)
+
+	// A message that is gone is placed by the timestamp of its cursor
+	cursor := &services.MessageCursor{Timestamp: msgs[1].Timestamp.Add(500 * time.Microsecond), ID: "gone"}
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{BeforeCursor: cursor}), msgs[:2])
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{AfterCursor: cursor}), msgs[2:])
+}
+
+func testSaveMessageAssignsIDAndTimestamp(t *testing.T, repo services.Repository) {
+	start := time.Now().Add(-time.Second)
+	first, second := save(t, repo, "room"), save(t, repo, "room")
+
+	if first.ID == "" || first.ID == second.ID {
+		t.Errorf("got IDs %q and %q, want distinct IDs", first.ID, second.ID)
+	}
+	if first.Timestamp.Before(start) || !second.Timestamp.After(first.Timestamp) {
+		t.Errorf("got timestamps %v and %v, want increasing timestamps after %v", first.Timestamp, second.Timestamp, start)
+	}
+
+	got, err := repo.GetMessage(context.Background(), "room", first.ID)
+	if err != nil {
+		t.Fatalf("GetMessage: %v", err)
+	}
+	if got.Content != "content" || got.User != "user" || !got.Timestamp.Equal(first.Timestamp) {
+		t.Errorf("got %+v, want the saved message %+v", got, first)
+	}
+}
+
+func testSaveMessageCreatesRoom(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.GetRoom(ctx, "room"); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Fatalf("GetRoom before any message: got %v, want ErrRoomNotFound", err)
+	}
+
+	save(t, repo, "room")
+
+	room, err := repo.GetRoom(ctx, "room")
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if room.HasPassword || room.Encrypted || room.Private {
+		t.Errorf("got %+v, want an auto-created room to be public", room)
+	}
+	if err := repo.ValidateRoomPassword(ctx, "room", ""); err != nil {
+		t.Errorf("ValidateRoomPassword: got %v, want nil for a public room", err)
+	}
+}
+
+func testSaveMessageRegistersUser(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.GetUser(ctx, "pk1"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Fatalf("GetUser before any message: got %v, want ErrUserNotFound", err)
+	}
+
+	if _, err := repo.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "hi", Pubkey: "pk1"}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	user, err := repo.GetUser(ctx, "pk1")
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if user.PublicKey != "pk1" || user.Verified {
+		t.Errorf("got %+v, want an unverified user pk1", user)
+	}
+
+	// A later message keeps the user as it is
+	if err := repo.VerifyUser(ctx, "pk1"); err != nil {
+		t.Fatalf("VerifyUser: %v", err)
+	}
+	if _, err := repo.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "again", Pubkey: "pk1"}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	if user, err := repo.GetUser(ctx, "pk1"); err != nil || !user.Verified {
+		t.Errorf("got %+v, %v, want pk1 to stay verified", user, err)
+	}
+
+	// Unsigned messages register no one
+	save(t, repo, "room")
+	users, err := repo.GetAllUsers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllUsers: %v", err)
+	}
+	if len(users) != 1 {
+		t.Errorf("got %d users, want 1", len(users))
+	}
+}
+
+func testSaveMessageDuplicateSignature(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	msg := models.Message{Room: "room", User: "alice", Content: "hi", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1}
+	if _, err := repo.SaveMessage(ctx, msg); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+
+	// Replayed, into another room
+	msg.Room = "other"
+	if _, err := repo.SaveMessage(ctx, msg); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replay: got %v, want ErrDuplicateMessage", err)
+	}
+
+	// The same signature from another key is another message
+	msg.Pubkey = "pk2"
+	if _, err := repo.SaveMessage(ctx, msg); err != nil {
+		t.Errorf("other pubkey: %v", err)
+	}
+}
+
+func testCreateRoomDuplicate(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", "", false); err == nil {
+		t.Error("creating an existing room: got nil, want an error")
+	}
+
+	save(t, repo, "auto")
+	if _, err := repo.CreateRoom(ctx, "auto", nil, "", "", false); err == nil {
+		t.Error("creating an auto-created room: got nil, want an error")
+	}
+}
+
+func testRoomPassword(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	created, err := repo.CreateRoom(ctx, "room", new("secret"), "", "", false)
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if !created.HasPassword {
+		t.Error("CreateRoom: got HasPassword false, want true")
+	}
+	room, err := repo.GetRoom(ctx, "room")
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if !room.HasPassword {
+		t.Error("GetRoom: got HasPassword false, want true")
+	}
+
+	if err := repo.ValidateRoomPassword(ctx, "room", "secret"); err != nil {
+		t.Errorf("right password: got %v, want nil", err)
+	}
+	for _, password := range []string{"wrong", "", "Secret"} {
+		if err := repo.ValidateRoomPassword(ctx, "room", password); !errors.Is(err, crypto.ErrInvalidPassword) {
+			t.Errorf("password %q: got %v, want ErrInvalidPassword", password, err)
+		}
+	}
+	if err := repo.ValidateRoomPassword(ctx, "noroom", "secret"); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("unknown room: got %v, want ErrRoomNotFound", err)
+	}
+}
+
+func testRoomPasswordEmpty(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	created, err := repo.CreateRoom(ctx, "room", new(""), "", "", false)
+	if err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	room, err := repo.GetRoom(ctx, "room")
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if created.HasPassword || room.HasPassword {
+		t.Errorf("got HasPassword %v on creation and %v after, want an empty password to make the room public", created.HasPassword, room.HasPassword)
+	}
+	if err := repo.ValidateRoomPassword(ctx, "room", "anything"); err != nil {
+		t.Errorf("ValidateRoomPassword: got %v, want nil for a public room", err)
+	}
+}
+
+func testSetRoomPassword(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	save(t, repo, "room")
+
+	if err := repo.SetRoomPassword(ctx, "room", new("secret")); err != nil {
+		t.Fatalf("SetRoomPassword: %v", err)
+	}
+	if err := repo.ValidateRoomPassword(ctx, "room", "wrong"); !errors.Is(err, crypto.ErrInvalidPassword) {
+		t.Errorf("wrong password: got %v, want ErrInvalidPassword", err)
+	}
+	if err := repo.ValidateRoomPassword(ctx, "room", "secret"); err != nil {
+		t.Errorf("right password: got %v, want nil", err)
+	}
+
+	for _, password := range []*string{nil, new("")} {
+		if err := repo.SetRoomPassword(ctx, "room", new("secret")); err != nil {
+			t.Fatalf("SetRoomPassword: %v", err)
+		}
+		if err := repo.SetRoomPassword(ctx, "room", password); err != nil {
+			t.Fatalf("SetRoomPassword: %v", err)
+		}
+		if room, err := repo.GetRoom(ctx, "room"); err != nil || room.HasPassword {
+			t.Errorf("got %+v, %v, want the room public again", room, err)
+		}
+	}
+
+	if err := repo.SetRoomPassword(ctx, "noroom", new("secret")); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("unknown room: got %v, want ErrRoomNotFound", err)
+	}
+}
+
+func testGetRoomsLastMessageFirst(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	for _, name := range []string{"quiet-b", "quiet-a"} {
+		if _, err := repo.CreateRoom(ctx, name, nil, "", "", false); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+	save(t, repo, "old")
+	save(t, repo, "recent")
+	last, err := repo.SaveMessage(ctx, models.Message{Room: "old", User: "bob", Content: "latest"})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+
+	rooms, err := repo.GetRooms(ctx)
+	if err != nil {
+		t.Fatalf("GetRooms: %v", err)
+	}
+	// Most recently active first, then rooms without messages by name
+	want := []string{"old", "recent", "quiet-a", "quiet-b"}
+	if got := roomNames(rooms); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got rooms %v, want %v", got, want)
+	}
+
+	old := rooms[0]
+	if old.LastMessageContent == nil || *old.LastMessageContent != "latest" ||
+		old.LastMessageUser == nil || *old.LastMessageUser != "bob" {
+		t.Errorf("got last message %v by %v, want %q by %q", old.LastMessageContent, old.LastMessageUser, "latest", "bob")
+	}
+	if old.LastMessageTimestamp == nil || *old.LastMessageTimestamp != last.Timestamp.Format(time.RFC3339) {
+		t.Errorf("got last message timestamp %v, want %s", old.LastMessageTimestamp, last.Timestamp.Format(time.RFC3339))
+	}
+	quiet := rooms[3]
+	if quiet.LastMessageContent != nil || quiet.LastMessageUser != nil || quiet.LastMessageTimestamp != nil {
+		t.Errorf("got last message fields %+v, want none for a room without messages", quiet)
+	}
+}
+
+func testGetRoomsLimit(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	for i := range 105 {
+		if _, err := repo.CreateRoom(ctx, fmt.Sprintf("room-%03d", i), nil, "", "", false); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+
+	rooms, err := repo.GetRooms(ctx)
+	if err != nil {
+		t.Fatalf("GetRooms: %v", err)
+	}
+	if len(rooms) != 100 {
+		t.Errorf("got %d rooms, want 100", len(rooms))
+	}
+}
+
+func testGetSearchableRooms(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	password := "secret"
+	for _, room := range []struct {
+		name     string
+		password *string
+		keySalt  string
+		private  bool
+	}{
+		{"protected", &password, "", false},
+		{"encrypted", &password, "salt", false},
+		{"private", nil, "", true},
+	} {
+		if _, err := repo.CreateRoom(ctx, room.name, room.password, room.keySalt, "owner", room.private); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+	// Unlike GetRooms, not limited to 100 rooms
+	for i := range 105 {
+		if _, err := repo.CreateRoom(ctx, fmt.Sprintf("room-%03d", i), nil, "", "", false); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+
+	rooms, err := repo.GetSearchableRooms(ctx)
+	if err != nil {
+		t.Fatalf("GetSearchableRooms: %v", err)
+	}
+	if len(rooms) != 106 {
+		t.Fatalf("got %d rooms, want 106", len(rooms))
+	}
+	if rooms[0].Name != "private" || !rooms[0].Private {
+		t.Errorf("first room: got %+v, want the private room", rooms[0])
+	}
+	if rooms[1].Name != "room-000" || rooms[1].Private || rooms[105].Name != "room-104" {
+		t.Errorf("got rooms %s, %s, ..., %s, want them by name", rooms[0].Name, rooms[1].Name, rooms[105].Name)
+	}
+}
+
+func testSearchRooms(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	for _, name := range []string{"golang", "Go-Nuts", "rust"} {
+		if _, err := repo.CreateRoom(ctx, name, nil, "", "", false); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+	save(t, repo, "golang")
+
+	rooms, err := repo.SearchRooms(ctx, "go")
+	if err != nil {
+		t.Fatalf("SearchRooms: %v", err)
+	}
+	// Case-insensitive, ordered as GetRooms
+	want := []string{"golang", "Go-Nuts"}
+	if got := roomNames(rooms); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got rooms %v, want %v", got, want)
+	}
+	if rooms[0].LastMessageContent == nil {
+		t.Error("got no last message, want the message of golang")
+	}
+
+	rooms, err = repo.SearchRooms(ctx, "")
+	if err != nil {
+		t.Fatalf("SearchRooms: %v", err)
+	}
+	if len(rooms) != 3 {
+		t.Errorf("empty query: got %d rooms, want 3", len(rooms))
+	}
+}
+
+func roomNames(rooms []models.Room) []string {
+	names := make([]string, len(rooms))
+	for i, room := range rooms {
+		names[i] = room.Name
+	}
+	return names
+}
+
+func testRegisterUser(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	registered, err := repo.RegisterUser(ctx, "pk1")
+	if err != nil {
+		t.Fatalf("RegisterUser: %v", err)
+	}
+	if registered.PublicKey != "pk1" || registered.Verified {
+		t.Errorf("got %+v, want an unverified user pk1", registered)
+	}
+	if _, err := repo.RegisterUser(ctx, "pk1"); err == nil {
+		t.Error("registering twice: got nil, want an error")
+	}
+
+	for _, get := range []func(context.Context, string) (*models.User, error){repo.GetUser, repo.GetUserByPublicKey} {
+		user, err := get(ctx, "pk1")
+		if err != nil {
+			t.Fatalf("getting a registered user: %v", err)
+		}
+		if user.PublicKey != "pk1" {
+			t.Errorf("got %+v, want pk1", user)
+		}
+		if _, err := get(ctx, "pk2"); !errors.Is(err, services.ErrUserNotFound) {
+			t.Errorf("unknown user: got %v, want ErrUserNotFound", err)
+		}
+	}
+
+	users, err := repo.GetAllUsers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllUsers: %v", err)
+	}
+	if len(users) != 1 || users[0].PublicKey != "pk1" {
+		t.Errorf("got %+v, want pk1 only", users)
+	}
+}
+
+func testVerifyUser(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.RegisterUser(ctx, "pk1"); err != nil {
+		t.Fatalf("RegisterUser: %v", err)
+	}
+
+	if err := repo.VerifyUser(ctx, "pk1"); err != nil {
+		t.Fatalf("VerifyUser: %v", err)
+	}
+	if user, err := repo.GetUser(ctx, "pk1"); err != nil || !user.Verified {
+		t.Errorf("got %+v, %v, want a verified user", user, err)
+	}
+	if err := repo.UnverifyUser(ctx, "pk1"); err != nil {
+		t.Fatalf("UnverifyUser: %v", err)
+	}
+	if user, err := repo.GetUser(ctx, "pk1"); err != nil || user.Verified {
+		t.Errorf("got %+v, %v, want an unverified user", user, err)
+	}
+
+	if err := repo.VerifyUser(ctx, "pk2"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("VerifyUser of an unknown user: got %v, want ErrUserNotFound", err)
+	}
+	if err := repo.UnverifyUser(ctx, "pk2"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("UnverifyUser of an unknown user: got %v, want ErrUserNotFound", err)
+	}
+}
+
+func testPostCount(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.RegisterUser(ctx, "quiet"); err != nil {
+		t.Fatalf("RegisterUser: %v", err)
+	}
+	for i, room := range []string{"a", "b", "b"} {
+		msg := models.Message{Room: room, User: "alice", Content: "hi", Pubkey: "pk1", Signature: fmt.Sprintf("sig%d", i), SignedTimestamp: 1}
+		if _, err := repo.SaveMessage(ctx, msg); err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+	}
+	save(t, repo, "a")
+
+	for pubkey, want := range map[string]int64{"pk1": 3, "quiet": 0} {
+		user, err := repo.GetUserWithPostCount(ctx, pubkey)
+		if err != nil {
+			t.Fatalf("GetUserWithPostCount(%s): %v", pubkey, err)
+		}
+		if user.PublicKey != pubkey || user.PostCount != want {
+			t.Errorf("got %s with %d posts, want %s with %d", user.PublicKey, user.PostCount, pubkey, want)
+		}
+	}
+	if _, err := repo.GetUserWithPostCount(ctx, "pk2"); !errors.Is(err, services.ErrUserNotFound) {
+		t.Errorf("unknown user: got %v, want ErrUserNotFound", err)
+	}
+}
+
+func testRoomRetention(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	policy := models.RetentionPolicy{MaxAge: 3600, MaxMessages: 100}
+	if err := repo.SetRoomRetention(ctx, "missing", policy); !errors.Is(err, services.ErrRoomNotFound) {
+		t.Errorf("SetRoomRetention of a missing room: got %v, want ErrRoomNotFound", err)
+	}
+
+	for _, name := range []string{"kept", "bounded"} {
+		if _, err := repo.CreateRoom(ctx, name, nil, "", "", false); err != nil {
+			t.Fatalf("CreateRoom: %v", err)
+		}
+	}
+	if err := repo.SetRoomRetention(ctx, "bounded", policy); err != nil {
+		t.Fatalf("SetRoomRetention: %v", err)
+	}
+
+	room, err := repo.GetRoom(ctx, "bounded")
+	if err != nil {
+		t.Fatalf("GetRoom: %v", err)
+	}
+	if room.Retention != policy {
+		t.Errorf("GetRoom: got retention %+v, want %+v", room.Retention, policy)
+	}
+	policies, err := repo.GetRetentionPolicies(ctx)
+	if err != nil {
+		t.Fatalf("GetRetentionPolicies: %v", err)
+	}
+	want := map[string]models.RetentionPolicy{"kept": {}, "bounded": policy}
+	if fmt.Sprint(policies) != fmt.Sprint(want) {
+		t.Errorf("GetRetentionPolicies: got %v, want %v", policies, want)
+	}
+}
+
+// prune prunes room and checks how many messages it deleted.
+func prune(t *testing.T, repo services.Repository, room string, before time.Time, keep int, want int64) {
+	t.Helper()
+	pruned, err := repo.PruneMessages(context.Background(), room, before, keep)
+	if err != nil {
+		t.Fatalf("PruneMessages: %v", err)
+	}
+	if pruned != want {
+		t.Errorf("PruneMessages: got %d messages pruned, want %d", pruned, want)
+	}
+}
+
+func testPruneMessagesKeep(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+	others := saveN(t, repo, "other", 2)
+
+	prune(t, repo, "room", time.Time{}, 2, 3)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs[3:])
+	checkIDs(t, getMessages(t, repo, "other", services.MessageQueryParams{}), others)
+
+	prune(t, repo, "room", time.Time{}, 2, 0)
+	prune(t, repo, "room", time.Time{}, 10, 0)
+}
+
+func testPruneMessagesBefore(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+
+	// Exclusive, whatever the zone of the time
+	before := msgs[2].Timestamp.In(time.FixedZone("UTC+5", 5*60*60))
+	prune(t, repo, "room", before, 0, 2)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs[2:])
+}
+
+func testPruneMessagesStricterBoundWins(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 5)
+
+	// Keeping 3 prunes more than the time
+	prune(t, repo, "room", msgs[1].Timestamp, 3, 2)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs[2:])
+
+	// The time prunes more than keeping 2
+	prune(t, repo, "room", msgs[4].Timestamp, 2, 2)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs[4:])
+}
+
+func testPruneMessagesNoBound(t *testing.T, repo services.Repository) {
+	msgs := saveN(t, repo, "room", 3)
+
+	prune(t, repo, "room", time.Time{}, 0, 0)
+	prune(t, repo, "missing", time.Now(), 1, 0)
+	checkIDs(t, getMessages(t, repo, "room", services.MessageQueryParams{}), msgs)
+}
+
+func searchMessages(t *testing.T, repo services.Repository, search services.MessageSearch) []models.Message {
+	t.Helper()
+	msgs, err := repo.SearchMessages(context.Background(), search)
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	return msgs
+}
+
+func testSearchMessages(t *testing.T, repo services.Repository) {
+	first := saveContent(t, repo, "room", "Hello world")
+	second := saveContent(t, repo, "room", "hello again")
+	saveContent(t, repo, "room", "goodbye")
+	saveContent(t, repo, "other", "hello from elsewhere")
+
+	// Newest first, each term matching the start of a word
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hel", Rooms: []string{"room"}}), []*models.Message{second, first})
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello wor", Rooms: []string{"room"}}), []*models.Message{first})
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "ello", Rooms: []string{"room"}}), nil)
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room"}, Limit: 1}), []*models.Message{second})
+	if got := searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room", "other"}}); len(got) != 3 {
+		t.Errorf("got %d messages across rooms, want 3", len(got))
+	}
+}
+
+func testSearchMessagesEdited(t *testing.T, repo services.Repository) {
+	msg := saveContent(t, repo, "room", "hello world")
+	if _, err := repo.EditMessage(context.Background(), "room", msg.ID, models.MessageRevision{Content: "goodbye moon", SignedTimestamp: 2}); err != nil {
+		t.Fatalf("EditMessage: %v", err)
+	}
+
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room"}}), nil)
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "moon", Rooms: []string{"room"}}), []*models.Message{msg})
+}
+
+func testSearchMessagesDeleted(t *testing.T, repo services.Repository) {
+	msg := saveContent(t, repo, "room", "hello world")
+	kept := saveContent(t, repo, "room", "hello there")
+	if _, err := repo.DeleteMessage(context.Background(), "room", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room"}}), []*models.Message{kept})
+}
+
+func testSearchMessagesPruned(t *testing.T, repo services.Repository) {
+	saveContent(t, repo, "room", "hello world")
+	kept := saveContent(t, repo, "room", "hello there")
+	prune(t, repo, "room", time.Time{}, 1, 1)
+
+	checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room"}}), []*models.Message{kept})
+}
+
+func testSearchMessagesBeforeInOtherZone(t *testing.T, repo services.Repository) {
+	msgs := []*models.Message{
+		saveContent(t, repo, "room", "hello one"),
+		saveContent(t, repo, "room", "hello two"),
+		saveContent(t, repo, "room", "hello three"),
+	}
+
+	for _, zone := range []*time.Location{time.UTC, time.FixedZone("UTC+5", 5*60*60), time.FixedZone("UTC-5", -5*60*60)} {
+		before := msgs[2].Timestamp.In(zone)
+		checkIDs(t, searchMessages(t, repo, services.MessageSearch{Query: "hello", Rooms: []string{"room"}, Before: &before}), []*models.Message{msgs[1], msgs[0]})
+	}
+}
+
+func testDeleteMessage(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	signed := models.Message{Room: "room", User: "alice", Content: "spam", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1}
+	msg, err := repo.SaveMessage(ctx, signed)
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	kept, err := repo.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "kept", Signature: "sig2", Pubkey: "pk1", SignedTimestamp: 2})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+
+	deleted, err := repo.DeleteMessage(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if deleted.ID != msg.ID || deleted.Content != "" || deleted.DeletedAt == nil {
+		t.Errorf("got %+v, want a tombstone of %s", deleted, msg.ID)
+	}
+	again, err := repo.DeleteMessage(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("DeleteMessage of a tombstone: %v", err)
+	}
+	if again.DeletedAt == nil || !again.DeletedAt.Equal(*deleted.DeletedAt) {
+		t.Errorf("deleting again moved DeletedAt from %v to %v", deleted.DeletedAt, again.DeletedAt)
+	}
+	for _, tt := range []struct{ room, id string }{{"room", "missing"}, {"other", msg.ID}} {
+		if _, err := repo.DeleteMessage(ctx, tt.room, tt.id); !errors.Is(err, services.ErrMessageNotFound) {
+			t.Errorf("DeleteMessage(%s, %s): got %v, want ErrMessageNotFound", tt.room, tt.id, err)
+		}
+	}
+
+	// Tombstones stay in place, but out of the filters
+	msgs := getMessages(t, repo, "room", services.MessageQueryParams{})
+	checkIDs(t, msgs, []*models.Message{msg, kept})
+	if msgs[0].Content != "" || msgs[0].DeletedAt == nil {
+		t.Errorf("GetMessages: got %+v, want the tombstone", msgs[0])
+	}
+	if got, err := repo.GetMessage(ctx, "room", msg.ID); err != nil || got.DeletedAt == nil {
+		t.Errorf("GetMessage: got %+v, %v, want the tombstone", got, err)
+	}
+	found, err := repo.FindMessages(ctx, services.MessageFilter{Rooms: []string{"room"}})
+	if err != nil {
+		t.Fatalf("FindMessages: %v", err)
+	}
+	checkIDs(t, found, []*models.Message{kept})
+
+	// Its signature cannot be replayed
+	if _, err := repo.SaveMessage(ctx, signed); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replay of a deleted message: got %v, want ErrDuplicateMessage", err)
+	}
+}
+
+func testEditMessage(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	msg, err := repo.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "helo", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+
+	edit := models.MessageRevision{
+		Content: "hello", Signature: "sig2", SignedTimestamp: 2, Version: 1,
+		Tags: [][]string{{"edit", msg.ID}}, SigScheme: crypto.SigSchnorr,
+	}
+	edited, err := repo.EditMessage(ctx, "room", msg.ID, edit)
+	if err != nil {
+		t.Fatalf("EditMessage: %v", err)
+	}
+	if edited.Content != "hello" || edited.Signature != "sig2" || edited.SignedTimestamp != 2 || edited.Revisions != 1 || edited.EditedAt == nil {
+		t.Errorf("got %+v, want the new revision", edited)
+	}
+	if edited.User != "alice" || edited.Pubkey != "pk1" || !edited.Timestamp.Equal(msg.Timestamp) {
+		t.Errorf("got %+v, want the author and timestamp of %+v", edited, msg)
+	}
+	got, err := repo.GetMessage(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("GetMessage: %v", err)
+	}
+	if got.Content != "hello" || got.Revisions != 1 || got.EditedAt == nil || got.SigScheme != crypto.SigSchnorr || fmt.Sprint(got.Tags) != fmt.Sprint(edit.Tags) {
+		t.Errorf("GetMessage: got %+v, want the new revision", got)
+	}
+
+	// Edits must be newer than the current revision
+	for _, ts := range []int64{1, 2} {
+		if _, err := repo.EditMessage(ctx, "room", msg.ID, models.MessageRevision{Content: "hi", Signature: "sig3", SignedTimestamp: ts}); !errors.Is(err, services.ErrStaleRevision) {
+			t.Errorf("edit signed at %d: got %v, want ErrStaleRevision", ts, err)
+		}
+	}
+	for _, tt := range []struct{ room, id string }{{"room", "missing"}, {"other", msg.ID}} {
+		if _, err := repo.EditMessage(ctx, tt.room, tt.id, models.MessageRevision{Content: "hi", SignedTimestamp: 3}); !errors.Is(err, services.ErrMessageNotFound) {
+			t.Errorf("EditMessage(%s, %s): got %v, want ErrMessageNotFound", tt.room, tt.id, err)
+		}
+	}
+
+	if _, err := repo.DeleteMessage(ctx, "room", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if _, err := repo.EditMessage(ctx, "room", msg.ID, models.MessageRevision{Content: "back", SignedTimestamp: 3}); !errors.Is(err, services.ErrMessageDeleted) {
+		t.Errorf("edit of a tombstone: got %v, want ErrMessageDeleted", err)
+	}
+}
+
+func testGetMessageRevisions(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	msg, err := repo.SaveMessage(ctx, models.Message{Room: "room", User: "alice", Content: "helo", Signature: "sig1", Pubkey: "pk1", SignedTimestamp: 1, Version: 1, Tags: [][]string{{"t", "x"}}})
+	if err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	if revisions, err := repo.GetMessageRevisions(ctx, "room", msg.ID); err != nil || len(revisions) != 0 {
+		t.Errorf("before any edit: got %+v, %v, want no revisions", revisions, err)
+	}
+
+	for i, content := range []string{"hello", "hello!"} {
+		if _, err := repo.EditMessage(ctx, "room", msg.ID, models.MessageRevision{Content: content, Signature: fmt.Sprintf("edit%d", i), SignedTimestamp: int64(i + 2)}); err != nil {
+			t.Fatalf("EditMessage: %v", err)
+		}
+	}
+
+	// The earlier revisions, oldest first, with their signed fields
+	revisions, err := repo.GetMessageRevisions(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("GetMessageRevisions: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("got %d revisions, want 2", len(revisions))
+	}
+	first := revisions[0]
+	if first.Revision != 0 || first.Content != "helo" || first.Signature != "sig1" || first.SignedTimestamp != 1 || first.Version != 1 || fmt.Sprint(first.Tags) != "[[t x]]" {
+		t.Errorf("revision 0: got %+v, want the original message", first)
+	}
+	if !first.CreatedAt.Equal(msg.Timestamp) {
+		t.Errorf("revision 0: got CreatedAt %v, want the timestamp of the message %v", first.CreatedAt, msg.Timestamp)
+	}
+	if second := revisions[1]; second.Revision != 1 || second.Content != "hello" || second.Signature != "edit0" || second.SignedTimestamp != 2 {
+		t.Errorf("revision 1: got %+v, want the first edit", second)
+	}
+
+	for _, tt := range []struct{ room, id string }{{"room", "missing"}, {"other", msg.ID}} {
+		if _, err := repo.GetMessageRevisions(ctx, tt.room, tt.id); !errors.Is(err, services.ErrMessageNotFound) {
+			t.Errorf("GetMessageRevisions(%s, %s): got %v, want ErrMessageNotFound", tt.room, tt.id, err)
+		}
+	}
+
+	// Deleting the message deletes its history
+	if _, err := repo.DeleteMessage(ctx, "room", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if revisions, err := repo.GetMessageRevisions(ctx, "room", msg.ID); err != nil || len(revisions) != 0 {
+		t.Errorf("after delete: got %+v, %v, want no revisions", revisions, err)
+	}
+}
+
+// react adds the reaction of pubkey with emoji to a message of room. Like
+// save, it waits a little so that reactions are ordered by time.
+func react(t *testing.T, repo services.Repository, room, id, pubkey, emoji string) []models.ReactionCount {
+	t.Helper()
+	counts, err := repo.AddReaction(context.Background(), room, models.Reaction{MessageID: id, Pubkey: pubkey, Emoji: emoji, Signature: pubkey + emoji, SignedTimestamp: 1})
+	if err != nil {
+		t.Fatalf("AddReaction: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	return counts
+}
+
+func testReactions(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	msg := save(t, repo, "room")
+	other := save(t, repo, "room")
+
+	react(t, repo, "room", msg.ID, "pk1", "👍")
+	react(t, repo, "room", msg.ID, "pk2", "🎉")
+	react(t, repo, "room", other.ID, "pk1", "🎉")
+	counts := react(t, repo, "room", msg.ID, "pk2", "👍")
+
+	// By emoji, in the order they were first used
+	want := []models.ReactionCount{{Emoji: "👍", Count: 2}, {Emoji: "🎉", Count: 1}}
+	if fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("AddReaction: got %v, want %v", counts, want)
+	}
+	// Reacting again changes nothing
+	if counts := react(t, repo, "room", msg.ID, "pk1", "👍"); fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("AddReaction again: got %v, want %v", counts, want)
+	}
+	got, err := repo.GetMessage(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("GetMessage: %v", err)
+	}
+	if fmt.Sprint(got.Reactions) != fmt.Sprint(want) {
+		t.Errorf("GetMessage: got reactions %v, want %v", got.Reactions, want)
+	}
+	if msgs := getMessages(t, repo, "room", services.MessageQueryParams{}); fmt.Sprint(msgs[0].Reactions) != fmt.Sprint(want) {
+		t.Errorf("GetMessages: got reactions %v, want %v", msgs[0].Reactions, want)
+	}
+
+	reactions, err := repo.GetReactions(ctx, "room", msg.ID)
+	if err != nil {
+		t.Fatalf("GetReactions: %v", err)
+	}
+	var gotReactions []string
+	for _, r := range reactions {
+		gotReactions = append(gotReactions, r.Pubkey+" "+r.Emoji)
+		if r.MessageID != msg.ID || r.Signature != r.Pubkey+r.Emoji || r.SignedTimestamp != 1 || r.CreatedAt.IsZero() {
+			t.Errorf("GetReactions: got %+v, want the stored reaction", r)
+		}
+	}
+	if want := []string{"pk1 👍", "pk2 🎉", "pk2 👍"}; fmt.Sprint(gotReactions) != fmt.Sprint(want) {
+		t.Errorf("GetReactions: got %v, want %v", gotReactions, want)
+	}
+
+	counts, err = repo.RemoveReaction(ctx, "room", msg.ID, "pk2", "🎉")
+	if err != nil {
+		t.Fatalf("RemoveReaction: %v", err)
+	}
+	if fmt.Sprint(counts) != fmt.Sprint(want[:1]) {
+		t.Errorf("RemoveReaction: got %v, want %v", counts, want[:1])
+	}
+	if _, err := repo.RemoveReaction(ctx, "room", msg.ID, "pk2", "🎉"); !errors.Is(err, services.ErrReactionNotFound) {
+		t.Errorf("RemoveReaction again: got %v, want ErrReactionNotFound", err)
+	}
+	if _, err := repo.AddReaction(ctx, "room", models.Reaction{MessageID: "missing", Pubkey: "pk1", Emoji: "👍"}); !errors.Is(err, services.ErrMessageNotFound) {
+		t.Errorf("reaction to a missing message: got %v, want ErrMessageNotFound", err)
+	}
+
+	// Deleting the message deletes its reactions
+	if _, err := repo.DeleteMessage(ctx, "room", msg.ID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+	if reactions, err := repo.GetReactions(ctx, "room", msg.ID); err != nil || len(reactions) != 0 {
+		t.Errorf("after delete: got %+v, %v, want no reactions", reactions, err)
+	}
+	if _, err := repo.AddReaction(ctx, "room", models.Reaction{MessageID: msg.ID, Pubkey: "pk1", Emoji: "👍"}); !errors.Is(err, services.ErrMessageDeleted) {
+		t.Errorf("reaction to a tombstone: got %v, want ErrMessageDeleted", err)
+	}
+	if reactions, err := repo.GetReactions(ctx, "room", other.ID); err != nil || len(reactions) != 1 {
+		t.Errorf("other message: got %+v, %v, want its reaction kept", reactions, err)
+	}
+}
+
+func testDirectMessages(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	alice, bob, carol := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+
+	for i, dm := range []models.DirectMessage{
+		{Sender: "02" + alice, Recipient: bob, Signature: "s1"},
+		{Sender: bob, Recipient: "03" + alice, Signature: "s2"},
+		{Sender: carol, Recipient: bob, Signature: "s3"},
+	} {
+		dm.Content, dm.SignedTimestamp, dm.Version, dm.Tags = "ciphertext", int64(i+1), 1, [][]string{{"p", dm.Recipient}}
+		saved, err := repo.SaveDirectMessage(ctx, dm)
+		if err != nil {
+			t.Fatalf("SaveDirectMessage %d: %v", i, err)
+		}
+		if saved.ID == "" || saved.Timestamp.IsZero() {
+			t.Errorf("SaveDirectMessage %d: got %+v, want an ID and a timestamp", i, saved)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := repo.SaveDirectMessage(ctx, models.DirectMessage{Sender: carol, Recipient: alice, Signature: "s3"}); !errors.Is(err, services.ErrDuplicateMessage) {
+		t.Errorf("replayed signature: got %v, want ErrDuplicateMessage", err)
+	}
+	if _, err := repo.SaveDirectMessage(ctx, models.DirectMessage{Sender: alice, Recipient: carol, Signature: "s3"}); err != nil {
+		t.Errorf("same signature from another sender: %v", err)
+	}
+
+	for _, tt := range []struct {
+		pubkey, peer string
+		limit        int
+		want         []string
+	}{
+		{alice, "", 0, []string{"s1", "s2", "s3"}},
+		{bob, "", 0, []string{"s1", "s2", "s3"}},
+		{bob, "", 2, []string{"s2", "s3"}},
+		{bob, carol, 0, []string{"s3"}},
+		{bob, alice, 0, []string{"s1", "s2"}},
+		{carol, bob, 0, []string{"s3"}},
+		{carol, strings.Repeat("d", 64), 0, nil},
+	} {
+		dms, err := repo.GetDirectMessages(ctx, tt.pubkey, tt.peer, services.MessageQueryParams{Limit: tt.limit})
+		if err != nil {
+			t.Fatalf("GetDirectMessages: %v", err)
+		}
+		var got []string
+		for _, dm := range dms {
+			got = append(got, dm.Signature)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("GetDirectMessages(%.1s…, %.1s…, %d): got %v, want %v", tt.pubkey, tt.peer, tt.limit, got, tt.want)
+		}
+	}
+
+	dms, err := repo.GetDirectMessages(ctx, bob, carol, services.MessageQueryParams{})
+	if err != nil {
+		t.Fatalf("GetDirectMessages: %v", err)
+	}
+	if dm := dms[0]; dm.Sender != carol || dm.Recipient != bob || dm.Content != "ciphertext" || dm.SignedTimestamp != 3 || dm.Version != 1 || fmt.Sprint(dm.Tags) != fmt.Sprint([][]string{{"p", bob}}) {
+		t.Errorf("got %+v, want the stored message", dm)
+	}
+}
+
+func testSanctions(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	expires := time.Now().Add(time.Hour)
+	mute, err := repo.CreateSanction(ctx, models.Sanction{
+		Kind: models.SanctionMute, Room: "room", Pubkey: strings.Repeat("aa", 32),
+		Reason: "spam", CreatedBy: strings.Repeat("bb", 32), ExpiresAt: &expires,
+	})
+	if err != nil {
+		t.Fatalf("CreateSanction: %v", err)
+	}
+	if mute.ID == "" || mute.CreatedAt.IsZero() {
+		t.Errorf("CreateSanction: got %+v, want an ID and CreatedAt", mute)
+	}
+	time.Sleep(time.Millisecond)
+	ban, err := repo.CreateSanction(ctx, models.Sanction{Kind: models.SanctionBan, Room: "room", IP: "203.0.113.7"})
+	if err != nil {
+		t.Fatalf("CreateSanction: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	global, err := repo.CreateSanction(ctx, models.Sanction{Kind: models.SanctionBan, IP: "203.0.113.8"})
+	if err != nil {
+		t.Fatalf("CreateSanction: %v", err)
+	}
+
+	// Scoped by room, oldest first
+	sanctions, err := repo.GetSanctions(ctx, "room")
+	if err != nil {
+		t.Fatalf("GetSanctions: %v", err)
+	}
+	if len(sanctions) != 2 || sanctions[0].ID != mute.ID || sanctions[1].ID != ban.ID {
+		t.Fatalf("GetSanctions(room): got %+v, want the mute and the ban", sanctions)
+	}
+	got := sanctions[0]
+	if got.Kind != models.SanctionMute || got.Room != "room" || got.Pubkey != mute.Pubkey || got.Reason != "spam" || got.CreatedBy != mute.CreatedBy ||
+		got.ExpiresAt == nil || !got.ExpiresAt.Truncate(time.Second).Equal(expires.Truncate(time.Second)) {
+		t.Errorf("GetSanctions(room): got %+v, want the stored mute %+v", got, mute)
+	}
+	if sanctions[1].IP != "203.0.113.7" || sanctions[1].ExpiresAt != nil {
+		t.Errorf("GetSanctions(room): got %+v, want the ban until lifted", sanctions[1])
+	}
+	if sanctions, err := repo.GetSanctions(ctx, ""); err != nil || len(sanctions) != 1 || sanctions[0].ID != global.ID {
+		t.Errorf("GetSanctions(server): got %+v, %v, want the server-wide ban", sanctions, err)
+	}
+
+	if err := repo.DeleteSanction(ctx, "room", global.ID); !errors.Is(err, services.ErrSanctionNotFound) {
+		t.Errorf("DeleteSanction from another scope: got %v, want ErrSanctionNotFound", err)
+	}
+	if err := repo.DeleteSanction(ctx, "room", mute.ID); err != nil {
+		t.Fatalf("DeleteSanction: %v", err)
+	}
+	if err := repo.DeleteSanction(ctx, "room", mute.ID); !errors.Is(err, services.ErrSanctionNotFound) {
+		t.Errorf("DeleteSanction again: got %v, want ErrSanctionNotFound", err)
+	}
+	if err := repo.DeleteSanction(ctx, "", global.ID); err != nil {
+		t.Errorf("DeleteSanction(server): %v", err)
+	}
+
+	// Deleting the room lifts its sanctions
+	if err := repo.DeleteRoom(ctx, "room"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if sanctions, err := repo.GetSanctions(ctx, "room"); err != nil || len(sanctions) != 0 {
+		t.Errorf("after DeleteRoom: got %+v, %v, want no sanctions", sanctions, err)
+	}
+}
+
+func testInvites(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", "", true); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	owner := strings.Repeat("ff", 32)
+	expires := time.Now().Add(time.Hour)
+	invite, err := repo.CreateInvite(ctx, models.Invite{Room: "room", CreatedBy: owner, ExpiresAt: &expires, MaxUses: 3}, "hash")
+	if err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+	if invite.ID == "" || invite.CreatedAt.IsZero() || invite.Uses != 0 {
+		t.Errorf("CreateInvite: got %+v, want an unused invite with an ID and CreatedAt", invite)
+	}
+	time.Sleep(time.Millisecond)
+	expired, err := repo.CreateInvite(ctx, models.Invite{Room: "room", CreatedBy: owner, ExpiresAt: new(time.Now().Add(-time.Minute))}, "expired")
+	if err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+
+	// Oldest first, expired ones included
+	invites, err := repo.GetInvites(ctx, "room")
+	if err != nil {
+		t.Fatalf("GetInvites: %v", err)
+	}
+	if len(invites) != 2 || invites[0].ID != invite.ID || invites[1].ID != expired.ID {
+		t.Fatalf("GetInvites: got %+v, want both invites", invites)
+	}
+	got := invites[0]
+	if got.Room != "room" || got.CreatedBy != owner || got.MaxUses != 3 || got.Token != "" ||
+		got.ExpiresAt == nil || !got.ExpiresAt.Truncate(time.Second).Equal(expires.Truncate(time.Second)) {
+		t.Errorf("GetInvites: got %+v, want the stored invite without its token", got)
+	}
+	if invites, err := repo.GetInvites(ctx, "other"); err != nil || len(invites) != 0 {
+		t.Errorf("GetInvites(other): got %+v, %v, want none", invites, err)
+	}
+
+	for _, tt := range []struct{ room, tokenHash string }{{"room", "unknown"}, {"room", "expired"}, {"other", "hash"}} {
+		if err := repo.RedeemInvite(ctx, tt.room, tt.tokenHash, owner); !errors.Is(err, services.ErrInviteInvalid) {
+			t.Errorf("RedeemInvite(%s, %s): got %v, want ErrInviteInvalid", tt.room, tt.tokenHash, err)
+		}
+	}
+
+	if err := repo.DeleteInvite(ctx, "other", invite.ID); !errors.Is(err, services.ErrInviteNotFound) {
+		t.Errorf("DeleteInvite from another room: got %v, want ErrInviteNotFound", err)
+	}
+	if err := repo.DeleteInvite(ctx, "room", invite.ID); err != nil {
+		t.Fatalf("DeleteInvite: %v", err)
+	}
+	if err := repo.DeleteInvite(ctx, "room", invite.ID); !errors.Is(err, services.ErrInviteNotFound) {
+		t.Errorf("DeleteInvite again: got %v, want ErrInviteNotFound", err)
+	}
+	if err := repo.RedeemInvite(ctx, "room", "hash", owner); !errors.Is(err, services.ErrInviteInvalid) {
+		t.Errorf("RedeemInvite of a revoked invite: got %v, want ErrInviteInvalid", err)
+	}
+
+	// Deleting the room revokes its invites
+	if err := repo.DeleteRoom(ctx, "room"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if err := repo.DeleteInvite(ctx, "room", expired.ID); !errors.Is(err, services.ErrInviteNotFound) {
+		t.Errorf("DeleteInvite after DeleteRoom: got %v, want ErrInviteNotFound", err)
+	}
+}
+
+func testRedeemInviteMaxUses(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", "", true); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if _, err := repo.CreateInvite(ctx, models.Invite{Room: "room", MaxUses: 2}, "hash"); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+	alice, bob, carol := strings.Repeat("aa", 32), strings.Repeat("bb", 32), strings.Repeat("cc", 32)
+
+	// Uses count distinct pubkeys, which may redeem again once it is used up
+	for _, pubkey := range []string{alice, alice, bob, alice, bob} {
+		if err := repo.RedeemInvite(ctx, "room", "hash", pubkey); err != nil {
+			t.Errorf("RedeemInvite(%.1s…): %v", pubkey, err)
+		}
+	}
+	if err := repo.RedeemInvite(ctx, "room", "hash", carol); !errors.Is(err, services.ErrInviteInvalid) {
+		t.Errorf("RedeemInvite past max uses: got %v, want ErrInviteInvalid", err)
+	}
+	if invites, err := repo.GetInvites(ctx, "room"); err != nil || len(invites) != 1 || invites[0].Uses != 2 {
+		t.Errorf("GetInvites: got %+v, %v, want one invite used twice", invites, err)
+	}
+}
+
+func testRoomMembers(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	owner, mod, member := strings.Repeat("aa", 32), strings.Repeat("bb", 32), strings.Repeat("cc", 32)
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", owner, true); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	members := func() []models.RoomMember {
+		t.Helper()
+		members, err := repo.GetRoomMembers(ctx, "room")
+		if err != nil {
+			t.Fatalf("GetRoomMembers: %v", err)
+		}
+		return members
+	}
+	if got, want := members(), []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}}; !slices.Equal(got, want) {
+		t.Errorf("after CreateRoom: got %+v, want %+v", got, want)
+	}
+
+	// Adding a member again changes nothing
+	for _, pubkey := range []string{member, mod, member} {
+		if err := repo.AddRoomMember(ctx, "room", pubkey); err != nil {
+			t.Fatalf("AddRoomMember: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := repo.SetRoomRole(ctx, "room", mod, models.RoleModerator); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	// The owner first, then the moderators, then the other members
+	want := []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: mod, Role: models.RoleModerator}, {Pubkey: member, Role: models.RoleMember}}
+	if got := members(); !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// Revoking the role of a listed member leaves it a member, listed in the
+	// order members were added
+	if err := repo.SetRoomRole(ctx, "room", mod, ""); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	want = []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: member, Role: models.RoleMember}, {Pubkey: mod, Role: models.RoleMember}}
+	if got := members(); !slices.Equal(got, want) {
+		t.Errorf("after revoking the moderator: got %+v, want %+v", got, want)
+	}
+
+	if err := repo.RemoveRoomMember(ctx, "room", member); err != nil {
+		t.Fatalf("RemoveRoomMember: %v", err)
+	}
+	if err := repo.RemoveRoomMember(ctx, "room", member); !errors.Is(err, services.ErrMemberNotFound) {
+		t.Errorf("RemoveRoomMember again: got %v, want ErrMemberNotFound", err)
+	}
+	want = []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: mod, Role: models.RoleMember}}
+	if got := members(); !slices.Equal(got, want) {
+		t.Errorf("after RemoveRoomMember: got %+v, want %+v", got, want)
+	}
+
+	for name, err := range map[string]error{
+		"GetRoomMembers": func() error { _, err := repo.GetRoomMembers(ctx, "missing"); return err }(),
+		"AddRoomMember":  repo.AddRoomMember(ctx, "missing", member),
+		"SetRoomRole":    repo.SetRoomRole(ctx, "missing", member, models.RoleModerator),
+	} {
+		if !errors.Is(err, services.ErrRoomNotFound) {
+			t.Errorf("%s of a missing room: got %v, want ErrRoomNotFound", name, err)
+		}
+	}
+}
+
+func testRoomRoles(t *testing.T, repo services.Repository) {
+	ctx := context.Background()
+	owner, mod := strings.Repeat("aa", 32), strings.Repeat("bb", 32)
+	if _, err := repo.CreateRoom(ctx, "room", nil, "", owner, false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+
+	if err := repo.SetRoomRole(ctx, "room", mod, models.RoleModerator); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	members, err := repo.GetRoomMembers(ctx, "room")
+	want := []models.RoomMember{{Pubkey: owner, Role: models.RoleOwner}, {Pubkey: mod, Role: models.RoleModerator}}
+	if err != nil || !slices.Equal(members, want) {
+		t.Errorf("got %+v, %v, want %+v", members, err, want)
+	}
+
+	// Revoking the role of a moderator outside the member list removes it
+	if err := repo.SetRoomRole(ctx, "room", mod, ""); err != nil {
+		t.Fatalf("SetRoomRole: %v", err)
+	}
+	if members, err := repo.GetRoomMembers(ctx, "room"); err != nil || !slices.Equal(members, want[:1]) {
+		t.Errorf("after revoking the moderator: got %+v, %v, want %+v", members, err, want[:1])
+	}
+
+	// A room created without an owner has none
+	if _, err := repo.CreateRoom(ctx, "unowned", nil, "", "", false); err != nil {
+		t.Fatalf("CreateRoom: %v", err)
+	}
+	if members, err := repo.GetRoomMembers(ctx, "unowned"); err != nil || len(members) != 0 {
+		t.Errorf("unowned room: got %+v, %v, want no members", members, err)
+	}
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Reject replayed signed messages: a (pubkey, signature) pair may be stored once.
+-- Replays accepted before this index existed are dropped, keeping the first copy.
+DELETE FROM messages
+WHERE signature IS NOT NULL
+  AND rowid NOT IN (
+    SELECT MIN(rowid) FROM messages
+    WHERE signature IS NOT NULL
+    GROUP BY pubkey, signature
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_pubkey_signature ON messages(pubkey, signature);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_messages_pubkey_signature;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Event hash format the signature covers (0 = legacy, 1 = includes user and tags)
+ALTER TABLE messages ADD COLUMN event_version INTEGER NOT NULL DEFAULT 0;
+-- Signed tags as a JSON array of string arrays; NULL when the message has none
+ALTER TABLE messages ADD COLUMN tags TEXT;
+
+-- +goose Down
+ALTER TABLE messages DROP COLUMN tags;
+ALTER TABLE messages DROP COLUMN event_version;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Signature scheme: 'ecdsa' over the microchat event hash, or 'schnorr' (BIP-340) over the NIP-01 event id
+ALTER TABLE messages ADD COLUMN sig_scheme TEXT NOT NULL DEFAULT 'ecdsa';
+
+-- +goose Down
+ALTER TABLE messages DROP COLUMN sig_scheme;
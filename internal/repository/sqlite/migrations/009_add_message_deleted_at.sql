@@ -0,0 +1,6 @@
+-- +goose Up
+-- Deleted messages are kept as tombstones: content cleared, deleted_at set
+ALTER TABLE messages ADD COLUMN deleted_at DATETIME;
+
+-- +goose Down
+ALTER TABLE messages DROP COLUMN deleted_at;
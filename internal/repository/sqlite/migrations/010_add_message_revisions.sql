@@ -0,0 +1,23 @@
+-- +goose Up
+-- Edited messages keep their latest revision in messages and the earlier ones here
+ALTER TABLE messages ADD COLUMN edited_at DATETIME;
+ALTER TABLE messages ADD COLUMN revisions INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS message_revisions (
+    message_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    signature TEXT,
+    signed_timestamp INTEGER,
+    event_version INTEGER NOT NULL DEFAULT 0,
+    tags TEXT,
+    sig_scheme TEXT NOT NULL DEFAULT 'ecdsa',
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (message_id, revision),
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS message_revisions;
+ALTER TABLE messages DROP COLUMN revisions;
+ALTER TABLE messages DROP COLUMN edited_at;
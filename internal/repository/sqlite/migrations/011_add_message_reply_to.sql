@@ -0,0 +1,11 @@
+-- +goose Up
+-- ID of the message a reply answers, and the number of replies a message received
+ALTER TABLE messages ADD COLUMN reply_to TEXT;
+ALTER TABLE messages ADD COLUMN replies INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_messages_room_reply_to ON messages(room, reply_to, timestamp DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_messages_room_reply_to;
+ALTER TABLE messages DROP COLUMN replies;
+ALTER TABLE messages DROP COLUMN reply_to;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Signed emoji reactions, one per (message, pubkey, emoji)
+CREATE TABLE IF NOT EXISTS reactions (
+    message_id TEXT NOT NULL,
+    pubkey TEXT NOT NULL,
+    emoji TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    signed_timestamp INTEGER NOT NULL,
+    event_version INTEGER NOT NULL DEFAULT 0,
+    tags TEXT,
+    sig_scheme TEXT NOT NULL DEFAULT 'ecdsa',
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (message_id, pubkey, emoji),
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS reactions;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- End-to-end encrypted direct messages: the content is an opaque NIP-44 payload
+CREATE TABLE IF NOT EXISTS direct_messages (
+    id TEXT PRIMARY KEY,
+    sender TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    content TEXT NOT NULL,
+    timestamp DATETIME NOT NULL,
+    signature TEXT NOT NULL,
+    signed_timestamp INTEGER NOT NULL,
+    event_version INTEGER NOT NULL,
+    tags TEXT,
+    UNIQUE (sender, signature)
+);
+
+CREATE INDEX IF NOT EXISTS idx_direct_messages_sender ON direct_messages(sender, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_direct_messages_recipient ON direct_messages(recipient, timestamp DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_direct_messages_recipient;
+DROP INDEX IF EXISTS idx_direct_messages_sender;
+DROP TABLE IF EXISTS direct_messages;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Salt of the key of end-to-end encrypted rooms; NULL for plaintext rooms
+ALTER TABLE rooms ADD COLUMN key_salt TEXT;
+
+-- +goose Down
+ALTER TABLE rooms DROP COLUMN key_salt;
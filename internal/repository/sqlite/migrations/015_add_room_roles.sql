@@ -0,0 +1,17 @@
+-- +goose Up
+-- Description of a room, set by its owner
+ALTER TABLE rooms ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+-- Owner and moderators of each room, pubkeys in x-only hex
+CREATE TABLE IF NOT EXISTS room_roles (
+    room_name TEXT NOT NULL,
+    pubkey TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (room_name, pubkey),
+    FOREIGN KEY (room_name) REFERENCES rooms(name) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_roles;
+ALTER TABLE rooms DROP COLUMN description;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Mutes and bans of a pubkey or IP address, in a room or server-wide (empty room)
+CREATE TABLE IF NOT EXISTS sanctions (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    room TEXT NOT NULL DEFAULT '',
+    pubkey TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanctions_room ON sanctions(room);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sanctions_room;
+DROP TABLE IF EXISTS sanctions;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Invites granting access to a room in place of its password; only the hash
+-- of their token is stored
+CREATE TABLE IF NOT EXISTS room_invites (
+    id TEXT PRIMARY KEY,
+    room TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_by TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    max_uses INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_invites_room ON room_invites(room);
+
+-- Pubkeys that redeemed an invite
+CREATE TABLE IF NOT EXISTS room_invite_uses (
+    invite_id TEXT NOT NULL,
+    pubkey TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (invite_id, pubkey)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_invite_uses;
+DROP INDEX IF EXISTS idx_room_invites_room;
+DROP TABLE IF EXISTS room_invites;
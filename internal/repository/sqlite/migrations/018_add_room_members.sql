@@ -0,0 +1,16 @@
+-- +goose Up
+-- Private rooms are only readable by their owner, moderators and members
+ALTER TABLE rooms ADD COLUMN private BOOLEAN NOT NULL DEFAULT 0;
+
+-- Member lists of private rooms, pubkeys in x-only hex
+CREATE TABLE IF NOT EXISTS room_members (
+    room_name TEXT NOT NULL,
+    pubkey TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (room_name, pubkey),
+    FOREIGN KEY (room_name) REFERENCES rooms(name) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_members;
+ALTER TABLE rooms DROP COLUMN private;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Order the messages of a room as its history does, by timestamp then id, so
+-- that fetching the messages before or after a cursor is a range scan.
+CREATE INDEX IF NOT EXISTS idx_messages_room_timestamp_id ON messages(room, timestamp, id);
+DROP INDEX IF EXISTS idx_messages_room_timestamp;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_messages_room_timestamp ON messages(room, timestamp DESC);
+DROP INDEX IF EXISTS idx_messages_room_timestamp_id;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Retention policy set by the owner of a room: the messages older than
+-- retention_max_age seconds, and those before the latest
+-- retention_max_messages, are pruned. 0 sets no bound.
+ALTER TABLE rooms ADD COLUMN retention_max_age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rooms ADD COLUMN retention_max_messages INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE rooms DROP COLUMN retention_max_messages;
+ALTER TABLE rooms DROP COLUMN retention_max_age;
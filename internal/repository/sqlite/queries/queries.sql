@@ -1,8 +1,20 @@
 -- name: CreateMessage :one
-INSERT INTO messages (id, room, user, content, timestamp, signature, pubkey, signed_timestamp)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO messages (id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, reply_to)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
+-- name: IncrementReplies :exec
+UPDATE messages SET replies = replies + 1 WHERE room = ? AND id = ?;
+
+-- name: GetReplies :many
+SELECT * FROM messages
+WHERE room = ? AND reply_to = ?
+ORDER BY timestamp DESC
+LIMIT ?;
+
+-- name: MessageSignatureExists :one
+SELECT COUNT(*) > 0 as signature_exists FROM messages WHERE pubkey = ? AND signature = ?;
+
 -- name: GetMessagesByRoomPaginated :many
 SELECT * FROM messages
 WHERE room = ?
@@ -10,10 +22,139 @@ WHERE room = ?
 ORDER BY timestamp DESC
 LIMIT ?;
 
+-- name: GetMessagesBeforeCursor :many
+-- The cursor is at the stored timestamp of its message, which compares exactly,
+-- or at the timestamp it carries once the message is gone.
+SELECT m.* FROM messages m
+WHERE m.room = sqlc.arg(room)
+  AND (m.timestamp, m.id) < (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = sqlc.arg(room) AND c.id = sqlc.arg(cursor_id)), sqlc.arg(cursor_timestamp)),
+    sqlc.arg(cursor_id)
+  )
+ORDER BY m.timestamp DESC, m.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: GetMessagesAfterCursor :many
+SELECT m.* FROM messages m
+WHERE m.room = sqlc.arg(room)
+  AND (m.timestamp, m.id) > (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = sqlc.arg(room) AND c.id = sqlc.arg(cursor_id)), sqlc.arg(cursor_timestamp)),
+    sqlc.arg(cursor_id)
+  )
+ORDER BY m.timestamp, m.id
+LIMIT sqlc.arg(limit);
+
+-- name: FindMessagesInRoom :many
+SELECT * FROM messages
+WHERE room = sqlc.arg(room)
+  AND (sqlc.arg(author) = '' OR pubkey = sqlc.arg(author) OR substr(pubkey, 3) = sqlc.arg(author))
+  AND (sqlc.arg(sig_scheme) = '' OR sig_scheme = sqlc.arg(sig_scheme))
+  AND signature IS NOT NULL
+  AND deleted_at IS NULL
+  AND signed_timestamp >= sqlc.arg(since)
+  AND signed_timestamp <= sqlc.arg(until)
+ORDER BY signed_timestamp DESC
+LIMIT sqlc.arg(limit);
+
+-- name: GetMessage :one
+SELECT * FROM messages WHERE room = ? AND id = ?;
+
+-- name: TombstoneMessage :exec
+UPDATE messages
+SET content = '', deleted_at = ?
+WHERE room = ? AND id = ? AND deleted_at IS NULL;
+
+-- name: ReviseMessage :execrows
+UPDATE messages
+SET content = sqlc.arg(content),
+    signature = sqlc.arg(signature),
+    signed_timestamp = sqlc.arg(signed_timestamp),
+    event_version = sqlc.arg(event_version),
+    tags = sqlc.arg(tags),
+    sig_scheme = sqlc.arg(sig_scheme),
+    edited_at = sqlc.arg(edited_at),
+    revisions = revisions + 1
+WHERE room = sqlc.arg(room) AND id = sqlc.arg(id)
+  AND deleted_at IS NULL
+  AND signed_timestamp < sqlc.arg(signed_timestamp);
+
+-- name: ArchiveMessageRevision :exec
+INSERT INTO message_revisions (message_id, revision, content, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+SELECT id, revisions, content, signature, signed_timestamp, event_version, tags, sig_scheme, COALESCE(edited_at, timestamp)
+FROM messages
+WHERE room = ? AND id = ?;
+
+-- name: GetMessageRevisions :many
+SELECT * FROM message_revisions
+WHERE message_id = ?
+ORDER BY revision;
+
+-- name: DeleteMessageRevisions :exec
+DELETE FROM message_revisions WHERE message_id = ?;
+
+-- name: DeleteMessageRevisionsByRoom :exec
+DELETE FROM message_revisions
+WHERE message_id IN (SELECT id FROM messages WHERE room = ?);
+
+-- name: CreateReaction :exec
+INSERT INTO reactions (message_id, pubkey, emoji, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (message_id, pubkey, emoji) DO NOTHING;
+
+-- name: DeleteReaction :execrows
+DELETE FROM reactions WHERE message_id = ? AND pubkey = ? AND emoji = ?;
+
+-- name: GetReactions :many
+SELECT * FROM reactions
+WHERE message_id = ?
+ORDER BY created_at;
+
+-- name: CountReactions :many
+SELECT emoji, COUNT(*) AS count FROM reactions
+WHERE message_id = ?
+GROUP BY emoji
+ORDER BY MIN(created_at), emoji;
+
+-- name: DeleteMessageReactions :exec
+DELETE FROM reactions WHERE message_id = ?;
+
+-- name: DeleteReactionsByRoom :exec
+DELETE FROM reactions
+WHERE message_id IN (SELECT id FROM messages WHERE room = ?);
+
+-- name: DeleteMessagesByRoom :exec
+DELETE FROM messages WHERE room = ?;
+
+-- name: PruneMessages :execrows
+-- Deletes the messages of a room received before a time, and those older than
+-- its latest keep messages: the message at offset keep is the newest pruned.
+DELETE FROM messages
+WHERE room = sqlc.arg(room)
+  AND (
+    timestamp < sqlc.arg(before)
+    OR (timestamp, id) <= (
+      SELECT k.timestamp, k.id FROM messages k
+      WHERE k.room = sqlc.arg(room)
+      ORDER BY k.timestamp DESC, k.id DESC
+      LIMIT 1 OFFSET sqlc.arg(keep)
+    )
+  );
+
+-- name: DeleteOrphanMessageRevisions :exec
+DELETE FROM message_revisions
+WHERE message_id NOT IN (SELECT id FROM messages);
+
+-- name: DeleteOrphanReactions :exec
+DELETE FROM reactions
+WHERE message_id NOT IN (SELECT id FROM messages);
+
 -- name: GetRoomsWithLasMessage :many
 SELECT
     r.name,
+    r.description,
     CASE WHEN r.password_hash IS NOT NULL THEN 1 ELSE 0 END as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
     COALESCE(last_msg.content, '') as last_message_content,
     COALESCE(last_msg.user, '') as last_message_user,
     CASE
@@ -54,7 +195,7 @@ SELECT COUNT(*) > 0 as user_exists FROM users WHERE public_key = ?;
 SELECT * FROM users
 LIMIT 100;
 
--- name: UpdateUserVerified :exec
+-- name: UpdateUserVerified :execrows
 UPDATE users
 SET verified = ?, updated_at = ?
 WHERE public_key = ?;
@@ -71,8 +212,8 @@ FROM users u
 WHERE u.public_key = ?;
 
 -- name: CreateRoom :one
-INSERT INTO rooms (name, password_hash, created_at, updated_at)
-VALUES (?, ?, ?, ?)
+INSERT INTO rooms (name, password_hash, key_salt, private, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetRoomByName :one
@@ -85,7 +226,10 @@ SELECT COUNT(*) > 0 as room_exists FROM rooms WHERE name = ?;
 -- name: SearchRoomsByName :many
 SELECT
     r.name,
+    r.description,
     CASE WHEN r.password_hash IS NOT NULL THEN 1 ELSE 0 END as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
     COALESCE(last_msg.content, '') as last_message_content,
     COALESCE(last_msg.user, '') as last_message_user,
     CASE
@@ -106,5 +250,143 @@ WHERE r.name LIKE '%' || ? || '%'
 ORDER BY last_message_timestamp DESC, r.name ASC
 LIMIT 100;
 
+-- name: GetSearchableRooms :many
+SELECT name, private FROM rooms
+WHERE password_hash IS NULL AND COALESCE(key_salt, '') = ''
+ORDER BY name ASC;
+
 -- name: GetRoomPasswordHash :one
 SELECT password_hash FROM rooms WHERE name = ?;
+
+-- name: UpdateRoomPassword :execrows
+UPDATE rooms
+SET password_hash = ?, updated_at = ?
+WHERE name = ?;
+
+-- name: DeleteRoom :execrows
+DELETE FROM rooms WHERE name = ?;
+
+-- name: UpdateRoomDescription :execrows
+UPDATE rooms
+SET description = ?, updated_at = ?
+WHERE name = ?;
+
+-- name: UpdateRoomRetention :execrows
+UPDATE rooms
+SET retention_max_age = ?, retention_max_messages = ?, updated_at = ?
+WHERE name = ?;
+
+-- name: GetRoomRetentions :many
+SELECT name, retention_max_age, retention_max_messages FROM rooms;
+
+-- name: GetRoomRoles :many
+SELECT pubkey, role FROM room_roles
+WHERE room_name = ?
+ORDER BY role = 'owner' DESC, rowid ASC;
+
+-- name: UpsertRoomRole :exec
+INSERT INTO room_roles (room_name, pubkey, role, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (room_name, pubkey) DO UPDATE SET role = excluded.role;
+
+-- name: DeleteRoomRole :exec
+DELETE FROM room_roles WHERE room_name = ? AND pubkey = ?;
+
+-- name: DeleteRoomRolesByRoom :exec
+DELETE FROM room_roles WHERE room_name = ?;
+
+-- name: CreateDirectMessage :one
+INSERT INTO direct_messages (id, sender, recipient, content, timestamp, signature, signed_timestamp, event_version, tags)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetDirectMessages :many
+SELECT * FROM direct_messages
+WHERE ((sender = sqlc.arg(pubkey) OR substr(sender, 3) = sqlc.arg(pubkey))
+       AND (sqlc.arg(peer) = '' OR recipient = sqlc.arg(peer) OR substr(recipient, 3) = sqlc.arg(peer))
+    OR (recipient = sqlc.arg(pubkey) OR substr(recipient, 3) = sqlc.arg(pubkey))
+       AND (sqlc.arg(peer) = '' OR sender = sqlc.arg(peer) OR substr(sender, 3) = sqlc.arg(peer)))
+  AND timestamp < sqlc.arg(before)
+ORDER BY timestamp DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CreateSanction :one
+INSERT INTO sanctions (id, kind, room, pubkey, ip, reason, created_by, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetSanctions :many
+SELECT * FROM sanctions
+WHERE room = ?
+ORDER BY rowid ASC;
+
+-- name: DeleteSanction :execrows
+DELETE FROM sanctions WHERE room = ? AND id = ?;
+
+-- name: DeleteSanctionsByRoom :exec
+DELETE FROM sanctions WHERE room = ?;
+
+-- name: CreateInvite :one
+INSERT INTO room_invites (id, room, token_hash, created_by, created_at, expires_at, max_uses)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetInvites :many
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = ?
+ORDER BY rowid ASC;
+
+-- name: GetInviteByTokenHash :one
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = ? AND token_hash = ?;
+
+-- name: InviteRedeemedBy :one
+SELECT COUNT(*) > 0 as redeemed FROM room_invite_uses WHERE invite_id = ? AND pubkey = ?;
+
+-- name: CreateInviteUse :exec
+INSERT INTO room_invite_uses (invite_id, pubkey, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (invite_id, pubkey) DO NOTHING;
+
+-- name: DeleteInvite :execrows
+DELETE FROM room_invites WHERE room = ? AND id = ?;
+
+-- name: DeleteInviteUses :exec
+DELETE FROM room_invite_uses WHERE invite_id = ?;
+
+-- name: DeleteInvitesByRoom :exec
+DELETE FROM room_invites WHERE room = ?;
+
+-- name: DeleteInviteUsesByRoom :exec
+DELETE FROM room_invite_uses WHERE invite_id IN (SELECT id FROM room_invites WHERE room = ?);
+
+-- name: GetRoomMemberList :many
+SELECT pubkey FROM room_members
+WHERE room_name = ?
+ORDER BY rowid ASC;
+
+-- name: CreateRoomMember :exec
+INSERT INTO room_members (room_name, pubkey, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (room_name, pubkey) DO NOTHING;
+
+-- name: DeleteRoomMember :execrows
+DELETE FROM room_members WHERE room_name = ? AND pubkey = ?;
+
+-- name: DeleteRoomMembersByRoom :exec
+DELETE FROM room_members WHERE room_name = ?;
+
+-- name: SearchMessages :many
+SELECT m.* FROM messages_fts
+JOIN messages m ON m.rowid = messages_fts.rowid
+WHERE messages_fts MATCH sqlc.arg(query)
+  AND m.room IN (SELECT value FROM json_each(sqlc.arg(rooms)))
+  AND (sqlc.arg(author) = '' OR m.pubkey = sqlc.arg(author) OR substr(m.pubkey, 3) = sqlc.arg(author))
+  AND m.deleted_at IS NULL
+  AND m.timestamp < sqlc.arg(before)
+ORDER BY m.timestamp DESC
+LIMIT sqlc.arg(limit);
@@ -9,6 +9,18 @@ import (
 	"time"
 )
 
+type DirectMessage struct {
+	ID              string         `json:"id"`
+	Sender          string         `json:"sender"`
+	Recipient       string         `json:"recipient"`
+	Content         string         `json:"content"`
+	Timestamp       time.Time      `json:"timestamp"`
+	Signature       string         `json:"signature"`
+	SignedTimestamp int64          `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+}
+
 type Message struct {
 	ID              string         `json:"id"`
 	Room            string         `json:"room"`
@@ -18,13 +30,91 @@ type Message struct {
 	Signature       sql.NullString `json:"signature"`
 	Pubkey          sql.NullString `json:"pubkey"`
 	SignedTimestamp sql.NullInt64  `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	Revisions       int64          `json:"revisions"`
+	ReplyTo         sql.NullString `json:"reply_to"`
+	Replies         int64          `json:"replies"`
+}
+
+type MessageRevision struct {
+	MessageID       string         `json:"message_id"`
+	Revision        int64          `json:"revision"`
+	Content         string         `json:"content"`
+	Signature       sql.NullString `json:"signature"`
+	SignedTimestamp sql.NullInt64  `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type Reaction struct {
+	MessageID       string         `json:"message_id"`
+	Pubkey          string         `json:"pubkey"`
+	Emoji           string         `json:"emoji"`
+	Signature       string         `json:"signature"`
+	SignedTimestamp int64          `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	CreatedAt       time.Time      `json:"created_at"`
 }
 
 type Room struct {
-	Name         string         `json:"name"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	PasswordHash sql.NullString `json:"password_hash"`
+	Name                 string         `json:"name"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	PasswordHash         sql.NullString `json:"password_hash"`
+	KeySalt              sql.NullString `json:"key_salt"`
+	Description          string         `json:"description"`
+	Private              bool           `json:"private"`
+	RetentionMaxAge      int64          `json:"retention_max_age"`
+	RetentionMaxMessages int64          `json:"retention_max_messages"`
+}
+
+type RoomInvite struct {
+	ID        string       `json:"id"`
+	Room      string       `json:"room"`
+	TokenHash string       `json:"token_hash"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	MaxUses   int64        `json:"max_uses"`
+}
+
+type RoomInviteUse struct {
+	InviteID  string    `json:"invite_id"`
+	Pubkey    string    `json:"pubkey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RoomMember struct {
+	RoomName  string    `json:"room_name"`
+	Pubkey    string    `json:"pubkey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RoomRole struct {
+	RoomName  string    `json:"room_name"`
+	Pubkey    string    `json:"pubkey"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Sanction struct {
+	ID        string       `json:"id"`
+	Kind      string       `json:"kind"`
+	Room      string       `json:"room"`
+	Pubkey    string       `json:"pubkey"`
+	Ip        string       `json:"ip"`
+	Reason    string       `json:"reason"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
 }
 
 type User struct {
@@ -10,21 +10,78 @@ import (
 )
 
 type Querier interface {
+	ArchiveMessageRevision(ctx context.Context, arg ArchiveMessageRevisionParams) error
+	CountReactions(ctx context.Context, messageID string) ([]CountReactionsRow, error)
+	CreateDirectMessage(ctx context.Context, arg CreateDirectMessageParams) (DirectMessage, error)
+	CreateInvite(ctx context.Context, arg CreateInviteParams) (RoomInvite, error)
+	CreateInviteUse(ctx context.Context, arg CreateInviteUseParams) error
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	CreateReaction(ctx context.Context, arg CreateReactionParams) error
 	CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error)
+	CreateRoomMember(ctx context.Context, arg CreateRoomMemberParams) error
+	CreateSanction(ctx context.Context, arg CreateSanctionParams) (Sanction, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteInvite(ctx context.Context, arg DeleteInviteParams) (int64, error)
+	DeleteInviteUses(ctx context.Context, inviteID string) error
+	DeleteInviteUsesByRoom(ctx context.Context, room string) error
+	DeleteInvitesByRoom(ctx context.Context, room string) error
+	DeleteMessageReactions(ctx context.Context, messageID string) error
+	DeleteMessageRevisions(ctx context.Context, messageID string) error
+	DeleteMessageRevisionsByRoom(ctx context.Context, room string) error
+	DeleteMessagesByRoom(ctx context.Context, room string) error
+	DeleteOrphanMessageRevisions(ctx context.Context) error
+	DeleteOrphanReactions(ctx context.Context) error
+	DeleteReaction(ctx context.Context, arg DeleteReactionParams) (int64, error)
+	DeleteReactionsByRoom(ctx context.Context, room string) error
+	DeleteRoom(ctx context.Context, name string) (int64, error)
+	DeleteRoomMember(ctx context.Context, arg DeleteRoomMemberParams) (int64, error)
+	DeleteRoomMembersByRoom(ctx context.Context, roomName string) error
+	DeleteRoomRole(ctx context.Context, arg DeleteRoomRoleParams) error
+	DeleteRoomRolesByRoom(ctx context.Context, roomName string) error
+	DeleteSanction(ctx context.Context, arg DeleteSanctionParams) (int64, error)
+	DeleteSanctionsByRoom(ctx context.Context, room string) error
+	FindMessagesInRoom(ctx context.Context, arg FindMessagesInRoomParams) ([]Message, error)
 	GetAllUsers(ctx context.Context) ([]User, error)
+	GetDirectMessages(ctx context.Context, arg GetDirectMessagesParams) ([]DirectMessage, error)
+	GetInviteByTokenHash(ctx context.Context, arg GetInviteByTokenHashParams) (GetInviteByTokenHashRow, error)
+	GetInvites(ctx context.Context, room string) ([]GetInvitesRow, error)
+	GetMessage(ctx context.Context, arg GetMessageParams) (Message, error)
 	GetMessageCountByRoom(ctx context.Context, room string) (int64, error)
+	GetMessageRevisions(ctx context.Context, messageID string) ([]MessageRevision, error)
+	GetMessagesAfterCursor(ctx context.Context, arg GetMessagesAfterCursorParams) ([]Message, error)
+	// The cursor is at the stored timestamp of its message, which compares exactly,
+	// or at the timestamp it carries once the message is gone.
+	GetMessagesBeforeCursor(ctx context.Context, arg GetMessagesBeforeCursorParams) ([]Message, error)
 	GetMessagesByRoomPaginated(ctx context.Context, arg GetMessagesByRoomPaginatedParams) ([]Message, error)
+	GetReactions(ctx context.Context, messageID string) ([]Reaction, error)
+	GetReplies(ctx context.Context, arg GetRepliesParams) ([]Message, error)
 	GetRoomByName(ctx context.Context, name string) (Room, error)
+	GetRoomMemberList(ctx context.Context, roomName string) ([]string, error)
 	GetRoomPasswordHash(ctx context.Context, name string) (sql.NullString, error)
+	GetRoomRetentions(ctx context.Context) ([]GetRoomRetentionsRow, error)
+	GetRoomRoles(ctx context.Context, roomName string) ([]GetRoomRolesRow, error)
 	GetRoomsWithLasMessage(ctx context.Context) ([]GetRoomsWithLasMessageRow, error)
+	GetSanctions(ctx context.Context, room string) ([]Sanction, error)
+	GetSearchableRooms(ctx context.Context) ([]GetSearchableRoomsRow, error)
 	GetUserByPublicKey(ctx context.Context, publicKey string) (User, error)
 	GetUserVerified(ctx context.Context, publicKey string) (bool, error)
 	GetUserWithPostCount(ctx context.Context, publicKey string) (GetUserWithPostCountRow, error)
+	IncrementReplies(ctx context.Context, arg IncrementRepliesParams) error
+	InviteRedeemedBy(ctx context.Context, arg InviteRedeemedByParams) (bool, error)
+	MessageSignatureExists(ctx context.Context, arg MessageSignatureExistsParams) (bool, error)
+	// Deletes the messages of a room received before a time, and those older than
+	// its latest keep messages: the message at offset keep is the newest pruned.
+	PruneMessages(ctx context.Context, arg PruneMessagesParams) (int64, error)
+	ReviseMessage(ctx context.Context, arg ReviseMessageParams) (int64, error)
 	RoomExists(ctx context.Context, name string) (bool, error)
+	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]Message, error)
 	SearchRoomsByName(ctx context.Context, dollar_1 sql.NullString) ([]SearchRoomsByNameRow, error)
-	UpdateUserVerified(ctx context.Context, arg UpdateUserVerifiedParams) error
+	TombstoneMessage(ctx context.Context, arg TombstoneMessageParams) error
+	UpdateRoomDescription(ctx context.Context, arg UpdateRoomDescriptionParams) (int64, error)
+	UpdateRoomPassword(ctx context.Context, arg UpdateRoomPasswordParams) (int64, error)
+	UpdateRoomRetention(ctx context.Context, arg UpdateRoomRetentionParams) (int64, error)
+	UpdateUserVerified(ctx context.Context, arg UpdateUserVerifiedParams) (int64, error)
+	UpsertRoomRole(ctx context.Context, arg UpsertRoomRoleParams) error
 	UserExistsByPublicKey(ctx context.Context, publicKey string) (bool, error)
 }
 
@@ -11,10 +11,163 @@ import (
 	"time"
 )
 
+const archiveMessageRevision = `-- name: ArchiveMessageRevision :exec
+INSERT INTO message_revisions (message_id, revision, content, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+SELECT id, revisions, content, signature, signed_timestamp, event_version, tags, sig_scheme, COALESCE(edited_at, timestamp)
+FROM messages
+WHERE room = ? AND id = ?
+`
+
+type ArchiveMessageRevisionParams struct {
+	Room string `json:"room"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) ArchiveMessageRevision(ctx context.Context, arg ArchiveMessageRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, archiveMessageRevision, arg.Room, arg.ID)
+	return err
+}
+
+const countReactions = `-- name: CountReactions :many
+SELECT emoji, COUNT(*) AS count FROM reactions
+WHERE message_id = ?
+GROUP BY emoji
+ORDER BY MIN(created_at), emoji
+`
+
+type CountReactionsRow struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) CountReactions(ctx context.Context, messageID string) ([]CountReactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countReactions, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountReactionsRow{}
+	for rows.Next() {
+		var i CountReactionsRow
+		if err := rows.Scan(&i.Emoji, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createDirectMessage = `-- name: CreateDirectMessage :one
+INSERT INTO direct_messages (id, sender, recipient, content, timestamp, signature, signed_timestamp, event_version, tags)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, sender, recipient, content, timestamp, signature, signed_timestamp, event_version, tags
+`
+
+type CreateDirectMessageParams struct {
+	ID              string         `json:"id"`
+	Sender          string         `json:"sender"`
+	Recipient       string         `json:"recipient"`
+	Content         string         `json:"content"`
+	Timestamp       time.Time      `json:"timestamp"`
+	Signature       string         `json:"signature"`
+	SignedTimestamp int64          `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+}
+
+func (q *Queries) CreateDirectMessage(ctx context.Context, arg CreateDirectMessageParams) (DirectMessage, error) {
+	row := q.db.QueryRowContext(ctx, createDirectMessage,
+		arg.ID,
+		arg.Sender,
+		arg.Recipient,
+		arg.Content,
+		arg.Timestamp,
+		arg.Signature,
+		arg.SignedTimestamp,
+		arg.EventVersion,
+		arg.Tags,
+	)
+	var i DirectMessage
+	err := row.Scan(
+		&i.ID,
+		&i.Sender,
+		&i.Recipient,
+		&i.Content,
+		&i.Timestamp,
+		&i.Signature,
+		&i.SignedTimestamp,
+		&i.EventVersion,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const createInvite = `-- name: CreateInvite :one
+INSERT INTO room_invites (id, room, token_hash, created_by, created_at, expires_at, max_uses)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, room, token_hash, created_by, created_at, expires_at, max_uses
+`
+
+type CreateInviteParams struct {
+	ID        string       `json:"id"`
+	Room      string       `json:"room"`
+	TokenHash string       `json:"token_hash"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	MaxUses   int64        `json:"max_uses"`
+}
+
+func (q *Queries) CreateInvite(ctx context.Context, arg CreateInviteParams) (RoomInvite, error) {
+	row := q.db.QueryRowContext(ctx, createInvite,
+		arg.ID,
+		arg.Room,
+		arg.TokenHash,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+		arg.MaxUses,
+	)
+	var i RoomInvite
+	err := row.Scan(
+		&i.ID,
+		&i.Room,
+		&i.TokenHash,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.MaxUses,
+	)
+	return i, err
+}
+
+const createInviteUse = `-- name: CreateInviteUse :exec
+INSERT INTO room_invite_uses (invite_id, pubkey, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (invite_id, pubkey) DO NOTHING
+`
+
+type CreateInviteUseParams struct {
+	InviteID  string    `json:"invite_id"`
+	Pubkey    string    `json:"pubkey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CreateInviteUse(ctx context.Context, arg CreateInviteUseParams) error {
+	_, err := q.db.ExecContext(ctx, createInviteUse, arg.InviteID, arg.Pubkey, arg.CreatedAt)
+	return err
+}
+
 const createMessage = `-- name: CreateMessage :one
-INSERT INTO messages (id, room, user, content, timestamp, signature, pubkey, signed_timestamp)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, room, user, content, timestamp, signature, pubkey, signed_timestamp
+INSERT INTO messages (id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, reply_to)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, deleted_at, edited_at, revisions, reply_to, replies
 `
 
 type CreateMessageParams struct {
@@ -26,6 +179,10 @@ type CreateMessageParams struct {
 	Signature       sql.NullString `json:"signature"`
 	Pubkey          sql.NullString `json:"pubkey"`
 	SignedTimestamp sql.NullInt64  `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	ReplyTo         sql.NullString `json:"reply_to"`
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
@@ -38,6 +195,10 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		arg.Signature,
 		arg.Pubkey,
 		arg.SignedTimestamp,
+		arg.EventVersion,
+		arg.Tags,
+		arg.SigScheme,
+		arg.ReplyTo,
 	)
 	var i Message
 	err := row.Scan(
@@ -49,19 +210,62 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.Signature,
 		&i.Pubkey,
 		&i.SignedTimestamp,
+		&i.EventVersion,
+		&i.Tags,
+		&i.SigScheme,
+		&i.DeletedAt,
+		&i.EditedAt,
+		&i.Revisions,
+		&i.ReplyTo,
+		&i.Replies,
 	)
 	return i, err
 }
 
+const createReaction = `-- name: CreateReaction :exec
+INSERT INTO reactions (message_id, pubkey, emoji, signature, signed_timestamp, event_version, tags, sig_scheme, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (message_id, pubkey, emoji) DO NOTHING
+`
+
+type CreateReactionParams struct {
+	MessageID       string         `json:"message_id"`
+	Pubkey          string         `json:"pubkey"`
+	Emoji           string         `json:"emoji"`
+	Signature       string         `json:"signature"`
+	SignedTimestamp int64          `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+func (q *Queries) CreateReaction(ctx context.Context, arg CreateReactionParams) error {
+	_, err := q.db.ExecContext(ctx, createReaction,
+		arg.MessageID,
+		arg.Pubkey,
+		arg.Emoji,
+		arg.Signature,
+		arg.SignedTimestamp,
+		arg.EventVersion,
+		arg.Tags,
+		arg.SigScheme,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createRoom = `-- name: CreateRoom :one
-INSERT INTO rooms (name, password_hash, created_at, updated_at)
-VALUES (?, ?, ?, ?)
-RETURNING name, created_at, updated_at, password_hash
+INSERT INTO rooms (name, password_hash, key_salt, private, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING name, created_at, updated_at, password_hash, key_salt, description, private, retention_max_age, retention_max_messages
 `
 
 type CreateRoomParams struct {
 	Name         string         `json:"name"`
 	PasswordHash sql.NullString `json:"password_hash"`
+	KeySalt      sql.NullString `json:"key_salt"`
+	Private      bool           `json:"private"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 }
@@ -70,6 +274,8 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 	row := q.db.QueryRowContext(ctx, createRoom,
 		arg.Name,
 		arg.PasswordHash,
+		arg.KeySalt,
+		arg.Private,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -79,6 +285,73 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PasswordHash,
+		&i.KeySalt,
+		&i.Description,
+		&i.Private,
+		&i.RetentionMaxAge,
+		&i.RetentionMaxMessages,
+	)
+	return i, err
+}
+
+const createRoomMember = `-- name: CreateRoomMember :exec
+INSERT INTO room_members (room_name, pubkey, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (room_name, pubkey) DO NOTHING
+`
+
+type CreateRoomMemberParams struct {
+	RoomName  string    `json:"room_name"`
+	Pubkey    string    `json:"pubkey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CreateRoomMember(ctx context.Context, arg CreateRoomMemberParams) error {
+	_, err := q.db.ExecContext(ctx, createRoomMember, arg.RoomName, arg.Pubkey, arg.CreatedAt)
+	return err
+}
+
+const createSanction = `-- name: CreateSanction :one
+INSERT INTO sanctions (id, kind, room, pubkey, ip, reason, created_by, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, kind, room, pubkey, ip, reason, created_by, created_at, expires_at
+`
+
+type CreateSanctionParams struct {
+	ID        string       `json:"id"`
+	Kind      string       `json:"kind"`
+	Room      string       `json:"room"`
+	Pubkey    string       `json:"pubkey"`
+	Ip        string       `json:"ip"`
+	Reason    string       `json:"reason"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+}
+
+func (q *Queries) CreateSanction(ctx context.Context, arg CreateSanctionParams) (Sanction, error) {
+	row := q.db.QueryRowContext(ctx, createSanction,
+		arg.ID,
+		arg.Kind,
+		arg.Room,
+		arg.Pubkey,
+		arg.Ip,
+		arg.Reason,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var i Sanction
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Room,
+		&i.Pubkey,
+		&i.Ip,
+		&i.Reason,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -113,25 +386,277 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
-const getAllUsers = `-- name: GetAllUsers :many
-SELECT public_key, verified, created_at, updated_at FROM users
-LIMIT 100
+const deleteInvite = `-- name: DeleteInvite :execrows
+DELETE FROM room_invites WHERE room = ? AND id = ?
 `
 
-func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, getAllUsers)
+type DeleteInviteParams struct {
+	Room string `json:"room"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) DeleteInvite(ctx context.Context, arg DeleteInviteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteInvite, arg.Room, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteInviteUses = `-- name: DeleteInviteUses :exec
+DELETE FROM room_invite_uses WHERE invite_id = ?
+`
+
+func (q *Queries) DeleteInviteUses(ctx context.Context, inviteID string) error {
+	_, err := q.db.ExecContext(ctx, deleteInviteUses, inviteID)
+	return err
+}
+
+const deleteInviteUsesByRoom = `-- name: DeleteInviteUsesByRoom :exec
+DELETE FROM room_invite_uses WHERE invite_id IN (SELECT id FROM room_invites WHERE room = ?)
+`
+
+func (q *Queries) DeleteInviteUsesByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteInviteUsesByRoom, room)
+	return err
+}
+
+const deleteInvitesByRoom = `-- name: DeleteInvitesByRoom :exec
+DELETE FROM room_invites WHERE room = ?
+`
+
+func (q *Queries) DeleteInvitesByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteInvitesByRoom, room)
+	return err
+}
+
+const deleteMessageReactions = `-- name: DeleteMessageReactions :exec
+DELETE FROM reactions WHERE message_id = ?
+`
+
+func (q *Queries) DeleteMessageReactions(ctx context.Context, messageID string) error {
+	_, err := q.db.ExecContext(ctx, deleteMessageReactions, messageID)
+	return err
+}
+
+const deleteMessageRevisions = `-- name: DeleteMessageRevisions :exec
+DELETE FROM message_revisions WHERE message_id = ?
+`
+
+func (q *Queries) DeleteMessageRevisions(ctx context.Context, messageID string) error {
+	_, err := q.db.ExecContext(ctx, deleteMessageRevisions, messageID)
+	return err
+}
+
+const deleteMessageRevisionsByRoom = `-- name: DeleteMessageRevisionsByRoom :exec
+DELETE FROM message_revisions
+WHERE message_id IN (SELECT id FROM messages WHERE room = ?)
+`
+
+func (q *Queries) DeleteMessageRevisionsByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteMessageRevisionsByRoom, room)
+	return err
+}
+
+const deleteMessagesByRoom = `-- name: DeleteMessagesByRoom :exec
+DELETE FROM messages WHERE room = ?
+`
+
+func (q *Queries) DeleteMessagesByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteMessagesByRoom, room)
+	return err
+}
+
+const deleteOrphanMessageRevisions = `-- name: DeleteOrphanMessageRevisions :exec
+DELETE FROM message_revisions
+WHERE message_id NOT IN (SELECT id FROM messages)
+`
+
+func (q *Queries) DeleteOrphanMessageRevisions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteOrphanMessageRevisions)
+	return err
+}
+
+const deleteOrphanReactions = `-- name: DeleteOrphanReactions :exec
+DELETE FROM reactions
+WHERE message_id NOT IN (SELECT id FROM messages)
+`
+
+func (q *Queries) DeleteOrphanReactions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteOrphanReactions)
+	return err
+}
+
+const deleteReaction = `-- name: DeleteReaction :execrows
+DELETE FROM reactions WHERE message_id = ? AND pubkey = ? AND emoji = ?
+`
+
+type DeleteReactionParams struct {
+	MessageID string `json:"message_id"`
+	Pubkey    string `json:"pubkey"`
+	Emoji     string `json:"emoji"`
+}
+
+func (q *Queries) DeleteReaction(ctx context.Context, arg DeleteReactionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteReaction, arg.MessageID, arg.Pubkey, arg.Emoji)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteReactionsByRoom = `-- name: DeleteReactionsByRoom :exec
+DELETE FROM reactions
+WHERE message_id IN (SELECT id FROM messages WHERE room = ?)
+`
+
+func (q *Queries) DeleteReactionsByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteReactionsByRoom, room)
+	return err
+}
+
+const deleteRoom = `-- name: DeleteRoom :execrows
+DELETE FROM rooms WHERE name = ?
+`
+
+func (q *Queries) DeleteRoom(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoom, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoomMember = `-- name: DeleteRoomMember :execrows
+DELETE FROM room_members WHERE room_name = ? AND pubkey = ?
+`
+
+type DeleteRoomMemberParams struct {
+	RoomName string `json:"room_name"`
+	Pubkey   string `json:"pubkey"`
+}
+
+func (q *Queries) DeleteRoomMember(ctx context.Context, arg DeleteRoomMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoomMember, arg.RoomName, arg.Pubkey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoomMembersByRoom = `-- name: DeleteRoomMembersByRoom :exec
+DELETE FROM room_members WHERE room_name = ?
+`
+
+func (q *Queries) DeleteRoomMembersByRoom(ctx context.Context, roomName string) error {
+	_, err := q.db.ExecContext(ctx, deleteRoomMembersByRoom, roomName)
+	return err
+}
+
+const deleteRoomRole = `-- name: DeleteRoomRole :exec
+DELETE FROM room_roles WHERE room_name = ? AND pubkey = ?
+`
+
+type DeleteRoomRoleParams struct {
+	RoomName string `json:"room_name"`
+	Pubkey   string `json:"pubkey"`
+}
+
+func (q *Queries) DeleteRoomRole(ctx context.Context, arg DeleteRoomRoleParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRoomRole, arg.RoomName, arg.Pubkey)
+	return err
+}
+
+const deleteRoomRolesByRoom = `-- name: DeleteRoomRolesByRoom :exec
+DELETE FROM room_roles WHERE room_name = ?
+`
+
+func (q *Queries) DeleteRoomRolesByRoom(ctx context.Context, roomName string) error {
+	_, err := q.db.ExecContext(ctx, deleteRoomRolesByRoom, roomName)
+	return err
+}
+
+const deleteSanction = `-- name: DeleteSanction :execrows
+DELETE FROM sanctions WHERE room = ? AND id = ?
+`
+
+type DeleteSanctionParams struct {
+	Room string `json:"room"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) DeleteSanction(ctx context.Context, arg DeleteSanctionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSanction, arg.Room, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSanctionsByRoom = `-- name: DeleteSanctionsByRoom :exec
+DELETE FROM sanctions WHERE room = ?
+`
+
+func (q *Queries) DeleteSanctionsByRoom(ctx context.Context, room string) error {
+	_, err := q.db.ExecContext(ctx, deleteSanctionsByRoom, room)
+	return err
+}
+
+const findMessagesInRoom = `-- name: FindMessagesInRoom :many
+SELECT id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, deleted_at, edited_at, revisions, reply_to, replies FROM messages
+WHERE room = ?1
+  AND (?2 = '' OR pubkey = ?2 OR substr(pubkey, 3) = ?2)
+  AND (?3 = '' OR sig_scheme = ?3)
+  AND signature IS NOT NULL
+  AND deleted_at IS NULL
+  AND signed_timestamp >= ?4
+  AND signed_timestamp <= ?5
+ORDER BY signed_timestamp DESC
+LIMIT ?6
+`
+
+type FindMessagesInRoomParams struct {
+	Room      string         `json:"room"`
+	Author    sql.NullString `json:"author"`
+	SigScheme string         `json:"sig_scheme"`
+	Since     sql.NullInt64  `json:"since"`
+	Until     sql.NullInt64  `json:"until"`
+	Limit     int64          `json:"limit"`
+}
+
+func (q *Queries) FindMessagesInRoom(ctx context.Context, arg FindMessagesInRoomParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, findMessagesInRoom,
+		arg.Room,
+		arg.Author,
+		arg.SigScheme,
+		arg.Since,
+		arg.Until,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []Message{}
 	for rows.Next() {
-		var i User
+		var i Message
 		if err := rows.Scan(
-			&i.PublicKey,
-			&i.Verified,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.ID,
+			&i.Room,
+			&i.User,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.Pubkey,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
 		); err != nil {
 			return nil, err
 		}
@@ -146,11 +671,219 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
-const getMessageCountByRoom = `-- name: GetMessageCountByRoom :one
-SELECT COUNT(*) as count
-FROM messages
-WHERE room = ?
-`
+const getAllUsers = `-- name: GetAllUsers :many
+SELECT public_key, verified, created_at, updated_at FROM users
+LIMIT 100
+`
+
+func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getAllUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.PublicKey,
+			&i.Verified,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDirectMessages = `-- name: GetDirectMessages :many
+SELECT id, sender, recipient, content, timestamp, signature, signed_timestamp, event_version, tags FROM direct_messages
+WHERE ((sender = ?1 OR substr(sender, 3) = ?1)
+       AND (?2 = '' OR recipient = ?2 OR substr(recipient, 3) = ?2)
+    OR (recipient = ?1 OR substr(recipient, 3) = ?1)
+       AND (?2 = '' OR sender = ?2 OR substr(sender, 3) = ?2))
+  AND timestamp < ?3
+ORDER BY timestamp DESC
+LIMIT ?4
+`
+
+type GetDirectMessagesParams struct {
+	Pubkey string    `json:"pubkey"`
+	Peer   string    `json:"peer"`
+	Before time.Time `json:"before"`
+	Limit  int64     `json:"limit"`
+}
+
+func (q *Queries) GetDirectMessages(ctx context.Context, arg GetDirectMessagesParams) ([]DirectMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getDirectMessages,
+		arg.Pubkey,
+		arg.Peer,
+		arg.Before,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DirectMessage{}
+	for rows.Next() {
+		var i DirectMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sender,
+			&i.Recipient,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInviteByTokenHash = `-- name: GetInviteByTokenHash :one
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = ? AND token_hash = ?
+`
+
+type GetInviteByTokenHashParams struct {
+	Room      string `json:"room"`
+	TokenHash string `json:"token_hash"`
+}
+
+type GetInviteByTokenHashRow struct {
+	ID        string       `json:"id"`
+	Room      string       `json:"room"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	MaxUses   int64        `json:"max_uses"`
+	Uses      int64        `json:"uses"`
+}
+
+func (q *Queries) GetInviteByTokenHash(ctx context.Context, arg GetInviteByTokenHashParams) (GetInviteByTokenHashRow, error) {
+	row := q.db.QueryRowContext(ctx, getInviteByTokenHash, arg.Room, arg.TokenHash)
+	var i GetInviteByTokenHashRow
+	err := row.Scan(
+		&i.ID,
+		&i.Room,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.MaxUses,
+		&i.Uses,
+	)
+	return i, err
+}
+
+const getInvites = `-- name: GetInvites :many
+SELECT id, room, created_by, created_at, expires_at, max_uses,
+    (SELECT COUNT(*) FROM room_invite_uses WHERE invite_id = room_invites.id) as uses
+FROM room_invites
+WHERE room = ?
+ORDER BY rowid ASC
+`
+
+type GetInvitesRow struct {
+	ID        string       `json:"id"`
+	Room      string       `json:"room"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	MaxUses   int64        `json:"max_uses"`
+	Uses      int64        `json:"uses"`
+}
+
+func (q *Queries) GetInvites(ctx context.Context, room string) ([]GetInvitesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getInvites, room)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInvitesRow{}
+	for rows.Next() {
+		var i GetInvitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Room,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.MaxUses,
+			&i.Uses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessage = `-- name: GetMessage :one
+SELECT id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, deleted_at, edited_at, revisions, reply_to, replies FROM messages WHERE room = ? AND id = ?
+`
+
+type GetMessageParams struct {
+	Room string `json:"room"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) GetMessage(ctx context.Context, arg GetMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessage, arg.Room, arg.ID)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.Room,
+		&i.User,
+		&i.Content,
+		&i.Timestamp,
+		&i.Signature,
+		&i.Pubkey,
+		&i.SignedTimestamp,
+		&i.EventVersion,
+		&i.Tags,
+		&i.SigScheme,
+		&i.DeletedAt,
+		&i.EditedAt,
+		&i.Revisions,
+		&i.ReplyTo,
+		&i.Replies,
+	)
+	return i, err
+}
+
+const getMessageCountByRoom = `-- name: GetMessageCountByRoom :one
+SELECT COUNT(*) as count
+FROM messages
+WHERE room = ?
+`
 
 func (q *Queries) GetMessageCountByRoom(ctx context.Context, room string) (int64, error) {
 	row := q.db.QueryRowContext(ctx, getMessageCountByRoom, room)
@@ -159,22 +892,283 @@ func (q *Queries) GetMessageCountByRoom(ctx context.Context, room string) (int64
 	return count, err
 }
 
+const getMessageRevisions = `-- name: GetMessageRevisions :many
+SELECT message_id, revision, content, signature, signed_timestamp, event_version, tags, sig_scheme, created_at FROM message_revisions
+WHERE message_id = ?
+ORDER BY revision
+`
+
+func (q *Queries) GetMessageRevisions(ctx context.Context, messageID string) ([]MessageRevision, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageRevisions, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MessageRevision{}
+	for rows.Next() {
+		var i MessageRevision
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.Revision,
+			&i.Content,
+			&i.Signature,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesAfterCursor = `-- name: GetMessagesAfterCursor :many
+SELECT m.id, m.room, m.user, m.content, m.timestamp, m.signature, m.pubkey, m.signed_timestamp, m.event_version, m.tags, m.sig_scheme, m.deleted_at, m.edited_at, m.revisions, m.reply_to, m.replies FROM messages m
+WHERE m.room = ?1
+  AND (m.timestamp, m.id) > (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = ?1 AND c.id = ?2), ?3),
+    ?2
+  )
+ORDER BY m.timestamp, m.id
+LIMIT ?4
+`
+
+type GetMessagesAfterCursorParams struct {
+	Room            string    `json:"room"`
+	CursorID        string    `json:"cursor_id"`
+	CursorTimestamp time.Time `json:"cursor_timestamp"`
+	Limit           int64     `json:"limit"`
+}
+
+func (q *Queries) GetMessagesAfterCursor(ctx context.Context, arg GetMessagesAfterCursorParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesAfterCursor,
+		arg.Room,
+		arg.CursorID,
+		arg.CursorTimestamp,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Message{}
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.Room,
+			&i.User,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.Pubkey,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesBeforeCursor = `-- name: GetMessagesBeforeCursor :many
+-- The cursor is at the stored timestamp of its message, which compares exactly,
+-- or at the timestamp it carries once the message is gone.
+SELECT m.id, m.room, m.user, m.content, m.timestamp, m.signature, m.pubkey, m.signed_timestamp, m.event_version, m.tags, m.sig_scheme, m.deleted_at, m.edited_at, m.revisions, m.reply_to, m.replies FROM messages m
+WHERE m.room = ?1
+  AND (m.timestamp, m.id) < (
+    COALESCE((SELECT c.timestamp FROM messages c WHERE c.room = ?1 AND c.id = ?2), ?3),
+    ?2
+  )
+ORDER BY m.timestamp DESC, m.id DESC
+LIMIT ?4
+`
+
+type GetMessagesBeforeCursorParams struct {
+	Room            string    `json:"room"`
+	CursorID        string    `json:"cursor_id"`
+	CursorTimestamp time.Time `json:"cursor_timestamp"`
+	Limit           int64     `json:"limit"`
+}
+
+// The cursor is at the stored timestamp of its message, which compares exactly,
+// or at the timestamp it carries once the message is gone.
+func (q *Queries) GetMessagesBeforeCursor(ctx context.Context, arg GetMessagesBeforeCursorParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesBeforeCursor,
+		arg.Room,
+		arg.CursorID,
+		arg.CursorTimestamp,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Message{}
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.Room,
+			&i.User,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.Pubkey,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMessagesByRoomPaginated = `-- name: GetMessagesByRoomPaginated :many
-SELECT id, room, user, content, timestamp, signature, pubkey, signed_timestamp FROM messages
+SELECT id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, deleted_at, edited_at, revisions, reply_to, replies FROM messages
 WHERE room = ?
   AND timestamp < ?
 ORDER BY timestamp DESC
 LIMIT ?
 `
 
-type GetMessagesByRoomPaginatedParams struct {
-	Room      string    `json:"room"`
-	Timestamp time.Time `json:"timestamp"`
-	Limit     int64     `json:"limit"`
+type GetMessagesByRoomPaginatedParams struct {
+	Room      string    `json:"room"`
+	Timestamp time.Time `json:"timestamp"`
+	Limit     int64     `json:"limit"`
+}
+
+func (q *Queries) GetMessagesByRoomPaginated(ctx context.Context, arg GetMessagesByRoomPaginatedParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesByRoomPaginated, arg.Room, arg.Timestamp, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Message{}
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.Room,
+			&i.User,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.Pubkey,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReactions = `-- name: GetReactions :many
+SELECT message_id, pubkey, emoji, signature, signed_timestamp, event_version, tags, sig_scheme, created_at FROM reactions
+WHERE message_id = ?
+ORDER BY created_at
+`
+
+func (q *Queries) GetReactions(ctx context.Context, messageID string) ([]Reaction, error) {
+	rows, err := q.db.QueryContext(ctx, getReactions, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Reaction{}
+	for rows.Next() {
+		var i Reaction
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.Pubkey,
+			&i.Emoji,
+			&i.Signature,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReplies = `-- name: GetReplies :many
+SELECT id, room, user, content, timestamp, signature, pubkey, signed_timestamp, event_version, tags, sig_scheme, deleted_at, edited_at, revisions, reply_to, replies FROM messages
+WHERE room = ? AND reply_to = ?
+ORDER BY timestamp DESC
+LIMIT ?
+`
+
+type GetRepliesParams struct {
+	Room    string         `json:"room"`
+	ReplyTo sql.NullString `json:"reply_to"`
+	Limit   int64          `json:"limit"`
 }
 
-func (q *Queries) GetMessagesByRoomPaginated(ctx context.Context, arg GetMessagesByRoomPaginatedParams) ([]Message, error) {
-	rows, err := q.db.QueryContext(ctx, getMessagesByRoomPaginated, arg.Room, arg.Timestamp, arg.Limit)
+func (q *Queries) GetReplies(ctx context.Context, arg GetRepliesParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getReplies, arg.Room, arg.ReplyTo, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -191,6 +1185,14 @@ func (q *Queries) GetMessagesByRoomPaginated(ctx context.Context, arg GetMessage
 			&i.Signature,
 			&i.Pubkey,
 			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
 		); err != nil {
 			return nil, err
 		}
@@ -206,7 +1208,7 @@ func (q *Queries) GetMessagesByRoomPaginated(ctx context.Context, arg GetMessage
 }
 
 const getRoomByName = `-- name: GetRoomByName :one
-SELECT name, created_at, updated_at, password_hash FROM rooms
+SELECT name, created_at, updated_at, password_hash, key_salt, description, private, retention_max_age, retention_max_messages FROM rooms
 WHERE name = ?
 `
 
@@ -218,10 +1220,44 @@ func (q *Queries) GetRoomByName(ctx context.Context, name string) (Room, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PasswordHash,
+		&i.KeySalt,
+		&i.Description,
+		&i.Private,
+		&i.RetentionMaxAge,
+		&i.RetentionMaxMessages,
 	)
 	return i, err
 }
 
+const getRoomMemberList = `-- name: GetRoomMemberList :many
+SELECT pubkey FROM room_members
+WHERE room_name = ?
+ORDER BY rowid ASC
+`
+
+func (q *Queries) GetRoomMemberList(ctx context.Context, roomName string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getRoomMemberList, roomName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var pubkey string
+		if err := rows.Scan(&pubkey); err != nil {
+			return nil, err
+		}
+		items = append(items, pubkey)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRoomPasswordHash = `-- name: GetRoomPasswordHash :one
 SELECT password_hash FROM rooms WHERE name = ?
 `
@@ -233,10 +1269,80 @@ func (q *Queries) GetRoomPasswordHash(ctx context.Context, name string) (sql.Nul
 	return password_hash, err
 }
 
+const getRoomRetentions = `-- name: GetRoomRetentions :many
+SELECT name, retention_max_age, retention_max_messages FROM rooms
+`
+
+type GetRoomRetentionsRow struct {
+	Name                 string `json:"name"`
+	RetentionMaxAge      int64  `json:"retention_max_age"`
+	RetentionMaxMessages int64  `json:"retention_max_messages"`
+}
+
+func (q *Queries) GetRoomRetentions(ctx context.Context) ([]GetRoomRetentionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRoomRetentions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRoomRetentionsRow{}
+	for rows.Next() {
+		var i GetRoomRetentionsRow
+		if err := rows.Scan(&i.Name, &i.RetentionMaxAge, &i.RetentionMaxMessages); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoomRoles = `-- name: GetRoomRoles :many
+SELECT pubkey, role FROM room_roles
+WHERE room_name = ?
+ORDER BY role = 'owner' DESC, rowid ASC
+`
+
+type GetRoomRolesRow struct {
+	Pubkey string `json:"pubkey"`
+	Role   string `json:"role"`
+}
+
+func (q *Queries) GetRoomRoles(ctx context.Context, roomName string) ([]GetRoomRolesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRoomRoles, roomName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRoomRolesRow{}
+	for rows.Next() {
+		var i GetRoomRolesRow
+		if err := rows.Scan(&i.Pubkey, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRoomsWithLasMessage = `-- name: GetRoomsWithLasMessage :many
 SELECT
     r.name,
+    r.description,
     CASE WHEN r.password_hash IS NOT NULL THEN 1 ELSE 0 END as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
     COALESCE(last_msg.content, '') as last_message_content,
     COALESCE(last_msg.user, '') as last_message_user,
     CASE
@@ -259,7 +1365,10 @@ LIMIT 100
 
 type GetRoomsWithLasMessageRow struct {
 	Name                 string `json:"name"`
+	Description          string `json:"description"`
 	HasPassword          int64  `json:"has_password"`
+	KeySalt              string `json:"key_salt"`
+	Private              bool   `json:"private"`
 	LastMessageContent   string `json:"last_message_content"`
 	LastMessageUser      string `json:"last_message_user"`
 	LastMessageTimestamp string `json:"last_message_timestamp"`
@@ -276,7 +1385,10 @@ func (q *Queries) GetRoomsWithLasMessage(ctx context.Context) ([]GetRoomsWithLas
 		var i GetRoomsWithLasMessageRow
 		if err := rows.Scan(
 			&i.Name,
+			&i.Description,
 			&i.HasPassword,
+			&i.KeySalt,
+			&i.Private,
 			&i.LastMessageContent,
 			&i.LastMessageUser,
 			&i.LastMessageTimestamp,
@@ -294,6 +1406,79 @@ func (q *Queries) GetRoomsWithLasMessage(ctx context.Context) ([]GetRoomsWithLas
 	return items, nil
 }
 
+const getSanctions = `-- name: GetSanctions :many
+SELECT id, kind, room, pubkey, ip, reason, created_by, created_at, expires_at FROM sanctions
+WHERE room = ?
+ORDER BY rowid ASC
+`
+
+func (q *Queries) GetSanctions(ctx context.Context, room string) ([]Sanction, error) {
+	rows, err := q.db.QueryContext(ctx, getSanctions, room)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Sanction{}
+	for rows.Next() {
+		var i Sanction
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Room,
+			&i.Pubkey,
+			&i.Ip,
+			&i.Reason,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSearchableRooms = `-- name: GetSearchableRooms :many
+SELECT name, private FROM rooms
+WHERE password_hash IS NULL AND COALESCE(key_salt, '') = ''
+ORDER BY name ASC
+`
+
+type GetSearchableRoomsRow struct {
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+}
+
+func (q *Queries) GetSearchableRooms(ctx context.Context) ([]GetSearchableRoomsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSearchableRooms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSearchableRoomsRow{}
+	for rows.Next() {
+		var i GetSearchableRoomsRow
+		if err := rows.Scan(&i.Name, &i.Private); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByPublicKey = `-- name: GetUserByPublicKey :one
 SELECT public_key, verified, created_at, updated_at FROM users
 WHERE public_key = ?
@@ -352,6 +1537,127 @@ func (q *Queries) GetUserWithPostCount(ctx context.Context, publicKey string) (G
 	return i, err
 }
 
+const incrementReplies = `-- name: IncrementReplies :exec
+UPDATE messages SET replies = replies + 1 WHERE room = ? AND id = ?
+`
+
+type IncrementRepliesParams struct {
+	Room string `json:"room"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) IncrementReplies(ctx context.Context, arg IncrementRepliesParams) error {
+	_, err := q.db.ExecContext(ctx, incrementReplies, arg.Room, arg.ID)
+	return err
+}
+
+const inviteRedeemedBy = `-- name: InviteRedeemedBy :one
+SELECT COUNT(*) > 0 as redeemed FROM room_invite_uses WHERE invite_id = ? AND pubkey = ?
+`
+
+type InviteRedeemedByParams struct {
+	InviteID string `json:"invite_id"`
+	Pubkey   string `json:"pubkey"`
+}
+
+func (q *Queries) InviteRedeemedBy(ctx context.Context, arg InviteRedeemedByParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, inviteRedeemedBy, arg.InviteID, arg.Pubkey)
+	var redeemed bool
+	err := row.Scan(&redeemed)
+	return redeemed, err
+}
+
+const messageSignatureExists = `-- name: MessageSignatureExists :one
+SELECT COUNT(*) > 0 as signature_exists FROM messages WHERE pubkey = ? AND signature = ?
+`
+
+type MessageSignatureExistsParams struct {
+	Pubkey    sql.NullString `json:"pubkey"`
+	Signature sql.NullString `json:"signature"`
+}
+
+func (q *Queries) MessageSignatureExists(ctx context.Context, arg MessageSignatureExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, messageSignatureExists, arg.Pubkey, arg.Signature)
+	var signature_exists bool
+	err := row.Scan(&signature_exists)
+	return signature_exists, err
+}
+
+const pruneMessages = `-- name: PruneMessages :execrows
+DELETE FROM messages
+WHERE room = ?1
+  AND (
+    timestamp < ?2
+    OR (timestamp, id) <= (
+      SELECT k.timestamp, k.id FROM messages k
+      WHERE k.room = ?1
+      ORDER BY k.timestamp DESC, k.id DESC
+      LIMIT 1 OFFSET ?3
+    )
+  )
+`
+
+type PruneMessagesParams struct {
+	Room   string    `json:"room"`
+	Before time.Time `json:"before"`
+	Keep   int64     `json:"keep"`
+}
+
+// Deletes the messages of a room received before a time, and those older than
+// its latest keep messages: the message at offset keep is the newest pruned.
+func (q *Queries) PruneMessages(ctx context.Context, arg PruneMessagesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pruneMessages, arg.Room, arg.Before, arg.Keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reviseMessage = `-- name: ReviseMessage :execrows
+UPDATE messages
+SET content = ?1,
+    signature = ?2,
+    signed_timestamp = ?3,
+    event_version = ?4,
+    tags = ?5,
+    sig_scheme = ?6,
+    edited_at = ?7,
+    revisions = revisions + 1
+WHERE room = ?8 AND id = ?9
+  AND deleted_at IS NULL
+  AND signed_timestamp < ?3
+`
+
+type ReviseMessageParams struct {
+	Content         string         `json:"content"`
+	Signature       sql.NullString `json:"signature"`
+	SignedTimestamp sql.NullInt64  `json:"signed_timestamp"`
+	EventVersion    int64          `json:"event_version"`
+	Tags            sql.NullString `json:"tags"`
+	SigScheme       string         `json:"sig_scheme"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	Room            string         `json:"room"`
+	ID              string         `json:"id"`
+}
+
+func (q *Queries) ReviseMessage(ctx context.Context, arg ReviseMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reviseMessage,
+		arg.Content,
+		arg.Signature,
+		arg.SignedTimestamp,
+		arg.EventVersion,
+		arg.Tags,
+		arg.SigScheme,
+		arg.EditedAt,
+		arg.Room,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const roomExists = `-- name: RoomExists :one
 SELECT COUNT(*) > 0 as room_exists FROM rooms WHERE name = ?
 `
@@ -363,10 +1669,79 @@ func (q *Queries) RoomExists(ctx context.Context, name string) (bool, error) {
 	return room_exists, err
 }
 
+const searchMessages = `-- name: SearchMessages :many
+SELECT m.id, m.room, m.user, m.content, m.timestamp, m.signature, m.pubkey, m.signed_timestamp, m.event_version, m.tags, m.sig_scheme, m.deleted_at, m.edited_at, m.revisions, m.reply_to, m.replies FROM messages_fts
+JOIN messages m ON m.rowid = messages_fts.rowid
+WHERE messages_fts MATCH ?1
+  AND m.room IN (SELECT value FROM json_each(?2))
+  AND (?3 = '' OR m.pubkey = ?3 OR substr(m.pubkey, 3) = ?3)
+  AND m.deleted_at IS NULL
+  AND m.timestamp < ?4
+ORDER BY m.timestamp DESC
+LIMIT ?5
+`
+
+type SearchMessagesParams struct {
+	Query  string         `json:"query"`
+	Rooms  string         `json:"rooms"`
+	Author sql.NullString `json:"author"`
+	Before time.Time      `json:"before"`
+	Limit  int64          `json:"limit"`
+}
+
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, searchMessages,
+		arg.Query,
+		arg.Rooms,
+		arg.Author,
+		arg.Before,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Message{}
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.Room,
+			&i.User,
+			&i.Content,
+			&i.Timestamp,
+			&i.Signature,
+			&i.Pubkey,
+			&i.SignedTimestamp,
+			&i.EventVersion,
+			&i.Tags,
+			&i.SigScheme,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.Revisions,
+			&i.ReplyTo,
+			&i.Replies,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchRoomsByName = `-- name: SearchRoomsByName :many
 SELECT
     r.name,
+    r.description,
     CASE WHEN r.password_hash IS NOT NULL THEN 1 ELSE 0 END as has_password,
+    COALESCE(r.key_salt, '') as key_salt,
+    r.private,
     COALESCE(last_msg.content, '') as last_message_content,
     COALESCE(last_msg.user, '') as last_message_user,
     CASE
@@ -390,7 +1765,10 @@ LIMIT 100
 
 type SearchRoomsByNameRow struct {
 	Name                 string `json:"name"`
+	Description          string `json:"description"`
 	HasPassword          int64  `json:"has_password"`
+	KeySalt              string `json:"key_salt"`
+	Private              bool   `json:"private"`
 	LastMessageContent   string `json:"last_message_content"`
 	LastMessageUser      string `json:"last_message_user"`
 	LastMessageTimestamp string `json:"last_message_timestamp"`
@@ -407,7 +1785,10 @@ func (q *Queries) SearchRoomsByName(ctx context.Context, dollar_1 sql.NullString
 		var i SearchRoomsByNameRow
 		if err := rows.Scan(
 			&i.Name,
+			&i.Description,
 			&i.HasPassword,
+			&i.KeySalt,
+			&i.Private,
 			&i.LastMessageContent,
 			&i.LastMessageUser,
 			&i.LastMessageTimestamp,
@@ -425,7 +1806,90 @@ func (q *Queries) SearchRoomsByName(ctx context.Context, dollar_1 sql.NullString
 	return items, nil
 }
 
-const updateUserVerified = `-- name: UpdateUserVerified :exec
+const tombstoneMessage = `-- name: TombstoneMessage :exec
+UPDATE messages
+SET content = '', deleted_at = ?
+WHERE room = ? AND id = ? AND deleted_at IS NULL
+`
+
+type TombstoneMessageParams struct {
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	Room      string       `json:"room"`
+	ID        string       `json:"id"`
+}
+
+func (q *Queries) TombstoneMessage(ctx context.Context, arg TombstoneMessageParams) error {
+	_, err := q.db.ExecContext(ctx, tombstoneMessage, arg.DeletedAt, arg.Room, arg.ID)
+	return err
+}
+
+const updateRoomDescription = `-- name: UpdateRoomDescription :execrows
+UPDATE rooms
+SET description = ?, updated_at = ?
+WHERE name = ?
+`
+
+type UpdateRoomDescriptionParams struct {
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+}
+
+func (q *Queries) UpdateRoomDescription(ctx context.Context, arg UpdateRoomDescriptionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateRoomDescription, arg.Description, arg.UpdatedAt, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateRoomPassword = `-- name: UpdateRoomPassword :execrows
+UPDATE rooms
+SET password_hash = ?, updated_at = ?
+WHERE name = ?
+`
+
+type UpdateRoomPasswordParams struct {
+	PasswordHash sql.NullString `json:"password_hash"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	Name         string         `json:"name"`
+}
+
+func (q *Queries) UpdateRoomPassword(ctx context.Context, arg UpdateRoomPasswordParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateRoomPassword, arg.PasswordHash, arg.UpdatedAt, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateRoomRetention = `-- name: UpdateRoomRetention :execrows
+UPDATE rooms
+SET retention_max_age = ?, retention_max_messages = ?, updated_at = ?
+WHERE name = ?
+`
+
+type UpdateRoomRetentionParams struct {
+	RetentionMaxAge      int64     `json:"retention_max_age"`
+	RetentionMaxMessages int64     `json:"retention_max_messages"`
+	UpdatedAt            time.Time `json:"updated_at"`
+	Name                 string    `json:"name"`
+}
+
+func (q *Queries) UpdateRoomRetention(ctx context.Context, arg UpdateRoomRetentionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateRoomRetention,
+		arg.RetentionMaxAge,
+		arg.RetentionMaxMessages,
+		arg.UpdatedAt,
+		arg.Name,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserVerified = `-- name: UpdateUserVerified :execrows
 UPDATE users
 SET verified = ?, updated_at = ?
 WHERE public_key = ?
@@ -437,8 +1901,34 @@ type UpdateUserVerifiedParams struct {
 	PublicKey string    `json:"public_key"`
 }
 
-func (q *Queries) UpdateUserVerified(ctx context.Context, arg UpdateUserVerifiedParams) error {
-	_, err := q.db.ExecContext(ctx, updateUserVerified, arg.Verified, arg.UpdatedAt, arg.PublicKey)
+func (q *Queries) UpdateUserVerified(ctx context.Context, arg UpdateUserVerifiedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserVerified, arg.Verified, arg.UpdatedAt, arg.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertRoomRole = `-- name: UpsertRoomRole :exec
+INSERT INTO room_roles (room_name, pubkey, role, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (room_name, pubkey) DO UPDATE SET role = excluded.role
+`
+
+type UpsertRoomRoleParams struct {
+	RoomName  string    `json:"room_name"`
+	Pubkey    string    `json:"pubkey"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) UpsertRoomRole(ctx context.Context, arg UpsertRoomRoleParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRoomRole,
+		arg.RoomName,
+		arg.Pubkey,
+		arg.Role,
+		arg.CreatedAt,
+	)
 	return err
 }
 
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/repositorytest"
+	"github.com/EwenQuim/microchat/internal/services"
+)
+
+func TestConformance(t *testing.T) {
+	// Migrate once, then give each test a copy of the migrated database. A
+	// file rather than :memory:, which each pooled connection would open as
+	// a distinct empty database.
+	template := filepath.Join(t.TempDir(), "template.db")
+	db, err := InitDB(template)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	if err := Close(db); err != nil {
+		t.Fatal(err)
+	}
+	migrated, err := os.ReadFile(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repositorytest.Run(t, func(t *testing.T) services.Repository {
+		path := filepath.Join(t.TempDir(), "microchat.db")
+		if err := os.WriteFile(path, migrated, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Durability does not matter here, and syncing every write is slow
+		db, err := InitDB(path + "?_pragma=synchronous(off)")
+		if err != nil {
+			t.Fatalf("InitDB: %v", err)
+		}
+		t.Cleanup(func() { _ = Close(db) })
+		return NewStore(db)
+	})
+}
+
+func TestPruneMessages_DeletesRevisionsAndReactions(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitDB(filepath.Join(t.TempDir(), "microchat.db") + "?_pragma=synchronous(off)")
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(db) })
+	store := NewStore(db)
+
+	var ids []string
+	for range 2 {
+		msg, err := store.SaveMessage(ctx, models.Message{Room: "room", User: "user", Content: "content", SignedTimestamp: 1})
+		if err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+		ids = append(ids, msg.ID)
+		if _, err := store.EditMessage(ctx, "room", msg.ID, models.MessageRevision{Content: "edited", SignedTimestamp: 2}); err != nil {
+			t.Fatalf("EditMessage: %v", err)
+		}
+		if _, err := store.AddReaction(ctx, "room", models.Reaction{MessageID: msg.ID, Pubkey: "pubkey", Emoji: "👍", Signature: "sig", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("AddReaction: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := store.PruneMessages(ctx, "room", time.Time{}, 1); err != nil {
+		t.Fatalf("PruneMessages: %v", err)
+	}
+	for _, table := range []string{"message_revisions", "reactions"} {
+		var pruned, kept int
+		if err := db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE message_id = ?", ids[0]).Scan(&pruned); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE message_id = ?", ids[1]).Scan(&kept); err != nil {
+			t.Fatal(err)
+		}
+		if pruned != 0 || kept != 1 {
+			t.Errorf("%s: got %d rows of the pruned message and %d of the kept one, want 0 and 1", table, pruned, kept)
+		}
+	}
+}
+
+func TestCompact_EnablesIncrementalVacuum(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitDB(filepath.Join(t.TempDir(), "microchat.db"))
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(db) })
+	store := NewStore(db)
+
+	// Once to enable incremental vacuum, then to run it
+	for range 2 {
+		if err := store.Compact(ctx); err != nil {
+			t.Fatalf("Compact: %v", err)
+		}
+	}
+	var mode int
+	if err := db.QueryRow("PRAGMA auto_vacuum").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != autoVacuumIncremental {
+		t.Errorf("got auto_vacuum %d, want incremental (%d)", mode, autoVacuumIncremental)
+	}
+}
@@ -2,25 +2,294 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/pkg/crypto"
 )
 
+// ErrDuplicateMessage is returned by Repository.SaveMessage when a message with
+// the same (pubkey, signature) pair was already stored, i.e. a replay.
+var ErrDuplicateMessage = errors.New("message already received")
+
+// ErrRoomNotFound is returned by Repository.ValidateRoomPassword for a room
+// that does not exist yet.
+var ErrRoomNotFound = errors.New("room not found")
+
+// ErrRoomEncrypted is returned by ChatService.SetRoomPassword for an
+// encrypted room: its messages stay encrypted with a key derived from the
+// password it was created with.
+var ErrRoomEncrypted = errors.New("room is end-to-end encrypted: its password cannot change")
+
+// ErrPermissionDenied is returned by the ChatService operations restricted to
+// room roles when the actor does not hold a role allowing them.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrMuted is returned by ChatService.SendMessage and SendDirectMessage for a
+// muted pubkey or IP address.
+var ErrMuted = errors.New("muted")
+
+// ErrBanned is returned by the ChatService operations that send, edit or react
+// for a banned pubkey or IP address.
+var ErrBanned = errors.New("banned")
+
+// ErrSanctionNotFound is returned by Repository.DeleteSanction for an unknown
+// sanction.
+var ErrSanctionNotFound = errors.New("sanction not found")
+
+// ErrInviteInvalid is returned by Repository.RedeemInvite for an unknown,
+// expired or used up invite.
+var ErrInviteInvalid = errors.New("invalid or expired invite")
+
+// ErrInviteNotFound is returned by Repository.DeleteInvite for an unknown
+// invite.
+var ErrInviteNotFound = errors.New("invite not found")
+
+// ErrSignatureRequired is returned by ChatService.ValidateRoomAccess for a
+// private room when the caller is not identified by a signature.
+var ErrSignatureRequired = errors.New("private room: the request must be signed by a member")
+
+// ErrNotMember is returned by ChatService.ValidateRoomAccess for a private room
+// when the caller is not one of its members.
+var ErrNotMember = errors.New("private room: not a member")
+
+// ErrRoomNotPrivate is returned by ChatService.AddRoomMember for a room that
+// anyone may read.
+var ErrRoomNotPrivate = errors.New("room is not private: it has no member list")
+
+// ErrMemberNotFound is returned by Repository.RemoveRoomMember for a pubkey
+// missing from the member list of the room.
+var ErrMemberNotFound = errors.New("member not found")
+
+// ErrSearchEncrypted is returned by ChatService.SearchMessages for an
+// end-to-end encrypted room, whose contents only its members can read.
+var ErrSearchEncrypted = errors.New("room is end-to-end encrypted: the server cannot search its messages")
+
+// ErrUserNotFound is returned by the user lookups and updates of a Repository
+// for an unknown public key.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrMessageNotFound is returned by Repository.GetMessage and DeleteMessage
+// when the room holds no message with the given ID.
+var ErrMessageNotFound = errors.New("message not found")
+
+// ErrMessageDeleted is returned by Repository.EditMessage for a tombstone.
+var ErrMessageDeleted = errors.New("message was deleted")
+
+// ErrStaleRevision is returned by Repository.EditMessage when the edit is not
+// signed after the current revision, e.g. a replayed or concurrent edit.
+var ErrStaleRevision = errors.New("edit is not newer than the current revision")
+
+// ErrReactionNotFound is returned by Repository.RemoveReaction when the pubkey
+// did not react to the message with that emoji.
+var ErrReactionNotFound = errors.New("reaction not found")
+
 // MessageQueryParams controls pagination for GetMessages.
 // Zero values apply defaults: Limit=50, Before=now.
 type MessageQueryParams struct {
 	Limit  int        // 0 = default (50)
 	Before *time.Time // nil = latest
+
+	// Cursors take precedence over Before. They are only read by GetMessages.
+	BeforeCursor *MessageCursor // only messages older than the cursor
+	AfterCursor  *MessageCursor // only messages newer than the cursor: the oldest of them are returned
+}
+
+// MessageFilter selects signed messages across rooms, as needed by the Nostr
+// bridge. Empty fields do not filter; Limit 0 applies the default (50).
+type MessageFilter struct {
+	Rooms     []string // required: distinct rooms to read
+	Authors   []string // pubkeys, matched in compressed or x-only (32-byte) hex form
+	SigScheme string   // only messages signed with this scheme
+	Since     int64    // signed timestamp lower bound (Unix seconds, inclusive)
+	Until     int64    // signed timestamp upper bound (Unix seconds, inclusive)
+	Limit     int
+}
+
+// Match reports whether msg satisfies every field of the filter but Limit.
+// Deleted messages never match.
+func (f MessageFilter) Match(msg models.Message) bool {
+	if msg.Signature == "" || msg.DeletedAt != nil || !slices.Contains(f.Rooms, msg.Room) {
+		return false
+	}
+	if len(f.Authors) > 0 && !slices.ContainsFunc(f.Authors, func(author string) bool {
+		return msg.Pubkey == author || (len(msg.Pubkey) == 66 && msg.Pubkey[2:] == author)
+	}) {
+		return false
+	}
+	if f.SigScheme != "" && msg.SigScheme != f.SigScheme {
+		return false
+	}
+	if f.Since != 0 && msg.SignedTimestamp < f.Since {
+		return false
+	}
+	if f.Until != 0 && msg.SignedTimestamp > f.Until {
+		return false
+	}
+	return true
+}
+
+// MessageSearch selects the messages of some rooms matching a full-text query.
+// Empty Author and Before do not filter; Limit 0 applies the default (50).
+type MessageSearch struct {
+	Query  string     // words, each matching the start of a word of the content
+	Rooms  []string   // required: distinct rooms to search
+	Author string     // pubkey, matched in compressed or x-only (32-byte) hex form
+	Before *time.Time // only messages received before
+	Limit  int
+}
+
+// SearchTerms splits a search query into the lowercase words it matches:
+// runs of letters and digits, as the sqlite full-text index tokenizes them.
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Match reports whether msg satisfies every field of the search but Limit:
+// each term of the query starts a word of its content. Deleted messages never
+// match.
+func (q MessageSearch) Match(msg models.Message) bool {
+	if msg.DeletedAt != nil || !slices.Contains(q.Rooms, msg.Room) {
+		return false
+	}
+	if q.Author != "" && msg.Pubkey != q.Author && (len(msg.Pubkey) != 66 || msg.Pubkey[2:] != q.Author) {
+		return false
+	}
+	if q.Before != nil && !msg.Timestamp.Before(*q.Before) {
+		return false
+	}
+	terms := SearchTerms(q.Query)
+	words := SearchTerms(msg.Content)
+	for _, term := range terms {
+		if !slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, term) }) {
+			return false
+		}
+	}
+	return len(terms) > 0
 }
 
 type Repository interface {
-	SaveMessage(ctx context.Context, room, user, content, signature, pubkey string, timestamp int64) (*models.Message, error)
+	// SaveMessage stores msg; the repository assigns its ID and Timestamp.
+	SaveMessage(ctx context.Context, msg models.Message) (*models.Message, error)
 	GetMessages(ctx context.Context, room string, params MessageQueryParams) ([]models.Message, error)
+	GetMessage(ctx context.Context, room, id string) (*models.Message, error)
+	// GetReplies returns the latest limit replies to a message (0 = default
+	// of 50), oldest first.
+	GetReplies(ctx context.Context, room, id string, limit int) ([]models.Message, error)
+	// FindMessages returns the messages matching filter, newest signed timestamp first.
+	FindMessages(ctx context.Context, filter MessageFilter) ([]models.Message, error)
+	// SearchMessages returns the messages matching search, newest first.
+	SearchMessages(ctx context.Context, search MessageSearch) ([]models.Message, error)
+	// GetRooms returns at most 100 rooms with their last message: the most
+	// recently active first, then the rooms without messages by name.
 	GetRooms(ctx context.Context) ([]models.Room, error)
+	// GetSearchableRooms returns every room without a password, encrypted
+	// rooms excluded, by name and with only Name and Private set. Unlike
+	// GetRooms, it is not limited.
+	GetSearchableRooms(ctx context.Context) ([]models.Room, error)
+	// SearchRooms returns the rooms whose name contains query, ignoring case,
+	// ordered and limited as GetRooms.
 	SearchRooms(ctx context.Context, query string) ([]models.Room, error)
-	CreateRoom(ctx context.Context, name string, password *string) (*models.Room, error)
+	// CreateRoom creates a room, protected when password is set, end-to-end
+	// encrypted when keySalt is set and owned by owner (x-only hex) unless it
+	// is empty. A private room is only readable by its members.
+	CreateRoom(ctx context.Context, name string, password *string, keySalt, owner string, private bool) (*models.Room, error)
+	// GetRoom returns a room, or ErrRoomNotFound.
+	GetRoom(ctx context.Context, name string) (*models.Room, error)
+	// SetRoomDescription replaces the description of an existing room.
+	SetRoomDescription(ctx context.Context, roomName, description string) error
+	// SetRoomRetention replaces the retention policy of an existing room.
+	SetRoomRetention(ctx context.Context, roomName string, policy models.RetentionPolicy) error
+	// GetRetentionPolicies returns the retention policy of every room, zero
+	// for the rooms without one.
+	GetRetentionPolicies(ctx context.Context) (map[string]models.RetentionPolicy, error)
+	// PruneMessages deletes the messages of a room received before the time
+	// before, and those older than its latest keep messages, with their
+	// revisions and reactions. A zero before or keep sets no bound. It
+	// returns the number of messages deleted.
+	PruneMessages(ctx context.Context, roomName string, before time.Time, keep int) (int64, error)
+	// Compact returns the space freed by pruned messages to the system,
+	// where the database does not do it by itself.
+	Compact(ctx context.Context) error
+	// GetRoomMembers returns the pubkeys holding a role in an existing room:
+	// the owner first, then the moderators, then the members added to the
+	// member list that hold no other role.
+	GetRoomMembers(ctx context.Context, roomName string) ([]models.RoomMember, error)
+	// AddRoomMember adds pubkey (x-only hex) to the member list of an existing
+	// room. Adding a member again changes nothing.
+	AddRoomMember(ctx context.Context, roomName, pubkey string) error
+	// RemoveRoomMember removes pubkey from the member list of an existing
+	// room, or returns ErrMemberNotFound.
+	RemoveRoomMember(ctx context.Context, roomName, pubkey string) error
+	// SetRoomRole grants role to pubkey (x-only hex) in an existing room, or
+	// revokes its role when role is empty.
+	SetRoomRole(ctx context.Context, roomName, pubkey string, role models.RoomRole) error
 	ValidateRoomPassword(ctx context.Context, roomName, password string) error
+	// SetRoomPassword replaces the password of an existing room; nil or empty
+	// makes it public.
+	SetRoomPassword(ctx context.Context, roomName string, password *string) error
+	// DeleteRoom removes a room and all of its messages.
+	DeleteRoom(ctx context.Context, roomName string) error
+	// DeleteMessage replaces a message with a tombstone: its content is
+	// cleared and DeletedAt set. Deleting a tombstone returns it unchanged.
+	DeleteMessage(ctx context.Context, roomName, id string) (*models.Message, error)
+	// EditMessage replaces the signed content of a message with edit and
+	// keeps the replaced revision in the message history.
+	EditMessage(ctx context.Context, roomName, id string, edit models.MessageRevision) (*models.Message, error)
+	// GetMessageRevisions returns the earlier revisions of a message, oldest
+	// first; the latest one is the message itself.
+	GetMessageRevisions(ctx context.Context, roomName, id string) ([]models.MessageRevision, error)
+	// AddReaction stores a reaction to a message of the room and returns the
+	// updated counts. Adding the same reaction again changes nothing.
+	AddReaction(ctx context.Context, roomName string, reaction models.Reaction) ([]models.ReactionCount, error)
+	// RemoveReaction deletes the reaction of pubkey with emoji and returns the
+	// updated counts of the message.
+	RemoveReaction(ctx context.Context, roomName, messageID, pubkey, emoji string) ([]models.ReactionCount, error)
+	// GetReactions returns the reactions to a message, oldest first.
+	GetReactions(ctx context.Context, roomName, messageID string) ([]models.Reaction, error)
+
+	// Direct messages
+	// SaveDirectMessage stores an encrypted direct message, or returns
+	// ErrDuplicateMessage for an already stored (sender, signature) pair.
+	SaveDirectMessage(ctx context.Context, dm models.DirectMessage) (*models.DirectMessage, error)
+	// GetDirectMessages returns the latest direct messages sent or received by
+	// pubkey, oldest first, only those exchanged with peer unless it is empty.
+	// Both are x-only hex and match senders and recipients in compressed or
+	// x-only form.
+	GetDirectMessages(ctx context.Context, pubkey, peer string, params MessageQueryParams) ([]models.DirectMessage, error)
+
+	// Sanctions
+	// CreateSanction stores a sanction; the repository assigns its ID and
+	// CreatedAt.
+	CreateSanction(ctx context.Context, sanction models.Sanction) (*models.Sanction, error)
+	// GetSanctions returns the sanctions of a room, or the server-wide ones
+	// when room is empty, oldest first. Expired sanctions are included.
+	GetSanctions(ctx context.Context, room string) ([]models.Sanction, error)
+	// DeleteSanction lifts a sanction of a room, or a server-wide one when
+	// room is empty, or returns ErrSanctionNotFound.
+	DeleteSanction(ctx context.Context, room, id string) error
+
+	// Invites
+	// CreateInvite stores an invite with the hash of its token; the repository
+	// assigns its ID and CreatedAt.
+	CreateInvite(ctx context.Context, invite models.Invite, tokenHash string) (*models.Invite, error)
+	// GetInvites returns the invites of a room with their uses, oldest first.
+	// Expired invites are included.
+	GetInvites(ctx context.Context, room string) ([]models.Invite, error)
+	// DeleteInvite revokes an invite of a room, or returns ErrInviteNotFound.
+	DeleteInvite(ctx context.Context, room, id string) error
+	// RedeemInvite records that pubkey (x-only hex) used the invite of the room
+	// with tokenHash. It returns ErrInviteInvalid for an unknown or expired
+	// invite, and for a used up one unless pubkey already redeemed it.
+	RedeemInvite(ctx context.Context, room, tokenHash, pubkey string) error
 
 	// User management
 	RegisterUser(ctx context.Context, publicKey string) (*models.User, error)
@@ -32,40 +301,781 @@ type Repository interface {
 	UnverifyUser(ctx context.Context, publicKey string) error
 }
 
+// Actor is the verified caller of an operation restricted to room roles.
+type Actor struct {
+	Pubkey string // as signed, compressed or x-only hex
+	Admin  bool   // listed in ADMIN_PUBKEYS: allowed everything in every room
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns a copy of ctx carrying the IP address of the client,
+// checked against IP sanctions when it sends, edits or reacts.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// resumeBacklogLimit bounds the repository lookup used to resume a stream whose
+// Last-Event-ID has already left the hub history.
+const resumeBacklogLimit = 200
+
 type ChatService struct {
-	repo Repository
+	repo        Repository
+	hub         *Hub
+	broadcaster Broadcaster // relays the hub events to other replicas, when repo is shared
+
+	retentionMu    sync.Mutex
+	retentionStats models.RetentionStats
 }
 
 func NewChatService(repo Repository) *ChatService {
+	broadcaster, _ := repo.(Broadcaster)
 	return &ChatService{
-		repo: repo,
+		repo:        repo,
+		hub:         NewHub(),
+		broadcaster: broadcaster,
+	}
+}
+
+// Repository returns the repository of the service, for the state kept
+// outside of it, such as that of the middlewares, that replicas sharing the
+// repository must share too.
+func (s *ChatService) Repository() Repository {
+	return s.repo
+}
+
+// SendMessage saves a message and publishes it to the room's live subscribers.
+// Hex encodings are lowercased, and signatures made canonical, so that a
+// replay cannot dodge duplicate detection by changing their case or encoding.
+func (s *ChatService) SendMessage(ctx context.Context, msg models.Message) (*models.Message, error) {
+	msg.Signature = crypto.CanonicalSignature(msg.Signature)
+	msg.Pubkey = strings.ToLower(msg.Pubkey)
+	if err := s.checkSanctions(ctx, msg.Room, msg.Pubkey, models.SanctionMute, models.SanctionBan); err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.SaveMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, *saved)
+	return saved, nil
+}
+
+// Subscribe opens a live subscription to room. When lastEventID is set, backlog
+// holds the messages saved after it, read from the hub history or, failing
+// that, from the repository. The caller must Close the subscription.
+func (s *ChatService) Subscribe(ctx context.Context, room, lastEventID string) (sub *Subscription, backlog []models.Message, err error) {
+	sub, backlog, found := s.hub.Subscribe(room, lastEventID)
+	if lastEventID == "" || found {
+		return sub, backlog, nil
+	}
+
+	recent, err := s.repo.GetMessages(ctx, room, MessageQueryParams{Limit: resumeBacklogLimit})
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+	for i, msg := range recent {
+		if msg.ID == lastEventID {
+			return sub, recent[i+1:], nil
+		}
+	}
+
+	return sub, nil, nil
+}
+
+// SubscribeAll opens a live subscription to every room. The caller must check
+// that the recipient may read each message's room, and Close the subscription.
+func (s *ChatService) SubscribeAll() *Subscription {
+	return s.hub.SubscribeAll()
+}
+
+func (s *ChatService) FindMessages(ctx context.Context, filter MessageFilter) ([]models.Message, error) {
+	return s.repo.FindMessages(ctx, filter)
+}
+
+// SearchMessages returns the messages matching search, newest first. Without
+// rooms, it searches every room pubkey may read without a password. The
+// caller must check the access to the rooms it names; encrypted rooms return
+// ErrSearchEncrypted.
+func (s *ChatService) SearchMessages(ctx context.Context, search MessageSearch, pubkey string) ([]models.Message, error) {
+	if len(SearchTerms(search.Query)) == 0 {
+		return []models.Message{}, nil
+	}
+	if len(search.Rooms) > 0 {
+		for _, name := range search.Rooms {
+			room, err := s.repo.GetRoom(ctx, name)
+			if err != nil && !errors.Is(err, ErrRoomNotFound) {
+				return nil, err
+			}
+			if room != nil && room.Encrypted {
+				return nil, ErrSearchEncrypted
+			}
+		}
+		return s.repo.SearchMessages(ctx, search)
+	}
+
+	rooms, err := s.repo.GetSearchableRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rooms, err = s.visibleRooms(ctx, rooms, pubkey); err != nil {
+		return nil, err
+	}
+	for _, room := range rooms {
+		search.Rooms = append(search.Rooms, room.Name)
+	}
+	if len(search.Rooms) == 0 {
+		return []models.Message{}, nil
 	}
+	return s.repo.SearchMessages(ctx, search)
 }
 
-func (s *ChatService) SendMessage(ctx context.Context, room, user, content, signature, pubkey string, timestamp int64) (*models.Message, error) {
-	return s.repo.SaveMessage(ctx, room, user, content, signature, pubkey, timestamp)
+func (s *ChatService) GetMessage(ctx context.Context, room, id string) (*models.Message, error) {
+	return s.repo.GetMessage(ctx, room, id)
+}
+
+// GetThread returns a message and its latest limit replies.
+func (s *ChatService) GetThread(ctx context.Context, room, id string, limit int) (*models.MessageThread, error) {
+	root, err := s.repo.GetMessage(ctx, room, id)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.repo.GetReplies(ctx, room, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MessageThread{Root: *root, Replies: replies}, nil
 }
 
 func (s *ChatService) GetMessages(ctx context.Context, room string, params MessageQueryParams) ([]models.Message, error) {
 	return s.repo.GetMessages(ctx, room, params)
 }
 
-func (s *ChatService) GetRooms(ctx context.Context) ([]models.Room, error) {
-	return s.repo.GetRooms(ctx)
+// GetMessagesAfter returns the oldest messages of a room received after the
+// message id, oldest first, for clients catching up from the last message
+// they have seen. It returns ErrMessageNotFound when id is not a message of
+// the room.
+func (s *ChatService) GetMessagesAfter(ctx context.Context, room, id string, limit int) ([]models.Message, error) {
+	last, err := s.repo.GetMessage(ctx, room, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetMessages(ctx, room, MessageQueryParams{Limit: limit, AfterCursor: new(CursorOf(*last))})
 }
 
-func (s *ChatService) SearchRooms(ctx context.Context, query string) ([]models.Room, error) {
-	return s.repo.SearchRooms(ctx, query)
+// GetMessagePage returns a page of the messages of a room, oldest first, with
+// the cursors of the pages around it. Without cursors, it is the latest page.
+func (s *ChatService) GetMessagePage(ctx context.Context, room string, params MessageQueryParams) (*models.MessagePage, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	// One more message tells whether the page is the last in its direction
+	params.Limit = limit + 1
+	messages, err := s.repo.GetMessages(ctx, room, params)
+	if err != nil {
+		return nil, err
+	}
+	more := len(messages) > limit
+
+	page := &models.MessagePage{}
+	if params.AfterCursor != nil {
+		if more {
+			messages = messages[:limit]
+		}
+		// Older messages remain, starting with that of the cursor. An empty
+		// page keeps the cursor, to poll for the messages yet to come.
+		page.NextCursor = params.AfterCursor.String()
+		if len(messages) > 0 {
+			page.PrevCursor = CursorOf(messages[0]).String()
+		}
+	} else if more {
+		messages = messages[1:]
+		page.PrevCursor = CursorOf(messages[0]).String()
+	}
+	if len(messages) > 0 {
+		page.NextCursor = CursorOf(messages[len(messages)-1]).String()
+	}
+	page.Messages = messages
+	return page, nil
 }
 
-func (s *ChatService) CreateRoom(ctx context.Context, name string, password *string) (*models.Room, error) {
-	return s.repo.CreateRoom(ctx, name, password)
+// GetRooms returns the rooms visible to pubkey: private rooms are left out
+// unless it is one of their members.
+func (s *ChatService) GetRooms(ctx context.Context, pubkey string) ([]models.Room, error) {
+	rooms, err := s.repo.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibleRooms(ctx, rooms, pubkey)
+}
+
+// GetOpenRooms returns every room readable without a password or a
+// membership, by name and with only Name and Private set. Unlike GetRooms, it
+// is not limited.
+func (s *ChatService) GetOpenRooms(ctx context.Context) ([]models.Room, error) {
+	rooms, err := s.repo.GetSearchableRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibleRooms(ctx, rooms, "")
+}
+
+// SearchRooms returns the rooms matching query that are visible to pubkey, as
+// GetRooms does.
+func (s *ChatService) SearchRooms(ctx context.Context, query, pubkey string) ([]models.Room, error) {
+	rooms, err := s.repo.SearchRooms(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibleRooms(ctx, rooms, pubkey)
+}
+
+// visibleRooms drops the private rooms pubkey is not a member of.
+func (s *ChatService) visibleRooms(ctx context.Context, rooms []models.Room, pubkey string) ([]models.Room, error) {
+	visible := make([]models.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if room.Private {
+			err := s.checkMember(ctx, room.Name, pubkey)
+			if errors.Is(err, ErrSignatureRequired) || errors.Is(err, ErrNotMember) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		visible = append(visible, room)
+	}
+	return visible, nil
+}
+
+// CreateRoom creates a room owned by owner, the pubkey that signed the
+// creation, or by nobody when it is empty. With a keySalt, the room is
+// encrypted: members derive the room key from its password and salt, and
+// password is the verifier of that key. A private room needs an owner, who
+// adds its other members.
+func (s *ChatService) CreateRoom(ctx context.Context, owner, name string, password *string, keySalt string, private bool) (*models.Room, error) {
+	if owner != "" {
+		var err error
+		if owner, err = crypto.XOnlyPubkey(strings.ToLower(owner)); err != nil {
+			return nil, err
+		}
+	} else if private {
+		return nil, errors.New("private rooms need an owner")
+	}
+	if keySalt != "" {
+		if err := crypto.ValidateRoomKeySalt(keySalt); err != nil {
+			return nil, err
+		}
+		if password == nil || !crypto.IsRoomVerifier(*password) {
+			return nil, errors.New("encrypted rooms need the verifier of their key as password")
+		}
+	}
+	return s.repo.CreateRoom(ctx, name, password, keySalt, owner, private)
+}
+
+func (s *ChatService) GetRoom(ctx context.Context, name string) (*models.Room, error) {
+	return s.repo.GetRoom(ctx, name)
 }
 
 func (s *ChatService) ValidateRoomPassword(ctx context.Context, roomName, password string) error {
 	return s.repo.ValidateRoomPassword(ctx, roomName, password)
 }
 
+// ValidateRoomAccess checks that pubkey, the signer of the request or "" when
+// it is not signed, may read a room with password. A private room returns
+// ErrSignatureRequired or ErrNotMember to anyone but its members; the password
+// of any room is then checked as ValidateRoomPassword does.
+func (s *ChatService) ValidateRoomAccess(ctx context.Context, roomName, password, pubkey string) error {
+	if err := s.CheckRoomMember(ctx, roomName, pubkey); err != nil {
+		return err
+	}
+	return s.repo.ValidateRoomPassword(ctx, roomName, password)
+}
+
+// CheckRoomMember returns ErrSignatureRequired or ErrNotMember when roomName is
+// a private room and pubkey is not one of its members. Public rooms and rooms
+// that do not exist yet are open to anyone.
+func (s *ChatService) CheckRoomMember(ctx context.Context, roomName, pubkey string) error {
+	room, err := s.repo.GetRoom(ctx, roomName)
+	if errors.Is(err, ErrRoomNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !room.Private {
+		return nil
+	}
+	return s.checkMember(ctx, roomName, pubkey)
+}
+
+// checkMember returns ErrSignatureRequired or ErrNotMember unless pubkey holds
+// a role in the room.
+func (s *ChatService) checkMember(ctx context.Context, roomName, pubkey string) error {
+	if pubkey == "" {
+		return ErrSignatureRequired
+	}
+	role, err := s.RoomRole(ctx, roomName, pubkey)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrNotMember
+	}
+	return nil
+}
+
+// GetRoomMembers returns the owner, moderators and members of a room. Only the
+// members of a private room can list them.
+func (s *ChatService) GetRoomMembers(ctx context.Context, roomName, pubkey string) ([]models.RoomMember, error) {
+	if err := s.CheckRoomMember(ctx, roomName, pubkey); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoomMembers(ctx, roomName)
+}
+
+// AddRoomMember adds pubkey to the member list of a private room. Only the
+// owner of the room or an admin can add members.
+func (s *ChatService) AddRoomMember(ctx context.Context, actor Actor, roomName, pubkey string) ([]models.RoomMember, error) {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	room, err := s.repo.GetRoom(ctx, roomName)
+	if err != nil {
+		return nil, err
+	}
+	if !room.Private {
+		return nil, ErrRoomNotPrivate
+	}
+	if pubkey, err = crypto.XOnlyPubkey(strings.ToLower(pubkey)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddRoomMember(ctx, roomName, pubkey); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoomMembers(ctx, roomName)
+}
+
+// RemoveRoomMember removes pubkey from the member list of a room and ends the
+// live subscriptions to it, so they check access again. Only the owner of the
+// room or an admin can remove members, and the owner stays.
+func (s *ChatService) RemoveRoomMember(ctx context.Context, actor Actor, roomName, pubkey string) ([]models.RoomMember, error) {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	pubkey, err := crypto.XOnlyPubkey(strings.ToLower(pubkey))
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.RoomRole(ctx, roomName, pubkey)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleOwner {
+		return nil, fmt.Errorf("%w: the owner of a room cannot be removed from it", ErrPermissionDenied)
+	}
+	if err := s.repo.RemoveRoomMember(ctx, roomName, pubkey); err != nil {
+		return nil, err
+	}
+	s.closeRoom(ctx, roomName)
+	return s.repo.GetRoomMembers(ctx, roomName)
+}
+
+// RoomRole returns the role of pubkey in a room, or "" when it holds none.
+func (s *ChatService) RoomRole(ctx context.Context, roomName, pubkey string) (models.RoomRole, error) {
+	members, err := s.repo.GetRoomMembers(ctx, roomName)
+	if err != nil {
+		return "", err
+	}
+	for _, member := range members {
+		if crypto.SamePubkey(member.Pubkey, pubkey) {
+			return member.Role, nil
+		}
+	}
+	return "", nil
+}
+
+// authorize returns ErrPermissionDenied unless actor is an admin or holds one
+// of roles in the room.
+func (s *ChatService) authorize(ctx context.Context, actor Actor, roomName string, roles ...models.RoomRole) error {
+	if actor.Admin {
+		return nil
+	}
+	role, err := s.RoomRole(ctx, roomName, actor.Pubkey)
+	if err != nil {
+		return err
+	}
+	if role == "" || !slices.Contains(roles, role) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// SetRoomPassword replaces the password of a room, unless it is encrypted, and
+// ends the live subscriptions to it, so they check access again. Only the
+// owner of the room or an admin can change it.
+func (s *ChatService) SetRoomPassword(ctx context.Context, actor Actor, roomName string, password *string) error {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return err
+	}
+	room, err := s.repo.GetRoom(ctx, roomName)
+	if err != nil {
+		return err
+	}
+	if room.Encrypted {
+		return ErrRoomEncrypted
+	}
+	if err := s.repo.SetRoomPassword(ctx, roomName, password); err != nil {
+		return err
+	}
+	s.closeRoom(ctx, roomName)
+	return nil
+}
+
+// SetRoomDescription replaces the description of a room. Only the owner of the
+// room or an admin can change it.
+func (s *ChatService) SetRoomDescription(ctx context.Context, actor Actor, roomName, description string) (*models.Room, error) {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRoomDescription(ctx, roomName, description); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoom(ctx, roomName)
+}
+
+// SetRoomRetention replaces the retention policy of a room. Only the owner of
+// the room or an admin can change it; the server default still applies on
+// top of it.
+func (s *ChatService) SetRoomRetention(ctx context.Context, actor Actor, roomName string, policy models.RetentionPolicy) (*models.Room, error) {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRoomRetention(ctx, roomName, policy); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoom(ctx, roomName)
+}
+
+// SetModerator promotes pubkey to moderator of a room, or demotes it. Only the
+// owner of the room or an admin can change moderators, and the owner keeps
+// its role.
+func (s *ChatService) SetModerator(ctx context.Context, actor Actor, roomName, pubkey string, moderator bool) ([]models.RoomMember, error) {
+	if err := s.authorize(ctx, actor, roomName, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	pubkey, err := crypto.XOnlyPubkey(strings.ToLower(pubkey))
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.RoomRole(ctx, roomName, pubkey)
+	if err != nil {
+		return nil, err
+	}
+	if role == models.RoleOwner {
+		return nil, fmt.Errorf("%w: the owner of a room cannot become a moderator", ErrPermissionDenied)
+	}
+	if moderator {
+		err = s.repo.SetRoomRole(ctx, roomName, pubkey, models.RoleModerator)
+	} else if role == models.RoleModerator {
+		err = s.repo.SetRoomRole(ctx, roomName, pubkey, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoomMembers(ctx, roomName)
+}
+
+func (s *ChatService) DeleteRoom(ctx context.Context, roomName string) error {
+	if err := s.repo.DeleteRoom(ctx, roomName); err != nil {
+		return err
+	}
+	s.closeRoom(ctx, roomName)
+	return nil
+}
+
+// DeleteMessage stores a tombstone for the message and publishes it, so live
+// subscribers can hide the message. Only its author, the owner or a moderator
+// of the room, or an admin can delete it.
+func (s *ChatService) DeleteMessage(ctx context.Context, actor Actor, roomName, id string) (*models.Message, error) {
+	msg, err := s.repo.GetMessage(ctx, roomName, id)
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.SamePubkey(msg.Pubkey, actor.Pubkey) {
+		if err := s.authorize(ctx, actor, roomName, models.RoleOwner, models.RoleModerator); err != nil {
+			return nil, err
+		}
+	}
+
+	tombstone, err := s.repo.DeleteMessage(ctx, roomName, id)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, *tombstone)
+	return tombstone, nil
+}
+
+// EditMessage stores a new revision of the message and publishes the edited
+// message, so live subscribers can replace it.
+func (s *ChatService) EditMessage(ctx context.Context, roomName, id string, edit models.MessageRevision) (*models.Message, error) {
+	msg, err := s.repo.GetMessage(ctx, roomName, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSanctions(ctx, roomName, msg.Pubkey, models.SanctionBan); err != nil {
+		return nil, err
+	}
+	edit.Signature = crypto.CanonicalSignature(edit.Signature)
+	edited, err := s.repo.EditMessage(ctx, roomName, id, edit)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, *edited)
+	return edited, nil
+}
+
+func (s *ChatService) GetMessageRevisions(ctx context.Context, roomName, id string) ([]models.MessageRevision, error) {
+	return s.repo.GetMessageRevisions(ctx, roomName, id)
+}
+
+func (s *ChatService) AddReaction(ctx context.Context, roomName string, reaction models.Reaction) ([]models.ReactionCount, error) {
+	if err := s.checkSanctions(ctx, roomName, reaction.Pubkey, models.SanctionBan); err != nil {
+		return nil, err
+	}
+	reaction.Signature = crypto.CanonicalSignature(reaction.Signature)
+	return s.repo.AddReaction(ctx, roomName, reaction)
+}
+
+func (s *ChatService) RemoveReaction(ctx context.Context, roomName, messageID, pubkey, emoji string) ([]models.ReactionCount, error) {
+	return s.repo.RemoveReaction(ctx, roomName, messageID, pubkey, emoji)
+}
+
+func (s *ChatService) GetReactions(ctx context.Context, roomName, messageID string) ([]models.Reaction, error) {
+	return s.repo.GetReactions(ctx, roomName, messageID)
+}
+
+// SendDirectMessage saves an encrypted direct message. Hex encodings and
+// signatures are made canonical like those of room messages.
+func (s *ChatService) SendDirectMessage(ctx context.Context, dm models.DirectMessage) (*models.DirectMessage, error) {
+	dm.Signature = crypto.CanonicalSignature(dm.Signature)
+	dm.Sender = strings.ToLower(dm.Sender)
+	dm.Recipient = strings.ToLower(dm.Recipient)
+	if err := s.checkSanctions(ctx, "", dm.Sender, models.SanctionMute, models.SanctionBan); err != nil {
+		return nil, err
+	}
+	return s.repo.SaveDirectMessage(ctx, dm)
+}
+
+// checkSanctions returns ErrMuted or ErrBanned when pubkey or the client IP of
+// ctx is under an active sanction of one of kinds, in room or server-wide.
+func (s *ChatService) checkSanctions(ctx context.Context, room, pubkey string, kinds ...models.SanctionKind) error {
+	ip := clientIP(ctx)
+	now := time.Now()
+	scopes := []string{""}
+	if room != "" {
+		scopes = append(scopes, room)
+	}
+	for _, scope := range scopes {
+		sanctions, err := s.repo.GetSanctions(ctx, scope)
+		if err != nil {
+			return err
+		}
+		for _, sanction := range sanctions {
+			if !sanction.Active(now) || !slices.Contains(kinds, sanction.Kind) {
+				continue
+			}
+			if (sanction.Pubkey != "" && crypto.SamePubkey(sanction.Pubkey, pubkey)) || (sanction.IP != "" && sanction.IP == ip) {
+				return sanctionError(sanction)
+			}
+		}
+	}
+	return nil
+}
+
+// sanctionError describes a sanction to the client it stops.
+func sanctionError(sanction models.Sanction) error {
+	err := ErrMuted
+	if sanction.Kind == models.SanctionBan {
+		err = ErrBanned
+	}
+	scope := "in this room"
+	if sanction.Room == "" {
+		scope = "on this server"
+	}
+	if sanction.ExpiresAt != nil {
+		scope += " until " + sanction.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if sanction.Reason != "" {
+		scope += ": " + sanction.Reason
+	}
+	return fmt.Errorf("%w %s", err, scope)
+}
+
+// authorizeSanctions lets the owner and moderators of a room, and admins,
+// manage its sanctions. Only admins manage server-wide sanctions.
+func (s *ChatService) authorizeSanctions(ctx context.Context, actor Actor, room string) error {
+	if room == "" {
+		if !actor.Admin {
+			return ErrPermissionDenied
+		}
+		return nil
+	}
+	if _, err := s.repo.GetRoom(ctx, room); err != nil {
+		return err
+	}
+	return s.authorize(ctx, actor, room, models.RoleOwner, models.RoleModerator)
+}
+
+// GetSanctions returns the active sanctions of a room, or the server-wide ones
+// when room is empty.
+func (s *ChatService) GetSanctions(ctx context.Context, actor Actor, room string) ([]models.Sanction, error) {
+	if err := s.authorizeSanctions(ctx, actor, room); err != nil {
+		return nil, err
+	}
+	sanctions, err := s.repo.GetSanctions(ctx, room)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return slices.DeleteFunc(sanctions, func(sanction models.Sanction) bool { return !sanction.Active(now) }), nil
+}
+
+// Sanction mutes or bans a pubkey or an IP address in a room, or server-wide
+// when room is empty. The owner and moderators of a room cannot be sanctioned
+// in it but by an admin.
+func (s *ChatService) Sanction(ctx context.Context, actor Actor, room string, req models.CreateSanctionRequest) (*models.Sanction, error) {
+	if err := s.authorizeSanctions(ctx, actor, room); err != nil {
+		return nil, err
+	}
+	createdBy, err := crypto.XOnlyPubkey(strings.ToLower(actor.Pubkey))
+	if err != nil {
+		return nil, err
+	}
+	sanction := models.Sanction{Kind: req.Kind, Room: room, IP: req.IP, Reason: req.Reason, CreatedBy: createdBy}
+	if req.Pubkey != "" {
+		if sanction.Pubkey, err = crypto.XOnlyPubkey(strings.ToLower(req.Pubkey)); err != nil {
+			return nil, err
+		}
+		if room != "" && !actor.Admin {
+			role, err := s.RoomRole(ctx, room, sanction.Pubkey)
+			if err != nil {
+				return nil, err
+			}
+			if role == models.RoleOwner || role == models.RoleModerator {
+				return nil, fmt.Errorf("%w: the %s of a room cannot be sanctioned in it", ErrPermissionDenied, role)
+			}
+		}
+	}
+	if req.Duration > 0 {
+		sanction.ExpiresAt = new(time.Now().Add(time.Duration(req.Duration) * time.Second))
+	}
+	return s.repo.CreateSanction(ctx, sanction)
+}
+
+// LiftSanction removes a sanction of a room, or a server-wide one when room is
+// empty.
+func (s *ChatService) LiftSanction(ctx context.Context, actor Actor, room, id string) error {
+	if err := s.authorizeSanctions(ctx, actor, room); err != nil {
+		return err
+	}
+	return s.repo.DeleteSanction(ctx, room, id)
+}
+
+// GetInvites returns the invites of a room. Only its owner or an admin can
+// list them; their tokens are not kept.
+func (s *ChatService) GetInvites(ctx context.Context, actor Actor, room string) ([]models.Invite, error) {
+	if err := s.authorizeInvites(ctx, actor, room); err != nil {
+		return nil, err
+	}
+	return s.repo.GetInvites(ctx, room)
+}
+
+// CreateInvite mints an invite granting access to a room in place of its
+// password, for req.Duration seconds and req.MaxUses pubkeys when they are
+// set. The returned invite holds the token, which is only stored hashed.
+// Encrypted rooms get no invites, as they could not carry the room key.
+func (s *ChatService) CreateInvite(ctx context.Context, actor Actor, room string, req models.CreateInviteRequest) (*models.Invite, error) {
+	if err := s.authorizeInvites(ctx, actor, room); err != nil {
+		return nil, err
+	}
+	r, err := s.repo.GetRoom(ctx, room)
+	if err != nil {
+		return nil, err
+	}
+	if r.Encrypted {
+		return nil, fmt.Errorf("%w: an invite cannot carry the key of an encrypted room", ErrPermissionDenied)
+	}
+	createdBy, err := crypto.XOnlyPubkey(strings.ToLower(actor.Pubkey))
+	if err != nil {
+		return nil, err
+	}
+	token, err := crypto.NewInviteToken()
+	if err != nil {
+		return nil, err
+	}
+	invite := models.Invite{Room: room, CreatedBy: createdBy, MaxUses: req.MaxUses}
+	if req.Duration > 0 {
+		invite.ExpiresAt = new(time.Now().Add(time.Duration(req.Duration) * time.Second))
+	}
+	created, err := s.repo.CreateInvite(ctx, invite, crypto.InviteTokenHash(token))
+	if err != nil {
+		return nil, err
+	}
+	created.Token = token
+	return created, nil
+}
+
+// RevokeInvite deletes an invite of a room. Only its owner or an admin can
+// revoke it.
+func (s *ChatService) RevokeInvite(ctx context.Context, actor Actor, room, id string) error {
+	if err := s.authorizeInvites(ctx, actor, room); err != nil {
+		return err
+	}
+	return s.repo.DeleteInvite(ctx, room, id)
+}
+
+// RedeemInvite checks that token is an active invite to the room for pubkey,
+// and counts pubkey among its uses. Redeeming an invite to a private room
+// adds pubkey to its members.
+func (s *ChatService) RedeemInvite(ctx context.Context, room, token, pubkey string) error {
+	pubkey, err := crypto.XOnlyPubkey(strings.ToLower(pubkey))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RedeemInvite(ctx, room, crypto.InviteTokenHash(token), pubkey); err != nil {
+		return err
+	}
+	r, err := s.repo.GetRoom(ctx, room)
+	if err != nil {
+		return err
+	}
+	if !r.Private {
+		return nil
+	}
+	return s.repo.AddRoomMember(ctx, room, pubkey)
+}
+
+// authorizeInvites lets the owner of an existing room, and admins, manage its
+// invites.
+func (s *ChatService) authorizeInvites(ctx context.Context, actor Actor, room string) error {
+	if _, err := s.repo.GetRoom(ctx, room); err != nil {
+		return err
+	}
+	return s.authorize(ctx, actor, room, models.RoleOwner)
+}
+
+func (s *ChatService) GetDirectMessages(ctx context.Context, pubkey, peer string, params MessageQueryParams) ([]models.DirectMessage, error) {
+	return s.repo.GetDirectMessages(ctx, strings.ToLower(pubkey), strings.ToLower(peer), params)
+}
+
 func (s *ChatService) RegisterUser(ctx context.Context, publicKey string) (*models.User, error) {
 	return s.repo.RegisterUser(ctx, publicKey)
 }
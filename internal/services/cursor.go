@@ -0,0 +1,63 @@
+package services
+
+import (
+	"cmp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+// ErrInvalidCursor is returned by ParseMessageCursor for a string that is not
+// a cursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// MessageCursor is the position of a message in the history of its room,
+// which is ordered by timestamp, then ID. Unlike a timestamp, it tells apart
+// the messages received at the same instant.
+type MessageCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// CursorOf returns the position of msg.
+func CursorOf(msg models.Message) MessageCursor {
+	return MessageCursor{Timestamp: msg.Timestamp, ID: msg.ID}
+}
+
+// CompareMessages orders two messages of a room as its history does.
+func CompareMessages(a, b models.Message) int {
+	return cmp.Or(a.Timestamp.Compare(b.Timestamp), cmp.Compare(a.ID, b.ID))
+}
+
+// Compare returns -1 when msg comes before the cursor, +1 when it comes after
+// it and 0 for the message of the cursor.
+func (c MessageCursor) Compare(msg models.Message) int {
+	return CompareMessages(msg, models.Message{Timestamp: c.Timestamp, ID: c.ID})
+}
+
+// String encodes the cursor for clients, which must treat it as opaque.
+func (c MessageCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%s", c.Timestamp.UnixNano(), c.ID))
+}
+
+// ParseMessageCursor decodes a cursor encoded by MessageCursor.String.
+func ParseMessageCursor(s string) (MessageCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || id == "" {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+	return MessageCursor{Timestamp: time.Unix(0, unixNano), ID: id}, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+func TestMessageCursor_RoundTrip(t *testing.T) {
+	cursor := CursorOf(models.Message{ID: "id:with:colons", Timestamp: time.Unix(1700000000, 123456789)})
+
+	parsed, err := ParseMessageCursor(cursor.String())
+	if err != nil {
+		t.Fatalf("ParseMessageCursor: %v", err)
+	}
+	if !parsed.Timestamp.Equal(cursor.Timestamp) || parsed.ID != cursor.ID {
+		t.Errorf("got %+v, want %+v", parsed, cursor)
+	}
+}
+
+func TestParseMessageCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"", "not base64!", "bm9jb2xvbg", "MTIzOg", "eHg6aWQ"} {
+		if _, err := ParseMessageCursor(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("ParseMessageCursor(%q) = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestMessageCursor_Compare_TiesOnID(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	cursor := MessageCursor{Timestamp: at, ID: "b"}
+
+	if got := cursor.Compare(models.Message{ID: "a", Timestamp: at}); got != -1 {
+		t.Errorf("same instant, smaller id: got %d, want -1", got)
+	}
+	if got := cursor.Compare(models.Message{ID: "b", Timestamp: at}); got != 0 {
+		t.Errorf("cursor message: got %d, want 0", got)
+	}
+	if got := cursor.Compare(models.Message{ID: "a", Timestamp: at.Add(time.Nanosecond)}); got != 1 {
+		t.Errorf("later message: got %d, want 1", got)
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+const (
+	hubHistorySize    = 100 // recent messages kept per room for Last-Event-ID resume
+	subscriberBufSize = 32  // pending deliveries before a slow subscriber is dropped
+
+	// allRooms is the subscription key of subscribers following every room.
+	allRooms = ""
+)
+
+var (
+	// ErrSubscriberBehind ends a subscription that fell too far behind.
+	ErrSubscriberBehind = errors.New("subscriber fell behind")
+	// ErrAccessChanged ends the subscriptions to a room whose password or
+	// members changed, or that was deleted, so subscribers must pass the
+	// access checks again.
+	ErrAccessChanged = errors.New("access to the room changed")
+)
+
+// Hub is an in-process pub/sub broker that fans out saved messages to the
+// subscribers of their room. It keeps a short per-room history so reconnecting
+// clients can resume from the last message they received.
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[string]map[*Subscription]struct{}
+	history map[string][]models.Message
+}
+
+// Subscription receives the messages published to a single room, or to
+// every room when Room is empty.
+// Messages is closed when the subscription ends, either through Close or
+// because the hub ended it, in which case Err reports why.
+type Subscription struct {
+	Room     string
+	Messages <-chan models.Message
+
+	ch   chan models.Message
+	hub  *Hub
+	once sync.Once
+	err  error
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subs:    make(map[string]map[*Subscription]struct{}),
+		history: make(map[string][]models.Message),
+	}
+}
+
+// Publish records msg in the room history and delivers it to every subscriber
+// of the room. Subscribers whose buffer is full are dropped rather than
+// blocking the sender.
+func (h *Hub) Publish(msg models.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	history := append(h.history[msg.Room], msg)
+	if len(history) > hubHistorySize {
+		history = history[len(history)-hubHistorySize:]
+	}
+	h.history[msg.Room] = history
+
+	for _, key := range []string{msg.Room, allRooms} {
+		for sub := range h.subs[key] {
+			select {
+			case sub.ch <- msg:
+			default:
+				h.removeLocked(sub, ErrSubscriberBehind)
+			}
+		}
+	}
+}
+
+// Subscribe registers a new subscription to room. When lastEventID is not
+// empty, backlog holds the messages published after it and found reports
+// whether lastEventID was still in the history.
+func (h *Hub) Subscribe(room, lastEventID string) (sub *Subscription, backlog []models.Message, found bool) {
+	ch := make(chan models.Message, subscriberBufSize)
+	sub = &Subscription{Room: room, Messages: ch, ch: ch, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subs[room] == nil {
+		h.subs[room] = make(map[*Subscription]struct{})
+	}
+	h.subs[room][sub] = struct{}{}
+
+	if lastEventID != "" {
+		history := h.history[room]
+		for i, msg := range history {
+			if msg.ID == lastEventID {
+				backlog = append([]models.Message(nil), history[i+1:]...)
+				found = true
+				break
+			}
+		}
+	}
+
+	return sub, backlog, found
+}
+
+// SubscribeAll registers a subscription receiving the messages of every room.
+// Callers are responsible for filtering out rooms the subscriber may not read.
+func (h *Hub) SubscribeAll() *Subscription {
+	sub, _, _ := h.Subscribe(allRooms, "")
+	return sub
+}
+
+// ClearHistory forgets the recent messages of room, once some of them may
+// have been deleted from the repository, so that a resume reads them from it.
+func (h *Hub) ClearHistory(room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.history, room)
+}
+
+// CloseRoom ends every subscription to room with ErrAccessChanged.
+// Subscriptions to every room are kept: they check access per message.
+func (h *Hub) CloseRoom(room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[room] {
+		h.removeLocked(sub, ErrAccessChanged)
+	}
+}
+
+// Reset ends every subscription with ErrSubscriberBehind and forgets the
+// history of every room, once the hub may have missed messages, so that
+// subscribers resume from the repository.
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for sub := range subs {
+			h.removeLocked(sub, ErrSubscriberBehind)
+		}
+	}
+	clear(h.history)
+}
+
+// SubscriberCount returns the number of live subscriptions to room.
+func (h *Hub) SubscriberCount(room string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[room])
+}
+
+// Close ends the subscription and releases its resources. It is safe to call
+// more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.removeLocked(s, nil)
+}
+
+// Err returns why the hub ended the subscription, or nil if it is still open
+// or was ended through Close.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+func (h *Hub) removeLocked(sub *Subscription, err error) {
+	sub.once.Do(func() {
+		sub.err = err
+		delete(h.subs[sub.Room], sub)
+		if len(h.subs[sub.Room]) == 0 {
+			delete(h.subs, sub.Room)
+		}
+		close(sub.ch)
+	})
+}
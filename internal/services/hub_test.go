@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+func TestHub_PublishDeliversToRoomSubscribers(t *testing.T) {
+	h := NewHub()
+	sub, _, _ := h.Subscribe("general", "")
+	defer sub.Close()
+	other, _, _ := h.Subscribe("random", "")
+	defer other.Close()
+
+	h.Publish(models.Message{ID: "1", Room: "general"})
+
+	select {
+	case msg := <-sub.Messages:
+		if msg.ID != "1" {
+			t.Errorf("got message %q, want %q", msg.ID, "1")
+		}
+	default:
+		t.Fatal("expected a message for the general subscriber")
+	}
+
+	select {
+	case msg := <-other.Messages:
+		t.Errorf("subscriber of another room got message %q", msg.ID)
+	default:
+	}
+}
+
+func TestHub_SubscribeResumesAfterLastEventID(t *testing.T) {
+	h := NewHub()
+	for _, id := range []string{"1", "2", "3"} {
+		h.Publish(models.Message{ID: id, Room: "general"})
+	}
+
+	sub, backlog, found := h.Subscribe("general", "1")
+	defer sub.Close()
+
+	if !found {
+		t.Fatal("expected last event ID to be found in history")
+	}
+	if len(backlog) != 2 || backlog[0].ID != "2" || backlog[1].ID != "3" {
+		t.Errorf("backlog = %v, want messages 2 and 3", backlog)
+	}
+}
+
+func TestHub_SubscribeUnknownLastEventID(t *testing.T) {
+	h := NewHub()
+	h.Publish(models.Message{ID: "1", Room: "general"})
+
+	sub, backlog, found := h.Subscribe("general", "missing")
+	defer sub.Close()
+
+	if found {
+		t.Error("expected unknown last event ID not to be found")
+	}
+	if len(backlog) != 0 {
+		t.Errorf("backlog = %v, want empty", backlog)
+	}
+}
+
+func TestHub_ClearHistory(t *testing.T) {
+	h := NewHub()
+	h.Publish(models.Message{ID: "1", Room: "general"})
+	h.Publish(models.Message{ID: "2", Room: "general"})
+	h.Publish(models.Message{ID: "3", Room: "other"})
+	h.ClearHistory("general")
+
+	sub, backlog, found := h.Subscribe("general", "1")
+	defer sub.Close()
+	if found || len(backlog) != 0 {
+		t.Errorf("backlog = %v, found = %v; want the history cleared", backlog, found)
+	}
+	other, backlog, found := h.Subscribe("other", "3")
+	defer other.Close()
+	if !found || len(backlog) != 0 {
+		t.Errorf("other room: backlog = %v, found = %v; want its history kept", backlog, found)
+	}
+}
+
+func TestHub_HistoryIsBounded(t *testing.T) {
+	h := NewHub()
+	for range hubHistorySize + 10 {
+		h.Publish(models.Message{ID: "x", Room: "general"})
+	}
+
+	if got := len(h.history["general"]); got != hubHistorySize {
+		t.Errorf("history length = %d, want %d", got, hubHistorySize)
+	}
+}
+
+func TestHub_SlowSubscriberIsDropped(t *testing.T) {
+	h := NewHub()
+	sub, _, _ := h.Subscribe("general", "")
+
+	for range subscriberBufSize + 1 {
+		h.Publish(models.Message{ID: "x", Room: "general"})
+	}
+
+	if n := h.SubscriberCount("general"); n != 0 {
+		t.Errorf("SubscriberCount = %d, want 0 after overflowing the buffer", n)
+	}
+	for range sub.Messages {
+		// drain until closed
+	}
+	if !errors.Is(sub.Err(), ErrSubscriberBehind) {
+		t.Errorf("Err = %v, want ErrSubscriberBehind", sub.Err())
+	}
+	sub.Close() // must not panic after the hub already closed it
+}
+
+func TestHub_SubscribeAllReceivesEveryRoom(t *testing.T) {
+	h := NewHub()
+	sub := h.SubscribeAll()
+	defer sub.Close()
+
+	h.Publish(models.Message{ID: "1", Room: "general"})
+	h.Publish(models.Message{ID: "2", Room: "random"})
+
+	for _, want := range []string{"1", "2"} {
+		select {
+		case msg := <-sub.Messages:
+			if msg.ID != want {
+				t.Errorf("got message %q, want %q", msg.ID, want)
+			}
+		default:
+			t.Fatalf("expected message %q for the all-rooms subscriber", want)
+		}
+	}
+}
+
+func TestHub_CloseRoom(t *testing.T) {
+	h := NewHub()
+	sub, _, _ := h.Subscribe("general", "")
+	other, _, _ := h.Subscribe("random", "")
+	defer other.Close()
+	all := h.SubscribeAll()
+	defer all.Close()
+
+	h.CloseRoom("general")
+
+	if _, ok := <-sub.Messages; ok {
+		t.Fatal("subscription to the closed room still open")
+	}
+	if !errors.Is(sub.Err(), ErrAccessChanged) {
+		t.Errorf("Err = %v, want ErrAccessChanged", sub.Err())
+	}
+	if n := h.SubscriberCount("random"); n != 1 {
+		t.Errorf("other room: SubscriberCount = %d, want 1", n)
+	}
+	if n := h.SubscriberCount(allRooms); n != 1 {
+		t.Errorf("all rooms: SubscriberCount = %d, want 1", n)
+	}
+	if other.Err() != nil {
+		t.Errorf("other room: Err = %v, want nil", other.Err())
+	}
+}
+
+func TestHub_Reset(t *testing.T) {
+	h := NewHub()
+	h.Publish(models.Message{ID: "1", Room: "general"})
+	sub, _, _ := h.Subscribe("general", "")
+	all := h.SubscribeAll()
+
+	h.Reset()
+
+	for _, s := range []*Subscription{sub, all} {
+		if _, ok := <-s.Messages; ok {
+			t.Fatalf("subscription to %q still open", s.Room)
+		}
+		if !errors.Is(s.Err(), ErrSubscriberBehind) {
+			t.Errorf("Err = %v, want ErrSubscriberBehind", s.Err())
+		}
+	}
+	resumed, _, found := h.Subscribe("general", "1")
+	defer resumed.Close()
+	if found {
+		t.Error("the history should be forgotten, so a resume reads the repository")
+	}
+}
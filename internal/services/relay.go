@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+// relayRetryDelay is how long RunRelay waits before listening again to the
+// other replicas once the connection to them failed.
+const relayRetryDelay = 5 * time.Second
+
+// HubEventKind names what a HubEvent does to the hub of a replica.
+type HubEventKind string
+
+const (
+	HubEventMessage      HubEventKind = "message"       // publish the message, as saved in the repository
+	HubEventCloseRoom    HubEventKind = "close_room"    // end the subscriptions to the room
+	HubEventClearHistory HubEventKind = "clear_history" // forget the recent messages of the room
+)
+
+// HubEvent is a change of the hub of a replica that the other replicas
+// sharing its repository apply to theirs. Messages are read back from the
+// repository rather than sent along, as events must stay small.
+type HubEvent struct {
+	Kind      HubEventKind `json:"kind"`
+	Room      string       `json:"room"`
+	MessageID string       `json:"message_id,omitempty"`
+}
+
+// Broadcaster is implemented by repositories that several server replicas can
+// share, to relay the hub events of each replica to the others, so that live
+// subscribers get the messages saved through any replica.
+type Broadcaster interface {
+	// Broadcast sends event to the other replicas.
+	Broadcast(ctx context.Context, event HubEvent) error
+	// Listen calls ready once it receives the events broadcast by the other
+	// replicas, then handle with each of them, in order, until ctx is done or
+	// the connection to them fails.
+	Listen(ctx context.Context, ready func(), handle func(HubEvent)) error
+}
+
+// RunRelay applies the hub events of the other replicas sharing the
+// repository to the hub of this one, until ctx is done. It returns right away
+// when the repository is not shared. When the connection to the other
+// replicas fails, it listens again and resets the hub, as it may have missed
+// events meanwhile.
+func (s *ChatService) RunRelay(ctx context.Context) {
+	if s.broadcaster == nil {
+		return
+	}
+	reconnecting := false
+	for {
+		err := s.broadcaster.Listen(ctx, func() {
+			if reconnecting {
+				s.hub.Reset()
+			}
+		}, func(event HubEvent) {
+			s.relay(ctx, event)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Error("Lost the live events of the other replicas", "error", err)
+		reconnecting = true
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relayRetryDelay):
+		}
+	}
+}
+
+// relay applies an event broadcast by another replica to the hub.
+func (s *ChatService) relay(ctx context.Context, event HubEvent) {
+	switch event.Kind {
+	case HubEventMessage:
+		msg, err := s.repo.GetMessage(ctx, event.Room, event.MessageID)
+		if err != nil {
+			slog.Error("Failed to read a message saved by another replica", "error", err, "room", event.Room, "id", event.MessageID)
+			return
+		}
+		s.hub.Publish(*msg)
+	case HubEventCloseRoom:
+		s.hub.CloseRoom(event.Room)
+	case HubEventClearHistory:
+		s.hub.ClearHistory(event.Room)
+	}
+}
+
+// publish delivers msg to the live subscribers of its room, on every replica.
+func (s *ChatService) publish(ctx context.Context, msg models.Message) {
+	s.hub.Publish(msg)
+	s.broadcast(ctx, HubEvent{Kind: HubEventMessage, Room: msg.Room, MessageID: msg.ID})
+}
+
+// closeRoom ends the live subscriptions to room, on every replica.
+func (s *ChatService) closeRoom(ctx context.Context, room string) {
+	s.hub.CloseRoom(room)
+	s.broadcast(ctx, HubEvent{Kind: HubEventCloseRoom, Room: room})
+}
+
+// clearHistory forgets the recent messages of room, on every replica.
+func (s *ChatService) clearHistory(ctx context.Context, room string) {
+	s.hub.ClearHistory(room)
+	s.broadcast(ctx, HubEvent{Kind: HubEventClearHistory, Room: room})
+}
+
+// broadcast sends event to the other replicas, if any. The change it relays
+// is already saved, so a failure is logged rather than returned; the other
+// replicas reset their hub if they lost their own connection meanwhile.
+func (s *ChatService) broadcast(ctx context.Context, event HubEvent) {
+	if s.broadcaster == nil {
+		return
+	}
+	if err := s.broadcaster.Broadcast(context.WithoutCancel(ctx), event); err != nil {
+		slog.ErrorContext(ctx, "Failed to relay a live event to the other replicas", "error", err, "kind", event.Kind, "room", event.Room)
+	}
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/EwenQuim/microchat/internal/repository/memory"
+	"github.com/EwenQuim/microchat/internal/services"
+)
+
+// replicaRepo is the repository of one of several replicas sharing a memory
+// store, relaying hub events to the other replicas as the Postgres store
+// does with NOTIFY.
+type replicaRepo struct {
+	services.Repository
+	replicas *[]*replicaRepo
+	mu       *sync.Mutex
+	events   chan services.HubEvent
+}
+
+func newReplicas(n int) []*replicaRepo {
+	store := memory.NewStore()
+	replicas := make([]*replicaRepo, n)
+	var mu sync.Mutex
+	for i := range replicas {
+		replicas[i] = &replicaRepo{Repository: store, replicas: &replicas, mu: &mu, events: make(chan services.HubEvent, 16)}
+	}
+	return replicas
+}
+
+func (r *replicaRepo) Broadcast(_ context.Context, event services.HubEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range *r.replicas {
+		if other != r {
+			other.events <- event
+		}
+	}
+	return nil
+}
+
+func (r *replicaRepo) Listen(ctx context.Context, ready func(), handle func(services.HubEvent)) error {
+	ready()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-r.events:
+			handle(event)
+		}
+	}
+}
+
+func receive(t *testing.T, sub *services.Subscription) (models.Message, bool) {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Messages:
+		return msg, ok
+	case <-time.After(time.Second):
+		t.Fatalf("nothing received on the subscription to %q", sub.Room)
+		return models.Message{}, false
+	}
+}
+
+func TestRunRelay_DeliversAcrossReplicas(t *testing.T) {
+	replicas := newReplicas(2)
+	sender, receiver := services.NewChatService(replicas[0]), services.NewChatService(replicas[1])
+	ctx, cancel := context.WithCancel(t.Context())
+	var relay sync.WaitGroup
+	relay.Go(func() { receiver.RunRelay(ctx) })
+	defer relay.Wait()
+	defer cancel()
+
+	remote, _, err := receiver.Subscribe(ctx, "general", "")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	local, _, _ := sender.Subscribe(ctx, "general", "")
+	defer local.Close()
+
+	saved, err := sender.SendMessage(ctx, models.Message{Room: "general", User: "alice", Content: "hello"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if msg, _ := receive(t, remote); msg.ID != saved.ID || msg.Content != "hello" {
+		t.Errorf("other replica received %+v, want message %q", msg, saved.ID)
+	}
+	if msg, _ := receive(t, local); msg.ID != saved.ID {
+		t.Errorf("sending replica received %+v, want message %q", msg, saved.ID)
+	}
+	select {
+	case msg := <-local.Messages:
+		t.Errorf("sending replica received message %q twice", msg.ID)
+	default:
+	}
+
+	if err := sender.DeleteRoom(ctx, "general"); err != nil {
+		t.Fatalf("DeleteRoom: %v", err)
+	}
+	if _, ok := receive(t, remote); ok {
+		t.Fatal("the subscription on the other replica should end with the room")
+	}
+	if !errors.Is(remote.Err(), services.ErrAccessChanged) {
+		t.Errorf("Err = %v, want ErrAccessChanged", remote.Err())
+	}
+}
+
+func TestRunRelay_NotShared(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		services.NewChatService(memory.NewStore()).RunRelay(t.Context())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunRelay should return right away when the repository is not shared")
+	}
+}
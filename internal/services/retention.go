@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+)
+
+// PruneMessages deletes the messages past the retention of their room, the
+// stricter of its own policy and defaults, then compacts the repository. It
+// returns the number of messages deleted, which RetentionStats accumulates.
+func (s *ChatService) PruneMessages(ctx context.Context, defaults models.RetentionPolicy, now time.Time) (int64, error) {
+	pruned, err := s.pruneMessages(ctx, defaults, now)
+
+	s.retentionMu.Lock()
+	defer s.retentionMu.Unlock()
+	s.retentionStats.Runs++
+	s.retentionStats.PrunedMessages += pruned
+	s.retentionStats.LastRunAt = &now
+	s.retentionStats.LastPruned = pruned
+	s.retentionStats.LastError = ""
+	if err != nil {
+		s.retentionStats.LastError = err.Error()
+	}
+	return pruned, err
+}
+
+func (s *ChatService) pruneMessages(ctx context.Context, defaults models.RetentionPolicy, now time.Time) (int64, error) {
+	policies, err := s.repo.GetRetentionPolicies(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var pruned int64
+	for _, room := range slices.Sorted(maps.Keys(policies)) {
+		policy := policies[room].Within(defaults)
+		if policy == (models.RetentionPolicy{}) {
+			continue
+		}
+		var before time.Time
+		if policy.MaxAge > 0 {
+			before = now.Add(-time.Duration(policy.MaxAge) * time.Second)
+		}
+		n, err := s.repo.PruneMessages(ctx, room, before, int(policy.MaxMessages))
+		if err != nil {
+			return pruned, fmt.Errorf("prune room %q: %w", room, err)
+		}
+		if n > 0 {
+			// A resume must not replay the pruned messages
+			s.clearHistory(ctx, room)
+		}
+		pruned += n
+	}
+	if pruned > 0 {
+		if err := s.repo.Compact(ctx); err != nil {
+			return pruned, fmt.Errorf("compact: %w", err)
+		}
+	}
+	return pruned, nil
+}
+
+// RetentionStats returns the counts of the prunings since the server started.
+func (s *ChatService) RetentionStats() models.RetentionStats {
+	s.retentionMu.Lock()
+	defer s.retentionMu.Unlock()
+	return s.retentionStats
+}
+
+// RunJanitor prunes the messages past their retention right away, then every
+// interval, until ctx is done. A pruning in progress stops with ctx.
+func (s *ChatService) RunJanitor(ctx context.Context, defaults models.RetentionPolicy, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		pruned, err := s.PruneMessages(ctx, defaults, time.Now())
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Error("Failed to prune messages", "error", err, "pruned", pruned)
+		case pruned > 0:
+			slog.Info("Pruned messages past their retention", "pruned", pruned)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -209,3 +209,27 @@ func pubKeyHexToNpub(hexPubKey string) (string, error) {
 	}
 	return bech32Encode("npub", words), nil
 }
+
+// npubToPubKeyHex decodes a Nostr bech32 npub to the x-only public key hex
+// (64 chars) it encodes.
+func npubToPubKeyHex(npub string) (string, error) {
+	npub = strings.ToLower(npub)
+	if !strings.HasPrefix(npub, "npub1") {
+		return "", fmt.Errorf("expected an npub")
+	}
+	vals, err := bech32SuffixToVals(npub[len("npub1"):])
+	if err != nil {
+		return "", err
+	}
+	if len(vals) < 6 || bech32Polymod(append(bech32HRPExpand("npub"), vals...)) != 1 {
+		return "", fmt.Errorf("invalid npub checksum")
+	}
+	xBytes, err := convertBits(vals[:len(vals)-6], 5, 8, false)
+	if err != nil {
+		return "", fmt.Errorf("convertBits: %w", err)
+	}
+	if len(xBytes) != 32 {
+		return "", fmt.Errorf("expected a 32-byte key, got %d bytes", len(xBytes))
+	}
+	return hex.EncodeToString(xBytes), nil
+}
@@ -1,11 +1,16 @@
 package tui
 
 import (
-	"crypto/sha256"
+	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 )
@@ -67,20 +72,157 @@ func identityFromHex(privKeyHex string) (identity, error) {
 	}, nil
 }
 
-// SignMessage signs a chat message using the Nostr event format expected by the backend.
+// SignMessage signs a chat message using the latest event format expected by the
+// backend (crypto.EventV1), which also covers the display name.
 // It returns a hex-encoded 64-byte compact ECDSA signature (R || S).
-func (id identity) SignMessage(content, room string, timestamp int64) (string, error) {
-	event := []any{0, id.PubKeyHex, timestamp, content, room}
-	serialized, err := json.Marshal(event)
+func (id identity) SignMessage(content, room, user string, timestamp int64) (string, error) {
+	hash, err := crypto.Event{
+		Version:   crypto.LatestEventVersion,
+		Pubkey:    id.PubKeyHex,
+		CreatedAt: timestamp,
+		Content:   content,
+		Room:      room,
+		User:      user,
+	}.Hash()
 	if err != nil {
-		return "", fmt.Errorf("marshal event: %w", err)
+		return "", fmt.Errorf("hash event: %w", err)
 	}
-	hash := sha256.Sum256(serialized)
-	sig := ecdsa.Sign(id.privKey, hash[:])
+	sig := ecdsa.Sign(id.privKey, hash)
 	compact := derToCompact(sig.Serialize())
 	return hex.EncodeToString(compact), nil
 }
 
+// SignMessageSchnorr signs a chat message as a NIP-01 event (kind 9, tagged
+// with the room and the display name), so the signature is also valid for
+// Nostr tooling. It returns the hex-encoded 64-byte BIP-340 signature and the
+// signed tags.
+func (id identity) SignMessageSchnorr(content, room, user string, timestamp int64) (string, [][]string, error) {
+	tags := [][]string{{crypto.RoomTag, room}, {crypto.NameTag, user}}
+	hash, err := crypto.Event{
+		Scheme:    crypto.SigSchnorr,
+		Pubkey:    id.PubKeyHex,
+		CreatedAt: timestamp,
+		Content:   content,
+		Room:      room,
+		User:      user,
+		Tags:      tags,
+	}.Hash()
+	if err != nil {
+		return "", nil, fmt.Errorf("hash event: %w", err)
+	}
+	sig, err := schnorr.Sign(id.privKey, hash)
+	if err != nil {
+		return "", nil, fmt.Errorf("sign event: %w", err)
+	}
+	return hex.EncodeToString(sig.Serialize()), tags, nil
+}
+
+// SignEdit signs a new content for the message messageID, tagged so that the
+// signature cannot be used for another message. user must be the display name
+// the message was sent with. It returns the hex signature and the signed tags.
+func (id identity) SignEdit(messageID, content, room, user string, timestamp int64, useSchnorr bool) (string, [][]string, error) {
+	return id.signTagged([]string{crypto.EditTag, messageID}, content, room, user, timestamp, useSchnorr)
+}
+
+// SignReply signs a message replying to parentID; the reply tag binds the
+// signature to that parent. It returns the hex signature and the signed tags.
+func (id identity) SignReply(parentID, content, room, user string, timestamp int64, useSchnorr bool) (string, [][]string, error) {
+	return id.signTagged([]string{crypto.ReplyTag, parentID}, content, room, user, timestamp, useSchnorr)
+}
+
+// SignReaction signs emoji as a reaction to messageID. Reactions carry no
+// display name. It returns the hex signature and the signed tags.
+func (id identity) SignReaction(messageID, emoji, room string, timestamp int64, useSchnorr bool) (string, [][]string, error) {
+	return id.signTagged([]string{crypto.ReactTag, messageID}, emoji, room, "", timestamp, useSchnorr)
+}
+
+// SignDirectMessage signs an encrypted direct message payload, tagged with
+// the recipient so that it cannot be readdressed. It returns the hex signature
+// and the signed tags.
+func (id identity) SignDirectMessage(recipient, payload string, timestamp int64) (string, [][]string, error) {
+	return id.signTagged([]string{crypto.RecipientTag, recipient}, payload, "", "", timestamp, false)
+}
+
+// ConversationKey derives the key encrypting the direct messages exchanged
+// with the hex public key, compressed or x-only.
+func (id identity) ConversationKey(pubkeyHex string) ([]byte, error) {
+	return crypto.ConversationKey(id.privKey, pubkeyHex)
+}
+
+// signTagged signs a message event carrying tag, as a latest version event or
+// as a NIP-01 event tagged with the room and user when useSchnorr is set.
+func (id identity) signTagged(tag []string, content, room, user string, timestamp int64, useSchnorr bool) (string, [][]string, error) {
+	event := crypto.Event{
+		Version:   crypto.LatestEventVersion,
+		Pubkey:    id.PubKeyHex,
+		CreatedAt: timestamp,
+		Content:   content,
+		Room:      room,
+		User:      user,
+		Tags:      [][]string{tag},
+	}
+	if useSchnorr {
+		event.Scheme = crypto.SigSchnorr
+		event.Tags = [][]string{{crypto.RoomTag, room}, tag}
+		if user != "" {
+			event.Tags = append(event.Tags, []string{crypto.NameTag, user})
+		}
+	}
+	hash, err := event.Hash()
+	if err != nil {
+		return "", nil, fmt.Errorf("hash event: %w", err)
+	}
+	if !useSchnorr {
+		compact := derToCompact(ecdsa.Sign(id.privKey, hash).Serialize())
+		return hex.EncodeToString(compact), event.Tags, nil
+	}
+	sig, err := schnorr.Sign(id.privKey, hash)
+	if err != nil {
+		return "", nil, fmt.Errorf("sign event: %w", err)
+	}
+	return hex.EncodeToString(sig.Serialize()), event.Tags, nil
+}
+
+// SignRequest signs an HTTP request as a NIP-98 event and returns the value
+// of its Authorization header.
+func (id identity) SignRequest(method, url string, body []byte, timestamp int64) (string, error) {
+	event := crypto.NewHTTPAuthEvent(id.PubKeyHex, method, url, body, timestamp)
+	hash, err := event.Hash()
+	if err != nil {
+		return "", fmt.Errorf("hash event: %w", err)
+	}
+	sig, err := schnorr.Sign(id.privKey, hash)
+	if err != nil {
+		return "", fmt.Errorf("sign event: %w", err)
+	}
+	event.ID = hex.EncodeToString(hash)
+	event.Sig = hex.EncodeToString(sig.Serialize())
+	return event.AuthorizationHeader()
+}
+
+// signRequests returns a request editor that signs each request with
+// SignRequest, covering its body when it has one.
+func (id identity) signRequests() generated.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		var body []byte
+		if req.GetBody != nil {
+			r, err := req.GetBody()
+			if err != nil {
+				return fmt.Errorf("signing failed: %w", err)
+			}
+			if body, err = io.ReadAll(r); err != nil {
+				return fmt.Errorf("signing failed: %w", err)
+			}
+		}
+		auth, err := id.SignRequest(req.Method, req.URL.String(), body, time.Now().Unix())
+		if err != nil {
+			return fmt.Errorf("signing failed: %w", err)
+		}
+		req.Header.Set("Authorization", auth)
+		return nil
+	}
+}
+
 // GenerateKeypair generates a random secp256k1 keypair and returns npub and private key hex.
 func GenerateKeypair() (npub, privKeyHex string, err error) {
 	id, err := generateIdentity()
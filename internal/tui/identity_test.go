@@ -2,11 +2,14 @@ package tui
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"strings"
 	"sync/atomic"
 	"testing"
 
+	"github.com/EwenQuim/microchat/pkg/crypto"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
@@ -86,7 +89,7 @@ func TestSignMessage_Length(t *testing.T) {
 		t.Fatalf("generateIdentity() error: %v", err)
 	}
 
-	sig, err := id.SignMessage("hello", "general", 1234567890)
+	sig, err := id.SignMessage("hello", "general", "alice", 1234567890)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
@@ -106,11 +109,11 @@ func TestSignMessage_DifferentContentDifferentSig(t *testing.T) {
 		t.Fatalf("generateIdentity() error: %v", err)
 	}
 
-	sig1, err := id.SignMessage("hello", "general", 1234567890)
+	sig1, err := id.SignMessage("hello", "general", "alice", 1234567890)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
-	sig2, err := id.SignMessage("world", "general", 1234567890)
+	sig2, err := id.SignMessage("world", "general", "alice", 1234567890)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
@@ -126,11 +129,11 @@ func TestSignMessage_Deterministic(t *testing.T) {
 		t.Fatalf("generateIdentity() error: %v", err)
 	}
 
-	sig1, err := id.SignMessage("hello", "room", 42)
+	sig1, err := id.SignMessage("hello", "room", "alice", 42)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
-	sig2, err := id.SignMessage("hello", "room", 42)
+	sig2, err := id.SignMessage("hello", "room", "alice", 42)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
@@ -141,6 +144,33 @@ func TestSignMessage_Deterministic(t *testing.T) {
 	}
 }
 
+func TestSignMessageSchnorr_Verifies(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity() error: %v", err)
+	}
+
+	sig, tags, err := id.SignMessageSchnorr("hello", "general", "alice", 1234567890)
+	if err != nil {
+		t.Fatalf("SignMessageSchnorr() error: %v", err)
+	}
+	if len(sig) != 128 {
+		t.Errorf("signature length = %d, want 128 hex chars", len(sig))
+	}
+	if !crypto.HasTag(tags, crypto.RoomTag, "general") || !crypto.HasTag(tags, crypto.NameTag, "alice") {
+		t.Errorf("tags = %v, want a room and a name tag", tags)
+	}
+
+	event := crypto.Event{Scheme: crypto.SigSchnorr, Pubkey: id.PubKeyHex, CreatedAt: 1234567890, Content: "hello", Room: "general", User: "alice", Tags: tags}
+	if err := crypto.VerifyEventSignatureBTCD(event, sig); err != nil {
+		t.Errorf("schnorr signature does not verify: %v", err)
+	}
+	event.User = "mallory"
+	if err := crypto.VerifyEventSignatureBTCD(event, sig); err == nil {
+		t.Error("expected a renamed message not to verify")
+	}
+}
+
 func TestDerToCompact_Length(t *testing.T) {
 	id, err := generateIdentity()
 	if err != nil {
@@ -148,7 +178,7 @@ func TestDerToCompact_Length(t *testing.T) {
 	}
 
 	// Sign something to produce a real DER signature
-	sig, err := id.SignMessage("test", "room", 1)
+	sig, err := id.SignMessage("test", "room", "alice", 1)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
@@ -168,7 +198,7 @@ func TestDerToCompact_NotAllZero(t *testing.T) {
 		t.Fatalf("generateIdentity() error: %v", err)
 	}
 
-	sig, err := id.SignMessage("content", "room", 999)
+	sig, err := id.SignMessage("content", "room", "alice", 999)
 	if err != nil {
 		t.Fatalf("SignMessage() error: %v", err)
 	}
@@ -348,3 +378,140 @@ func BenchmarkGenerateVanityIteration_Baseline(b *testing.B) {
 		vanityIterationOld("qqq")
 	}
 }
+
+func TestSignRequest_Verifies(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity() error: %v", err)
+	}
+
+	header, err := id.SignRequest("POST", "http://localhost:8080/api/users/me", []byte("{}"), 1234567890)
+	if err != nil {
+		t.Fatalf("SignRequest() error: %v", err)
+	}
+	encoded, ok := strings.CutPrefix(header, crypto.HTTPAuthScheme+" ")
+	if !ok {
+		t.Fatalf("header = %q, want the %s scheme", header, crypto.HTTPAuthScheme)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var event crypto.NostrEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := event.Verify(); err != nil {
+		t.Errorf("signed request does not verify: %v", err)
+	}
+	if payload, _ := crypto.TagValue(event.Tags, crypto.PayloadTag); payload != crypto.PayloadHash([]byte("{}")) {
+		t.Errorf("payload tag = %q, want the body hash", payload)
+	}
+}
+
+func TestSignEdit_VerifiesForBothSchemes(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity() error: %v", err)
+	}
+
+	for _, useSchnorr := range []bool{false, true} {
+		sig, tags, err := id.SignEdit("msg-1", "hello", "general", "alice", 42, useSchnorr)
+		if err != nil {
+			t.Fatalf("SignEdit(schnorr=%v) error: %v", useSchnorr, err)
+		}
+		if !crypto.HasTag(tags, crypto.EditTag, "msg-1") {
+			t.Errorf("schnorr=%v: tags = %v, want an edit tag", useSchnorr, tags)
+		}
+		event := crypto.Event{Version: crypto.LatestEventVersion, Pubkey: id.PubKeyHex, CreatedAt: 42, Content: "hello", Room: "general", User: "alice", Tags: tags}
+		if useSchnorr {
+			event.Scheme = crypto.SigSchnorr
+		}
+		if err := crypto.VerifyEventSignature(event, sig); err != nil {
+			t.Errorf("schnorr=%v: signature does not verify: %v", useSchnorr, err)
+		}
+	}
+}
+
+func TestSignReaction_VerifiesForBothSchemes(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity() error: %v", err)
+	}
+
+	for _, useSchnorr := range []bool{false, true} {
+		sig, tags, err := id.SignReaction("msg-1", "👍", "general", 42, useSchnorr)
+		if err != nil {
+			t.Fatalf("SignReaction(schnorr=%v) error: %v", useSchnorr, err)
+		}
+		if !crypto.HasTag(tags, crypto.ReactTag, "msg-1") {
+			t.Errorf("schnorr=%v: tags = %v, want a react tag", useSchnorr, tags)
+		}
+		event := crypto.Event{Version: crypto.LatestEventVersion, Pubkey: id.PubKeyHex, CreatedAt: 42, Content: "👍", Room: "general", Tags: tags}
+		if useSchnorr {
+			event.Scheme = crypto.SigSchnorr
+		}
+		if err := crypto.VerifyEventSignature(event, sig); err != nil {
+			t.Errorf("schnorr=%v: signature does not verify: %v", useSchnorr, err)
+		}
+	}
+}
+
+func TestNpubToPubKeyHex_RoundTrip(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity() error: %v", err)
+	}
+	got, err := npubToPubKeyHex(id.NpubKey)
+	if err != nil {
+		t.Fatalf("npubToPubKeyHex() error: %v", err)
+	}
+	if got != id.PubKeyHex[2:] {
+		t.Errorf("npubToPubKeyHex() = %s, want %s", got, id.PubKeyHex[2:])
+	}
+
+	corrupted := []byte(id.NpubKey)
+	if corrupted[10] == 'q' {
+		corrupted[10] = 'p'
+	} else {
+		corrupted[10] = 'q'
+	}
+	for _, npub := range []string{"", "nsec1qqqq", id.NpubKey[:len(id.NpubKey)-1], string(corrupted)} {
+		if _, err := npubToPubKeyHex(npub); err == nil {
+			t.Errorf("npubToPubKeyHex(%q) should fail", npub)
+		}
+	}
+}
+
+func TestSignDirectMessage_DecryptsAndVerifies(t *testing.T) {
+	alice, _ := generateIdentity()
+	bob, _ := generateIdentity()
+
+	key, err := alice.ConversationKey(bob.PubKeyHex)
+	if err != nil {
+		t.Fatalf("ConversationKey() error: %v", err)
+	}
+	payload, err := crypto.EncryptDM(key, "hello bob")
+	if err != nil {
+		t.Fatalf("EncryptDM() error: %v", err)
+	}
+	sig, tags, err := alice.SignDirectMessage(bob.PubKeyHex, payload, 42)
+	if err != nil {
+		t.Fatalf("SignDirectMessage() error: %v", err)
+	}
+	if !crypto.HasTag(tags, crypto.RecipientTag, bob.PubKeyHex) {
+		t.Errorf("tags = %v, want a p tag", tags)
+	}
+	event := crypto.Event{Version: crypto.LatestEventVersion, Pubkey: alice.PubKeyHex, CreatedAt: 42, Content: payload, Tags: tags}
+	if err := crypto.VerifyEventSignature(event, sig); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+
+	bobKey, err := bob.ConversationKey(alice.PubKeyHex)
+	if err != nil {
+		t.Fatalf("ConversationKey() error: %v", err)
+	}
+	if plaintext, err := crypto.DecryptDM(bobKey, payload); err != nil || plaintext != "hello bob" {
+		t.Errorf("DecryptDM() = %q, %v", plaintext, err)
+	}
+}
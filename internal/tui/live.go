@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/coder/websocket"
+)
+
+const (
+	livePollInterval   = 5 * time.Second // refresh cadence when a server has no push transport
+	liveDialTimeout    = 5 * time.Second // how long to wait for the WebSocket handshake
+	liveWriteTimeout   = 5 * time.Second // per-frame write deadline
+	maxLiveRoomsPerSrv = 20              // server-side cap on subscriptions per connection
+	liveEventBuffer    = 64              // pushed frames queued before the reader blocks
+	liveFrameReadLimit = 1 << 20         // largest server frame accepted
+	liveCloseGrace     = 50 * time.Millisecond
+)
+
+// liveConn is a push connection to one server's /api/ws endpoint. Frames are
+// read by a background goroutine and handed to the update loop one at a time
+// through waitLive.
+type liveConn struct {
+	serverURL  string
+	conn       *websocket.Conn
+	events     chan liveFrame
+	subscribed map[string]bool   // rooms with a subscribe frame sent and not ended since; update loop only
+	passwords  map[string]string // password each room was subscribed with, to resubscribe; update loop only
+	lastIDs    map[string]string // last new message pushed per room, to resume from; update loop only
+}
+
+func newLiveConn(serverURL string, conn *websocket.Conn) *liveConn {
+	return &liveConn{
+		serverURL:  serverURL,
+		conn:       conn,
+		events:     make(chan liveFrame, liveEventBuffer),
+		subscribed: make(map[string]bool),
+		passwords:  make(map[string]string),
+		lastIDs:    make(map[string]string),
+	}
+}
+
+// liveFrame mirrors models.WSServerFrame with the SDK message type, so pushed
+// messages can be merged with the ones fetched over HTTP.
+type liveFrame struct {
+	Type    models.WSFrameType `json:"type"`
+	Room    string             `json:"room,omitempty"`
+	Message *generated.Message `json:"message,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// liveConnectedMsg reports that a server accepted a push connection.
+type liveConnectedMsg struct {
+	conn *liveConn
+}
+
+// liveUnavailableMsg reports that a server has no usable push transport (or
+// the connection dropped); the TUI falls back to polling it.
+type liveUnavailableMsg struct {
+	serverURL string
+	err       error
+}
+
+// liveMessageMsg carries a message pushed by a server for one of its rooms.
+type liveMessageMsg struct {
+	serverURL string
+	room      string
+	message   generated.Message
+}
+
+// liveEndedMsg reports that a server stopped following a room: it rejected
+// the subscribe frame, or ended the subscription (a client too slow, or a
+// change of access), in which case resume is set.
+type liveEndedMsg struct {
+	serverURL string
+	room      string
+	resume    bool
+}
+
+// livePollMsg is the tea.Tick fallback for servers without push support.
+type livePollMsg struct {
+	serverURL string
+}
+
+// chatPollMsg polls the open chat when the push connection cannot follow it,
+// e.g. a room joined with an invite, which the subscribe frame cannot carry.
+type chatPollMsg struct {
+	generation int // mainModel.chatPoll when armed; ticks of a closed chat are dropped
+}
+
+// liveWSURL derives the WebSocket endpoint from a configured server URL,
+// normalising it the same way buildClientsMap does.
+func liveWSURL(serverURL string) string {
+	u := serverURL
+	if !strings.Contains(u, "http") {
+		u = "https://" + u
+	}
+	u = strings.TrimSuffix(u, "/")
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	return u + "/api/ws"
+}
+
+// connectLive dials the server's push endpoint.
+func connectLive(serverURL string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), liveDialTimeout)
+		defer cancel()
+		conn, _, err := websocket.Dial(ctx, liveWSURL(serverURL), nil)
+		if err != nil {
+			return liveUnavailableMsg{serverURL: serverURL, err: err}
+		}
+		conn.SetReadLimit(liveFrameReadLimit)
+
+		lc := newLiveConn(serverURL, conn)
+		go lc.readLoop()
+		return liveConnectedMsg{conn: lc}
+	}
+}
+
+func (lc *liveConn) readLoop() {
+	defer close(lc.events)
+	for {
+		_, data, err := lc.conn.Read(context.Background())
+		if err != nil {
+			return
+		}
+		var frame liveFrame
+		if json.Unmarshal(data, &frame) != nil {
+			continue
+		}
+		lc.events <- frame
+	}
+}
+
+// waitLive blocks until the next pushed message or ended subscription. It
+// skips the other control frames and reports liveUnavailableMsg once the
+// connection is gone.
+func waitLive(lc *liveConn) tea.Cmd {
+	return func() tea.Msg {
+		for frame := range lc.events {
+			switch {
+			case frame.Type == models.WSMessage && frame.Message != nil:
+				return liveMessageMsg{serverURL: lc.serverURL, room: frame.Room, message: *frame.Message}
+			case frame.Type == models.WSError && frame.Room != "":
+				return liveEndedMsg{serverURL: lc.serverURL, room: frame.Room}
+			case frame.Type == models.WSUnsubscribed && frame.Error != "": // not the reply to an unsubscribe frame
+				return liveEndedMsg{serverURL: lc.serverURL, room: frame.Room, resume: true}
+			}
+		}
+		return liveUnavailableMsg{serverURL: lc.serverURL, err: errors.New("push connection closed")}
+	}
+}
+
+// subscribe marks room as followed and returns the command sending the
+// subscribe frame, resuming after the last message pushed for the room, or
+// nil when nothing needs to be sent. The room is not followed when the
+// connection already follows maxLiveRoomsPerSrv rooms.
+func (lc *liveConn) subscribe(room, password string) tea.Cmd {
+	if room == "" || lc.subscribed[room] || len(lc.subscribed) >= maxLiveRoomsPerSrv {
+		return nil
+	}
+	lc.subscribed[room] = true
+	lc.passwords[room] = password
+	return lc.send(models.WSClientFrame{Type: models.WSSubscribe, Room: room, Password: password, LastEventID: lc.lastIDs[room]})
+}
+
+// unsubscribe stops following room, freeing its place on the connection.
+func (lc *liveConn) unsubscribe(room string) tea.Cmd {
+	if !lc.subscribed[room] {
+		return nil
+	}
+	delete(lc.subscribed, room)
+	delete(lc.passwords, room)
+	return lc.send(models.WSClientFrame{Type: models.WSUnsubscribe, Room: room})
+}
+
+// ended forgets a subscription the server ended or rejected, so it can be
+// sent again, and returns the command resubscribing when resume is set.
+func (lc *liveConn) ended(room string, resume bool) tea.Cmd {
+	if !lc.subscribed[room] {
+		return nil // unsubscribed since
+	}
+	password := lc.passwords[room]
+	delete(lc.subscribed, room)
+	delete(lc.passwords, room)
+	if !resume {
+		return nil
+	}
+	return lc.subscribe(room, password)
+}
+
+// received records a pushed message. It reports whether the message is new,
+// as opposed to the edit or tombstone of a message pushed before.
+func (lc *liveConn) received(room string, msg generated.Message) bool {
+	if msg.EditedAt != nil || msg.DeletedAt != nil {
+		return false
+	}
+	if msg.Id != nil {
+		lc.lastIDs[room] = *msg.Id
+	}
+	return true
+}
+
+func (lc *liveConn) send(frame models.WSClientFrame) tea.Cmd {
+	conn := lc.conn
+	return func() tea.Msg {
+		data, err := json.Marshal(frame)
+		if err != nil {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), liveWriteTimeout)
+		defer cancel()
+		_ = conn.Write(ctx, websocket.MessageText, data) // a dead connection surfaces through readLoop
+		return nil
+	}
+}
+
+// close shuts the connection down; readLoop then ends and waitLive reports it.
+func (lc *liveConn) close() {
+	go func() {
+		time.Sleep(liveCloseGrace)
+		_ = lc.conn.Close(websocket.StatusNormalClosure, "")
+	}()
+}
+
+// schedulePoll arms the polling fallback for one server.
+func schedulePoll(serverURL string) tea.Cmd {
+	return tea.Tick(livePollInterval, func(time.Time) tea.Msg {
+		return livePollMsg{serverURL: serverURL}
+	})
+}
+
+// scheduleChatPoll arms the polling of the open chat.
+func scheduleChatPoll(generation int) tea.Cmd {
+	return tea.Tick(livePollInterval, func(time.Time) tea.Msg {
+		return chatPollMsg{generation: generation}
+	})
+}
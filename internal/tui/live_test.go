@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/microchat/internal/models"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+func TestLiveWSURL(t *testing.T) {
+	cases := map[string]string{
+		"http://localhost:8080":   "ws://localhost:8080/api/ws",
+		"https://chat.example/":   "wss://chat.example/api/ws",
+		"chat.example":            "wss://chat.example/api/ws",
+		"http://host/prefix/path": "ws://host/prefix/path/api/ws",
+	}
+	for in, want := range cases {
+		if got := liveWSURL(in); got != want {
+			t.Errorf("liveWSURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestLive_SubscribeAndReceive runs the client against a fake /api/ws endpoint.
+func TestLive_SubscribeAndReceive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/ws" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		var frame models.WSClientFrame
+		if err := wsjson.Read(r.Context(), conn, &frame); err != nil {
+			return
+		}
+		_ = wsjson.Write(r.Context(), conn, models.WSServerFrame{Type: models.WSSubscribed, Room: frame.Room})
+		_ = wsjson.Write(r.Context(), conn, models.WSServerFrame{
+			Type:    models.WSMessage,
+			Room:    frame.Room,
+			Message: &models.Message{ID: "m1", Room: frame.Room, Content: "hello"},
+		})
+		_, _, _ = conn.Read(r.Context())
+	}))
+	defer srv.Close()
+
+	connected, ok := connectLive(srv.URL)().(liveConnectedMsg)
+	if !ok {
+		t.Fatal("expected liveConnectedMsg")
+	}
+	lc := connected.conn
+	defer lc.close()
+
+	lc.subscribe("general", "")()
+	if lc.subscribe("general", "") != nil {
+		t.Error("second subscribe to the same room should be a no-op")
+	}
+
+	done := make(chan any, 1)
+	go func() { done <- waitLive(lc)() }()
+	select {
+	case msg := <-done:
+		live, ok := msg.(liveMessageMsg)
+		if !ok {
+			t.Fatalf("got %T, want liveMessageMsg", msg)
+		}
+		if live.room != "general" || deref(live.message.Content) != "hello" {
+			t.Errorf("unexpected message: room=%q content=%q", live.room, deref(live.message.Content))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for pushed message")
+	}
+}
+
+// TestLive_ResubscribesAfterLastMessage verifies a subscription the server
+// ends is sent again, resuming after the last message pushed for the room.
+func TestLive_ResubscribesAfterLastMessage(t *testing.T) {
+	frames := make(chan models.WSClientFrame, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		for {
+			var frame models.WSClientFrame
+			if err := wsjson.Read(r.Context(), conn, &frame); err != nil {
+				return
+			}
+			frames <- frame
+			if frame.LastEventID == "" {
+				_ = wsjson.Write(r.Context(), conn, models.WSServerFrame{Type: models.WSMessage, Room: frame.Room, Message: &models.Message{ID: "m1", Room: frame.Room}})
+				_ = wsjson.Write(r.Context(), conn, models.WSServerFrame{Type: models.WSUnsubscribed, Room: frame.Room, Error: "subscriber fell behind"})
+			}
+		}
+	}))
+	defer srv.Close()
+
+	connected, ok := connectLive(srv.URL)().(liveConnectedMsg)
+	if !ok {
+		t.Fatal("expected liveConnectedMsg")
+	}
+	lc := connected.conn
+	defer lc.close()
+
+	lc.subscribe("general", "pw")()
+	live, ok := waitLive(lc)().(liveMessageMsg)
+	if !ok || !lc.received(live.room, live.message) {
+		t.Fatal("expected the new message m1")
+	}
+	ended, ok := waitLive(lc)().(liveEndedMsg)
+	if !ok || ended.room != "general" || !ended.resume {
+		t.Fatalf("got %+v, want the end of the general subscription", ended)
+	}
+	lc.ended(ended.room, ended.resume)()
+
+	<-frames
+	select {
+	case frame := <-frames:
+		if frame.Type != models.WSSubscribe || frame.Password != "pw" || frame.LastEventID != "m1" {
+			t.Errorf("resubscribe frame = %+v, want a subscribe with the password after m1", frame)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the resubscribe frame")
+	}
+	if !lc.subscribed["general"] {
+		t.Error("general should be followed again")
+	}
+}
+
+// TestWaitLive_EndedFrames verifies rejected and ended subscriptions are
+// reported, and the reply to an unsubscribe frame is skipped.
+func TestWaitLive_EndedFrames(t *testing.T) {
+	lc := newLiveConn("http://a.example", nil)
+	lc.events <- liveFrame{Type: models.WSUnsubscribed, Room: "left"}
+	lc.events <- liveFrame{Type: models.WSError, Room: "secret", Error: "cannot access room"}
+	lc.events <- liveFrame{Type: models.WSUnsubscribed, Room: "general", Error: "room access changed"}
+	close(lc.events)
+
+	if got := waitLive(lc)(); got != (liveEndedMsg{serverURL: lc.serverURL, room: "secret"}) {
+		t.Errorf("got %+v, want the rejection of secret", got)
+	}
+	if got := waitLive(lc)(); got != (liveEndedMsg{serverURL: lc.serverURL, room: "general", resume: true}) {
+		t.Errorf("got %+v, want the end of general", got)
+	}
+	if _, ok := waitLive(lc)().(liveUnavailableMsg); !ok {
+		t.Error("expected liveUnavailableMsg once the connection is gone")
+	}
+}
+
+// TestLive_NoPushEndpoint verifies dialling a server without /api/ws reports it unavailable.
+func TestLive_NoPushEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, ok := connectLive(srv.URL)().(liveUnavailableMsg); !ok {
+		t.Error("expected liveUnavailableMsg for a server without a push endpoint")
+	}
+}
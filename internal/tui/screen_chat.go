@@ -2,7 +2,10 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -36,23 +39,117 @@ func formatMsgTime(t *time.Time) string {
 	return t.Format("15:04")
 }
 
-// messagesLoadedMsg carries fetched messages or an error.
+// messagesLoadedMsg carries fetched messages, with the cursor of the older
+// ones, or an error.
 type messagesLoadedMsg struct {
-	messages []generated.Message
-	err      error
+	messages   []generated.Message
+	prevCursor string
+	err        error
 }
 
-// olderMessagesLoadedMsg carries older (paginated) messages or an error.
+// olderMessagesLoadedMsg carries older (paginated) messages, with the cursor of
+// even older ones, or an error.
 type olderMessagesLoadedMsg struct {
+	messages   []generated.Message
+	prevCursor string
+	err        error
+}
+
+// messagesPolledMsg carries the latest messages fetched by the polling fallback.
+type messagesPolledMsg struct {
+	room     string
 	messages []generated.Message
 	err      error
 }
 
+// searchResultsMsg carries the messages of the room matching a search, newest
+// first.
+type searchResultsMsg struct {
+	query string
+	hits  []generated.Message
+	err   error
+}
+
+// searchJumpMsg carries the older messages loaded to reach a search hit, oldest
+// first; prevCursor is empty once the start of the room was reached.
+type searchJumpMsg struct {
+	id         string
+	messages   []generated.Message
+	prevCursor string
+	err        error
+}
+
+// signatureSchemesMsg carries the signature schemes a server advertises in
+// /api/server-info.
+type signatureSchemesMsg struct {
+	schemes []string
+}
+
 // messageSentMsg is sent after posting a message.
 type messageSentMsg struct {
 	err error
 }
 
+// messageDeletedMsg carries the tombstone returned after deleting a message.
+type messageDeletedMsg struct {
+	message generated.Message
+	err     error
+}
+
+// messageEditedMsg carries the message returned after editing it.
+type messageEditedMsg struct {
+	message generated.Message
+	err     error
+}
+
+// revisionsLoadedMsg carries the earlier revisions of a message.
+type revisionsLoadedMsg struct {
+	messageID string
+	revisions []generated.MessageRevision
+	err       error
+}
+
+// threadLoadedMsg carries a message and its replies.
+type threadLoadedMsg struct {
+	rootID  string
+	root    generated.Message
+	replies []generated.Message
+	err     error
+}
+
+// reactionsLoadedMsg carries the emojis the current identity reacted with to a
+// message.
+type reactionsLoadedMsg struct {
+	messageID string
+	mine      []string
+	err       error
+}
+
+// reactionToggledMsg carries the reaction counts of a message after adding or
+// removing a reaction.
+type reactionToggledMsg struct {
+	messageID string
+	counts    []generated.ReactionCount
+	err       error
+}
+
+// sanctionCreatedMsg reports the result of muting or banning the author of a
+// message.
+type sanctionCreatedMsg struct {
+	kind string
+	err  error
+}
+
+// openUsersMsg is emitted when the user presses "u" to open the users of the room.
+type openUsersMsg struct{}
+
+// openMembersMsg is emitted when the user presses "m" in a private room to open
+// its members.
+type openMembersMsg struct{}
+
+// reactionChoices are the emojis offered by the reaction picker.
+var reactionChoices = []string{"👍", "❤️", "😂", "🎉", "😮", "😢"}
+
 // addContactFromChatMsg is emitted when the user presses "a" in cursor mode on a message.
 type addContactFromChatMsg struct {
 	pubKeyHex   string
@@ -64,8 +161,14 @@ type chatModel struct {
 	server   serverConfig
 	room     string
 	password string
+	invite   string // invite token used in place of the password, "" otherwise
+	private  bool   // members only: every read is signed by the current identity
 	id       *identity
 	username string
+	schnorr  bool // server accepts BIP-340 signatures; sign messages as Nostr events
+
+	roomKey    []byte            // key of an encrypted room, nil for a plaintext room
+	plaintexts map[string]string // decrypted contents of an encrypted room, by ciphertext
 
 	messages   []generated.Message
 	inputText  string
@@ -78,6 +181,28 @@ type chatModel struct {
 	msgCursorMode bool // true = message cursor active
 	msgCursor     int  // absolute index into m.messages
 
+	editingID string // ID of the message being edited in insert mode, "" when composing
+	replyTo   string // ID of the message replied to in insert mode, "" when composing
+
+	historyMode bool                        // true = showing the revisions of historyMsg
+	historyMsg  generated.Message           // message whose revisions are shown
+	history     []generated.MessageRevision // earlier revisions, oldest first
+
+	threadMode bool                // true = showing the replies to threadRoot
+	threadRoot generated.Message   // message whose thread is shown
+	thread     []generated.Message // replies, oldest first; nil while loading
+
+	searching   bool                // true = reading a search query after "/"
+	searchInput string              // query being typed
+	searchQuery string              // query of searchHits
+	searchHits  []generated.Message // messages matching searchQuery, newest first
+	searchHit   int                 // index into searchHits of the hit under the cursor
+
+	reactionMode   bool     // true = picking a reaction to reactionMsg
+	reactionMsg    string   // ID of the message reacted to
+	reactionCursor int      // index into reactionChoices
+	myReactions    []string // emojis already reacted with to reactionMsg; nil while loading
+
 	contacts    []contactEntry // for display-name substitution
 	invalidSigs map[string]bool
 
@@ -88,7 +213,7 @@ type chatModel struct {
 
 	hasMore      bool
 	loadingOlder bool
-	oldestTime   *time.Time
+	prevCursor   string // fetches the messages older than the history
 }
 
 func newChatModel(client *generated.ClientWithResponses, server serverConfig, room, password string, id *identity, username string) chatModel {
@@ -106,6 +231,106 @@ func newChatModel(client *generated.ClientWithResponses, server serverConfig, ro
 	}
 }
 
+// withKeySalt makes the chat an encrypted room: the room key is derived from
+// the password and salt, contents are encrypted before signing and decrypted
+// for display. The password is replaced by the verifier of the key, so it
+// never reaches the server.
+func (m chatModel) withKeySalt(salt string) chatModel {
+	key, err := crypto.RoomKey(m.password, salt)
+	m.password = ""
+	if err != nil {
+		m.err = "cannot derive the room key: " + err.Error()
+		return m
+	}
+	m.roomKey = key
+	m.password = crypto.RoomVerifier(key)
+	m.plaintexts = make(map[string]string)
+	return m
+}
+
+// withInvite makes the chat read and post with an invite token instead of the
+// room password. Reads with an invite are signed by the current identity.
+func (m chatModel) withInvite(token string) chatModel {
+	m.invite = token
+	return m
+}
+
+// withPrivate makes the chat a private room, which only its members can read:
+// reads are signed by the current identity.
+func (m chatModel) withPrivate() chatModel {
+	m.private = true
+	return m
+}
+
+// messagesAccess fills in how a read of the room messages proves access: the
+// password, or the invite or membership in a request signed by the current
+// identity.
+func (m chatModel) messagesAccess(params *generated.GETapiroomsRoommessagespageParams) ([]generated.RequestEditorFn, error) {
+	if m.invite == "" && !m.private {
+		if m.password != "" {
+			params.Password = new(m.password)
+		}
+		return nil, nil
+	}
+	if m.id == nil {
+		return nil, fmt.Errorf("no identity configured — invites and private rooms need one, add it in the Identities screen")
+	}
+	if m.invite != "" {
+		params.Invite = new(m.invite)
+	} else if m.password != "" {
+		params.Password = new(m.password)
+	}
+	return []generated.RequestEditorFn{m.id.signRequests()}, nil
+}
+
+// readSigner signs the other reads of a private room, those of its threads,
+// revisions and reactions. It is empty for other rooms.
+func (m chatModel) readSigner() []generated.RequestEditorFn {
+	if !m.private || m.id == nil {
+		return nil
+	}
+	return []generated.RequestEditorFn{m.id.signRequests()}
+}
+
+// encrypt returns the content to sign and send: the content itself in a
+// plaintext room, a payload encrypted with the room key otherwise.
+func encrypt(roomKey []byte, content string) (string, error) {
+	if roomKey == nil {
+		return content, nil
+	}
+	payload, err := crypto.EncryptDM(roomKey, content)
+	if err != nil {
+		return "", fmt.Errorf("encryption failed: %w", err)
+	}
+	return payload, nil
+}
+
+// decrypt returns the plaintext of a content of an encrypted room, or reports
+// that it does not decrypt with the room key. Contents of plaintext rooms are
+// returned as is.
+func (m chatModel) decrypt(content string) (string, bool) {
+	if m.roomKey == nil || content == "" {
+		return content, true
+	}
+	if plaintext, ok := m.plaintexts[content]; ok {
+		return plaintext, true
+	}
+	plaintext, err := crypto.DecryptDM(m.roomKey, content)
+	if err != nil {
+		return "", false
+	}
+	m.plaintexts[content] = plaintext
+	return plaintext, true
+}
+
+// plaintext returns a content ready for display.
+func (m chatModel) plaintext(content string) string {
+	if plaintext, ok := m.decrypt(content); ok {
+		return plaintext
+	}
+	return dim("(cannot decrypt)")
+}
+
 func (m chatModel) cachedColor(key string) (r, g, b uint8) {
 	if c, ok := m.colorCache[key]; ok {
 		return c[0], c[1], c[2]
@@ -116,93 +341,647 @@ func (m chatModel) cachedColor(key string) (r, g, b uint8) {
 }
 
 func (m chatModel) init() tea.Cmd {
-	return m.fetchMessages()
+	return tea.Batch(m.fetchMessages(), m.fetchSignatureSchemes())
 }
 
-func (m chatModel) fetchMessages() tea.Cmd {
+// fetchSignatureSchemes asks the server which signature schemes it accepts.
+// Servers that do not answer are assumed to accept ECDSA only.
+func (m chatModel) fetchSignatureSchemes() tea.Cmd {
 	client := m.client
-	room := m.room
-	password := m.password
 	return func() tea.Msg {
-		params := &generated.GETapiroomsRoommessagesParams{
-			Limit: new(50),
+		resp, err := client.GETapiserverInfoWithResponse(context.Background(), nil)
+		if err != nil || resp.JSON200 == nil || resp.JSON200.SignatureSchemes == nil {
+			return signatureSchemesMsg{}
 		}
-		if password != "" {
-			params.Password = &password
-		}
-		resp, err := client.GETapiroomsRoommessagesWithResponse(context.Background(), room, params)
+		return signatureSchemesMsg{schemes: *resp.JSON200.SignatureSchemes}
+	}
+}
+
+func (m chatModel) fetchMessages() tea.Cmd {
+	latest := m.latestMessages
+	return func() tea.Msg {
+		page, err := latest()
 		if err != nil {
 			return messagesLoadedMsg{err: err}
 		}
-		if resp.JSON200 == nil {
-			return messagesLoadedMsg{err: fmt.Errorf("server error: %d", resp.StatusCode())}
+		return messagesLoadedMsg{messages: page.messages, prevCursor: page.prevCursor}
+	}
+}
+
+// pollMessages fetches the messages received since the last one shown, for
+// servers without a push stream. It falls back to the latest page when none
+// is shown yet or the server no longer has it; the result is merged into the
+// history rather than replacing it.
+func (m chatModel) pollMessages() tea.Cmd {
+	latest, since := m.latestMessages, m.messagesSince
+	room := m.room
+	lastID := ""
+	if len(m.messages) > 0 {
+		lastID = deref(m.messages[len(m.messages)-1].Id)
+	}
+	return func() tea.Msg {
+		if lastID != "" {
+			messages, err := since(lastID)
+			if !errors.Is(err, errMessageGone) {
+				return messagesPolledMsg{room: room, messages: messages, err: err}
+			}
+		}
+		page, err := latest()
+		return messagesPolledMsg{room: room, messages: page.messages, err: err}
+	}
+}
+
+// errMessageGone reports that the message a poll resumes from is not in the
+// room anymore.
+var errMessageGone = errors.New("message not found")
+
+// maxPolledMessages is the most messages a poll fetches; the next poll
+// resumes from the last of them.
+const maxPolledMessages = 200
+
+// messagesSince fetches the messages of the room received after the message
+// id, oldest first.
+func (m chatModel) messagesSince(id string) ([]generated.Message, error) {
+	var access generated.GETapiroomsRoommessagespageParams
+	editors, err := m.messagesAccess(&access)
+	if err != nil {
+		return nil, err
+	}
+	params := &generated.GETapiroomsRoommessagesParams{
+		Password: access.Password,
+		Invite:   access.Invite,
+		Limit:    new(maxPolledMessages),
+		After:    new(id),
+	}
+	resp, err := m.client.GETapiroomsRoommessagesWithResponse(context.Background(), m.room, params, editors...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, errMessageGone
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("server error: %d", resp.StatusCode())
+	}
+	return *resp.JSON200, nil
+}
+
+// messagePage is a page of messages, oldest first, with the cursor of the
+// older ones; it is empty when none remain.
+type messagePage struct {
+	messages   []generated.Message
+	prevCursor string
+}
+
+// latestMessages fetches the most recent page of the room.
+func (m chatModel) latestMessages() (messagePage, error) {
+	return fetchPage(m.client, m.room, m.messagesAccess, 50, "")
+}
+
+// fetchPage fetches limit messages of the room older than the cursor before,
+// or the latest ones when it is empty.
+func fetchPage(client *generated.ClientWithResponses, room string, access func(*generated.GETapiroomsRoommessagespageParams) ([]generated.RequestEditorFn, error), limit int, before string) (messagePage, error) {
+	params := &generated.GETapiroomsRoommessagespageParams{
+		Limit: new(limit),
+	}
+	if before != "" {
+		params.Before = new(before)
+	}
+	editors, err := access(params)
+	if err != nil {
+		return messagePage{}, err
+	}
+	resp, err := client.GETapiroomsRoommessagespageWithResponse(context.Background(), room, params, editors...)
+	if err != nil {
+		return messagePage{}, err
+	}
+	if resp.JSON200 == nil {
+		return messagePage{}, fmt.Errorf("server error: %d", resp.StatusCode())
+	}
+	page := messagePage{messages: []generated.Message{}, prevCursor: deref(resp.JSON200.PrevCursor)}
+	if resp.JSON200.Messages != nil {
+		for _, message := range *resp.JSON200.Messages {
+			page.messages = append(page.messages, generated.Message(message))
+		}
+	}
+	return page, nil
+}
+
+// appendMessages adds messages not yet shown to the end of the history. The view
+// follows new messages only when already at the bottom (scroll == 0); otherwise
+// the scroll offset grows so the lines being read stay in place.
+func (m chatModel) appendMessages(messages []generated.Message) chatModel {
+	seen := make(map[string]int, len(m.messages)) // id -> index in m.messages
+	for i, message := range m.messages {
+		if message.Id != nil {
+			seen[*message.Id] = i
+		}
+	}
+	if m.invalidSigs == nil {
+		m.invalidSigs = make(map[string]bool)
+	}
+	loaded := len(m.messages)
+	added := 0
+	for _, message := range messages {
+		if message.Id != nil {
+			if i, ok := seen[*message.Id]; ok {
+				// Deletions and edits replace the message they apply to
+				if message.DeletedAt != nil || deref(message.Revisions) > deref(m.messages[i].Revisions) {
+					m.messages[i] = message
+					if sigInvalid(message) {
+						m.invalidSigs[msgKey(message, i)] = true
+					} else {
+						delete(m.invalidSigs, msgKey(message, i))
+					}
+				}
+				continue
+			}
+			seen[*message.Id] = len(m.messages)
+		}
+		m.messages = append(m.messages, message)
+		if sigInvalid(message) {
+			m.invalidSigs[msgKey(message, len(m.messages)-1)] = true
+		}
+		added++
+		if parent := deref(message.ReplyTo); parent != "" {
+			// Parents received in this batch already count their replies
+			if i, ok := seen[parent]; ok && i < loaded {
+				m.messages[i].Replies = new(deref(m.messages[i].Replies) + 1)
+			}
+			if m.threadMode && parent == deref(m.threadRoot.Id) && m.thread != nil {
+				m.thread = append(m.thread, message)
+				m.threadRoot.Replies = new(deref(m.threadRoot.Replies) + 1)
+			}
 		}
-		return messagesLoadedMsg{messages: *resp.JSON200}
 	}
+	if m.scroll > 0 {
+		m.scroll += added
+	}
+	return m
 }
 
 func (m chatModel) fetchOlderMessages() tea.Cmd {
 	client := m.client
 	room := m.room
-	password := m.password
-	oldest := m.oldestTime
+	access := m.messagesAccess
+	before := m.prevCursor
 	return func() tea.Msg {
-		if oldest == nil {
-			return olderMessagesLoadedMsg{err: fmt.Errorf("no oldest time")}
-		}
-		before := oldest.Format(time.RFC3339)
-		params := &generated.GETapiroomsRoommessagesParams{
-			Limit:  new(50),
-			Before: new(before),
-		}
-		if password != "" {
-			params.Password = &password
+		if before == "" {
+			return olderMessagesLoadedMsg{err: fmt.Errorf("no older messages")}
 		}
-		resp, err := client.GETapiroomsRoommessagesWithResponse(context.Background(), room, params)
+		page, err := fetchPage(client, room, access, 50, before)
 		if err != nil {
 			return olderMessagesLoadedMsg{err: err}
 		}
+		return olderMessagesLoadedMsg{messages: page.messages, prevCursor: page.prevCursor}
+	}
+}
+
+// searchMessages asks the server for the messages of the room matching query,
+// proving access as reads of the room do.
+func (m chatModel) searchMessages(query string) tea.Cmd {
+	var access generated.GETapiroomsRoommessagespageParams
+	editors, err := m.messagesAccess(&access)
+	if err != nil {
+		return func() tea.Msg { return searchResultsMsg{query: query, err: err} }
+	}
+	client := m.client
+	params := &generated.GETapisearchParams{
+		Q:        &query,
+		Room:     new(m.room),
+		Password: access.Password,
+		Invite:   access.Invite,
+		Limit:    new(50),
+	}
+	return func() tea.Msg {
+		resp, err := client.GETapisearchWithResponse(context.Background(), params, editors...)
+		if err != nil {
+			return searchResultsMsg{query: query, err: err}
+		}
 		if resp.JSON200 == nil {
-			return olderMessagesLoadedMsg{err: fmt.Errorf("server error: %d", resp.StatusCode())}
+			return searchResultsMsg{query: query, err: fmt.Errorf("search failed: %d", resp.StatusCode())}
 		}
-		return olderMessagesLoadedMsg{messages: *resp.JSON200}
+		return searchResultsMsg{query: query, hits: *resp.JSON200}
 	}
 }
 
-func (m chatModel) sendMessage(content string) tea.Cmd {
+// searchLoaded finds the loaded messages matching query, newest first. It
+// searches encrypted rooms, whose contents the server cannot read: each word of
+// the query must appear in the decrypted content.
+func (m chatModel) searchLoaded(query string) []generated.Message {
+	words := strings.Fields(strings.ToLower(query))
+	var hits []generated.Message
+	for _, message := range slices.Backward(m.messages) {
+		if message.DeletedAt != nil {
+			continue
+		}
+		content, _ := m.decrypt(deref(message.Content))
+		content = strings.ToLower(content)
+		if !slices.ContainsFunc(words, func(word string) bool { return !strings.Contains(content, word) }) {
+			hits = append(hits, message)
+		}
+	}
+	return hits
+}
+
+// fetchUntil loads older messages, page after page, until the message id.
+func (m chatModel) fetchUntil(id string) tea.Cmd {
+	client := m.client
+	room := m.room
+	access := m.messagesAccess
+	before := m.prevCursor
+	return func() tea.Msg {
+		var loaded []generated.Message
+		for before != "" {
+			page, err := fetchPage(client, room, access, 200, before)
+			if err != nil {
+				return searchJumpMsg{id: id, err: err}
+			}
+			loaded = append(page.messages, loaded...)
+			before = page.prevCursor
+			if slices.ContainsFunc(page.messages, func(message generated.Message) bool { return deref(message.Id) == id }) {
+				break
+			}
+		}
+		return searchJumpMsg{id: id, messages: loaded, prevCursor: before}
+	}
+}
+
+// showHit selects the search hit searchHit with the message cursor, loading the
+// older messages up to it first when needed.
+func (m chatModel) showHit() (chatModel, tea.Cmd) {
+	id := deref(m.searchHits[m.searchHit].Id)
+	i := slices.IndexFunc(m.messages, func(message generated.Message) bool { return deref(message.Id) == id })
+	if i == -1 {
+		if !m.hasMore || m.loadingOlder {
+			m.statusMsg = "Match is not in the loaded history"
+			return m, nil
+		}
+		m.loadingOlder = true
+		return m, m.fetchUntil(id)
+	}
+	m.msgCursorMode = true
+	m.msgCursor = i
+	m.scroll = len(m.messages) - 1 - i
+	m.statusMsg = fmt.Sprintf("Match %d/%d for %q", m.searchHit+1, len(m.searchHits), m.searchQuery)
+	return m, nil
+}
+
+// showResults keeps the hits of a search and selects the newest one.
+func (m chatModel) showResults(hits []generated.Message) (chatModel, tea.Cmd) {
+	m.err = ""
+	if len(hits) == 0 {
+		m.searchHits = nil
+		m.statusMsg = fmt.Sprintf("No messages match %q", m.searchQuery)
+		return m, nil
+	}
+	m.searchHits = hits
+	m.searchHit = 0
+	return m.showHit()
+}
+
+// prependMessages adds older messages before the history; prevCursor fetches
+// even older ones, and is empty when none remain.
+func (m chatModel) prependMessages(messages []generated.Message, prevCursor string) chatModel {
+	prepended := len(messages)
+	m.messages = append(messages, m.messages...)
+	m.scroll += prepended
+	// Rebuild invalidSigs with new indices
+	m.invalidSigs = make(map[string]bool)
+	for i, message := range m.messages {
+		if sigInvalid(message) {
+			m.invalidSigs[msgKey(message, i)] = true
+		}
+	}
+	m.prevCursor = prevCursor
+	m.hasMore = prevCursor != ""
+	return m
+}
+
+// sendMessage posts a message, as a reply to the message replyTo when set.
+func (m chatModel) sendMessage(content, replyTo string) tea.Cmd {
 	client := m.client
 	room := m.room
 	password := m.password
+	invite := m.invite
 	id := m.id
 	username := m.username
+	useSchnorr := m.schnorr
+	roomKey := m.roomKey
 	return func() tea.Msg {
+		content, err := encrypt(roomKey, content)
+		if err != nil {
+			return messageSentMsg{err: err}
+		}
 		req := generated.SendMessageRequest{
 			Content: content,
 			User:    username,
 		}
-		if password != "" {
+		if invite != "" {
+			req.RoomInvite = &invite
+		} else if password != "" {
 			req.RoomPassword = &password
 		}
 		if id == nil {
 			return messageSentMsg{err: fmt.Errorf("no identity configured — add one in the Identities screen")}
 		}
 		ts := time.Now().Unix()
-		sig, err := id.SignMessage(content, room, ts)
+		req.Pubkey = id.PubKeyHex
+		req.Timestamp = ts
+		if replyTo != "" {
+			sig, tags, err := id.SignReply(replyTo, content, room, username, ts, useSchnorr)
+			if err != nil {
+				return messageSentMsg{err: fmt.Errorf("signing failed: %w", err)}
+			}
+			req.Signature = sig
+			req.Tags = sdkTags(tags)
+			req.ReplyTo = &replyTo
+			if useSchnorr {
+				req.SigScheme = new(crypto.SigSchnorr)
+			} else {
+				req.Version = new(crypto.LatestEventVersion)
+			}
+		} else if useSchnorr {
+			sig, tags, err := id.SignMessageSchnorr(content, room, username, ts)
+			if err != nil {
+				return messageSentMsg{err: fmt.Errorf("signing failed: %w", err)}
+			}
+			req.Signature = sig
+			req.SigScheme = new(crypto.SigSchnorr)
+			req.Tags = sdkTags(tags)
+		} else {
+			sig, err := id.SignMessage(content, room, username, ts)
+			if err != nil {
+				return messageSentMsg{err: fmt.Errorf("signing failed: %w", err)}
+			}
+			req.Signature = sig
+			req.Version = new(crypto.LatestEventVersion)
+		}
+		resp, err := client.POSTapiroomsRoommessagesWithResponse(context.Background(), room, nil, req)
+		if err != nil {
+			return messageSentMsg{err: err}
+		}
+		if resp.JSON200 == nil {
+			return messageSentMsg{err: fmt.Errorf("send failed: %d", resp.StatusCode())}
+		}
+		return messageSentMsg{}
+	}
+}
+
+// editMessage sends a new content for one of the user's messages, signed as
+// a revision of it.
+func (m chatModel) editMessage(original generated.Message, content string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	useSchnorr := m.schnorr
+	roomKey := m.roomKey
+	return func() tea.Msg {
+		if id == nil {
+			return messageEditedMsg{err: fmt.Errorf("no identity configured — add one in the Identities screen")}
+		}
+		content, err := encrypt(roomKey, content)
+		if err != nil {
+			return messageEditedMsg{err: err}
+		}
+		ts := time.Now().Unix()
+		sig, tags, err := id.SignEdit(deref(original.Id), content, room, deref(original.User), ts, useSchnorr)
+		if err != nil {
+			return messageEditedMsg{err: fmt.Errorf("signing failed: %w", err)}
+		}
+		req := generated.EditMessageRequest{
+			Content:   content,
+			Signature: sig,
+			Tags:      tags,
+			Timestamp: ts,
+		}
+		if useSchnorr {
+			req.SigScheme = new(crypto.SigSchnorr)
+		} else {
+			req.Version = new(crypto.LatestEventVersion)
+		}
+		resp, err := client.PUTapiroomsRoommessagesIdWithResponse(context.Background(), room, deref(original.Id), nil, req)
+		if err != nil {
+			return messageEditedMsg{err: err}
+		}
+		if resp.JSON200 == nil {
+			return messageEditedMsg{err: fmt.Errorf("edit failed: %d", resp.StatusCode())}
+		}
+		return messageEditedMsg{message: *resp.JSON200}
+	}
+}
+
+// fetchRevisions loads the earlier revisions of an edited message.
+func (m chatModel) fetchRevisions(messageID string) tea.Cmd {
+	client := m.client
+	room := m.room
+	password := m.password
+	signer := m.readSigner()
+	return func() tea.Msg {
+		params := &generated.GETapiroomsRoommessagesIdrevisionsParams{}
+		if password != "" {
+			params.Password = &password
+		}
+		resp, err := client.GETapiroomsRoommessagesIdrevisionsWithResponse(context.Background(), room, messageID, params, signer...)
 		if err != nil {
-			return messageSentMsg{err: fmt.Errorf("signing failed: %w", err)}
+			return revisionsLoadedMsg{messageID: messageID, err: err}
+		}
+		if resp.JSON200 == nil {
+			return revisionsLoadedMsg{messageID: messageID, err: fmt.Errorf("server error: %d", resp.StatusCode())}
+		}
+		return revisionsLoadedMsg{messageID: messageID, revisions: *resp.JSON200}
+	}
+}
+
+// fetchThread loads a message and its latest replies.
+func (m chatModel) fetchThread(rootID string) tea.Cmd {
+	client := m.client
+	room := m.room
+	password := m.password
+	signer := m.readSigner()
+	return func() tea.Msg {
+		params := &generated.GETapiroomsRoommessagesIdthreadParams{Limit: new(50)}
+		if password != "" {
+			params.Password = &password
+		}
+		resp, err := client.GETapiroomsRoommessagesIdthreadWithResponse(context.Background(), room, rootID, params, signer...)
+		if err != nil {
+			return threadLoadedMsg{rootID: rootID, err: err}
+		}
+		if resp.JSON200 == nil || resp.JSON200.Root == nil {
+			return threadLoadedMsg{rootID: rootID, err: fmt.Errorf("server error: %d", resp.StatusCode())}
+		}
+		loaded := threadLoadedMsg{rootID: rootID, root: generated.Message(*resp.JSON200.Root), replies: []generated.Message{}}
+		if resp.JSON200.Replies != nil {
+			for _, reply := range *resp.JSON200.Replies {
+				loaded.replies = append(loaded.replies, generated.Message(reply))
+			}
+		}
+		return loaded
+	}
+}
+
+// deleteMessage asks the server to delete a message. The request is signed
+// with the current identity, which must be the author or an admin key.
+func (m chatModel) deleteMessage(msgID string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		if id == nil {
+			return messageDeletedMsg{err: fmt.Errorf("no identity configured — add one in the Identities screen")}
+		}
+		resp, err := client.DELETEapiroomsRoommessagesIdWithResponse(context.Background(), room, msgID, nil, id.signRequests())
+		if err != nil {
+			return messageDeletedMsg{err: err}
+		}
+		if resp.JSON200 == nil {
+			return messageDeletedMsg{err: fmt.Errorf("delete failed: %d", resp.StatusCode())}
+		}
+		return messageDeletedMsg{message: *resp.JSON200}
+	}
+}
+
+// sanctionAuthor mutes or bans pubkey in the room. The request is signed with
+// the current identity, which must be an owner or moderator of the room, or an
+// admin key.
+func (m chatModel) sanctionAuthor(pubkey, kind string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		if id == nil {
+			return sanctionCreatedMsg{kind: kind, err: fmt.Errorf("no identity configured — add one in the Identities screen")}
+		}
+		resp, err := client.POSTapiroomsRoomsanctionsWithResponse(context.Background(), room, nil, generated.CreateSanctionRequest{
+			Kind:   kind,
+			Pubkey: &pubkey,
+		}, id.signRequests())
+		if err != nil {
+			return sanctionCreatedMsg{kind: kind, err: err}
+		}
+		if resp.JSON200 == nil {
+			return sanctionCreatedMsg{kind: kind, err: fmt.Errorf("%s failed: %d", kind, resp.StatusCode())}
+		}
+		return sanctionCreatedMsg{kind: kind}
+	}
+}
+
+// fetchMyReactions loads the emojis the current identity reacted with to a
+// message.
+func (m chatModel) fetchMyReactions(messageID string) tea.Cmd {
+	client := m.client
+	room := m.room
+	password := m.password
+	id := m.id
+	signer := m.readSigner()
+	return func() tea.Msg {
+		params := &generated.GETapiroomsRoommessagesIdreactionsParams{}
+		if password != "" {
+			params.Password = &password
+		}
+		resp, err := client.GETapiroomsRoommessagesIdreactionsWithResponse(context.Background(), room, messageID, params, signer...)
+		if err != nil {
+			return reactionsLoadedMsg{messageID: messageID, err: err}
+		}
+		if resp.JSON200 == nil {
+			return reactionsLoadedMsg{messageID: messageID, err: fmt.Errorf("server error: %d", resp.StatusCode())}
+		}
+		mine := []string{}
+		for _, reaction := range *resp.JSON200 {
+			if crypto.SamePubkey(deref(reaction.Pubkey), id.PubKeyHex) {
+				mine = append(mine, deref(reaction.Emoji))
+			}
+		}
+		return reactionsLoadedMsg{messageID: messageID, mine: mine}
+	}
+}
+
+// toggleReaction adds a signed reaction to a message, or removes it with a
+// signed request when remove is set.
+func (m chatModel) toggleReaction(messageID, emoji string, remove bool) tea.Cmd {
+	client := m.client
+	room := m.room
+	password := m.password
+	id := m.id
+	useSchnorr := m.schnorr
+	return func() tea.Msg {
+		if id == nil {
+			return reactionToggledMsg{messageID: messageID, err: fmt.Errorf("no identity configured — add one in the Identities screen")}
+		}
+		var counts *[]generated.ReactionCount
+		var status int
+		if remove {
+			params := &generated.DELETEapiroomsRoommessagesIdreactionsEmojiParams{}
+			if password != "" {
+				params.Password = &password
+			}
+			resp, err := client.DELETEapiroomsRoommessagesIdreactionsEmojiWithResponse(context.Background(), room, messageID, emoji, params, id.signRequests())
+			if err != nil {
+				return reactionToggledMsg{messageID: messageID, err: err}
+			}
+			counts, status = resp.JSON200, resp.StatusCode()
+		} else {
+			ts := time.Now().Unix()
+			sig, tags, err := id.SignReaction(messageID, emoji, room, ts, useSchnorr)
+			if err != nil {
+				return reactionToggledMsg{messageID: messageID, err: fmt.Errorf("signing failed: %w", err)}
+			}
+			req := generated.AddReactionRequest{
+				Emoji:     emoji,
+				Pubkey:    id.PubKeyHex,
+				Signature: sig,
+				Tags:      tags,
+				Timestamp: ts,
+			}
+			if password != "" {
+				req.RoomPassword = &password
+			}
+			if useSchnorr {
+				req.SigScheme = new(crypto.SigSchnorr)
+			} else {
+				req.Version = new(crypto.LatestEventVersion)
+			}
+			resp, err := client.POSTapiroomsRoommessagesIdreactionsWithResponse(context.Background(), room, messageID, nil, req)
+			if err != nil {
+				return reactionToggledMsg{messageID: messageID, err: err}
+			}
+			counts, status = resp.JSON200, resp.StatusCode()
 		}
-		req.Pubkey = id.PubKeyHex
-		req.Signature = sig
-		req.Timestamp = ts
-		resp, err := client.POSTapiroomsRoommessagesWithResponse(context.Background(), room, nil, req)
-		if err != nil {
-			return messageSentMsg{err: err}
+		if counts == nil {
+			return reactionToggledMsg{messageID: messageID, err: fmt.Errorf("reaction failed: %d", status)}
 		}
-		if resp.JSON200 == nil {
-			return messageSentMsg{err: fmt.Errorf("send failed: %d", resp.StatusCode())}
+		return reactionToggledMsg{messageID: messageID, counts: *counts}
+	}
+}
+
+// setReactions replaces the reaction counts of a message wherever it is shown.
+func (m chatModel) setReactions(messageID string, counts []generated.ReactionCount) chatModel {
+	reactions := make([]*struct {
+		Count *int    `json:"count,omitempty"`
+		Emoji *string `json:"emoji,omitempty"`
+	}, len(counts))
+	for i := range counts {
+		reactions[i] = (*struct {
+			Count *int    `json:"count,omitempty"`
+			Emoji *string `json:"emoji,omitempty"`
+		})(&counts[i])
+	}
+	set := func(message *generated.Message) {
+		if deref(message.Id) == messageID {
+			message.Reactions = &reactions
 		}
-		return messageSentMsg{}
 	}
+	m.messages = slices.Clone(m.messages)
+	for i := range m.messages {
+		set(&m.messages[i])
+	}
+	set(&m.threadRoot)
+	m.thread = slices.Clone(m.thread)
+	for i := range m.thread {
+		set(&m.thread[i])
+	}
+	return m
 }
 
 func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
@@ -223,10 +1002,8 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 				m.invalidSigs[key] = true
 			}
 		}
-		if len(m.messages) > 0 {
-			m.oldestTime = m.messages[0].Timestamp
-		}
-		m.hasMore = len(m.messages) == 50
+		m.prevCursor = msg.prevCursor
+		m.hasMore = msg.prevCursor != ""
 		return m, nil
 
 	case olderMessagesLoadedMsg:
@@ -235,24 +1012,115 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 			m.err = msg.err.Error()
 			return m, nil
 		}
-		prepended := len(msg.messages)
-		m.messages = append(msg.messages, m.messages...)
-		m.scroll += prepended
-		// Rebuild invalidSigs with new indices
-		m.invalidSigs = make(map[string]bool)
-		for i, message := range m.messages {
-			if sigInvalid(message) {
-				m.invalidSigs[msgKey(message, i)] = true
-			}
+		return m.prependMessages(msg.messages, msg.prevCursor), nil
+
+	case searchResultsMsg:
+		if msg.query != m.searchQuery {
+			return m, nil // answer to an earlier search
+		}
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		return m.showResults(msg.hits)
+
+	case searchJumpMsg:
+		m.loadingOlder = false
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m = m.prependMessages(msg.messages, msg.prevCursor)
+		if len(m.searchHits) == 0 || deref(m.searchHits[m.searchHit].Id) != msg.id {
+			return m, nil // search closed since
+		}
+		return m.showHit()
+
+	case liveMessageMsg:
+		return m.appendMessages([]generated.Message{msg.message}), nil
+
+	case messagesPolledMsg:
+		if msg.err != nil || msg.room != m.room {
+			return m, nil // keep the current history; the next poll retries
+		}
+		return m.appendMessages(msg.messages), nil
+
+	case messageDeletedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.statusMsg = "Message deleted"
+		return m.appendMessages([]generated.Message{msg.message}), nil
+
+	case messageEditedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		return m.appendMessages([]generated.Message{msg.message}), nil
+
+	case revisionsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		if !m.historyMode || deref(m.historyMsg.Id) != msg.messageID {
+			return m, nil // closed before the revisions arrived
+		}
+		m.history = msg.revisions
+		return m, nil
+
+	case threadLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		if !m.threadMode || deref(m.threadRoot.Id) != msg.rootID {
+			return m, nil // closed before the thread arrived
+		}
+		m.threadRoot = msg.root
+		m.thread = msg.replies
+		return m, nil
+
+	case reactionsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		if !m.reactionMode || m.reactionMsg != msg.messageID {
+			return m, nil // closed before the reactions arrived
+		}
+		m.myReactions = msg.mine
+		return m, nil
+
+	case reactionToggledMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
 		}
-		if prepended > 0 {
-			m.oldestTime = m.messages[0].Timestamp
+		m.err = ""
+		return m.setReactions(msg.messageID, msg.counts), nil
+
+	case sanctionCreatedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
 		}
-		if prepended < 50 {
-			m.hasMore = false
+		m.err = ""
+		if msg.kind == "ban" {
+			m.statusMsg = "Author banned from #" + m.room
+		} else {
+			m.statusMsg = "Author muted in #" + m.room
 		}
 		return m, nil
 
+	case signatureSchemesMsg:
+		m.schnorr = slices.Contains(msg.schemes, crypto.SigSchnorr)
+		return m, nil
+
 	case messageSentMsg:
 		if msg.err != nil {
 			m.err = msg.err.Error()
@@ -260,6 +1128,9 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 		}
 		m.err = ""
 		// Refresh messages after sending
+		if m.threadMode {
+			return m, tea.Batch(m.fetchMessages(), m.fetchThread(deref(m.threadRoot.Id)))
+		}
 		return m, m.fetchMessages()
 
 	case tea.KeyMsg:
@@ -267,17 +1138,41 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 			switch msg.String() {
 			case "esc", "tab":
 				m.typing = false
+				if m.editingID != "" {
+					m.editingID = ""
+					m.inputText = ""
+				}
+				if m.replyTo != "" {
+					m.replyTo = ""
+					m.inputText = ""
+				}
 			case "enter":
 				content := strings.TrimSpace(m.inputText)
+				if m.editingID != "" {
+					i := slices.IndexFunc(m.messages, func(message generated.Message) bool { return deref(message.Id) == m.editingID })
+					m.editingID = ""
+					m.inputText = ""
+					m.typing = false
+					if content == "" || i == -1 {
+						return m, nil
+					}
+					m.err = ""
+					return m, m.editMessage(m.messages[i], content)
+				}
 				if content == "" || m.username == "" {
 					if m.username == "" {
 						m.err = "Set a username in Identity screen first"
 					}
 					return m, nil
 				}
+				replyTo := m.replyTo
+				if replyTo != "" {
+					m.replyTo = ""
+					m.typing = false
+				}
 				m.inputText = ""
 				m.err = ""
-				return m, m.sendMessage(content)
+				return m, m.sendMessage(content, replyTo)
 			case "backspace":
 				if _, size := utf8.DecodeLastRuneInString(m.inputText); size > 0 {
 					m.inputText = m.inputText[:len(m.inputText)-size]
@@ -287,6 +1182,34 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 					m.inputText += t
 				}
 			}
+		} else if m.searching {
+			switch msg.String() {
+			case "esc":
+				m.searching = false
+				m.searchInput = ""
+			case "enter":
+				query := strings.TrimSpace(m.searchInput)
+				m.searching = false
+				m.searchInput = ""
+				if query == "" {
+					return m, nil
+				}
+				m.searchQuery = query
+				m.searchHits = nil
+				if m.roomKey != nil {
+					return m.showResults(m.searchLoaded(query))
+				}
+				m.statusMsg = "Searching…"
+				return m, m.searchMessages(query)
+			case "backspace":
+				if _, size := utf8.DecodeLastRuneInString(m.searchInput); size > 0 {
+					m.searchInput = m.searchInput[:len(m.searchInput)-size]
+				}
+			default:
+				if t := msg.Key().Text; t != "" {
+					m.searchInput += t
+				}
+			}
 		} else if m.chatRenameMode {
 			switch msg.String() {
 			case "esc":
@@ -312,11 +1235,61 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 					m.renameInput += t
 				}
 			}
+		} else if m.historyMode {
+			if msg.String() == "esc" {
+				m.historyMode = false
+				m.history = nil
+			}
+		} else if m.threadMode {
+			switch msg.String() {
+			case "esc":
+				m.threadMode = false
+				m.thread = nil
+			case "r":
+				if m.threadRoot.DeletedAt == nil {
+					m.replyTo = deref(m.threadRoot.Id)
+					m.typing = true
+				}
+			}
+		} else if m.reactionMode {
+			switch msg.String() {
+			case "esc":
+				m.reactionMode = false
+				m.myReactions = nil
+			case "left", "h":
+				if m.reactionCursor > 0 {
+					m.reactionCursor--
+				}
+			case "right", "l":
+				if m.reactionCursor < len(reactionChoices)-1 {
+					m.reactionCursor++
+				}
+			case "enter":
+				if m.myReactions == nil {
+					return m, nil // still loading
+				}
+				emoji := reactionChoices[m.reactionCursor]
+				remove := slices.Contains(m.myReactions, emoji)
+				m.reactionMode = false
+				m.myReactions = nil
+				return m, m.toggleReaction(m.reactionMsg, emoji, remove)
+			}
 		} else if m.msgCursorMode {
 			m.statusMsg = ""
 			switch msg.String() {
 			case "esc":
 				m.msgCursorMode = false
+				m.searchHits = nil
+			case "n", "N":
+				if len(m.searchHits) == 0 {
+					return m, nil
+				}
+				if msg.String() == "n" && m.searchHit < len(m.searchHits)-1 {
+					m.searchHit++
+				} else if msg.String() == "N" && m.searchHit > 0 {
+					m.searchHit--
+				}
+				return m.showHit()
 			case "up", "k":
 				if m.msgCursor > 0 {
 					m.msgCursor--
@@ -341,12 +1314,120 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 					m.chatRenameMode = true
 					return m, nil
 				}
+			case "e":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil || selected.DeletedAt != nil {
+						return m, nil
+					}
+					if m.id == nil || !crypto.SamePubkey(deref(selected.Pubkey), m.id.PubKeyHex) {
+						m.err = "You can only edit your own messages"
+						return m, nil
+					}
+					m.err = ""
+					m.editingID = *selected.Id
+					m.inputText, _ = m.decrypt(deref(selected.Content))
+					m.msgCursorMode = false
+					m.typing = true
+				}
+			case "h":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil || deref(selected.Revisions) == 0 {
+						m.statusMsg = "Message was not edited"
+						return m, nil
+					}
+					m.historyMode = true
+					m.historyMsg = selected
+					m.history = nil
+					return m, m.fetchRevisions(*selected.Id)
+				}
+			case "r":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil || selected.DeletedAt != nil {
+						return m, nil
+					}
+					m.err = ""
+					m.replyTo = *selected.Id
+					m.msgCursorMode = false
+					m.typing = true
+				}
+			case "t":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil {
+						return m, nil
+					}
+					// A reply opens the thread of its parent
+					root := selected
+					if parent := deref(selected.ReplyTo); parent != "" {
+						root = generated.Message{Id: &parent}
+						if i := slices.IndexFunc(m.messages, func(message generated.Message) bool { return deref(message.Id) == parent }); i != -1 {
+							root = m.messages[i]
+						}
+					} else if deref(selected.Replies) == 0 {
+						m.statusMsg = "Message has no replies"
+						return m, nil
+					}
+					m.threadMode = true
+					m.threadRoot = root
+					m.thread = nil
+					return m, m.fetchThread(*root.Id)
+				}
+			case "+":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil || selected.DeletedAt != nil {
+						return m, nil
+					}
+					if m.id == nil {
+						m.err = "no identity configured — add one in the Identities screen"
+						return m, nil
+					}
+					m.err = ""
+					m.reactionMode = true
+					m.reactionMsg = *selected.Id
+					m.reactionCursor = 0
+					m.myReactions = nil
+					return m, m.fetchMyReactions(*selected.Id)
+				}
+			case "d":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if selected.Id == nil || selected.DeletedAt != nil {
+						return m, nil
+					}
+					return m, m.deleteMessage(*selected.Id)
+				}
+			case "b", "m":
+				if m.msgCursor < len(m.messages) {
+					selected := m.messages[m.msgCursor]
+					if deref(selected.Pubkey) == "" {
+						m.err = "Message has no public key"
+						return m, nil
+					}
+					if m.id != nil && crypto.SamePubkey(*selected.Pubkey, m.id.PubKeyHex) {
+						m.err = "You cannot sanction yourself"
+						return m, nil
+					}
+					kind := "mute"
+					if msg.String() == "b" {
+						kind = "ban"
+					}
+					m.err = ""
+					return m, m.sanctionAuthor(*selected.Pubkey, kind)
+				}
 			}
 		} else {
 			m.statusMsg = ""
 			switch msg.String() {
 			case "i":
 				m.typing = true
+			case "/":
+				m.searching = true
+				m.searchInput = ""
+				m.err = ""
 			case "v":
 				if len(m.messages) > 0 {
 					m.msgCursorMode = true
@@ -357,7 +1438,7 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 				if content != "" && m.username != "" {
 					m.inputText = ""
 					m.err = ""
-					return m, m.sendMessage(content)
+					return m, m.sendMessage(content, "")
 				}
 			case "up":
 				m.scroll++
@@ -373,6 +1454,12 @@ func (m chatModel) update(msg tea.Msg) (chatModel, tea.Cmd) {
 			case "r":
 				m.loading = true
 				return m, m.fetchMessages()
+			case "u":
+				return m, func() tea.Msg { return openUsersMsg{} }
+			case "m":
+				if m.private {
+					return m, func() tea.Msg { return openMembersMsg{} }
+				}
 			case "ctrl+c":
 				return m, tea.Quit
 			}
@@ -389,13 +1476,21 @@ func (m chatModel) viewPanel(width, height int, focused bool) string {
 		focusMark = "*"
 	}
 	sep := strings.Repeat("─", width)
-	b.WriteString(focusMark + " " + dim(serverDisplayName(m.server)+"~") + m.room + "\n")
+	lock := ""
+	if m.roomKey != nil {
+		lock = " 🔐"
+	}
+	b.WriteString(focusMark + " " + dim(serverDisplayName(m.server)+"~") + m.room + lock + "\n")
 	b.WriteString(sep + "\n")
 
 	// Reserve: header(1) + sep(1) + bottom_sep(1) + input(1) + footer(1) = 5
 	contentHeight := max(height-5, 1)
 
-	if m.loading {
+	if m.historyMode {
+		b.WriteString(m.viewHistory(contentHeight))
+	} else if m.threadMode {
+		b.WriteString(m.viewThread(contentHeight))
+	} else if m.loading {
 		for i := 0; i < contentHeight-1; i++ {
 			b.WriteString("\n")
 		}
@@ -422,66 +1517,31 @@ func (m chatModel) viewPanel(width, height int, focused bool) string {
 			b.WriteString(dim(" Loading older…") + "\n")
 		}
 		for i, msg := range m.messages[start:end] {
-			var fullPk, truncPk string
-			if msg.Pubkey != nil && *msg.Pubkey != "" {
-				fullPk = *msg.Pubkey
-				npub, err := pubKeyHexToNpub(fullPk)
-				if err == nil && len(npub) >= 8 {
-					truncPk = npub[len(npub)-8:]
-				}
-			}
-			if m.id != nil && fullPk == m.id.PubKeyHex {
-				truncPk = "(me)"
-			}
-			contactName := ""
-			for _, c := range m.contacts {
-				if c.PubKey == fullPk {
-					contactName = c.DisplayName
-					break
-				}
-			}
-			isContact := contactName != ""
-			user := "?"
-			if isContact {
-				user = contactName
-			} else if msg.User != nil && *msg.User != "" {
-				user = *msg.User
-			} else if truncPk != "" {
-				user = truncPk + "…"
-			}
-			content := ""
-			if msg.Content != nil {
-				content = *msg.Content
-			}
-			suffix := ""
-			if m.invalidSigs[msgKey(msg, start+i)] {
-				suffix = " \x1b[33m⚠\x1b[0m"
-			} else if !isContact && truncPk != "" {
-				suffix = " " + dim(truncPk)
-			}
-			colorKey := user
-			if fullPk != "" {
-				colorKey = fullPk
-			}
-			r, g, bv := m.cachedColor(colorKey)
-			coloredUser := ansiColor(user, r, g, bv)
 			prefix := " "
 			if m.msgCursorMode && start+i == m.msgCursor {
 				prefix = ">"
 			}
-			fmt.Fprintf(&b, "%s %s %s%s%s %s\n", prefix, dim(formatMsgTime(msg.Timestamp)), coloredUser, suffix, dim(":"), content)
+			b.WriteString(prefix + " " + m.formatMessage(msg, m.invalidSigs[msgKey(msg, start+i)], true) + "\n")
 		}
 	}
 
 	b.WriteString(sep + "\n")
 	if m.chatRenameMode {
 		b.WriteString(" Add contact as: " + m.renameInput + "█\n")
+	} else if m.reactionMode {
+		b.WriteString(" " + dim("react") + " > " + m.viewReactionPicker() + "\n")
+	} else if m.searching {
+		b.WriteString(" " + dim("search") + " / " + m.searchInput + "█\n")
 	} else {
 		cursor := ""
 		if m.typing {
 			cursor = "█"
 		}
-		if m.username != "" && m.id != nil {
+		if m.editingID != "" {
+			b.WriteString(" " + dim("edit") + " > " + m.inputText + cursor + "\n")
+		} else if m.replyTo != "" {
+			b.WriteString(" " + dim("reply") + " > " + m.inputText + cursor + "\n")
+		} else if m.username != "" && m.id != nil {
 			r, g, bv := m.cachedColor(m.id.PubKeyHex)
 			coloredName := ansiColor(m.username, r, g, bv)
 			b.WriteString(" " + coloredName + " " + dim("(me)") + " > " + m.inputText + cursor + "\n")
@@ -497,17 +1557,200 @@ func (m chatModel) viewPanel(width, height int, focused bool) string {
 		b.WriteString(" ✓ " + m.statusMsg + "\n")
 	} else if m.chatRenameMode {
 		b.WriteString(helpBar("enter", "confirm", "esc", "cancel") + "\n")
+	} else if m.reactionMode {
+		b.WriteString(helpBar("←→", "choose", "enter", "toggle", "esc", "cancel") + "\n")
+	} else if m.searching {
+		b.WriteString(helpBar("enter", "search", "esc", "cancel") + "\n")
+	} else if m.typing && m.editingID != "" {
+		b.WriteString(helpBar("esc", "cancel", "enter", "save") + "\n")
+	} else if m.typing && m.replyTo != "" {
+		b.WriteString(helpBar("esc", "cancel", "enter", "reply") + "\n")
 	} else if m.typing {
 		b.WriteString(helpBar("esc", "exit", "enter", "send", "⌫", "delete") + "\n")
+	} else if m.historyMode {
+		b.WriteString(helpBar("esc", "back") + "\n")
+	} else if m.threadMode {
+		b.WriteString(helpBar("r", "reply", "esc", "back") + "\n")
+	} else if m.msgCursorMode && len(m.searchHits) > 0 {
+		b.WriteString(helpBar("n", "older match", "N", "newer match", "↑↓", "navigate", "r", "reply", "t", "thread", "+", "react", "esc", "exit") + "\n")
 	} else if m.msgCursorMode {
-		b.WriteString(helpBar("↑↓", "navigate", "a", "add contact", "esc", "exit") + "\n")
+		b.WriteString(helpBar("↑↓", "navigate", "a", "add contact", "r", "reply", "t", "thread", "+", "react", "e", "edit", "h", "history", "d", "delete", "m", "mute", "b", "ban", "esc", "exit") + "\n")
 	} else {
-		b.WriteString(helpBar("i", "insert", "r", "refresh", "↑↓", "scroll", "v", "select", "tab", "servers") + "\n")
+		if m.private {
+			b.WriteString(helpBar("i", "insert", "r", "refresh", "↑↓", "scroll", "v", "select", "/", "search", "u", "users", "m", "members", "tab", "servers") + "\n")
+		} else {
+			b.WriteString(helpBar("i", "insert", "r", "refresh", "↑↓", "scroll", "v", "select", "/", "search", "u", "users", "tab", "servers") + "\n")
+		}
 	}
 
 	return b.String()
 }
 
+// formatMessage renders a message on one line: time, author and content. With
+// quote set, a reply starts with a snippet of the message it answers.
+func (m chatModel) formatMessage(msg generated.Message, invalid, quote bool) string {
+	var fullPk, truncPk string
+	if msg.Pubkey != nil && *msg.Pubkey != "" {
+		fullPk = *msg.Pubkey
+		npub, err := pubKeyHexToNpub(fullPk)
+		if err == nil && len(npub) >= 8 {
+			truncPk = npub[len(npub)-8:]
+		}
+	}
+	if m.id != nil && fullPk == m.id.PubKeyHex {
+		truncPk = "(me)"
+	}
+	contactName := ""
+	for _, c := range m.contacts {
+		if c.PubKey == fullPk {
+			contactName = c.DisplayName
+			break
+		}
+	}
+	isContact := contactName != ""
+	user := "?"
+	if isContact {
+		user = contactName
+	} else if msg.User != nil && *msg.User != "" {
+		user = *msg.User
+	} else if truncPk != "" {
+		user = truncPk + "…"
+	}
+	content := ""
+	if msg.Content != nil {
+		content = m.plaintext(*msg.Content)
+	}
+	suffix := ""
+	if msg.EditedAt != nil && msg.DeletedAt == nil {
+		content += " " + dim("(edited)")
+	}
+	if msg.DeletedAt != nil {
+		// The signature covered the cleared content; nothing to verify.
+		content = dim("(message deleted)")
+	} else if invalid {
+		suffix = " \x1b[33m⚠\x1b[0m"
+	} else if !isContact && truncPk != "" {
+		suffix = " " + dim(truncPk)
+	}
+	colorKey := user
+	if fullPk != "" {
+		colorKey = fullPk
+	}
+	r, g, bv := m.cachedColor(colorKey)
+	coloredUser := ansiColor(user, r, g, bv)
+	if quote && deref(msg.ReplyTo) != "" && msg.DeletedAt == nil {
+		content = dim("↪ "+m.quoteParent(*msg.ReplyTo)) + " " + content
+	}
+	if counts := formatReactions(msg); counts != "" && msg.DeletedAt == nil {
+		content += " " + dim(counts)
+	}
+	switch n := deref(msg.Replies); {
+	case n == 1:
+		content += " " + dim("(1 reply)")
+	case n > 1:
+		content += " " + dim(fmt.Sprintf("(%d replies)", n))
+	}
+	return fmt.Sprintf("%s %s%s%s %s", dim(formatMsgTime(msg.Timestamp)), coloredUser, suffix, dim(":"), content)
+}
+
+// formatReactions renders the reaction counts of a message, e.g. "👍 2 🎉 1".
+func formatReactions(msg generated.Message) string {
+	var parts []string
+	for _, reaction := range deref(msg.Reactions) {
+		if reaction != nil && deref(reaction.Count) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", deref(reaction.Emoji), deref(reaction.Count)))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// viewReactionPicker renders the reaction choices with the cursor in brackets
+// and a check mark on the emojis already reacted with.
+func (m chatModel) viewReactionPicker() string {
+	if m.myReactions == nil {
+		return dim("loading…")
+	}
+	parts := make([]string, len(reactionChoices))
+	for i, emoji := range reactionChoices {
+		label := emoji
+		if slices.Contains(m.myReactions, emoji) {
+			label += "✓"
+		}
+		if i == m.reactionCursor {
+			label = "[" + label + "]"
+		} else {
+			label = " " + label + " "
+		}
+		parts[i] = label
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteParent returns "user: snippet" for a loaded message, or an ellipsis
+// when it is not in the loaded history.
+func (m chatModel) quoteParent(id string) string {
+	i := slices.IndexFunc(m.messages, func(message generated.Message) bool { return deref(message.Id) == id })
+	if i == -1 {
+		return "…"
+	}
+	parent := m.messages[i]
+	if parent.DeletedAt != nil {
+		return "(message deleted)"
+	}
+	const maxSnippet = 24
+	snippet, _ := m.decrypt(deref(parent.Content))
+	if utf8.RuneCountInString(snippet) > maxSnippet {
+		snippet = string([]rune(snippet)[:maxSnippet]) + "…"
+	}
+	return deref(parent.User) + ": " + snippet
+}
+
+// viewThread renders threadRoot and its replies, bottom-aligned in height
+// lines like the message list.
+func (m chatModel) viewThread(height int) string {
+	lines := []string{dim(" Thread:"), "   " + m.formatMessage(m.threadRoot, sigInvalid(m.threadRoot), false)}
+	if m.thread == nil {
+		lines = append(lines, " Loading replies…")
+	}
+	for _, reply := range m.thread {
+		lines = append(lines, "     "+m.formatMessage(reply, sigInvalid(reply), false))
+	}
+
+	lines = lines[max(len(lines)-height, 0):]
+	var b strings.Builder
+	for i := len(lines); i < height; i++ {
+		b.WriteString("\n")
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// viewHistory renders the revisions of historyMsg, oldest first, bottom-aligned
+// in height lines like the message list.
+func (m chatModel) viewHistory(height int) string {
+	lines := []string{dim(" Revisions of the message:")}
+	if m.history == nil {
+		lines = append(lines, " Loading revisions…")
+	}
+	for _, rev := range m.history {
+		lines = append(lines, fmt.Sprintf("   %s %s %s", dim(fmt.Sprintf("#%d", deref(rev.Revision))), dim(formatMsgTime(rev.CreatedAt)), m.plaintext(deref(rev.Content))))
+	}
+	current := fmt.Sprintf("#%d", deref(m.historyMsg.Revisions))
+	lines = append(lines, fmt.Sprintf(" > %s %s %s", dim(current), dim(formatMsgTime(m.historyMsg.EditedAt)), m.plaintext(deref(m.historyMsg.Content))))
+
+	lines = lines[max(len(lines)-height, 0):]
+	var b strings.Builder
+	for i := len(lines); i < height; i++ {
+		b.WriteString("\n")
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
 // msgKey returns a stable map key for a message.
 func msgKey(msg generated.Message, idx int) string {
 	if msg.Id != nil && *msg.Id != "" {
@@ -523,11 +1766,49 @@ func sigInvalid(msg generated.Message) bool {
 		msg.SignedTimestamp == nil || msg.Room == nil {
 		return true
 	}
-	content := ""
-	if msg.Content != nil {
-		content = *msg.Content
+	event := crypto.Event{
+		Scheme:    deref(msg.SigScheme),
+		Pubkey:    *msg.Pubkey,
+		CreatedAt: *msg.SignedTimestamp,
+		Content:   deref(msg.Content),
+		Room:      *msg.Room,
+		User:      deref(msg.User),
+		Tags:      messageTags(msg),
+	}
+	if msg.Version != nil {
+		event.Version = *msg.Version
+	}
+	return crypto.VerifyEventSignatureBTCD(event, *msg.Signature) != nil
+}
+
+// messageTags flattens the SDK's nullable tag representation.
+func messageTags(msg generated.Message) [][]string {
+	if msg.Tags == nil {
+		return nil
+	}
+	tags := make([][]string, 0, len(*msg.Tags))
+	for _, tag := range *msg.Tags {
+		if tag == nil {
+			continue
+		}
+		values := make([]string, 0, len(*tag))
+		for _, v := range *tag {
+			values = append(values, deref(v))
+		}
+		tags = append(tags, values)
+	}
+	return tags
+}
+
+// sdkTags converts tags to the SDK's nullable representation.
+func sdkTags(tags [][]string) *[]*[]*string {
+	out := make([]*[]*string, 0, len(tags))
+	for _, tag := range tags {
+		values := make([]*string, 0, len(tag))
+		for _, v := range tag {
+			values = append(values, new(v))
+		}
+		out = append(out, &values)
 	}
-	return crypto.VerifyMessageSignatureBTCD(
-		*msg.Pubkey, *msg.Signature, content, *msg.Room, *msg.SignedTimestamp,
-	) != nil
+	return &out
 }
@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/internal/middleware"
+	"github.com/EwenQuim/microchat/pkg/crypto"
 )
 
 // pressRealChar simulates a real terminal keypress where both Code and Text are set.
@@ -276,6 +281,437 @@ func TestChatModel_CursorMode_AEntersRenameMode(t *testing.T) {
 	}
 }
 
+// TestChatModel_CursorMode_DDeletesMessage verifies "d" sends a DELETE signed
+// with the identity and replaces the message with the returned tombstone.
+func TestChatModel_CursorMode_DDeletesMessage(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/rooms/room/messages/m1" {
+			http.NotFound(w, r)
+			return
+		}
+		if pubkey, err := auth.Verify(r); err != nil || pubkey != id.PubKeyHex {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.Message{Id: new("m1"), Content: new(""), DeletedAt: new(time.Now())})
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "alice")
+	m.loading = false
+	m.messages = []generated.Message{makeIDMessage("m1", "oops")}
+	m.msgCursorMode = true
+	m.msgCursor = 0
+
+	m, cmd := m.update(pressRealChar('d', "d"))
+	if cmd == nil {
+		t.Fatal("expected a delete command")
+	}
+	deleted, ok := cmd().(messageDeletedMsg)
+	if !ok || deleted.err != nil {
+		t.Fatalf("delete result = %+v", deleted)
+	}
+	m, _ = m.update(deleted)
+
+	if len(m.messages) != 1 || m.messages[0].DeletedAt == nil {
+		t.Fatalf("messages = %+v, want the tombstone in place", m.messages)
+	}
+	if v := m.viewPanel(80, 10, true); !strings.Contains(v, "(message deleted)") || strings.Contains(v, "oops") {
+		t.Errorf("view should show the deletion, got:\n%s", v)
+	}
+}
+
+func TestChatModel_CursorMode_BBansAuthor(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	author, _ := generateIdentity()
+	auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+	var got generated.CreateSanctionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/rooms/room/sanctions" {
+			http.NotFound(w, r)
+			return
+		}
+		if pubkey, err := auth.Verify(r); err != nil || pubkey != id.PubKeyHex {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.Sanction{Id: new("s1"), Kind: &got.Kind, Pubkey: got.Pubkey})
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "alice")
+	m.loading = false
+	spam := makeIDMessage("m1", "spam")
+	spam.Pubkey = &author.PubKeyHex
+	m.messages = []generated.Message{spam}
+	m.msgCursorMode = true
+	m.msgCursor = 0
+
+	m, cmd := m.update(pressRealChar('b', "b"))
+	if cmd == nil {
+		t.Fatal("expected a ban command")
+	}
+	created, ok := cmd().(sanctionCreatedMsg)
+	if !ok || created.err != nil {
+		t.Fatalf("ban result = %+v", created)
+	}
+	if got.Kind != "ban" || deref(got.Pubkey) != author.PubKeyHex {
+		t.Errorf("request = %+v, want a ban of the author", got)
+	}
+	m, _ = m.update(created)
+	if !strings.Contains(m.statusMsg, "banned") {
+		t.Errorf("statusMsg = %q, want a ban confirmation", m.statusMsg)
+	}
+
+	m.messages[0].Pubkey = &id.PubKeyHex
+	if m, cmd = m.update(pressRealChar('m', "m")); cmd != nil || m.err == "" {
+		t.Errorf("muting yourself should be refused, err = %q", m.err)
+	}
+}
+
+// TestChatModel_LiveTombstone_ReplacesMessage verifies a pushed deletion
+// replaces the message it deletes instead of being ignored as a duplicate.
+func TestChatModel_LiveTombstone_ReplacesMessage(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+	m.loading = false
+	m.messages = []generated.Message{makeIDMessage("a", "first"), makeIDMessage("b", "second")}
+
+	tombstone := makeIDMessage("a", "")
+	tombstone.DeletedAt = new(time.Now())
+	m, _ = m.update(liveMessageMsg{room: "room", message: tombstone})
+
+	if len(m.messages) != 2 || m.messages[0].DeletedAt == nil {
+		t.Fatalf("messages = %+v, want the first replaced by its tombstone", m.messages)
+	}
+	if v := m.viewPanel(80, 10, true); strings.Contains(v, "⚠") {
+		t.Errorf("a tombstone should not be flagged as tampered, got:\n%s", v)
+	}
+}
+
+// TestChatModel_CursorMode_EEditsOwnMessage verifies "e" pre-fills the input
+// with the message and enter sends a revision signed by the identity.
+func TestChatModel_CursorMode_EEditsOwnMessage(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body generated.EditMessageRequest
+		if r.Method != http.MethodPut || r.URL.Path != "/api/rooms/room/messages/m1" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		event := crypto.Event{
+			Version: deref(body.Version), Pubkey: id.PubKeyHex, CreatedAt: body.Timestamp,
+			Content: body.Content, Room: "room", User: "alice", Tags: body.Tags,
+		}
+		if !crypto.HasTag(body.Tags, crypto.EditTag, "m1") || crypto.VerifyEventSignature(event, body.Signature) != nil {
+			http.Error(w, "bad signature", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.Message{
+			Id: new("m1"), Content: new(body.Content), Pubkey: new(id.PubKeyHex), User: new("alice"),
+			EditedAt: new(time.Now()), Revisions: new(1),
+		})
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "bob")
+	m.loading = false
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("helo"), Pubkey: new(id.PubKeyHex), User: new("alice")}}
+	m.msgCursorMode = true
+
+	m, _ = m.update(pressRealChar('e', "e"))
+	if !m.typing || m.editingID != "m1" || m.inputText != "helo" {
+		t.Fatalf("typing = %v, editingID = %q, input = %q; want the message in the input", m.typing, m.editingID, m.inputText)
+	}
+	m, _ = m.update(pressRealChar('l', "l"))
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("expected an edit command")
+	}
+	edited, ok := cmd().(messageEditedMsg)
+	if !ok || edited.err != nil {
+		t.Fatalf("edit result = %+v", edited)
+	}
+	m, _ = m.update(edited)
+
+	if len(m.messages) != 1 || deref(m.messages[0].Content) != "helol" {
+		t.Fatalf("messages = %+v, want the edited message in place", m.messages)
+	}
+	if v := m.viewPanel(80, 10, true); !strings.Contains(v, "(edited)") {
+		t.Errorf("view should mark the message as edited, got:\n%s", v)
+	}
+}
+
+func TestChatModel_CursorMode_ECannotEditOthers(t *testing.T) {
+	id, _ := generateIdentity()
+	m := newChatModel(nil, serverConfig{}, "room", "", &id, "bob")
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("hi"), Pubkey: new("02" + strings.Repeat("ab", 32))}}
+	m.msgCursorMode = true
+
+	m, _ = m.update(pressRealChar('e', "e"))
+	if m.editingID != "" || m.err == "" {
+		t.Errorf("editingID = %q, err = %q; want an error", m.editingID, m.err)
+	}
+}
+
+// TestChatModel_History_ShowsRevisions verifies "h" opens the revisions of an
+// edited message and esc closes them.
+func TestChatModel_History_ShowsRevisions(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "bob")
+	m.loading = false
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("hello"), Revisions: new(1), EditedAt: new(time.Now())}}
+	m.msgCursorMode = true
+
+	m, cmd := m.update(pressRealChar('h', "h"))
+	if !m.historyMode || cmd == nil {
+		t.Fatalf("historyMode = %v, cmd = %v; want the revisions to load", m.historyMode, cmd)
+	}
+	m, _ = m.update(revisionsLoadedMsg{messageID: "m1", revisions: []generated.MessageRevision{{Revision: new(0), Content: new("helo")}}})
+
+	v := m.viewPanel(80, 10, true)
+	if !strings.Contains(v, "helo") || !strings.Contains(v, "hello") {
+		t.Errorf("history should show both revisions, got:\n%s", v)
+	}
+
+	m, _ = m.update(pressKey(tea.KeyEscape))
+	if m.historyMode || !m.msgCursorMode {
+		t.Errorf("historyMode = %v, msgCursorMode = %v; want back to the cursor", m.historyMode, m.msgCursorMode)
+	}
+}
+
+func TestChatModel_CursorMode_RRepliesToMessage(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body generated.SendMessageRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/api/rooms/room/messages" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		event := crypto.Event{
+			Version: deref(body.Version), Pubkey: id.PubKeyHex, CreatedAt: body.Timestamp,
+			Content: body.Content, Room: "room", User: "bob", Tags: messageTags(generated.Message{Tags: body.Tags}),
+		}
+		if deref(body.ReplyTo) != "m1" || !crypto.HasTag(event.Tags, crypto.ReplyTag, "m1") || crypto.VerifyEventSignature(event, body.Signature) != nil {
+			http.Error(w, "bad reply", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.Message{Id: new("m2"), Content: new(body.Content), ReplyTo: body.ReplyTo})
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "bob")
+	m.loading = false
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("question?"), User: new("alice")}}
+	m.msgCursorMode = true
+
+	m, _ = m.update(pressRealChar('r', "r"))
+	if !m.typing || m.replyTo != "m1" {
+		t.Fatalf("typing = %v, replyTo = %q; want a reply to m1", m.typing, m.replyTo)
+	}
+	if v := m.viewPanel(80, 10, true); !strings.Contains(v, "reply") {
+		t.Errorf("input should show the reply prefix, got:\n%s", v)
+	}
+	m, _ = m.update(pressRealChar('y', "y"))
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("expected a send command")
+	}
+	if sent, ok := cmd().(messageSentMsg); !ok || sent.err != nil {
+		t.Fatalf("send result = %+v", sent)
+	}
+	if m.replyTo != "" || m.typing {
+		t.Errorf("replyTo = %q, typing = %v; want the reply cleared", m.replyTo, m.typing)
+	}
+}
+
+// TestChatModel_Replies_QuoteParentAndCount verifies replies quote their
+// parent and parents show how many replies they received.
+func TestChatModel_Replies_QuoteParentAndCount(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "bob")
+	m.loading = false
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("what time is it?"), User: new("alice")}}
+
+	m, _ = m.update(liveMessageMsg{message: generated.Message{Id: new("m2"), Content: new("noon"), User: new("bob"), ReplyTo: new("m1")}})
+
+	if got := deref(m.messages[0].Replies); got != 1 {
+		t.Errorf("parent Replies = %d, want 1", got)
+	}
+	v := m.viewPanel(80, 10, true)
+	if !strings.Contains(v, "↪ alice: what time is it?") {
+		t.Errorf("reply should quote its parent, got:\n%s", v)
+	}
+	if !strings.Contains(v, "(1 reply)") {
+		t.Errorf("parent should show its reply count, got:\n%s", v)
+	}
+}
+
+// TestChatModel_Thread_ShowsReplies verifies "t" opens the thread of a message,
+// live replies join it and esc closes it.
+func TestChatModel_Thread_ShowsReplies(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "bob")
+	m.loading = false
+	m.messages = []generated.Message{
+		{Id: new("m1"), Content: new("question?"), User: new("alice"), Replies: new(1)},
+		{Id: new("m2"), Content: new("other topic"), User: new("carol")},
+	}
+	m.msgCursorMode = true
+	m.msgCursor = 0
+
+	m, cmd := m.update(pressRealChar('t', "t"))
+	if !m.threadMode || cmd == nil {
+		t.Fatalf("threadMode = %v, cmd = %v; want the thread to load", m.threadMode, cmd)
+	}
+	m, _ = m.update(threadLoadedMsg{
+		rootID:  "m1",
+		root:    m.messages[0],
+		replies: []generated.Message{{Id: new("r1"), Content: new("first answer"), User: new("bob"), ReplyTo: new("m1")}},
+	})
+	m, _ = m.update(liveMessageMsg{message: generated.Message{Id: new("r2"), Content: new("second answer"), User: new("dave"), ReplyTo: new("m1")}})
+
+	v := m.viewPanel(80, 10, true)
+	for _, want := range []string{"question?", "first answer", "second answer"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("thread should show %q, got:\n%s", want, v)
+		}
+	}
+	if strings.Contains(v, "other topic") {
+		t.Errorf("thread should not show unrelated messages, got:\n%s", v)
+	}
+
+	m, _ = m.update(pressRealChar('r', "r"))
+	if !m.typing || m.replyTo != "m1" {
+		t.Errorf("typing = %v, replyTo = %q; want a reply to the thread root", m.typing, m.replyTo)
+	}
+	m, _ = m.update(pressKey(tea.KeyEscape))
+	m, _ = m.update(pressKey(tea.KeyEscape))
+	if m.threadMode || !m.msgCursorMode {
+		t.Errorf("threadMode = %v, msgCursorMode = %v; want back to the cursor", m.threadMode, m.msgCursorMode)
+	}
+}
+
+func TestChatModel_Reactions_PickerTogglesReaction(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	counts := func(pairs ...any) []generated.ReactionCount {
+		var out []generated.ReactionCount
+		for i := 0; i < len(pairs); i += 2 {
+			out = append(out, generated.ReactionCount{Emoji: new(pairs[i].(string)), Count: new(pairs[i+1].(int))})
+		}
+		return out
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/rooms/room/messages/m1/reactions":
+			_ = json.NewEncoder(w).Encode([]generated.Reaction{{Emoji: new("👍"), Pubkey: new(id.PubKeyHex)}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/rooms/room/messages/m1/reactions":
+			var body generated.AddReactionRequest
+			if json.NewDecoder(r.Body).Decode(&body) != nil {
+				http.NotFound(w, r)
+				return
+			}
+			event := crypto.Event{Version: deref(body.Version), Pubkey: body.Pubkey, CreatedAt: body.Timestamp, Content: body.Emoji, Room: "room", Tags: body.Tags}
+			if !crypto.HasTag(body.Tags, crypto.ReactTag, "m1") || crypto.VerifyEventSignature(event, body.Signature) != nil {
+				http.Error(w, "bad signature", http.StatusForbidden)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(counts("👍", 1, body.Emoji, 1))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/rooms/room/messages/m1/reactions/👍" && r.Header.Get("Authorization") != "":
+			_ = json.NewEncoder(w).Encode(counts("❤️", 1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "bob")
+	m.loading = false
+	m.messages = []generated.Message{{Id: new("m1"), Content: new("ship it?"), User: new("alice")}}
+	m.msgCursorMode = true
+
+	// Add ❤️ next to the 👍 already given
+	m, cmd := m.update(pressRealChar('+', "+"))
+	if !m.reactionMode || cmd == nil {
+		t.Fatalf("reactionMode = %v, cmd = %v; want the picker to load", m.reactionMode, cmd)
+	}
+	m, _ = m.update(cmd())
+	if v := m.viewPanel(80, 10, true); !strings.Contains(v, "[👍✓]") {
+		t.Errorf("picker should mark the reaction already given, got:\n%s", v)
+	}
+	m, _ = m.update(pressRealChar('l', "l"))
+	m, cmd = m.update(pressKey(tea.KeyEnter))
+	if m.reactionMode || cmd == nil {
+		t.Fatalf("reactionMode = %v, cmd = %v; want the reaction sent", m.reactionMode, cmd)
+	}
+	toggled, ok := cmd().(reactionToggledMsg)
+	if !ok || toggled.err != nil {
+		t.Fatalf("add result = %+v", toggled)
+	}
+	m, _ = m.update(toggled)
+	if v := m.viewPanel(80, 10, true); !strings.Contains(v, "👍 1 ❤️ 1") {
+		t.Errorf("view should show the reaction counts, got:\n%s", v)
+	}
+
+	// Enter on 👍 again removes it
+	m, cmd = m.update(pressRealChar('+', "+"))
+	m, _ = m.update(cmd())
+	m, cmd = m.update(pressKey(tea.KeyEnter))
+	toggled, ok = cmd().(reactionToggledMsg)
+	if !ok || toggled.err != nil {
+		t.Fatalf("remove result = %+v", toggled)
+	}
+	m, _ = m.update(toggled)
+	if !m.msgCursorMode {
+		t.Error("closing the picker should return to the cursor")
+	}
+	if v := m.viewPanel(80, 10, true); strings.Contains(v, "👍 1") || !strings.Contains(v, "❤️ 1") {
+		t.Errorf("view should show the remaining reaction only, got:\n%s", v)
+	}
+}
+
 func TestChatModel_RenameMode_TypingAppendsToInput(t *testing.T) {
 	m := newChatModel(nil, serverConfig{}, "room", "", nil, "bob")
 	m.chatRenameMode = true
@@ -519,7 +955,7 @@ func TestChatModel_View_NonContactShowsUser(t *testing.T) {
 
 func TestChatModel_SendWithNoIdentity_ReturnsError(t *testing.T) {
 	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
-	cmd := m.sendMessage("hello")
+	cmd := m.sendMessage("hello", "")
 	if cmd == nil {
 		t.Fatal("expected non-nil cmd")
 	}
@@ -563,7 +999,7 @@ func TestChatModel_SigVerification_ValidSig_NoWarning(t *testing.T) {
 	content := "hello world"
 	room := "testroom"
 	ts := time.Now().Unix()
-	sig, err := id.SignMessage(content, room, ts)
+	sig, err := id.SignMessage(content, room, "alice", ts)
 	if err != nil {
 		t.Fatalf("SignMessage: %v", err)
 	}
@@ -575,6 +1011,8 @@ func TestChatModel_SigVerification_ValidSig_NoWarning(t *testing.T) {
 		SignedTimestamp: &ts,
 		Room:            &room,
 		Content:         &content,
+		User:            new("alice"),
+		Version:         new(1),
 	}
 	m := newChatModel(nil, serverConfig{}, room, "", nil, "alice")
 	m.loading = false
@@ -647,7 +1085,7 @@ func TestChatModel_SigVerification_TamperedContent_ShowsWarning(t *testing.T) {
 	tamperedContent := "tampered"
 	room := "testroom"
 	ts := time.Now().Unix()
-	sig, err := id.SignMessage(originalContent, room, ts)
+	sig, err := id.SignMessage(originalContent, room, "alice", ts)
 	if err != nil {
 		t.Fatalf("SignMessage: %v", err)
 	}
@@ -659,6 +1097,8 @@ func TestChatModel_SigVerification_TamperedContent_ShowsWarning(t *testing.T) {
 		SignedTimestamp: &ts,
 		Room:            &room,
 		Content:         &tamperedContent, // content changed after signing
+		User:            new("alice"),
+		Version:         new(1),
 	}
 	m := newChatModel(nil, serverConfig{}, room, "", nil, "alice")
 	m.loading = false
@@ -673,3 +1113,435 @@ func TestChatModel_SigVerification_TamperedContent_ShowsWarning(t *testing.T) {
 		t.Errorf("view should show ⚠ for tampered content, got:\n%s", v)
 	}
 }
+
+func makeIDMessage(id, content string) generated.Message {
+	return generated.Message{Id: new(id), Content: new(content)}
+}
+
+// TestChatModel_LiveMessage_AtBottomFollows verifies a pushed message is appended
+// and the view stays pinned to the bottom when scroll == 0.
+func TestChatModel_LiveMessage_AtBottomFollows(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+	m.loading = false
+	m.messages = []generated.Message{makeIDMessage("a", "first")}
+
+	m, _ = m.update(liveMessageMsg{room: "room", message: makeIDMessage("b", "second")})
+
+	if len(m.messages) != 2 {
+		t.Fatalf("messages = %d, want 2", len(m.messages))
+	}
+	if m.scroll != 0 {
+		t.Errorf("scroll = %d, want 0 (auto-scroll at bottom)", m.scroll)
+	}
+}
+
+// TestChatModel_LiveMessage_ScrolledUpKeepsPosition verifies a pushed message does
+// not move the viewport when the user has scrolled up.
+func TestChatModel_LiveMessage_ScrolledUpKeepsPosition(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+	m.loading = false
+	m.messages = []generated.Message{makeIDMessage("a", "first"), makeIDMessage("b", "second")}
+	m.scroll = 1
+
+	m, _ = m.update(liveMessageMsg{room: "room", message: makeIDMessage("c", "third")})
+
+	if m.scroll != 2 {
+		t.Errorf("scroll = %d, want 2 (viewport anchored)", m.scroll)
+	}
+}
+
+// TestChatModel_LiveMessage_Deduplicates verifies a message already shown is not appended twice.
+func TestChatModel_LiveMessage_Deduplicates(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+	m.messages = []generated.Message{makeIDMessage("a", "first")}
+
+	m, _ = m.update(liveMessageMsg{room: "room", message: makeIDMessage("a", "first")})
+
+	if len(m.messages) != 1 {
+		t.Errorf("messages = %d, want 1 (duplicate ignored)", len(m.messages))
+	}
+}
+
+// TestChatModel_PolledMessages_MergesNewOnly verifies polling merges unseen messages
+// and ignores results for a room that is no longer open.
+func TestChatModel_PolledMessages_MergesNewOnly(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+	m.messages = []generated.Message{makeIDMessage("a", "first")}
+	polled := []generated.Message{makeIDMessage("a", "first"), makeIDMessage("b", "second")}
+
+	m, _ = m.update(messagesPolledMsg{room: "other", messages: polled})
+	if len(m.messages) != 1 {
+		t.Fatalf("messages = %d, want 1 (other room ignored)", len(m.messages))
+	}
+
+	m, _ = m.update(messagesPolledMsg{room: "room", messages: polled})
+	if len(m.messages) != 2 {
+		t.Errorf("messages = %d, want 2 after merge", len(m.messages))
+	}
+}
+
+// TestChatModel_PollMessages_ResumesAfterLastMessage verifies polling fetches
+// only the messages after the last one shown, and falls back to the latest
+// page once the server no longer has it.
+func TestChatModel_PollMessages_ResumesAfterLastMessage(t *testing.T) {
+	var gone bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/rooms/room/messages" && r.URL.Query().Get("after") == "b" && !gone:
+			_ = json.NewEncoder(w).Encode([]generated.Message{makeIDMessage("c", "third")})
+		case r.URL.Path == "/api/rooms/room/messages/page" && gone:
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []generated.Message{makeIDMessage("d", "fourth")}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", nil, "alice")
+	m.loading = false
+	m.messages = []generated.Message{makeIDMessage("a", "first"), makeIDMessage("b", "second")}
+
+	polled, ok := m.pollMessages()().(messagesPolledMsg)
+	if !ok || polled.err != nil || len(polled.messages) != 1 || deref(polled.messages[0].Id) != "c" {
+		t.Fatalf("poll = %+v, want the message after b", polled)
+	}
+
+	gone = true
+	polled, ok = m.pollMessages()().(messagesPolledMsg)
+	if !ok || polled.err != nil || len(polled.messages) != 1 || deref(polled.messages[0].Id) != "d" {
+		t.Errorf("poll = %+v, want the latest page once b is gone", polled)
+	}
+}
+
+func TestChatModel_SigVerification_RenamedUser_ShowsWarning(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	content := "hello"
+	room := "testroom"
+	ts := time.Now().Unix()
+	sig, err := id.SignMessage(content, room, "alice", ts)
+	if err != nil {
+		t.Fatalf("SignMessage: %v", err)
+	}
+	msg := generated.Message{
+		Id:              new("msg-renamed"),
+		Pubkey:          &id.PubKeyHex,
+		Signature:       &sig,
+		SignedTimestamp: &ts,
+		Room:            &room,
+		Content:         &content,
+		User:            new("mallory"), // display name changed after signing
+		Version:         new(1),
+	}
+	m := newChatModel(nil, serverConfig{}, room, "", nil, "alice")
+	m.loading = false
+	m2, _ := m.update(messagesLoadedMsg{messages: []generated.Message{msg}})
+
+	if !m2.invalidSigs[msgKey(msg, 0)] {
+		t.Error("expected a message with a changed display name to be in invalidSigs")
+	}
+}
+
+func TestChatModel_SignatureSchemes_EnablesSchnorr(t *testing.T) {
+	m := newChatModel(nil, serverConfig{}, "room", "", nil, "alice")
+
+	m2, _ := m.update(signatureSchemesMsg{schemes: []string{"ecdsa"}})
+	if m2.schnorr {
+		t.Error("expected ECDSA-only servers to keep ECDSA signing")
+	}
+
+	m3, _ := m.update(signatureSchemesMsg{schemes: []string{"ecdsa", "schnorr"}})
+	if !m3.schnorr {
+		t.Error("expected schnorr signing when the server advertises it")
+	}
+}
+
+func TestChatModel_SigVerification_Schnorr_NoWarning(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	content := "hello"
+	room := "testroom"
+	ts := time.Now().Unix()
+	sig, tags, err := id.SignMessageSchnorr(content, room, "alice", ts)
+	if err != nil {
+		t.Fatalf("SignMessageSchnorr: %v", err)
+	}
+	msg := generated.Message{
+		Id:              new("msg-schnorr"),
+		Pubkey:          &id.PubKeyHex,
+		Signature:       &sig,
+		SignedTimestamp: &ts,
+		Room:            &room,
+		Content:         &content,
+		User:            new("alice"),
+		Tags:            sdkTags(tags),
+		SigScheme:       new("schnorr"),
+	}
+	m := newChatModel(nil, serverConfig{}, room, "", nil, "alice")
+	m.loading = false
+	m2, _ := m.update(messagesLoadedMsg{messages: []generated.Message{msg}})
+
+	if m2.invalidSigs[msgKey(msg, 0)] {
+		t.Error("expected a valid schnorr signature not to be in invalidSigs")
+	}
+}
+
+func TestChatModel_EncryptedRoom_EncryptsAndDecrypts(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	salt, _ := crypto.NewRoomKeySalt()
+	key, _ := crypto.RoomKey("hunter22", salt)
+
+	var sent generated.SendMessageRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&sent) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(generated.Message{Content: &sent.Content})
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "vault", "hunter22", &id, "bob").withKeySalt(salt)
+	if msg, ok := m.sendMessage("the eagle has landed", "")().(messageSentMsg); !ok || msg.err != nil {
+		t.Fatalf("send: got %+v", msg)
+	}
+	if sent.Content == "the eagle has landed" {
+		t.Fatal("content was sent in clear")
+	}
+	if deref(sent.RoomPassword) != crypto.RoomVerifier(key) {
+		t.Errorf("room password = %q, want the verifier of the key, never the password", deref(sent.RoomPassword))
+	}
+	if plaintext, err := crypto.DecryptDM(key, sent.Content); err != nil || plaintext != "the eagle has landed" {
+		t.Errorf("sent content decrypts to %q, %v", plaintext, err)
+	}
+	event := crypto.Event{Version: deref(sent.Version), Pubkey: sent.Pubkey, CreatedAt: sent.Timestamp, Content: sent.Content, Room: "vault", User: sent.User}
+	if err := crypto.VerifyEventSignature(event, sent.Signature); err != nil {
+		t.Errorf("the signature should cover the ciphertext: %v", err)
+	}
+
+	otherKey, _ := crypto.RoomKey("hunter23", salt)
+	foreign, _ := crypto.EncryptDM(otherKey, "not for you")
+	m.loading = false
+	m.messages = []generated.Message{
+		{Id: new("m1"), Content: &sent.Content, User: new("bob")},
+		{Id: new("m2"), Content: &foreign, User: new("eve")},
+	}
+	v := m.viewPanel(80, 10, true)
+	if !strings.Contains(v, "the eagle has landed") || !strings.Contains(v, "(cannot decrypt)") || !strings.Contains(v, "🔐") {
+		t.Errorf("view should show the plaintext and flag the foreign message, got:\n%s", v)
+	}
+}
+
+// TestChatModel_Invite_SignsReadsAndSends verifies a chat joined with an invite
+// presents it in place of the password: reads are signed, sends carry it.
+func TestChatModel_Invite_SignsReadsAndSends(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+	var sent generated.SendMessageRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			if q.Get("invite") != "tok" || q.Has("password") {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			if pubkey, err := auth.Verify(r); err != nil || pubkey != id.PubKeyHex {
+				http.Error(w, "unsigned", http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []generated.Message{makeIDMessage("m1", "welcome")}})
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(makeIDMessage("m2", sent.Content))
+		}
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "alice").withInvite("tok")
+	loaded, ok := m.fetchMessages()().(messagesLoadedMsg)
+	if !ok || loaded.err != nil || len(loaded.messages) != 1 {
+		t.Fatalf("fetch = %+v, want the room messages", loaded)
+	}
+	if msg, ok := m.sendMessage("hi", "")().(messageSentMsg); !ok || msg.err != nil {
+		t.Fatalf("send = %+v", msg)
+	}
+	if deref(sent.RoomInvite) != "tok" || sent.RoomPassword != nil {
+		t.Errorf("sent invite = %q, password = %v; want the invite only", deref(sent.RoomInvite), sent.RoomPassword)
+	}
+
+	anonymous := newChatModel(client, serverConfig{}, "room", "", nil, "alice").withInvite("tok")
+	if loaded := anonymous.fetchMessages()().(messagesLoadedMsg); loaded.err == nil {
+		t.Error("fetch without an identity: want an error, as invites need a signed request")
+	}
+}
+
+// TestChatModel_Private_SignsReads verifies a private room is read with signed
+// requests, and cannot be read without an identity.
+func TestChatModel_Private_SignsReads(t *testing.T) {
+	id, err := generateIdentity()
+	if err != nil {
+		t.Fatalf("generateIdentity: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+		if pubkey, err := auth.Verify(r); err != nil || pubkey != id.PubKeyHex {
+			http.Error(w, "unsigned", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/thread"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"root": makeIDMessage("m1", "welcome")})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []generated.Message{makeIDMessage("m1", "welcome")}})
+		}
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "", &id, "alice").withPrivate()
+	loaded, ok := m.fetchMessages()().(messagesLoadedMsg)
+	if !ok || loaded.err != nil || len(loaded.messages) != 1 {
+		t.Fatalf("fetch = %+v, want the room messages", loaded)
+	}
+	if thread, ok := m.fetchThread("m1")().(threadLoadedMsg); !ok || thread.err != nil {
+		t.Errorf("thread = %+v, want a signed read", thread)
+	}
+
+	anonymous := newChatModel(client, serverConfig{}, "room", "", nil, "alice").withPrivate()
+	if loaded := anonymous.fetchMessages()().(messagesLoadedMsg); loaded.err == nil {
+		t.Error("fetch without an identity: want an error, as private rooms need a signed request")
+	}
+}
+
+// TestChatModel_Search_JumpsToHits verifies "/" searches the room on the server,
+// selects the newest hit, and "n" loads the older history up to the next one.
+func TestChatModel_Search_JumpsToHits(t *testing.T) {
+	now := time.Now()
+	at := func(message generated.Message, age time.Duration) generated.Message {
+		message.Timestamp = new(now.Add(-age))
+		return message
+	}
+	old := at(makeIDMessage("old", "hello from long ago"), time.Hour)
+	recent := at(makeIDMessage("recent", "hello again"), time.Minute)
+	var searched string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/search":
+			searched = r.URL.Query().Get("room") + ":" + r.URL.Query().Get("q") + ":" + r.URL.Query().Get("password")
+			_ = json.NewEncoder(w).Encode([]generated.Message{recent, old})
+		case "/api/rooms/room/messages/page":
+			if r.URL.Query().Get("before") != "older" {
+				http.Error(w, "unknown cursor", http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []generated.Message{old, at(makeIDMessage("between", "hi"), 30*time.Minute)}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	m := newChatModel(client, serverConfig{}, "room", "pw", nil, "alice")
+	m, _ = m.update(messagesLoadedMsg{messages: []generated.Message{recent, at(makeIDMessage("last", "bye"), 0)}})
+	m.hasMore, m.prevCursor = true, "older" // as for a full first page
+	m, _ = m.update(pressRealChar('/', "/"))
+	for _, r := range "hello" {
+		m, _ = m.update(pressRealChar(r, string(r)))
+	}
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("enter should search the room")
+	}
+	m, _ = m.update(cmd())
+	if searched != "room:hello:pw" {
+		t.Errorf("searched %q, want the room with its password", searched)
+	}
+	if !m.msgCursorMode || deref(m.messages[m.msgCursor].Id) != "recent" {
+		t.Fatalf("cursor = %v at %d, want the newest hit selected", m.msgCursorMode, m.msgCursor)
+	}
+
+	m, cmd = m.update(pressRealChar('n', "n"))
+	if cmd == nil {
+		t.Fatal("n should load the history up to the older hit")
+	}
+	m, _ = m.update(cmd())
+	if deref(m.messages[m.msgCursor].Id) != "old" || len(m.messages) != 4 {
+		t.Errorf("cursor on %q among %d messages, want the older hit after loading the history", deref(m.messages[m.msgCursor].Id), len(m.messages))
+	}
+	if !strings.Contains(m.statusMsg, "2/2") {
+		t.Errorf("status = %q, want the hit count", m.statusMsg)
+	}
+
+	m, _ = m.update(pressKey(tea.KeyEscape))
+	if m.msgCursorMode || m.searchHits != nil {
+		t.Error("esc should close the search")
+	}
+}
+
+// TestChatModel_Search_EncryptedRoomSearchesLoadedMessages verifies an encrypted
+// room is searched in the decrypted messages already loaded.
+func TestChatModel_Search_EncryptedRoomSearchesLoadedMessages(t *testing.T) {
+	salt, err := crypto.NewRoomKeySalt()
+	if err != nil {
+		t.Fatalf("NewRoomKeySalt: %v", err)
+	}
+	m := newChatModel(nil, serverConfig{}, "vault", "hunter22", nil, "bob").withKeySalt(salt)
+	m.loading = false
+	for i, content := range []string{"the eagle has landed", "roger that"} {
+		ciphertext, err := encrypt(m.roomKey, content)
+		if err != nil {
+			t.Fatalf("encrypt: %v", err)
+		}
+		m.messages = append(m.messages, makeIDMessage(string(rune('a'+i)), ciphertext))
+	}
+
+	m, _ = m.update(pressRealChar('/', "/"))
+	for _, r := range "EAGLE" {
+		m, _ = m.update(pressRealChar(r, string(r)))
+	}
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd != nil {
+		t.Error("an encrypted room should not be searched on the server")
+	}
+	if !m.msgCursorMode || m.msgCursor != 0 || len(m.searchHits) != 1 {
+		t.Errorf("cursor = %v at %d with %d hits, want the decrypted match", m.msgCursorMode, m.msgCursor, len(m.searchHits))
+	}
+}
@@ -74,6 +74,11 @@ func (m contactsModel) update(msg tea.Msg) (contactsModel, tea.Cmd) {
 					}
 					m.configChanged = true
 				}
+			case "m":
+				if len(m.contacts) > 0 {
+					contact := m.contacts[m.cursor]
+					return m, func() tea.Msg { return openDMMsg{contact: contact} }
+				}
 			case "esc", "tab":
 				return m, func() tea.Msg { return navigateMsg{to: screenRooms} }
 			case "ctrl+c", "q":
@@ -197,7 +202,7 @@ func (m contactsModel) viewPanel(width, height int, focused bool) string {
 			}
 			body = panelBodyLines(renderTable(cols, rows, m.cursor, ""))
 		}
-		help = helpBar("↑↓", "select", "a", "add", "r", "rename", "d", "delete", "←", "back")
+		help = helpBar("↑↓", "select", "m", "message", "a", "add", "r", "rename", "d", "delete", "←", "back")
 
 	case contactsStateAddNpub:
 		body = append(body, " Enter npub (public key):", "", " > "+m.inputNpub+"█")
@@ -389,3 +389,16 @@ func TestContactsModel_View_ShowsError(t *testing.T) {
 		t.Errorf("view should show error, got:\n%s", v)
 	}
 }
+
+func TestContactsModel_M_OpensDM(t *testing.T) {
+	m := makeContactsModel(contactEntry{PubKey: "aa", DisplayName: "Alice"}, contactEntry{PubKey: "bb", DisplayName: "Bob"})
+	m.cursor = 1
+	_, cmd := m.update(pressRealChar('m', "m"))
+	open, ok := runCmd(cmd).(openDMMsg)
+	if !ok || open.contact.DisplayName != "Bob" {
+		t.Errorf("expected openDMMsg for Bob, got %+v", open)
+	}
+	if _, cmd := makeContactsModel().update(pressRealChar('m', "m")); cmd != nil {
+		t.Error("m with no contacts should do nothing")
+	}
+}
@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// openDMMsg is sent by the Contacts section to open direct messages with a contact.
+type openDMMsg struct{ contact contactEntry }
+
+// dmsLoadedMsg carries the decrypted direct messages exchanged with peer, or an error.
+type dmsLoadedMsg struct {
+	peer     string
+	messages []dmLine
+	err      error
+}
+
+// dmSentMsg reports the result of sending a direct message.
+type dmSentMsg struct{ err error }
+
+// dmLine is a decrypted direct message ready for display.
+type dmLine struct {
+	fromMe    bool
+	text      string
+	timestamp *time.Time
+	invalid   bool // does not decrypt or is not signed by its sender
+}
+
+// dmModel shows the direct messages exchanged with one contact. Messages are
+// encrypted on this side: the server only stores payloads it cannot read.
+type dmModel struct {
+	client    *generated.ClientWithResponses
+	server    serverConfig
+	id        *identity
+	peer      contactEntry
+	peerHex   string // x-only public key of the contact
+	messages  []dmLine
+	inputText string
+	typing    bool
+	loading   bool
+	err       string
+}
+
+func newDMModel(client *generated.ClientWithResponses, server serverConfig, id *identity, peer contactEntry) dmModel {
+	m := dmModel{client: client, server: server, id: id, peer: peer}
+	peerHex, err := contactPubKeyHex(peer.PubKey)
+	switch {
+	case err != nil:
+		m.err = "invalid contact key: " + err.Error()
+	case id == nil:
+		m.err = "no identity configured — add one in the Identities screen"
+	default:
+		m.peerHex = peerHex
+		m.loading = true
+	}
+	return m
+}
+
+// contactPubKeyHex returns the x-only hex public key of a contact, stored
+// either as an npub or as a hex key.
+func contactPubKeyHex(pubKey string) (string, error) {
+	pubKey = strings.TrimSpace(pubKey)
+	if strings.HasPrefix(strings.ToLower(pubKey), "npub1") {
+		return npubToPubKeyHex(pubKey)
+	}
+	return crypto.XOnlyPubkey(strings.ToLower(pubKey))
+}
+
+func (m dmModel) init() tea.Cmd {
+	if m.peerHex == "" {
+		return nil
+	}
+	return m.fetchDMs()
+}
+
+// fetchDMs loads the latest direct messages with the contact. The inbox is
+// private, so the request is signed.
+func (m dmModel) fetchDMs() tea.Cmd {
+	client := m.client
+	id := m.id
+	peer := m.peerHex
+	return func() tea.Msg {
+		key, err := id.ConversationKey(peer)
+		if err != nil {
+			return dmsLoadedMsg{peer: peer, err: err}
+		}
+		params := &generated.GETapidmsParams{With: &peer, Limit: new(50)}
+		resp, err := client.GETapidmsWithResponse(context.Background(), params, id.signRequests())
+		if err != nil {
+			return dmsLoadedMsg{peer: peer, err: err}
+		}
+		if resp.JSON200 == nil {
+			return dmsLoadedMsg{peer: peer, err: fmt.Errorf("load failed: %d", resp.StatusCode())}
+		}
+		lines := make([]dmLine, 0, len(*resp.JSON200))
+		for _, dm := range *resp.JSON200 {
+			text, err := crypto.DecryptDM(key, deref(dm.Content))
+			lines = append(lines, dmLine{
+				fromMe:    crypto.SamePubkey(deref(dm.Sender), id.PubKeyHex),
+				text:      text,
+				timestamp: dm.Timestamp,
+				invalid:   err != nil || dmSigInvalid(dm),
+			})
+		}
+		return dmsLoadedMsg{peer: peer, messages: lines}
+	}
+}
+
+// sendDM encrypts content for the contact and sends it.
+func (m dmModel) sendDM(content string) tea.Cmd {
+	client := m.client
+	id := m.id
+	peer := m.peerHex
+	return func() tea.Msg {
+		key, err := id.ConversationKey(peer)
+		if err != nil {
+			return dmSentMsg{err: err}
+		}
+		payload, err := crypto.EncryptDM(key, content)
+		if err != nil {
+			return dmSentMsg{err: fmt.Errorf("encryption failed: %w", err)}
+		}
+		ts := time.Now().Unix()
+		sig, tags, err := id.SignDirectMessage(peer, payload, ts)
+		if err != nil {
+			return dmSentMsg{err: fmt.Errorf("signing failed: %w", err)}
+		}
+		resp, err := client.POSTapidmsWithResponse(context.Background(), nil, generated.SendDirectMessageRequest{
+			Content:   payload,
+			Pubkey:    id.PubKeyHex,
+			Recipient: peer,
+			Signature: sig,
+			Tags:      tags,
+			Timestamp: ts,
+			Version:   new(crypto.LatestEventVersion),
+		})
+		if err != nil {
+			return dmSentMsg{err: err}
+		}
+		if resp.JSON200 == nil {
+			return dmSentMsg{err: fmt.Errorf("send failed: %d", resp.StatusCode())}
+		}
+		return dmSentMsg{}
+	}
+}
+
+// dmSigInvalid returns true when a direct message is not signed by its sender.
+func dmSigInvalid(dm generated.DirectMessage) bool {
+	if deref(dm.Sender) == "" || deref(dm.Signature) == "" || dm.SignedTimestamp == nil {
+		return true
+	}
+	event := crypto.Event{
+		Version:   deref(dm.Version),
+		Pubkey:    *dm.Sender,
+		CreatedAt: *dm.SignedTimestamp,
+		Content:   deref(dm.Content),
+		Tags:      deref(dm.Tags),
+	}
+	return crypto.VerifyEventSignatureBTCD(event, *dm.Signature) != nil
+}
+
+func (m dmModel) update(msg tea.Msg) (dmModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dmsLoadedMsg:
+		if msg.peer != m.peerHex {
+			return m, nil // answer for a contact closed since
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.messages = msg.messages
+		m.err = ""
+
+	case dmSentMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		return m, m.fetchDMs()
+
+	case tea.PasteMsg:
+		if m.typing {
+			m.inputText += msg.Content
+		}
+
+	case tea.KeyMsg:
+		if m.typing {
+			switch msg.String() {
+			case "esc", "tab":
+				m.typing = false
+			case "enter":
+				content := strings.TrimSpace(m.inputText)
+				if content == "" {
+					return m, nil
+				}
+				m.inputText = ""
+				m.err = ""
+				return m, m.sendDM(content)
+			case "backspace":
+				if _, size := utf8.DecodeLastRuneInString(m.inputText); size > 0 {
+					m.inputText = m.inputText[:len(m.inputText)-size]
+				}
+			default:
+				if t := msg.Key().Text; t != "" {
+					m.inputText += t
+				}
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "i", "enter":
+			if m.peerHex != "" {
+				m.typing = true
+			}
+		case "r":
+			if m.peerHex != "" {
+				m.loading = true
+				return m, m.fetchDMs()
+			}
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// viewPanel renders the conversation inside the right pane of the main two-pane view.
+func (m dmModel) viewPanel(width, height int, focused bool) string {
+	name := m.peer.DisplayName
+	if name == "" {
+		name = m.peer.PubKey
+	}
+	r, g, bv := pubkeyColor(m.peer.PubKey)
+	peerName := ansiColor(name, r, g, bv)
+	title := dim(serverDisplayName(m.server)+"~") + "@" + peerName + " " + dim("(encrypted)")
+
+	// header(2) + messages + sep(1) + input(1) + help(1) == height
+	contentHeight := max(height-5, 1)
+	var lines []string
+	switch {
+	case m.loading:
+		lines = []string{" Loading messages…"}
+	case len(m.messages) == 0 && m.peerHex != "":
+		lines = []string{" (no messages yet — press i to write)"}
+	default:
+		start := max(len(m.messages)-contentHeight, 0)
+		for _, msg := range m.messages[start:] {
+			lines = append(lines, "  "+m.formatLine(msg, peerName))
+		}
+	}
+	body := make([]string, 0, height)
+	for range contentHeight - len(lines) {
+		body = append(body, "")
+	}
+	body = append(body, lines...)
+	body = append(body, strings.Repeat("─", width))
+	cursor := ""
+	if m.typing {
+		cursor = "█"
+	}
+	body = append(body, " "+dim("to")+" "+peerName+" > "+m.inputText+cursor)
+
+	var help string
+	switch {
+	case m.err != "":
+		help = " Err: " + m.err
+	case m.typing:
+		help = helpBar("esc", "exit", "enter", "send", "⌫", "delete")
+	default:
+		help = helpBar("i", "insert", "r", "refresh", "esc", "contacts", "tab", "rooms")
+	}
+	return renderPanel(width, height, focused, title, body, help)
+}
+
+// formatLine renders a direct message on one line: time, author and text.
+func (m dmModel) formatLine(msg dmLine, peerName string) string {
+	author := peerName
+	if msg.fromMe && m.id != nil {
+		r, g, bv := pubkeyColor(m.id.PubKeyHex)
+		author = ansiColor("me", r, g, bv)
+	}
+	text := msg.text
+	suffix := ""
+	if msg.invalid {
+		suffix = " \x1b[33m⚠\x1b[0m"
+		if text == "" {
+			text = dim("(cannot decrypt)")
+		}
+	}
+	return fmt.Sprintf("%s %s%s%s %s", dim(formatMsgTime(msg.timestamp)), author, suffix, dim(":"), text)
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// newDMServer fakes the direct message endpoints: it stores what is posted
+// when signed for the recipient, and returns everything to signed callers.
+func newDMServer(t *testing.T) *generated.ClientWithResponses {
+	t.Helper()
+	var mu sync.Mutex
+	var stored []generated.DirectMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dms" && r.Header.Get("Authorization") != "":
+			_ = json.NewEncoder(w).Encode(stored)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dms":
+			var body generated.SendDirectMessageRequest
+			if json.NewDecoder(r.Body).Decode(&body) != nil {
+				http.NotFound(w, r)
+				return
+			}
+			event := crypto.Event{Version: deref(body.Version), Pubkey: body.Pubkey, CreatedAt: body.Timestamp, Content: body.Content, Tags: body.Tags}
+			if !crypto.HasTag(body.Tags, crypto.RecipientTag, body.Recipient) || crypto.VerifyEventSignature(event, body.Signature) != nil {
+				http.Error(w, "bad signature", http.StatusForbidden)
+				return
+			}
+			dm := generated.DirectMessage{
+				Content: &body.Content, Sender: &body.Pubkey, Recipient: &body.Recipient, Signature: &body.Signature,
+				SignedTimestamp: &body.Timestamp, Tags: &body.Tags, Version: body.Version, Timestamp: new(time.Now()),
+			}
+			stored = append(stored, dm)
+			_ = json.NewEncoder(w).Encode(dm)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+	return client
+}
+
+func TestDMModel_SendsEncryptedAndShowsPlaintext(t *testing.T) {
+	alice, _ := generateIdentity()
+	bob, _ := generateIdentity()
+	client := newDMServer(t)
+
+	m := newDMModel(client, serverConfig{}, &alice, contactEntry{PubKey: bob.NpubKey, DisplayName: "bob"})
+	if m.peerHex != bob.PubKeyHex[2:] {
+		t.Fatalf("peerHex = %q, want bob's x-only key", m.peerHex)
+	}
+	m, _ = m.update(m.init()())
+	if v := m.viewPanel(80, 12, true); !strings.Contains(v, "no messages yet") {
+		t.Errorf("empty conversation should say so, got:\n%s", v)
+	}
+
+	m, _ = m.update(pressRealChar('i', "i"))
+	for _, r := range "see you at noon" {
+		m, _ = m.update(pressRealChar(r, string(r)))
+	}
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("enter should send the message")
+	}
+	sent, ok := cmd().(dmSentMsg)
+	if !ok || sent.err != nil {
+		t.Fatalf("send: got %+v", sent)
+	}
+	m, cmd = m.update(sent)
+	m, _ = m.update(cmd())
+	if len(m.messages) != 1 || m.messages[0].text != "see you at noon" || !m.messages[0].fromMe || m.messages[0].invalid {
+		t.Fatalf("messages = %+v, want the decrypted message from me", m.messages)
+	}
+
+	// Bob, who added Alice by her hex key, reads the same message
+	b := newDMModel(client, serverConfig{}, &bob, contactEntry{PubKey: alice.PubKeyHex, DisplayName: "alice"})
+	b, _ = b.update(b.init()())
+	if len(b.messages) != 1 || b.messages[0].text != "see you at noon" || b.messages[0].fromMe {
+		t.Fatalf("bob's messages = %+v, want alice's message decrypted", b.messages)
+	}
+	if v := b.viewPanel(80, 12, true); !strings.Contains(v, "see you at noon") || !strings.Contains(v, "alice") {
+		t.Errorf("view should show the plaintext from alice, got:\n%s", v)
+	}
+
+	// Carol cannot read it, even through the same server
+	carol, _ := generateIdentity()
+	c := newDMModel(client, serverConfig{}, &carol, contactEntry{PubKey: alice.PubKeyHex, DisplayName: "alice"})
+	c, _ = c.update(c.init()())
+	if len(c.messages) != 1 || !c.messages[0].invalid || c.messages[0].text != "" {
+		t.Fatalf("carol's messages = %+v, want an undecryptable message", c.messages)
+	}
+	if v := c.viewPanel(80, 12, true); !strings.Contains(v, "cannot decrypt") {
+		t.Errorf("view should flag the message, got:\n%s", v)
+	}
+}
+
+func TestDMModel_InvalidContactKey_ShowsError(t *testing.T) {
+	id, _ := generateIdentity()
+	m := newDMModel(nil, serverConfig{}, &id, contactEntry{PubKey: "not-a-key", DisplayName: "x"})
+	if m.init() != nil {
+		t.Error("init should not fetch without a valid contact key")
+	}
+	if v := m.viewPanel(80, 12, true); !strings.Contains(v, "invalid contact key") {
+		t.Errorf("view should show the error, got:\n%s", v)
+	}
+	if m, _ = m.update(pressRealChar('i', "i")); m.typing {
+		t.Error("should not enter typing mode without a valid contact key")
+	}
+}
+
+func TestMainModel_ContactsM_OpensDMAndEscReturns(t *testing.T) {
+	id, _ := generateIdentity()
+	peer, _ := generateIdentity()
+	cfg := appConfig{
+		Servers:  []serverConfig{{URL: "http://alpha.example"}},
+		Contacts: []contactEntry{{PubKey: peer.PubKeyHex, DisplayName: "peer"}},
+	}
+	m := newMainModel(cfg, buildClientsMap(cfg.Servers), cfg.Servers, &id, "me", cfg.Contacts)
+	m, _ = m.update(sectionSelectedMsg{to: screenContacts, focus: true})
+
+	m, cmd := m.update(pressRealChar('m', "m"))
+	if cmd == nil {
+		t.Fatal("m should open direct messages with the selected contact")
+	}
+	m, _ = m.update(cmd())
+	if m.right != rightDM || m.focus != focusRight || m.dm.peer.DisplayName != "peer" {
+		t.Fatalf("right = %v, focus = %v, peer = %+v; want the DM pane focused", m.right, m.focus, m.dm.peer)
+	}
+
+	// Typing bypasses the pane shortcuts
+	m = sendMainKey(m, 'i')
+	m, _ = m.update(pressRealChar('S', "S"))
+	if m.right != rightDM || m.dm.inputText != "S" {
+		t.Fatalf("right = %v, input = %q; want S typed in the DM", m.right, m.dm.inputText)
+	}
+	m = sendMainKey(m, tea.KeyEscape)
+	m = sendMainKey(m, tea.KeyEscape)
+	if m.right != rightContacts || m.focus != focusRight {
+		t.Errorf("right = %v, focus = %v; want back to the focused contact list", m.right, m.focus)
+	}
+}
@@ -49,6 +49,9 @@ const (
 	rightServers
 	rightIdentities
 	rightContacts
+	rightDM      // direct messages with a contact, opened from Contacts
+	rightUsers   // members and sanctions of the open room, opened from the chat
+	rightMembers // member list of the open private room, opened from the chat
 )
 
 // sectionSelectedMsg switches the right pane to a management section. focus=true also
@@ -82,6 +85,9 @@ type mainModel struct {
 	serversSec    serverModel
 	identitiesSec identitiesModel
 	contactsSec   contactsModel
+	dm            dmModel
+	users         usersModel
+	members       membersModel
 
 	hasChat  bool
 	cfg      appConfig
@@ -90,6 +96,15 @@ type mainModel struct {
 	id       *identity
 	username string
 	contacts []contactEntry
+
+	// live tracks the push connection per server URL. A nil entry means the
+	// server is being dialled or is served by the polling fallback.
+	live map[string]*liveConn
+	// chatPoll numbers the opened chats, so the poll of a chat joined with an
+	// invite, or of a private room, stops once another chat replaces it.
+	chatPoll int
+	// chatPolled is the chatPoll whose polling is armed, so it is armed once.
+	chatPolled int
 }
 
 func newMainModel(cfg appConfig, clients map[string]*generated.ClientWithResponses, servers []serverConfig, id *identity, username string, contacts []contactEntry) mainModel {
@@ -100,11 +115,12 @@ func newMainModel(cfg appConfig, clients map[string]*generated.ClientWithRespons
 		id:            id,
 		username:      username,
 		contacts:      contacts,
-		rooms:         newRoomModel(clients, servers),
+		rooms:         newRoomModel(clients, servers, id),
 		serversSec:    newServerModel(cfg),
 		identitiesSec: newIdentitiesModel(cfg),
 		contactsSec:   newContactsModel(cfg),
 		focus:         focusLeft,
+		live:          make(map[string]*liveConn),
 	}
 }
 
@@ -138,21 +154,188 @@ func (m mainModel) navIndexFor(to screen) int {
 }
 
 func (m mainModel) init() tea.Cmd {
-	return m.rooms.init()
+	return tea.Batch(m.rooms.init(), m.connectLive())
+}
+
+// connectLive dials the push endpoint of every configured server not yet
+// connected and drops the connections of servers no longer configured.
+func (m mainModel) connectLive() tea.Cmd {
+	configured := make(map[string]bool, len(m.servers))
+	cmds := make([]tea.Cmd, 0, len(m.servers))
+	for _, srv := range m.servers {
+		configured[srv.URL] = true
+		if _, ok := m.live[srv.URL]; ok {
+			continue
+		}
+		m.live[srv.URL] = nil
+		cmds = append(cmds, connectLive(srv.URL))
+	}
+	for url, lc := range m.live {
+		if configured[url] {
+			continue
+		}
+		if lc != nil {
+			lc.close()
+		}
+		delete(m.live, url)
+	}
+	return tea.Batch(cmds...)
+}
+
+// subscribeLive follows every listed room of the server on its push
+// connection. Password rooms are only followed once opened with a password;
+// private rooms need signed reads, which the push connection cannot make.
+func (m mainModel) subscribeLive(serverURL string) tea.Cmd {
+	lc := m.live[serverURL]
+	if lc == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if m.hasChat && m.chat.server.URL == serverURL && m.chat.invite == "" && !m.chat.private {
+		cmds = append(cmds, lc.subscribe(m.chat.room, m.chat.password))
+	}
+	for _, sr := range m.rooms.serverRooms {
+		if sr.server.URL != serverURL || !followedInList(sr) {
+			continue
+		}
+		cmds = append(cmds, lc.subscribe(deref(sr.room.Name), ""))
+	}
+	return tea.Batch(cmds...)
+}
+
+// followedInList reports whether subscribeLive follows a listed room for its
+// unread count, whether or not it is open.
+func followedInList(sr serverRoom) bool {
+	return (sr.room.HasPassword == nil || !*sr.room.HasPassword) && !deref(sr.room.Private)
+}
+
+// leaveChat unsubscribes the open chat's room, unless the room list follows
+// it anyway or next opens it again.
+func (m mainModel) leaveChat(next roomSelectedMsg) tea.Cmd {
+	if !m.hasChat || (m.chat.server.URL == next.server.URL && m.chat.room == next.room) {
+		return nil
+	}
+	lc := m.live[m.chat.server.URL]
+	if lc == nil {
+		return nil
+	}
+	for _, sr := range m.rooms.serverRooms {
+		if sr.server.URL == m.chat.server.URL && deref(sr.room.Name) == m.chat.room && followedInList(sr) {
+			return nil
+		}
+	}
+	return lc.unsubscribe(m.chat.room)
+}
+
+// pollChat arms the polling of the open chat, once per opened chat, for
+// chats the push connection cannot follow.
+func (m mainModel) pollChat() (mainModel, tea.Cmd) {
+	if m.chatPolled == m.chatPoll {
+		return m, nil
+	}
+	m.chatPolled = m.chatPoll
+	return m, scheduleChatPoll(m.chatPoll)
+}
+
+// updateLive handles push and polling events.
+func (m mainModel) updateLive(msg tea.Msg) (mainModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case liveConnectedMsg:
+		url := msg.conn.serverURL
+		if _, ok := m.live[url]; !ok {
+			msg.conn.close() // server removed while dialling
+			return m, nil
+		}
+		m.live[url] = msg.conn
+		return m, tea.Batch(m.subscribeLive(url), waitLive(msg.conn))
+
+	case liveUnavailableMsg:
+		if _, ok := m.live[msg.serverURL]; !ok {
+			return m, nil
+		}
+		m.live[msg.serverURL] = nil
+		return m, schedulePoll(msg.serverURL)
+
+	case liveMessageMsg:
+		lc := m.live[msg.serverURL]
+		if lc == nil {
+			return m, nil
+		}
+		isNew := lc.received(msg.room, msg.message)
+		var cmd tea.Cmd
+		if m.hasChat && m.chat.server.URL == msg.serverURL && m.chat.room == msg.room {
+			m.chat, cmd = m.chat.update(msg)
+		} else if isNew && (m.id == nil || deref(msg.message.Pubkey) != m.id.PubKeyHex) {
+			m.rooms = m.rooms.markUnread(msg.serverURL, msg.room)
+		}
+		return m, tea.Batch(cmd, waitLive(lc))
+
+	case liveEndedMsg:
+		lc := m.live[msg.serverURL]
+		if lc == nil {
+			return m, nil
+		}
+		cmds := []tea.Cmd{lc.ended(msg.room, msg.resume), waitLive(lc)}
+		if !lc.subscribed[msg.room] && m.hasChat && m.chat.server.URL == msg.serverURL && m.chat.room == msg.room {
+			var poll tea.Cmd
+			m, poll = m.pollChat()
+			cmds = append(cmds, poll)
+		}
+		return m, tea.Batch(cmds...)
+
+	case livePollMsg:
+		if lc, ok := m.live[msg.serverURL]; !ok || lc != nil {
+			return m, nil // server removed, or back on a push connection
+		}
+		cmds := []tea.Cmd{m.rooms.pollServerRooms(msg.serverURL), schedulePoll(msg.serverURL)}
+		if m.hasChat && m.chat.server.URL == msg.serverURL && m.chat.client != nil && m.chat.invite == "" {
+			cmds = append(cmds, m.chat.pollMessages())
+		}
+		return m, tea.Batch(cmds...)
+
+	case chatPollMsg:
+		if msg.generation != m.chatPoll || !m.hasChat {
+			return m, nil // the invite chat was closed since
+		}
+		return m, tea.Batch(m.chat.pollMessages(), scheduleChatPoll(msg.generation))
+	}
+	return m, nil
 }
 
 func (m mainModel) update(msg tea.Msg) (mainModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case roomSelectedMsg:
+		leave := m.leaveChat(msg)
 		client := m.clients[msg.server.URL]
 		m.chat = newChatModel(client, msg.server, msg.room, msg.password, m.id, m.username)
 		m.chat.contacts = m.contacts
+		if msg.keySalt != "" {
+			m.chat = m.chat.withKeySalt(msg.keySalt)
+		}
+		if msg.invite != "" {
+			m.chat = m.chat.withInvite(msg.invite)
+		}
+		if msg.private {
+			m.chat = m.chat.withPrivate()
+		}
 		m.hasChat = true
 		m.right = rightChat
+		m.rooms = m.rooms.openRoom(msg.server.URL, msg.room)
 		if !msg.preview {
 			m.focus = focusRight
 		}
-		return m, m.chat.init()
+		m.chatPoll++
+		var live tea.Cmd
+		switch lc := m.live[msg.server.URL]; {
+		case msg.invite != "" || msg.private:
+			m, live = m.pollChat()
+		case lc != nil:
+			if live = lc.subscribe(msg.room, m.chat.password); !lc.subscribed[msg.room] {
+				m, live = m.pollChat() // the connection follows too many rooms
+			}
+		}
+		// The unsubscribe frame goes first, freeing a place for the room.
+		return m, tea.Batch(m.chat.init(), tea.Sequence(leave, live))
 
 	case sectionSelectedMsg:
 		m.right = sectionToRight(msg.to)
@@ -170,12 +353,20 @@ func (m mainModel) update(msg tea.Msg) (mainModel, tea.Cmd) {
 		}
 		return m, nil
 
-	case serverRoomsLoadedMsg, roomCreatedMsg:
+	case serverRoomsLoadedMsg:
+		var cmd tea.Cmd
+		m.rooms, cmd = m.rooms.update(msg)
+		return m, tea.Batch(cmd, m.subscribeLive(msg.serverURL))
+
+	case serverRoomsPolledMsg, roomCreatedMsg:
 		var cmd tea.Cmd
 		m.rooms, cmd = m.rooms.update(msg)
 		return m, cmd
 
-	case messagesLoadedMsg, olderMessagesLoadedMsg, messageSentMsg:
+	case liveConnectedMsg, liveUnavailableMsg, liveMessageMsg, liveEndedMsg, livePollMsg, chatPollMsg:
+		return m.updateLive(msg)
+
+	case messagesLoadedMsg, olderMessagesLoadedMsg, messagesPolledMsg, messageSentMsg, messageDeletedMsg, messageEditedMsg, revisionsLoadedMsg, threadLoadedMsg, reactionsLoadedMsg, reactionToggledMsg, sanctionCreatedMsg, searchResultsMsg, searchJumpMsg:
 		if m.hasChat {
 			var cmd tea.Cmd
 			m.chat, cmd = m.chat.update(msg)
@@ -183,6 +374,50 @@ func (m mainModel) update(msg tea.Msg) (mainModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case openDMMsg:
+		server, ok := m.dmServer()
+		if !ok {
+			m.contactsSec.err = "add a server first"
+			return m, nil
+		}
+		m.dm = newDMModel(m.clients[server.URL], server, m.id, msg.contact)
+		m.right = rightDM
+		m.focus = focusRight
+		return m, m.dm.init()
+
+	case dmsLoadedMsg, dmSentMsg:
+		var cmd tea.Cmd
+		m.dm, cmd = m.dm.update(msg)
+		return m, cmd
+
+	case openUsersMsg:
+		if !m.hasChat {
+			return m, nil
+		}
+		m.users = newUsersModel(m.chat.client, m.chat.server, m.chat.room, m.id, m.contacts)
+		m.right = rightUsers
+		m.focus = focusRight
+		return m, m.users.init()
+
+	case usersLoadedMsg, sanctionLiftedMsg:
+		var cmd tea.Cmd
+		m.users, cmd = m.users.update(msg)
+		return m, cmd
+
+	case openMembersMsg:
+		if !m.hasChat {
+			return m, nil
+		}
+		m.members = newMembersModel(m.chat.client, m.chat.server, m.chat.room, m.id, m.contacts)
+		m.right = rightMembers
+		m.focus = focusRight
+		return m, m.members.init()
+
+	case membersLoadedMsg, memberChangedMsg:
+		var cmd tea.Cmd
+		m.members, cmd = m.members.update(msg)
+		return m, cmd
+
 	case serverInfoMsg:
 		// Result of adding a server in the in-pane Servers section.
 		var cmd tea.Cmd
@@ -193,27 +428,71 @@ func (m mainModel) update(msg tea.Msg) (mainModel, tea.Cmd) {
 		return m.handleKey(msg)
 	}
 
+	// Paste events go to the rooms panel when it has focus, e.g. an invite link.
+	if _, ok := msg.(tea.PasteMsg); ok && m.focus == focusLeft {
+		var cmd tea.Cmd
+		m.rooms, cmd = m.rooms.update(msg)
+		return m, cmd
+	}
 	// Forward any other async message to the active config section (e.g. identity
 	// creation / vanity progress, paste events).
 	if isConfigContent(m.right) {
 		return m.delegateConfig(msg)
 	}
+	if m.right == rightDM {
+		var cmd tea.Cmd
+		m.dm, cmd = m.dm.update(msg)
+		return m, cmd
+	}
+	if m.right == rightMembers {
+		var cmd tea.Cmd
+		m.members, cmd = m.members.update(msg)
+		return m, cmd
+	}
 	return m, nil
 }
 
+// dmServer returns the server direct messages go through: the one of the open
+// room, or else the first configured server.
+func (m mainModel) dmServer() (serverConfig, bool) {
+	if m.hasChat && m.clients[m.chat.server.URL] != nil {
+		return m.chat.server, true
+	}
+	for _, srv := range m.servers {
+		if m.clients[srv.URL] != nil {
+			return srv, true
+		}
+	}
+	return serverConfig{}, false
+}
+
 // handleKey routes a key press according to focus and right-pane content.
 func (m mainModel) handleKey(msg tea.KeyMsg) (mainModel, tea.Cmd) {
 	// Edit/typing bypass: when an input is active in the focused right pane, deliver
 	// the key raw so Esc/Tab/Enter behave as that input expects.
 	if m.focus == focusRight {
-		if m.right == rightChat && m.hasChat && m.chat.typing {
+		if m.right == rightChat && m.hasChat && (m.chat.typing || m.chat.searching) {
 			var cmd tea.Cmd
 			m.chat, cmd = m.chat.update(msg)
 			return m, cmd
 		}
+		if m.right == rightDM && m.dm.typing {
+			var cmd tea.Cmd
+			m.dm, cmd = m.dm.update(msg)
+			return m, cmd
+		}
+		if m.right == rightMembers && m.members.adding {
+			var cmd tea.Cmd
+			m.members, cmd = m.members.update(msg)
+			return m, cmd
+		}
 		if isConfigContent(m.right) && m.rightEditing() {
 			return m.delegateConfig(msg)
 		}
+	} else if m.rooms.typing() || m.rooms.promptPasswd {
+		var cmd tea.Cmd
+		m.rooms, cmd = m.rooms.update(msg)
+		return m, cmd
 	}
 
 	key := msg.String()
@@ -247,7 +526,11 @@ func (m mainModel) handleKey(msg tea.KeyMsg) (mainModel, tea.Cmd) {
 		}
 		return m, nil
 	case "esc":
-		if m.focus == focusRight {
+		if m.focus == focusRight && m.right == rightDM {
+			m.right = rightContacts // back to the contact list
+		} else if m.focus == focusRight && (m.right == rightUsers || m.right == rightMembers) {
+			m.right = rightChat // back to the room
+		} else if m.focus == focusRight {
 			m.focus = focusLeft
 		}
 		return m, nil
@@ -264,6 +547,21 @@ func (m mainModel) handleKey(msg tea.KeyMsg) (mainModel, tea.Cmd) {
 		m.chat, cmd = m.chat.update(msg)
 		return m, cmd
 	}
+	if m.right == rightDM {
+		var cmd tea.Cmd
+		m.dm, cmd = m.dm.update(msg)
+		return m, cmd
+	}
+	if m.right == rightUsers {
+		var cmd tea.Cmd
+		m.users, cmd = m.users.update(msg)
+		return m, cmd
+	}
+	if m.right == rightMembers {
+		var cmd tea.Cmd
+		m.members, cmd = m.members.update(msg)
+		return m, cmd
+	}
 	if isConfigContent(m.right) {
 		// List-state config: handle navigation keys here; delegate only safe list keys.
 		switch key {
@@ -316,6 +614,12 @@ func (m mainModel) view(width, height int) string {
 		rightStr = m.identitiesSec.viewPanel(rightWidth, height, rightFocused)
 	case rightContacts:
 		rightStr = m.contactsSec.viewPanel(rightWidth, height, rightFocused)
+	case rightDM:
+		rightStr = m.dm.viewPanel(rightWidth, height, rightFocused)
+	case rightUsers:
+		rightStr = m.users.viewPanel(rightWidth, height, rightFocused)
+	case rightMembers:
+		rightStr = m.members.viewPanel(rightWidth, height, rightFocused)
 	default:
 		rightStr = " Select a room\n"
 	}
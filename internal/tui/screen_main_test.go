@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -49,6 +51,27 @@ func TestMainModel_RightArrow_NoChat_NoOp(t *testing.T) {
 	}
 }
 
+// TestMainModel_OpenUsers_EscReturnsToChat verifies "u" in the chat opens the
+// users of the room and Esc goes back to the chat.
+func TestMainModel_OpenUsers_EscReturnsToChat(t *testing.T) {
+	m := makeMainModelWithChat()
+	m.focus = focusRight
+
+	m, cmd := m.update(tea.KeyPressMsg{Code: 'u', Text: "u"})
+	if cmd == nil {
+		t.Fatal("u should open the users pane")
+	}
+	m, _ = m.update(cmd())
+	if m.right != rightUsers || m.users.room != "general" {
+		t.Fatalf("right = %v, users room = %q; want the users of general", m.right, m.users.room)
+	}
+
+	m = sendMainKey(m, tea.KeyEscape)
+	if m.right != rightChat || m.focus != focusRight {
+		t.Errorf("right = %v, focus = %v; want back in the chat", m.right, m.focus)
+	}
+}
+
 // TestMainModel_LeftArrow_ShiftsFocusToRooms verifies ← moves focus to rooms when on right.
 func TestMainModel_LeftArrow_ShiftsFocusToRooms(t *testing.T) {
 	m := makeMainModelWithChat()
@@ -271,3 +294,192 @@ func TestMainModel_View_HeaderRuleIsContinuous(t *testing.T) {
 		t.Errorf("body rows should keep the │ divider, got:\n%s", v)
 	}
 }
+
+// TestMainModel_LiveMessage_RoutesToChatOrUnread verifies pushed messages go to the
+// open chat, and bump the unread counter of other rooms.
+func TestMainModel_LiveMessage_RoutesToChatOrUnread(t *testing.T) {
+	m := makeMainModelWithChat()
+	m.chat.server = serverConfig{URL: "http://a.example"}
+	m.live["http://a.example"] = newLiveConn("http://a.example", nil)
+
+	m, _ = m.update(liveMessageMsg{serverURL: "http://a.example", room: "general", message: makeIDMessage("a", "hi")})
+	m, _ = m.update(liveMessageMsg{serverURL: "http://a.example", room: "random", message: makeIDMessage("b", "yo")})
+
+	if len(m.chat.messages) != 1 {
+		t.Errorf("chat messages = %d, want 1", len(m.chat.messages))
+	}
+	if n := m.rooms.unread[roomKey("http://a.example", "random")]; n != 1 {
+		t.Errorf("unread[random] = %d, want 1", n)
+	}
+}
+
+// TestMainModel_LiveMessage_EditsAreNotUnread verifies the edits and
+// tombstones a server republishes do not count as unread messages.
+func TestMainModel_LiveMessage_EditsAreNotUnread(t *testing.T) {
+	m := makeMainModelWithChat()
+	m.chat.server = serverConfig{URL: "http://a.example"}
+	m.live["http://a.example"] = newLiveConn("http://a.example", nil)
+
+	edited := makeIDMessage("b", "yo!")
+	edited.EditedAt = new(time.Now())
+	deleted := makeIDMessage("c", "")
+	deleted.DeletedAt = new(time.Now())
+	m, _ = m.update(liveMessageMsg{serverURL: "http://a.example", room: "random", message: makeIDMessage("b", "yo")})
+	m, _ = m.update(liveMessageMsg{serverURL: "http://a.example", room: "random", message: edited})
+	m, _ = m.update(liveMessageMsg{serverURL: "http://a.example", room: "random", message: deleted})
+
+	if n := m.rooms.unread[roomKey("http://a.example", "random")]; n != 1 {
+		t.Errorf("unread[random] = %d, want 1", n)
+	}
+	if id := m.live["http://a.example"].lastIDs["random"]; id != "b" {
+		t.Errorf("last ID of random = %q, want b", id)
+	}
+}
+
+// TestMainModel_LiveSubscriptions_FollowOpenRooms verifies the room left is
+// unsubscribed, and a room the connection cannot follow is polled.
+func TestMainModel_LiveSubscriptions_FollowOpenRooms(t *testing.T) {
+	m := makeMainModelWithServers()
+	srv := m.servers[0]
+	lc := newLiveConn(srv.URL, nil)
+	m.live[srv.URL] = lc
+
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "secret", password: "pw"})
+	if !lc.subscribed["secret"] || lc.passwords["secret"] != "pw" {
+		t.Fatalf("subscribed = %v; want secret followed with its password", lc.subscribed)
+	}
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "general"})
+	if lc.subscribed["secret"] || !lc.subscribed["general"] {
+		t.Fatalf("subscribed = %v; want general followed in place of secret", lc.subscribed)
+	}
+
+	for i := range maxLiveRoomsPerSrv { // general is left for crowded
+		lc.subscribed[fmt.Sprintf("room%d", i)] = true
+	}
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "crowded"})
+	if lc.subscribed["crowded"] {
+		t.Fatal("crowded should not be followed past the limit")
+	}
+	if _, cmd := m.update(chatPollMsg{generation: m.chatPoll}); cmd == nil {
+		t.Error("expected the unfollowed chat to be polled and re-armed")
+	}
+}
+
+// TestMainModel_LiveEnded_ResubscribesOrPolls verifies a subscription the
+// server ended is sent again, and a rejected one leaves the open chat polled.
+func TestMainModel_LiveEnded_ResubscribesOrPolls(t *testing.T) {
+	m := makeMainModelWithServers()
+	srv := m.servers[0]
+	lc := newLiveConn(srv.URL, nil)
+	m.live[srv.URL] = lc
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "secret", password: "pw"})
+
+	m, _ = m.update(liveEndedMsg{serverURL: srv.URL, room: "secret", resume: true})
+	if !lc.subscribed["secret"] || lc.passwords["secret"] != "pw" {
+		t.Fatalf("subscribed = %v; want secret followed again with its password", lc.subscribed)
+	}
+	if m.chatPolled == m.chatPoll {
+		t.Error("a followed chat should not be polled")
+	}
+
+	m, _ = m.update(liveEndedMsg{serverURL: srv.URL, room: "secret"})
+	if lc.subscribed["secret"] {
+		t.Fatal("a rejected subscription should be forgotten, so it can be sent again")
+	}
+	if _, cmd := m.update(chatPollMsg{generation: m.chatPoll}); cmd == nil {
+		t.Error("expected the rejected chat to be polled and re-armed")
+	}
+}
+
+// TestMainModel_LiveUnavailable_FallsBackToPolling verifies a server without a push
+// transport is polled, and that polling stops once the server is removed.
+func TestMainModel_LiveUnavailable_FallsBackToPolling(t *testing.T) {
+	m := makeMainModelWithServers()
+	m.live["http://alpha.example"] = nil
+
+	m, cmd := m.update(liveUnavailableMsg{serverURL: "http://alpha.example"})
+	if cmd == nil {
+		t.Fatal("expected a poll tick to be scheduled")
+	}
+
+	_, cmd = m.update(livePollMsg{serverURL: "http://alpha.example"})
+	if cmd == nil {
+		t.Error("expected polling to refresh and re-arm")
+	}
+
+	delete(m.live, "http://alpha.example")
+	if _, cmd = m.update(livePollMsg{serverURL: "http://alpha.example"}); cmd != nil {
+		t.Error("polling should stop for a removed server")
+	}
+}
+
+// TestMainModel_InviteChat_PollsUntilReplaced verifies a room joined with an invite
+// is polled rather than followed on the push connection, until another room opens.
+func TestMainModel_InviteChat_PollsUntilReplaced(t *testing.T) {
+	m := makeMainModelWithServers()
+	srv := m.servers[0]
+	lc := newLiveConn(srv.URL, nil)
+	m.live[srv.URL] = lc
+
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "club", invite: "tok"})
+	if m.chat.invite != "tok" || lc.subscribed["club"] {
+		t.Fatalf("invite = %q, subscribed = %v; want an invite chat off the push connection", m.chat.invite, lc.subscribed)
+	}
+	generation := m.chatPoll
+	if _, cmd := m.update(chatPollMsg{generation: generation}); cmd == nil {
+		t.Error("expected the invite chat to be polled and re-armed")
+	}
+
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "general"})
+	if _, cmd := m.update(chatPollMsg{generation: generation}); cmd != nil {
+		t.Error("polling should stop once another room is open")
+	}
+}
+
+// TestMainModel_PrivateChat_PollsAndOpensMembers verifies a private room is
+// polled rather than followed on the push connection, and "m" opens its members.
+func TestMainModel_PrivateChat_PollsAndOpensMembers(t *testing.T) {
+	m := makeMainModelWithServers()
+	srv := m.servers[0]
+	lc := newLiveConn(srv.URL, nil)
+	m.live[srv.URL] = lc
+
+	m, _ = m.update(roomSelectedMsg{server: srv, room: "club", private: true})
+	if !m.chat.private || lc.subscribed["club"] {
+		t.Fatalf("private = %v, subscribed = %v; want a private chat off the push connection", m.chat.private, lc.subscribed)
+	}
+	if _, cmd := m.update(chatPollMsg{generation: m.chatPoll}); cmd == nil {
+		t.Error("expected the private chat to be polled and re-armed")
+	}
+
+	m, cmd := m.update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if cmd == nil {
+		t.Fatal("m should open the members pane")
+	}
+	m, _ = m.update(cmd())
+	if m.right != rightMembers || m.members.room != "club" {
+		t.Fatalf("right = %v, members room = %q; want the members of club", m.right, m.members.room)
+	}
+	m = sendMainKey(m, tea.KeyEscape)
+	if m.right != rightChat {
+		t.Errorf("right = %v, want back in the chat", m.right)
+	}
+}
+
+// TestMainModel_ChatSearch_EscCancelsSearch verifies keys typed in the chat search
+// reach it raw: Esc cancels the search instead of leaving the chat.
+func TestMainModel_ChatSearch_EscCancelsSearch(t *testing.T) {
+	m := makeMainModelWithChat()
+	m.focus = focusRight
+
+	m, _ = m.update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	m, _ = m.update(tea.KeyPressMsg{Code: 'u', Text: "u"})
+	if !m.chat.searching || m.chat.searchInput != "u" || m.right != rightChat {
+		t.Fatalf("searching = %v, input = %q, right = %v; want u typed in the search", m.chat.searching, m.chat.searchInput, m.right)
+	}
+
+	m = sendMainKey(m, tea.KeyEscape)
+	if m.chat.searching || m.focus != focusRight {
+		t.Errorf("searching = %v, focus = %v; want the search closed in the chat", m.chat.searching, m.focus)
+	}
+}
@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+)
+
+// membersLoadedMsg carries the owner, moderators and members of a room.
+type membersLoadedMsg struct {
+	room    string
+	members []generated.RoomMember
+	err     error
+}
+
+// memberChangedMsg reports the result of adding or removing a member, with
+// the updated member list.
+type memberChangedMsg struct {
+	room    string
+	members []generated.RoomMember
+	status  string
+	err     error
+}
+
+// membersModel shows who may read a private room and lets its owner add and
+// remove members. Owner and moderators are listed but managed elsewhere.
+type membersModel struct {
+	client    *generated.ClientWithResponses
+	server    serverConfig
+	room      string
+	id        *identity
+	contacts  []contactEntry
+	members   []generated.RoomMember
+	cursor    int  // index into members
+	adding    bool // reading the pubkey of a new member
+	input     string
+	loading   bool
+	err       string
+	statusMsg string
+}
+
+func newMembersModel(client *generated.ClientWithResponses, server serverConfig, room string, id *identity, contacts []contactEntry) membersModel {
+	return membersModel{client: client, server: server, room: room, id: id, contacts: contacts, loading: true}
+}
+
+func (m membersModel) init() tea.Cmd {
+	return m.fetchMembers()
+}
+
+// fetchMembers loads the members of the room. Only members may list those of
+// a private room, so the request is signed.
+func (m membersModel) fetchMembers() tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		if id == nil {
+			return membersLoadedMsg{room: room, err: fmt.Errorf("no identity configured — add one in the Identities screen")}
+		}
+		resp, err := client.GETapiroomsRoommembersWithResponse(context.Background(), room, nil, id.signRequests())
+		if err != nil {
+			return membersLoadedMsg{room: room, err: err}
+		}
+		if resp.JSON200 == nil {
+			return membersLoadedMsg{room: room, err: fmt.Errorf("load failed: %d", resp.StatusCode())}
+		}
+		return membersLoadedMsg{room: room, members: *resp.JSON200}
+	}
+}
+
+// addMember adds an x-only pubkey to the members of the room.
+func (m membersModel) addMember(pubkey, label string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		resp, err := client.PUTapiroomsRoommembersPubkeyWithResponse(context.Background(), room, pubkey, nil, id.signRequests())
+		if err != nil {
+			return memberChangedMsg{room: room, err: err}
+		}
+		if resp.JSON200 == nil {
+			return memberChangedMsg{room: room, err: fmt.Errorf("add failed: %d", resp.StatusCode())}
+		}
+		return memberChangedMsg{room: room, members: *resp.JSON200, status: label + " added"}
+	}
+}
+
+// removeMember removes a pubkey from the members of the room.
+func (m membersModel) removeMember(pubkey, label string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		resp, err := client.DELETEapiroomsRoommembersPubkeyWithResponse(context.Background(), room, pubkey, nil, id.signRequests())
+		if err != nil {
+			return memberChangedMsg{room: room, err: err}
+		}
+		if resp.JSON200 == nil {
+			if resp.StatusCode() == http.StatusForbidden {
+				return memberChangedMsg{room: room, err: fmt.Errorf("only the owner can remove members")}
+			}
+			return memberChangedMsg{room: room, err: fmt.Errorf("remove failed: %d", resp.StatusCode())}
+		}
+		return memberChangedMsg{room: room, members: *resp.JSON200, status: label + " removed"}
+	}
+}
+
+// resolvePubkey reads the pubkey of a new member: an npub, a hex key or the
+// name of a contact.
+func (m membersModel) resolvePubkey(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	for _, c := range m.contacts {
+		if strings.EqualFold(c.DisplayName, input) {
+			return contactPubKeyHex(c.PubKey)
+		}
+	}
+	pubkey, err := contactPubKeyHex(input)
+	if err != nil {
+		return "", fmt.Errorf("not an npub, a hex key or a contact: %q", input)
+	}
+	return pubkey, nil
+}
+
+func (m membersModel) update(msg tea.Msg) (membersModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case membersLoadedMsg:
+		if msg.room != m.room {
+			return m, nil // answer for a room closed since
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.members = msg.members
+		m.cursor = min(m.cursor, max(len(m.members)-1, 0))
+
+	case memberChangedMsg:
+		if msg.room != m.room {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.statusMsg = msg.status
+		m.members = msg.members
+		m.cursor = min(m.cursor, max(len(m.members)-1, 0))
+
+	case tea.PasteMsg:
+		if m.adding {
+			m.input += strings.TrimSpace(msg.Content)
+		}
+
+	case tea.KeyMsg:
+		if m.adding {
+			switch msg.String() {
+			case "enter":
+				pubkey, err := m.resolvePubkey(m.input)
+				if err != nil {
+					m.err = err.Error()
+					return m, nil
+				}
+				m.adding = false
+				m.input = ""
+				m.err = ""
+				return m, m.addMember(pubkey, pubkeyLabel(pubkey, m.id, m.contacts))
+			case "esc":
+				m.adding = false
+				m.input = ""
+				m.err = ""
+			case "backspace":
+				if _, size := utf8.DecodeLastRuneInString(m.input); size > 0 {
+					m.input = m.input[:len(m.input)-size]
+				}
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				if s := msg.String(); utf8.RuneCountInString(s) == 1 {
+					m.input += s
+				}
+			}
+			return m, nil
+		}
+
+		m.statusMsg = ""
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.members)-1 {
+				m.cursor++
+			}
+		case "a":
+			if m.id != nil {
+				m.adding = true
+				m.input = ""
+				m.err = ""
+			}
+		case "x":
+			if m.cursor < len(m.members) && m.id != nil {
+				member := m.members[m.cursor]
+				if deref(member.Role) != "member" {
+					m.err = "the " + deref(member.Role) + " is not on the member list"
+					return m, nil
+				}
+				pubkey := deref(member.Pubkey)
+				return m, m.removeMember(pubkey, pubkeyLabel(pubkey, m.id, m.contacts))
+			}
+		case "r":
+			m.loading = true
+			return m, m.fetchMembers()
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// viewPanel renders the members of the room inside the right pane of the main two-pane view.
+func (m membersModel) viewPanel(width, height int, focused bool) string {
+	title := dim(serverDisplayName(m.server)+"~") + "#" + m.room + " " + dim("members")
+
+	var body []string
+	if m.loading {
+		body = []string{" Loading…"}
+	} else {
+		body = append(body, " Who can read this private room")
+		for i, member := range m.members {
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "> "
+			}
+			pubkey := deref(member.Pubkey)
+			r, g, bv := pubkeyColor(pubkey)
+			body = append(body, fmt.Sprintf(" %s%-10s %s", cursor, deref(member.Role), ansiColor(pubkeyLabel(pubkey, m.id, m.contacts), r, g, bv)))
+		}
+		if m.adding {
+			body = append(body, "", " Add a member (npub, hex key or contact name):", " > "+m.input+"█")
+		}
+	}
+
+	var help string
+	switch {
+	case m.err != "":
+		help = " Err: " + m.err
+	case m.statusMsg != "":
+		help = " ✓ " + m.statusMsg
+	case m.adding:
+		help = helpBar("enter", "add", "esc", "cancel")
+	default:
+		help = helpBar("↑↓", "navigate", "a", "add", "x", "remove", "r", "refresh", "esc", "chat")
+	}
+	return renderPanel(width, height, focused, title, body, help)
+}
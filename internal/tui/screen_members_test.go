@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/internal/middleware"
+)
+
+// newMembersServer fakes the member endpoints of the private room "room",
+// owned by owner. Only its members may list them, only owner may edit them.
+func newMembersServer(t *testing.T, owner identity, members []string) *generated.ClientWithResponses {
+	t.Helper()
+	var mu sync.Mutex
+	list := func() []generated.RoomMember {
+		out := []generated.RoomMember{{Pubkey: new(owner.PubKeyHex[2:]), Role: new("owner")}}
+		for _, pubkey := range members {
+			out = append(out, generated.RoomMember{Pubkey: new(pubkey), Role: new("member")})
+		}
+		return out
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		// A fresh verifier per request, as an edit and a listing can share a second.
+		auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+		signer, err := auth.Verify(r)
+		if err != nil {
+			http.Error(w, "unsigned", http.StatusUnauthorized)
+			return
+		}
+		if signer != owner.PubKeyHex && !slices.Contains(members, signer[2:]) {
+			http.Error(w, "not a member", http.StatusForbidden)
+			return
+		}
+		pubkey, edit := strings.CutPrefix(r.URL.Path, "/api/rooms/room/members/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/rooms/room/members":
+		case edit && signer != owner.PubKeyHex:
+			http.Error(w, "owner only", http.StatusForbidden)
+			return
+		case edit && r.Method == http.MethodPut:
+			members = append(members, pubkey)
+		case edit && r.Method == http.MethodDelete:
+			members = slices.DeleteFunc(members, func(m string) bool { return m == pubkey })
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(list())
+	}))
+	t.Cleanup(srv.Close)
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+	return client
+}
+
+func TestMembersModel_AddsAndRemovesMembers(t *testing.T) {
+	owner, _ := generateIdentity()
+	friend, _ := generateIdentity()
+	client := newMembersServer(t, owner, nil)
+
+	m := newMembersModel(client, serverConfig{}, "room", &owner, []contactEntry{{PubKey: friend.NpubKey, DisplayName: "friend"}})
+	m, _ = m.update(m.init()())
+	if m.err != "" || len(m.members) != 1 {
+		t.Fatalf("err = %q, members = %+v; want the owner alone", m.err, m.members)
+	}
+
+	m, _ = m.update(pressRealChar('a', "a"))
+	for _, r := range "friend" {
+		m, _ = m.update(pressRealChar(r, string(r)))
+	}
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("enter should add the contact")
+	}
+	m, _ = m.update(cmd())
+	if m.err != "" || len(m.members) != 2 {
+		t.Fatalf("err = %q, members = %+v; want the friend added", m.err, m.members)
+	}
+	if v := m.viewPanel(80, 14, true); !strings.Contains(v, "friend") || !strings.Contains(v, "(me)") {
+		t.Errorf("view should name the owner and the friend, got:\n%s", v)
+	}
+
+	if _, cmd := m.update(pressRealChar('x', "x")); cmd != nil {
+		t.Error("x on the owner should not remove it")
+	}
+	m, _ = m.update(pressRealChar('j', "j"))
+	m, cmd = m.update(pressRealChar('x', "x"))
+	if cmd == nil {
+		t.Fatal("x should remove the selected member")
+	}
+	m, _ = m.update(cmd())
+	if m.err != "" || len(m.members) != 1 || m.statusMsg != "friend removed" {
+		t.Errorf("err = %q, status = %q, members = %+v; want the friend removed", m.err, m.statusMsg, m.members)
+	}
+}
+
+func TestMembersModel_RejectsUnknownKey(t *testing.T) {
+	owner, _ := generateIdentity()
+	m := newMembersModel(newMembersServer(t, owner, nil), serverConfig{}, "room", &owner, nil)
+	m.loading = false
+	m.adding = true
+	m.input = "bob"
+	if _, cmd := m.update(pressKey(tea.KeyEnter)); cmd != nil {
+		t.Error("enter should not send a name that is neither a key nor a contact")
+	}
+}
+
+func TestMembersModel_OthersCannotEdit(t *testing.T) {
+	owner, _ := generateIdentity()
+	member, _ := generateIdentity()
+	client := newMembersServer(t, owner, []string{member.PubKeyHex[2:]})
+
+	m := newMembersModel(client, serverConfig{}, "room", &member, nil)
+	m, _ = m.update(m.init()())
+	if m.err != "" || len(m.members) != 2 {
+		t.Fatalf("err = %q, members = %+v; want a member to see the list", m.err, m.members)
+	}
+	m, _ = m.update(pressRealChar('j', "j"))
+	m, cmd := m.update(pressRealChar('x', "x"))
+	if cmd == nil {
+		t.Fatal("x should try to remove the member")
+	}
+	if m, _ = m.update(cmd()); m.err != "only the owner can remove members" {
+		t.Errorf("err = %q, want the owner-only message", m.err)
+	}
+
+	stranger, _ := generateIdentity()
+	outsider := newMembersModel(client, serverConfig{}, "room", &stranger, nil)
+	if outsider, _ = outsider.update(outsider.init()()); outsider.err == "" {
+		t.Error("a stranger should not list the members")
+	}
+}
@@ -35,8 +35,49 @@ const (
 	roomStateSearch            // typing a search query
 	roomStateCreate            // typing a new room name
 	roomStateLoading           // waiting for rooms list
+	roomStateJoin              // typing an invite link
 )
 
+// inviteLinkScheme is the URL scheme of the invite links minted by servers.
+const inviteLinkScheme = "microchat"
+
+// inviteLink is a parsed microchat://server/room?invite=token link.
+type inviteLink struct {
+	host  string
+	room  string
+	token string
+}
+
+// parseInviteLink reads an invite link as shared by a room owner.
+func parseInviteLink(link string) (inviteLink, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Scheme != inviteLinkScheme || u.Host == "" {
+		return inviteLink{}, fmt.Errorf("not an invite link: expected %s://server/room?invite=…", inviteLinkScheme)
+	}
+	room := strings.TrimPrefix(u.Path, "/")
+	if room == "" || strings.Contains(room, "/") {
+		return inviteLink{}, fmt.Errorf("invite link has no room")
+	}
+	token := u.Query().Get("invite")
+	if token == "" {
+		return inviteLink{}, fmt.Errorf("invite link has no invite token")
+	}
+	return inviteLink{host: u.Host, room: room, token: token}, nil
+}
+
+// serverHost returns the host (and port) of a configured server URL,
+// normalised the same way buildClientsMap does.
+func serverHost(serverURL string) string {
+	if !strings.Contains(serverURL, "http") {
+		serverURL = "https://" + serverURL
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
 // serverRoom pairs a room with the server it belongs to.
 type serverRoom struct {
 	server serverConfig
@@ -50,6 +91,14 @@ type serverRoomsLoadedMsg struct {
 	err       error
 }
 
+// serverRoomsPolledMsg carries a room list re-fetched by the polling fallback.
+// Unlike serverRoomsLoadedMsg it keeps the list order and does not preview.
+type serverRoomsPolledMsg struct {
+	serverURL string
+	rooms     []generated.Room
+	err       error
+}
+
 // roomCreatedMsg is sent after creating a room.
 type roomCreatedMsg struct {
 	room *generated.Room
@@ -61,16 +110,22 @@ type roomSelectedMsg struct {
 	server   serverConfig
 	room     string
 	password string
-	preview  bool // true = auto-preview, don't shift focus to right panel
+	keySalt  string // salt of the room key, set for encrypted rooms
+	invite   string // invite token used in place of the password
+	private  bool   // members only: reads must be signed
+	preview  bool   // true = auto-preview, don't shift focus to right panel
 }
 
 type roomModel struct {
 	state          roomState
 	clients        map[string]*generated.ClientWithResponses // keyed by srv.URL
 	servers        []serverConfig
+	id             *identity // signs the listings, which show private rooms only to their members
 	serverRooms    []serverRoom
 	loading        map[string]bool
 	selectedServer serverConfig // for password prompt
+	selectedSalt   string       // key salt of the selected room, for password prompt
+	selectedPriv   bool         // the selected room is private, for password prompt
 	cursor         int
 	inputText      string
 	err            string
@@ -78,9 +133,18 @@ type roomModel struct {
 	roomPassword   string
 	promptPasswd   bool
 	passwdInput    string
+
+	unread     map[string]int    // new messages per roomKey since the room was last open
+	lastSeen   map[string]string // last_message_timestamp per roomKey, for polled servers
+	activeRoom string            // roomKey of the room open in the chat pane
 }
 
-func newRoomModel(clients map[string]*generated.ClientWithResponses, servers []serverConfig) roomModel {
+// roomKey identifies a room across servers.
+func roomKey(serverURL, room string) string {
+	return serverURL + "~" + room
+}
+
+func newRoomModel(clients map[string]*generated.ClientWithResponses, servers []serverConfig, id *identity) roomModel {
 	loading := make(map[string]bool, len(servers))
 	for _, srv := range servers {
 		loading[srv.URL] = true
@@ -90,10 +154,13 @@ func newRoomModel(clients map[string]*generated.ClientWithResponses, servers []s
 		state = roomStateList
 	}
 	return roomModel{
-		clients: clients,
-		servers: servers,
-		loading: loading,
-		state:   state,
+		clients:  clients,
+		servers:  servers,
+		id:       id,
+		loading:  loading,
+		state:    state,
+		unread:   make(map[string]int),
+		lastSeen: make(map[string]string),
 	}
 }
 
@@ -111,11 +178,12 @@ func (m roomModel) init() tea.Cmd {
 func (m roomModel) fetchServerRooms(srv serverConfig) tea.Cmd {
 	client := m.clients[srv.URL]
 	serverURL := srv.URL
+	signer := m.signer()
 	return func() tea.Msg {
 		if client == nil {
 			return serverRoomsLoadedMsg{serverURL: serverURL, err: fmt.Errorf("no client for %s", serverURL)}
 		}
-		resp, err := client.GETapiroomsWithResponse(context.Background(), nil)
+		resp, err := client.GETapiroomsWithResponse(context.Background(), nil, signer...)
 		if err != nil {
 			return serverRoomsLoadedMsg{serverURL: serverURL, err: err}
 		}
@@ -126,14 +194,48 @@ func (m roomModel) fetchServerRooms(srv serverConfig) tea.Cmd {
 	}
 }
 
+// signer signs the room listings with the current identity, if any, so they
+// include the private rooms it is a member of.
+func (m roomModel) signer() []generated.RequestEditorFn {
+	if m.id == nil {
+		return nil
+	}
+	return []generated.RequestEditorFn{m.id.signRequests()}
+}
+
+// pollServerRooms re-fetches one server's room list for the polling fallback.
+func (m roomModel) pollServerRooms(serverURL string) tea.Cmd {
+	fetch := m.fetchServerRooms(m.findServer(serverURL))
+	return func() tea.Msg {
+		return serverRoomsPolledMsg(fetch().(serverRoomsLoadedMsg))
+	}
+}
+
+// openRoom marks the room shown in the chat pane and clears its unread count.
+func (m roomModel) openRoom(serverURL, room string) roomModel {
+	m.activeRoom = roomKey(serverURL, room)
+	delete(m.unread, m.activeRoom)
+	return m
+}
+
+// markUnread counts a new message in a room that is not open.
+func (m roomModel) markUnread(serverURL, room string) roomModel {
+	key := roomKey(serverURL, room)
+	if key != m.activeRoom {
+		m.unread[key]++
+	}
+	return m
+}
+
 func (m roomModel) fetchSearch(srv serverConfig, query string) tea.Cmd {
 	client := m.clients[srv.URL]
 	serverURL := srv.URL
+	signer := m.signer()
 	return func() tea.Msg {
 		if client == nil {
 			return serverRoomsLoadedMsg{serverURL: serverURL, err: fmt.Errorf("no client for %s", serverURL)}
 		}
-		resp, err := client.GETapiroomssearchWithResponse(context.Background(), &generated.GETapiroomssearchParams{Q: &query})
+		resp, err := client.GETapiroomssearchWithResponse(context.Background(), &generated.GETapiroomssearchParams{Q: &query}, signer...)
 		if err != nil {
 			return serverRoomsLoadedMsg{serverURL: serverURL, err: err}
 		}
@@ -183,7 +285,35 @@ func (m roomModel) previewCmd() tea.Cmd {
 		name = *sr.room.Name
 	}
 	srv := sr.server
-	return func() tea.Msg { return roomSelectedMsg{server: srv, room: name, password: "", preview: true} }
+	private := deref(sr.room.Private)
+	return func() tea.Msg {
+		return roomSelectedMsg{server: srv, room: name, password: "", private: private, preview: true}
+	}
+}
+
+// joinInvite opens the room of an invite link on the configured server it
+// points to.
+func (m roomModel) joinInvite(link string) (roomModel, tea.Cmd) {
+	invite, err := parseInviteLink(link)
+	if err != nil {
+		m.err = err.Error()
+		return m, nil
+	}
+	for _, srv := range m.servers {
+		if strings.EqualFold(serverHost(srv.URL), invite.host) {
+			m.state = roomStateList
+			m.inputText = ""
+			m.err = ""
+			return m, func() tea.Msg { return roomSelectedMsg{server: srv, room: invite.room, invite: invite.token} }
+		}
+	}
+	m.err = "add the server " + invite.host + " first"
+	return m, nil
+}
+
+// typing reports whether the panel is reading text, so keys must reach it raw.
+func (m roomModel) typing() bool {
+	return m.state == roomStateSearch || m.state == roomStateCreate || m.state == roomStateJoin
 }
 
 func (m roomModel) findServer(serverURL string) serverConfig {
@@ -195,6 +325,28 @@ func (m roomModel) findServer(serverURL string) serverConfig {
 	return serverConfig{URL: serverURL}
 }
 
+// recordSeen stores the room's last message timestamp and reports whether it
+// moved since the previous listing. Polling only sees that a room changed, so
+// each changed poll counts as one unread message.
+func (m roomModel) recordSeen(serverURL string, rm generated.Room) bool {
+	if rm.LastMessageTimestamp == nil {
+		return false
+	}
+	key := roomKey(serverURL, deref(rm.Name))
+	prev, known := m.lastSeen[key]
+	m.lastSeen[key] = *rm.LastMessageTimestamp
+	return known && prev != *rm.LastMessageTimestamp
+}
+
+// deref returns the value p points to, or the zero value when p is nil.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
 // roomLine formats a single room entry for the list panel.
 func roomLine(sr serverRoom, cursor string) string {
 	srvName := runewidth.Truncate(serverDisplayName(sr.server), maxServerNameWidth, "")
@@ -205,9 +357,14 @@ func roomLine(sr serverRoom, cursor string) string {
 	}
 	name = runewidth.Truncate(name, maxRoomNameWidth, "")
 	lock := ""
-	if sr.room.HasPassword != nil && *sr.room.HasPassword {
+	if deref(sr.room.Encrypted) {
+		lock = " 🔐"
+	} else if sr.room.HasPassword != nil && *sr.room.HasPassword {
 		lock = " 🔒"
 	}
+	if deref(sr.room.Private) {
+		lock += " 👥"
+	}
 	return " " + cursor + prefix + name + lock + "\n"
 }
 
@@ -235,7 +392,11 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 				room := m.selectedRoom
 				password := m.roomPassword
 				srv := m.selectedServer
-				return m, func() tea.Msg { return roomSelectedMsg{server: srv, room: room, password: password} }
+				salt := m.selectedSalt
+				private := m.selectedPriv
+				return m, func() tea.Msg {
+					return roomSelectedMsg{server: srv, room: room, password: password, keySalt: salt, private: private}
+				}
 			case "backspace":
 				if _, size := utf8.DecodeLastRuneInString(m.passwdInput); size > 0 {
 					m.passwdInput = m.passwdInput[:len(m.passwdInput)-size]
@@ -254,6 +415,11 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case tea.PasteMsg:
+		if m.typing() {
+			m.inputText += msg.Content
+		}
+
 	case serverRoomsLoadedMsg:
 		delete(m.loading, msg.serverURL)
 		if msg.err != nil {
@@ -271,6 +437,7 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 			srv := m.findServer(msg.serverURL)
 			for _, rm := range msg.rooms {
 				filtered = append(filtered, serverRoom{server: srv, room: rm})
+				m.recordSeen(msg.serverURL, rm)
 			}
 			m.serverRooms = filtered
 			m.err = ""
@@ -280,6 +447,30 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 		}
 		return m, m.previewCmd()
 
+	case serverRoomsPolledMsg:
+		if msg.err != nil {
+			return m, nil // transient; the next poll retries
+		}
+		// Update rooms in place so the cursor stays put; append new ones.
+		srv := m.findServer(msg.serverURL)
+		for _, rm := range msg.rooms {
+			if m.recordSeen(msg.serverURL, rm) {
+				m = m.markUnread(msg.serverURL, deref(rm.Name))
+			}
+			found := false
+			for i, sr := range m.serverRooms {
+				if sr.server.URL == msg.serverURL && deref(sr.room.Name) == deref(rm.Name) {
+					m.serverRooms[i].room = rm
+					found = true
+					break
+				}
+			}
+			if !found {
+				m.serverRooms = append(m.serverRooms, serverRoom{server: srv, room: rm})
+			}
+		}
+		return m, nil
+
 	case roomCreatedMsg:
 		m.state = roomStateList
 		if msg.err != nil {
@@ -322,6 +513,11 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 						m.selectedRoom = *sr.room.Name
 					}
 					m.roomPassword = ""
+					m.selectedSalt = ""
+					m.selectedPriv = deref(sr.room.Private)
+					if deref(sr.room.Encrypted) {
+						m.selectedSalt = deref(sr.room.KeySalt)
+					}
 					if sr.room.HasPassword != nil && *sr.room.HasPassword {
 						m.promptPasswd = true
 						m.passwdInput = ""
@@ -329,7 +525,8 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 					}
 					room := m.selectedRoom
 					srv := m.selectedServer
-					return m, func() tea.Msg { return roomSelectedMsg{server: srv, room: room, password: ""} }
+					private := m.selectedPriv
+					return m, func() tea.Msg { return roomSelectedMsg{server: srv, room: room, password: "", private: private} }
 				}
 				// Cursor is on a pinned nav item: open the section in the right pane and focus it.
 				if navIdx := m.cursor - len(m.serverRooms); navIdx >= 0 && navIdx < len(roomNavTargets) {
@@ -344,6 +541,10 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 				m.state = roomStateCreate
 				m.inputText = ""
 				m.err = ""
+			case "i":
+				m.state = roomStateJoin
+				m.inputText = ""
+				m.err = ""
 			case "r":
 				if len(m.servers) > 0 {
 					for _, srv := range m.servers {
@@ -414,6 +615,27 @@ func (m roomModel) update(msg tea.Msg) (roomModel, tea.Cmd) {
 				}
 			}
 
+		case roomStateJoin:
+			switch msg.String() {
+			case "enter":
+				return m.joinInvite(m.inputText)
+			case "backspace":
+				if _, size := utf8.DecodeLastRuneInString(m.inputText); size > 0 {
+					m.inputText = m.inputText[:len(m.inputText)-size]
+				}
+			case "esc":
+				m.state = roomStateList
+				m.inputText = ""
+				m.err = ""
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				s := msg.String()
+				if utf8.RuneCountInString(s) == 1 {
+					m.inputText += s
+				}
+			}
+
 		case roomStateLoading:
 			if msg.String() == "ctrl+c" {
 				return m, tea.Quit
@@ -456,7 +678,7 @@ func (m roomModel) viewPanel(width, height int, focused bool) string {
 		} else {
 			// Partial results: show rooms from servers that already responded
 			for i, sr := range m.serverRooms {
-				body = append(body, roomCursorLine(sr, i == m.cursor))
+				body = append(body, m.roomCursorLine(sr, i == m.cursor))
 			}
 			body = append(body, dim(" (loading…)"))
 		}
@@ -467,17 +689,19 @@ func (m roomModel) viewPanel(width, height int, focused bool) string {
 			if len(m.servers) == 0 {
 				body = append(body, " [tab] to add a server")
 			} else {
-				body = append(body, " [c] to create one")
+				body = append(body, " [c] to create one", " [i] to join with an invite")
 			}
 		} else {
 			for i, sr := range m.serverRooms {
-				body = append(body, roomCursorLine(sr, i == m.cursor))
+				body = append(body, m.roomCursorLine(sr, i == m.cursor))
 			}
 		}
 	case roomStateSearch:
 		body = append(body, " Search: "+m.inputText+"█")
 	case roomStateCreate:
 		body = append(body, " New room name:", " > "+m.inputText+"█")
+	case roomStateJoin:
+		body = append(body, " Invite link:", " > "+m.inputText+"█")
 	}
 
 	if !showNav {
@@ -516,11 +740,16 @@ func (m roomModel) viewPanel(width, height int, focused bool) string {
 	return b.String()
 }
 
-// roomCursorLine renders a room entry with the selection caret, trimming the trailing newline.
-func roomCursorLine(sr serverRoom, selected bool) string {
+// roomCursorLine renders a room entry with the selection caret and unread count,
+// trimming the trailing newline.
+func (m roomModel) roomCursorLine(sr serverRoom, selected bool) string {
 	cursor := "  "
 	if selected {
 		cursor = "> "
 	}
-	return strings.TrimRight(roomLine(sr, cursor), "\n")
+	line := strings.TrimRight(roomLine(sr, cursor), "\n")
+	if n := m.unread[roomKey(sr.server.URL, deref(sr.room.Name))]; n > 0 {
+		line += fmt.Sprintf(" (%d)", n)
+	}
+	return line
 }
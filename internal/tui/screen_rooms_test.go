@@ -10,7 +10,7 @@ import (
 
 func makeRoomModel(servers ...serverConfig) roomModel {
 	clients := make(map[string]*generated.ClientWithResponses)
-	return newRoomModel(clients, servers)
+	return newRoomModel(clients, servers, nil)
 }
 
 func makeRoom(name string) generated.Room {
@@ -278,3 +278,112 @@ func TestRoomModel_Enter_OnRoom_StillOpensRoom(t *testing.T) {
 		t.Fatalf("expected roomSelectedMsg when entering a room, got %T", msg)
 	}
 }
+
+// TestRoomModel_Unread_CountsAndClears verifies unread counts are shown for rooms
+// that are not open and cleared when the room is opened.
+func TestRoomModel_Unread_CountsAndClears(t *testing.T) {
+	srv := serverConfig{URL: "http://a.example"}
+	m := makeRoomModel(srv)
+	m, _ = m.update(serverRoomsLoadedMsg{serverURL: srv.URL, rooms: []generated.Room{makeRoom("general"), makeRoom("random")}})
+	m = m.openRoom(srv.URL, "general")
+
+	m = m.markUnread(srv.URL, "general")
+	m = m.markUnread(srv.URL, "random")
+	m = m.markUnread(srv.URL, "random")
+
+	view := m.viewPanel(60, 20, false)
+	if !strings.Contains(view, "random (2)") {
+		t.Errorf("view should show unread count for random; got:\n%s", view)
+	}
+	if strings.Contains(view, "general (") {
+		t.Errorf("open room should not count unread; got:\n%s", view)
+	}
+
+	m = m.openRoom(srv.URL, "random")
+	if n := m.unread[roomKey(srv.URL, "random")]; n != 0 {
+		t.Errorf("unread after opening = %d, want 0", n)
+	}
+}
+
+// TestRoomModel_Polled_MarksChangedRooms verifies a polled listing counts rooms whose
+// last message moved and keeps the list order.
+func TestRoomModel_Polled_MarksChangedRooms(t *testing.T) {
+	srv := serverConfig{URL: "http://a.example"}
+	m := makeRoomModel(srv)
+	general := makeRoom("general")
+	general.LastMessageTimestamp = new("2026-01-01T10:00:00Z")
+	random := makeRoom("random")
+	random.LastMessageTimestamp = new("2026-01-01T10:00:00Z")
+	m, _ = m.update(serverRoomsLoadedMsg{serverURL: srv.URL, rooms: []generated.Room{general, random}})
+	m.cursor = 1
+
+	moved := makeRoom("random")
+	moved.LastMessageTimestamp = new("2026-01-01T10:05:00Z")
+	m, cmd := m.update(serverRoomsPolledMsg{serverURL: srv.URL, rooms: []generated.Room{moved, general}})
+
+	if cmd != nil {
+		t.Error("polled listing should not trigger a preview")
+	}
+	if got := deref(m.serverRooms[1].room.Name); got != "random" {
+		t.Errorf("serverRooms[1] = %q, want random (order kept)", got)
+	}
+	if n := m.unread[roomKey(srv.URL, "random")]; n != 1 {
+		t.Errorf("unread[random] = %d, want 1", n)
+	}
+	if n := m.unread[roomKey(srv.URL, "general")]; n != 0 {
+		t.Errorf("unread[general] = %d, want 0", n)
+	}
+}
+
+func TestParseInviteLink(t *testing.T) {
+	for _, tt := range []struct {
+		link    string
+		want    inviteLink
+		wantErr bool
+	}{
+		{link: "microchat://chat.example.com/club?invite=abc-_1", want: inviteLink{host: "chat.example.com", room: "club", token: "abc-_1"}},
+		{link: "  microchat://localhost:8080/club?invite=abc ", want: inviteLink{host: "localhost:8080", room: "club", token: "abc"}},
+		{link: "https://chat.example.com/club?invite=abc", wantErr: true},
+		{link: "microchat://chat.example.com/?invite=abc", wantErr: true},
+		{link: "microchat://chat.example.com/club", wantErr: true},
+		{link: "club", wantErr: true},
+	} {
+		got, err := parseInviteLink(tt.link)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseInviteLink(%q) = %+v, %v; want %+v, error %v", tt.link, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestRoomModel_JoinInvite_OpensRoomOnMatchingServer verifies a pasted invite link
+// opens its room on the configured server it points to, and only there.
+func TestRoomModel_JoinInvite_OpensRoomOnMatchingServer(t *testing.T) {
+	srvA := serverConfig{URL: "http://a.example"}
+	srvB := serverConfig{URL: "chat.example.com:8443"}
+	m := makeRoomModel(srvA, srvB)
+	m.state = roomStateList
+
+	m, _ = m.update(pressChar("i"))
+	if m.state != roomStateJoin {
+		t.Fatalf("state = %v, want roomStateJoin", m.state)
+	}
+	m, _ = m.update(tea.PasteMsg{Content: "microchat://chat.example.com:8443/club?invite=tok"})
+	m, cmd := m.update(pressKey(tea.KeyEnter))
+	selected, ok := runCmd(cmd).(roomSelectedMsg)
+	if !ok {
+		t.Fatalf("expected roomSelectedMsg, got %T (err %q)", runCmd(cmd), m.err)
+	}
+	if selected.server.URL != srvB.URL || selected.room != "club" || selected.invite != "tok" {
+		t.Errorf("roomSelectedMsg = %+v, want club on %s with the invite", selected, srvB.URL)
+	}
+	if m.state != roomStateList {
+		t.Errorf("state = %v, want back to the list", m.state)
+	}
+
+	m, _ = m.update(pressChar("i"))
+	m, _ = m.update(tea.PasteMsg{Content: "microchat://unknown.example/club?invite=tok"})
+	m, cmd = m.update(pressKey(tea.KeyEnter))
+	if cmd != nil || !strings.Contains(m.err, "unknown.example") {
+		t.Errorf("unknown server: err = %q, cmd = %v; want an error naming the server", m.err, cmd)
+	}
+}
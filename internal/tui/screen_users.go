@@ -1 +1,244 @@
 package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/pkg/crypto"
+)
+
+// usersLoadedMsg carries the members and active sanctions of a room. Only its
+// owner and moderators may list the sanctions: for anyone else hidden is set.
+type usersLoadedMsg struct {
+	room      string
+	members   []generated.RoomMember
+	sanctions []generated.Sanction
+	hidden    bool
+	err       error
+}
+
+// sanctionLiftedMsg reports the result of lifting a sanction.
+type sanctionLiftedMsg struct{ err error }
+
+// usersModel shows who runs a room and who is muted or banned in it, and lets
+// its moderators lift sanctions. Sanctions are added from the chat cursor mode.
+type usersModel struct {
+	client    *generated.ClientWithResponses
+	server    serverConfig
+	room      string
+	id        *identity
+	contacts  []contactEntry
+	members   []generated.RoomMember
+	sanctions []generated.Sanction
+	hidden    bool // the sanctions are only visible to moderators
+	cursor    int  // index into sanctions
+	loading   bool
+	err       string
+	statusMsg string
+}
+
+func newUsersModel(client *generated.ClientWithResponses, server serverConfig, room string, id *identity, contacts []contactEntry) usersModel {
+	return usersModel{client: client, server: server, room: room, id: id, contacts: contacts, loading: true}
+}
+
+func (m usersModel) init() tea.Cmd {
+	return m.fetchUsers()
+}
+
+// fetchUsers loads the members of the room, then its sanctions with a signed
+// request. The members are read signed too, as a private room only lists them
+// to its members.
+func (m usersModel) fetchUsers() tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		var signer []generated.RequestEditorFn
+		if id != nil {
+			signer = append(signer, id.signRequests())
+		}
+		resp, err := client.GETapiroomsRoommembersWithResponse(context.Background(), room, nil, signer...)
+		if err != nil {
+			return usersLoadedMsg{room: room, err: err}
+		}
+		if resp.JSON200 == nil {
+			return usersLoadedMsg{room: room, err: fmt.Errorf("load failed: %d", resp.StatusCode())}
+		}
+		loaded := usersLoadedMsg{room: room, members: *resp.JSON200, hidden: true}
+		if id == nil {
+			return loaded
+		}
+		sanctions, err := client.GETapiroomsRoomsanctionsWithResponse(context.Background(), room, nil, id.signRequests())
+		if err != nil {
+			return usersLoadedMsg{room: room, err: err}
+		}
+		switch {
+		case sanctions.JSON200 != nil:
+			loaded.sanctions = *sanctions.JSON200
+			loaded.hidden = false
+		case sanctions.StatusCode() != http.StatusForbidden:
+			return usersLoadedMsg{room: room, err: fmt.Errorf("load failed: %d", sanctions.StatusCode())}
+		}
+		return loaded
+	}
+}
+
+// liftSanction removes a sanction of the room.
+func (m usersModel) liftSanction(sanctionID string) tea.Cmd {
+	client := m.client
+	room := m.room
+	id := m.id
+	return func() tea.Msg {
+		resp, err := client.DELETEapiroomsRoomsanctionsIdWithResponse(context.Background(), room, sanctionID, nil, id.signRequests())
+		if err != nil {
+			return sanctionLiftedMsg{err: err}
+		}
+		if resp.StatusCode() != http.StatusNoContent {
+			return sanctionLiftedMsg{err: fmt.Errorf("lift failed: %d", resp.StatusCode())}
+		}
+		return sanctionLiftedMsg{}
+	}
+}
+
+func (m usersModel) update(msg tea.Msg) (usersModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case usersLoadedMsg:
+		if msg.room != m.room {
+			return m, nil // answer for a room closed since
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.members = msg.members
+		m.sanctions = msg.sanctions
+		m.hidden = msg.hidden
+		m.cursor = min(m.cursor, max(len(m.sanctions)-1, 0))
+
+	case sanctionLiftedMsg:
+		if msg.err != nil {
+			m.err = msg.err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.statusMsg = "Sanction lifted"
+		return m, m.fetchUsers()
+
+	case tea.KeyMsg:
+		m.statusMsg = ""
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.sanctions)-1 {
+				m.cursor++
+			}
+		case "x":
+			if m.cursor < len(m.sanctions) && m.id != nil {
+				return m, m.liftSanction(deref(m.sanctions[m.cursor].Id))
+			}
+		case "r":
+			m.loading = true
+			return m, m.fetchUsers()
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// userLabel names a pubkey by its contact name, or by the end of its npub.
+func (m usersModel) userLabel(pubkey string) string {
+	return pubkeyLabel(pubkey, m.id, m.contacts)
+}
+
+// pubkeyLabel names an x-only pubkey "(me)" for id, by its contact name, or by
+// the end of its npub.
+func pubkeyLabel(pubkey string, id *identity, contacts []contactEntry) string {
+	if id != nil && crypto.SamePubkey(pubkey, id.PubKeyHex) {
+		return "(me)"
+	}
+	for _, c := range contacts {
+		if hexKey, err := contactPubKeyHex(c.PubKey); err == nil && crypto.SamePubkey(hexKey, pubkey) {
+			return c.DisplayName
+		}
+	}
+	if npub, err := pubKeyHexToNpub("02" + pubkey); err == nil {
+		return "…" + npub[len(npub)-8:]
+	}
+	return pubkey
+}
+
+// viewPanel renders the users of the room inside the right pane of the main two-pane view.
+func (m usersModel) viewPanel(width, height int, focused bool) string {
+	title := dim(serverDisplayName(m.server)+"~") + "#" + m.room + " " + dim("users")
+
+	var body []string
+	if m.loading {
+		body = []string{" Loading…"}
+	} else {
+		body = append(body, " Members")
+		if len(m.members) == 0 {
+			body = append(body, dim("   (no owner — created without a signature)"))
+		}
+		for _, member := range m.members {
+			r, g, bv := pubkeyColor(deref(member.Pubkey))
+			body = append(body, fmt.Sprintf("   %-10s %s", deref(member.Role), ansiColor(m.userLabel(deref(member.Pubkey)), r, g, bv)))
+		}
+		body = append(body, "", " Muted and banned")
+		switch {
+		case m.hidden:
+			body = append(body, dim("   (only the owner and moderators can see them)"))
+		case len(m.sanctions) == 0:
+			body = append(body, dim("   (none)"))
+		}
+		for i, sanction := range m.sanctions {
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "> "
+			}
+			body = append(body, " "+cursor+m.formatSanction(sanction))
+		}
+	}
+
+	var help string
+	switch {
+	case m.err != "":
+		help = " Err: " + m.err
+	case m.statusMsg != "":
+		help = " ✓ " + m.statusMsg
+	case len(m.sanctions) > 0:
+		help = helpBar("↑↓", "navigate", "x", "lift", "r", "refresh", "esc", "chat")
+	default:
+		help = helpBar("r", "refresh", "esc", "chat")
+	}
+	return renderPanel(width, height, focused, title, body, help)
+}
+
+// formatSanction renders a sanction on one line: kind, target, expiry and reason.
+func (m usersModel) formatSanction(sanction generated.Sanction) string {
+	var targets []string
+	if pubkey := deref(sanction.Pubkey); pubkey != "" {
+		r, g, bv := pubkeyColor(pubkey)
+		targets = append(targets, ansiColor(m.userLabel(pubkey), r, g, bv))
+	}
+	if ip := deref(sanction.Ip); ip != "" {
+		targets = append(targets, ip)
+	}
+	line := fmt.Sprintf("%-5s %s", deref(sanction.Kind), strings.Join(targets, " "+dim("or")+" "))
+	if sanction.ExpiresAt != nil {
+		line += " " + dim("until "+sanction.ExpiresAt.Local().Format("Jan 2 15:04"))
+	}
+	if reason := deref(sanction.Reason); reason != "" {
+		line += dim(" · ") + reason
+	}
+	return line
+}
@@ -1 +1,103 @@
 package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/EwenQuim/microchat/client/sdk/generated"
+	"github.com/EwenQuim/microchat/internal/middleware"
+)
+
+// newUsersServer fakes the members and sanctions endpoints of the room "room",
+// where moderator is the only key allowed to see and lift sanctions.
+func newUsersServer(t *testing.T, moderator identity, sanctions []generated.Sanction) *generated.ClientWithResponses {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/api/rooms/room/members" {
+			_ = json.NewEncoder(w).Encode([]generated.RoomMember{{Pubkey: new(moderator.PubKeyHex[2:]), Role: new("owner")}})
+			return
+		}
+		// A fresh verifier per request, as the refresh after a lift can repeat
+		// the first listing within the same second.
+		auth := middleware.NewRequestAuth(middleware.DefaultSignedRequestMaxSkew, nil)
+		if pubkey, err := auth.Verify(r); err != nil || pubkey != moderator.PubKeyHex {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/rooms/room/sanctions":
+			_ = json.NewEncoder(w).Encode(sanctions)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/rooms/room/sanctions/"):
+			id := strings.TrimPrefix(r.URL.Path, "/api/rooms/room/sanctions/")
+			sanctions = slices.DeleteFunc(sanctions, func(s generated.Sanction) bool { return deref(s.Id) == id })
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client, err := generated.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+	return client
+}
+
+func TestUsersModel_ListsAndLiftsSanctions(t *testing.T) {
+	owner, _ := generateIdentity()
+	spammer, _ := generateIdentity()
+	client := newUsersServer(t, owner, []generated.Sanction{
+		{Id: new("s1"), Kind: new("mute"), Pubkey: new(spammer.PubKeyHex[2:]), Reason: new("spam")},
+		{Id: new("s2"), Kind: new("ban"), Ip: new("203.0.113.7")},
+	})
+
+	m := newUsersModel(client, serverConfig{}, "room", &owner, []contactEntry{{PubKey: spammer.NpubKey, DisplayName: "spammer"}})
+	m, _ = m.update(m.init()())
+	v := m.viewPanel(80, 14, true)
+	for _, want := range []string{"owner", "(me)", "mute", "spammer", "spam", "203.0.113.7"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, v)
+		}
+	}
+
+	m, _ = m.update(pressRealChar('j', "j"))
+	m, cmd := m.update(pressRealChar('x', "x"))
+	if cmd == nil {
+		t.Fatal("x should lift the selected sanction")
+	}
+	m, cmd = m.update(cmd())
+	if m.err != "" || cmd == nil {
+		t.Fatalf("lift: err = %q, want a refresh", m.err)
+	}
+	m, _ = m.update(cmd())
+	if len(m.sanctions) != 1 || deref(m.sanctions[0].Id) != "s1" {
+		t.Errorf("sanctions = %+v, want only the mute left", m.sanctions)
+	}
+}
+
+func TestUsersModel_HidesSanctionsFromOthers(t *testing.T) {
+	owner, _ := generateIdentity()
+	stranger, _ := generateIdentity()
+	client := newUsersServer(t, owner, []generated.Sanction{{Id: new("s1"), Kind: new("ban"), Ip: new("203.0.113.7")}})
+
+	m := newUsersModel(client, serverConfig{}, "room", &stranger, nil)
+	m, _ = m.update(m.init()())
+	if m.err != "" || !m.hidden {
+		t.Fatalf("err = %q, hidden = %v; want the sanctions hidden", m.err, m.hidden)
+	}
+	if v := m.viewPanel(80, 14, true); !strings.Contains(v, "only the owner and moderators") || strings.Contains(v, "203.0.113.7") {
+		t.Errorf("view should hide the sanctions, got:\n%s", v)
+	}
+	if _, cmd := m.update(pressRealChar('x', "x")); cmd != nil {
+		t.Error("x should do nothing without sanctions")
+	}
+}
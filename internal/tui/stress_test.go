@@ -185,7 +185,7 @@ func TestChatBackspace_MultiByteUTF8(t *testing.T) {
 
 func TestNonChatInput_AcceptsMultiByteChars(t *testing.T) {
 	t.Run("room search", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.state = roomStateSearch
 		m, _ = m.update(pressChar("中"))
 		if m.inputText != "中" {
@@ -194,7 +194,7 @@ func TestNonChatInput_AcceptsMultiByteChars(t *testing.T) {
 	})
 
 	t.Run("room create", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.state = roomStateCreate
 		m, _ = m.update(pressChar("中"))
 		if m.inputText != "中" {
@@ -203,7 +203,7 @@ func TestNonChatInput_AcceptsMultiByteChars(t *testing.T) {
 	})
 
 	t.Run("room passwd", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.promptPasswd = true
 		m, _ = m.update(pressChar("中"))
 		if m.passwdInput != "中" {
@@ -250,7 +250,7 @@ func TestNonChatBackspace_MultiByteClean(t *testing.T) {
 	}
 
 	t.Run("room search", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.state = roomStateSearch
 		m.inputText = "中"
 		m, _ = m.update(pressKey(tea.KeyBackspace))
@@ -258,7 +258,7 @@ func TestNonChatBackspace_MultiByteClean(t *testing.T) {
 	})
 
 	t.Run("room create", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.state = roomStateCreate
 		m.inputText = "中"
 		m, _ = m.update(pressKey(tea.KeyBackspace))
@@ -266,7 +266,7 @@ func TestNonChatBackspace_MultiByteClean(t *testing.T) {
 	})
 
 	t.Run("room passwd", func(t *testing.T) {
-		m := newRoomModel(nil, nil)
+		m := newRoomModel(nil, nil, nil)
 		m.promptPasswd = true
 		m.passwdInput = "中"
 		m, _ = m.update(pressKey(tea.KeyBackspace))
@@ -79,7 +79,7 @@ func (m model) syncMainConfig() (model, tea.Cmd) {
 		for _, srv := range m.cfg.Servers {
 			m.main.rooms.loading[srv.URL] = true
 		}
-		cmd = tea.Batch(cmd, m.main.rooms.init())
+		cmd = tea.Batch(cmd, m.main.rooms.init(), m.main.connectLive())
 	}
 
 	if m.main.identitiesSec.configChanged {
@@ -92,6 +92,7 @@ func (m model) syncMainConfig() (model, tea.Cmd) {
 				m.main.id = &id
 				m.main.username = deriveUsername(&id, m.cfg)
 				m.main.chat.id = &id
+				m.main.rooms.id = &id
 				m.main.chat.username = m.main.username
 			}
 		}
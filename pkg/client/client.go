@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/EwenQuim/microchat/internal/models"
@@ -58,9 +59,19 @@ func (c *Client) SendMessage(room, user, message string) error {
 }
 
 func (c *Client) GetMessages(room string) ([]models.Message, error) {
-	url := fmt.Sprintf("%s/api/rooms/%s/messages", c.baseURL, room)
+	return c.getMessages(fmt.Sprintf("%s/api/rooms/%s/messages", c.baseURL, room))
+}
 
-	resp, err := c.httpClient.Get(url)
+// GetMessagesAfter returns the messages received after the message id, oldest
+// first, so that a bot polling a room only fetches what it has not seen yet.
+// It fails when id is no longer a message of the room; fetch the latest
+// messages with GetMessages then.
+func (c *Client) GetMessagesAfter(room, id string) ([]models.Message, error) {
+	return c.getMessages(fmt.Sprintf("%s/api/rooms/%s/messages?after=%s", c.baseURL, room, url.QueryEscape(id)))
+}
+
+func (c *Client) getMessages(endpoint string) ([]models.Message, error) {
+	resp, err := c.httpClient.Get(endpoint)
 	if err != nil {
 		return nil, err
 	}
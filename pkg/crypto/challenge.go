@@ -0,0 +1,56 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// challengeDomain prefixes every serialized challenge so that a challenge
+// signature can never be mistaken for a message signature.
+const challengeDomain = "microchat-challenge"
+
+// Challenge is a server-issued nonce signed to authenticate a single request.
+// It is bound to the request method, URI and body, so a signature cannot be
+// spent on another route, query or body.
+type Challenge struct {
+	Nonce   string
+	Method  string
+	URI     string // path and query, as http.Request.URL.RequestURI returns
+	Payload string // PayloadHash of the request body, empty bodies included
+}
+
+// Serialize returns the canonical JSON array
+// ["microchat-challenge", nonce, method, uri, payload] that is hashed and
+// signed.
+func (c Challenge) Serialize() ([]byte, error) {
+	return json.Marshal([]string{challengeDomain, c.Nonce, c.Method, c.URI, c.Payload})
+}
+
+// Hash returns the SHA-256 of the serialized challenge.
+func (c Challenge) Hash() ([]byte, error) {
+	serialized, err := c.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(serialized)
+	return hash[:], nil
+}
+
+// VerifyChallengeSignature verifies a signature over a challenge with the
+// given scheme (SigECDSA, the default when empty, or SigSchnorr).
+func VerifyChallengeSignature(scheme, pubkeyHex string, challenge Challenge, signatureHex string) error {
+	hash, err := challenge.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash challenge: %w", err)
+	}
+
+	switch scheme {
+	case "", SigECDSA:
+		return verifyECDSA(pubkeyHex, signatureHex, hash)
+	case SigSchnorr:
+		return verifySchnorrHash(pubkeyHex, signatureHex, hash)
+	default:
+		return fmt.Errorf("unsupported signature scheme %q", scheme)
+	}
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+func TestVerifyChallengeSignature(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	compressed := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	challenge := Challenge{Nonce: "abc123", Method: "POST", URI: "/api/admin/rooms/general/password", Payload: PayloadHash([]byte(`{"password":"secret"}`))}
+	hash, err := challenge.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	ecdsaSig := hex.EncodeToString(ecdsa.Sign(key, hash).Serialize())
+	schnorrSig, err := schnorr.Sign(key, hash)
+	if err != nil {
+		t.Fatalf("schnorr.Sign: %v", err)
+	}
+
+	if err := VerifyChallengeSignature("", compressed, challenge, ecdsaSig); err != nil {
+		t.Errorf("ecdsa: %v", err)
+	}
+	if err := VerifyChallengeSignature(SigSchnorr, compressed, challenge, hex.EncodeToString(schnorrSig.Serialize())); err != nil {
+		t.Errorf("schnorr: %v", err)
+	}
+
+	for name, other := range map[string]Challenge{
+		"other nonce":   {Nonce: "abc124", Method: challenge.Method, URI: challenge.URI, Payload: challenge.Payload},
+		"other method":  {Nonce: challenge.Nonce, Method: "PUT", URI: challenge.URI, Payload: challenge.Payload},
+		"other path":    {Nonce: challenge.Nonce, Method: challenge.Method, URI: "/api/admin/rooms/random/password", Payload: challenge.Payload},
+		"other query":   {Nonce: challenge.Nonce, Method: challenge.Method, URI: challenge.URI + "?force=1", Payload: challenge.Payload},
+		"other payload": {Nonce: challenge.Nonce, Method: challenge.Method, URI: challenge.URI, Payload: PayloadHash(nil)},
+	} {
+		if err := VerifyChallengeSignature("", compressed, other, ecdsaSig); err == nil {
+			t.Errorf("%s: expected verification to fail", name)
+		}
+	}
+
+	if err := VerifyChallengeSignature("rsa", compressed, challenge, ecdsaSig); err == nil {
+		t.Error("expected an unknown scheme to be rejected")
+	}
+}
+
+func TestChallengeSerialize(t *testing.T) {
+	challenge, err := Challenge{Nonce: "n", Method: "GET", URI: "/?q=1", Payload: "p"}.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if want := `["microchat-challenge","n","GET","/?q=1","p"]`; string(challenge) != want {
+		t.Errorf("Serialize() = %s, want %s", challenge, want)
+	}
+}
@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+func TestEventSerialize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{
+			name:     "v0 ignores user and tags",
+			event:    Event{Version: EventV0, Pubkey: "02ab", CreatedAt: 1700000000, Content: "hi", Room: "general", User: "alice", Tags: [][]string{{"t", "x"}}},
+			expected: `[0,"02ab",1700000000,"hi","general"]`,
+		},
+		{
+			name:     "v1 without tags",
+			event:    Event{Version: EventV1, Pubkey: "02ab", CreatedAt: 1700000000, Content: "hi", Room: "general", User: "alice"},
+			expected: `[1,"02ab",1700000000,"hi","general","alice",[]]`,
+		},
+		{
+			name:     "v1 with tags",
+			event:    Event{Version: EventV1, Pubkey: "02ab", CreatedAt: 1700000000, Content: "hi", Room: "general", User: "alice", Tags: [][]string{{"e", "abc"}, {"client", "tui"}}},
+			expected: `[1,"02ab",1700000000,"hi","general","alice",[["e","abc"],["client","tui"]]]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.event.Serialize()
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+			if string(got) != tc.expected {
+				t.Errorf("Serialize = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestEventSerialize_Invalid(t *testing.T) {
+	if _, err := (Event{Version: 2}).Serialize(); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+	if _, err := (Event{Version: EventV1, Tags: [][]string{nil}}).Serialize(); err == nil {
+		t.Error("expected an error for a null tag")
+	}
+}
+
+func TestVerifyEventSignature_V1CoversUser(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	event := Event{
+		Version:   EventV1,
+		Pubkey:    hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+		CreatedAt: 1700000000,
+		Content:   "hello",
+		Room:      "general",
+		User:      "alice",
+		Tags:      [][]string{{"client", "test"}},
+	}
+	hash, err := event.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	signatureHex := hex.EncodeToString(ecdsa.SignCompact(privateKey, hash, true)[1:])
+
+	if err := VerifyEventSignature(event, signatureHex); err != nil {
+		t.Fatalf("valid v1 signature rejected: %v", err)
+	}
+	if err := VerifyEventSignatureBTCD(event, signatureHex); err != nil {
+		t.Fatalf("valid v1 signature rejected by btcd: %v", err)
+	}
+
+	renamed := event
+	renamed.User = "mallory"
+	if err := VerifyEventSignature(renamed, signatureHex); err == nil {
+		t.Error("changing the user must invalidate a v1 signature")
+	}
+
+	retagged := event
+	retagged.Tags = nil
+	if err := VerifyEventSignature(retagged, signatureHex); err == nil {
+		t.Error("changing the tags must invalidate a v1 signature")
+	}
+
+	downgraded := event
+	downgraded.Version = EventV0
+	if err := VerifyEventSignature(downgraded, signatureHex); err == nil {
+		t.Error("a v1 signature must not verify as v0")
+	}
+}
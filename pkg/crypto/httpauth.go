@@ -0,0 +1,83 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// KindHTTPAuth is the Nostr event kind of a signed HTTP request (NIP-98).
+	KindHTTPAuth = 27235
+	// HTTPAuthScheme prefixes the base64-encoded event in the Authorization
+	// header.
+	HTTPAuthScheme = "Nostr"
+
+	// Tags of a KindHTTPAuth event.
+	URLTag     = "u"       // absolute request URL, query string included
+	MethodTag  = "method"  // HTTP method
+	PayloadTag = "payload" // hex SHA-256 of the request body, when there is one
+)
+
+// NostrEvent is a signed NIP-01 event of any kind.
+type NostrEvent struct {
+	ID        string     `json:"id"`
+	Pubkey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// Hash returns the NIP-01 event id. Pubkey may be given in compressed or
+// x-only form.
+func (e NostrEvent) Hash() ([]byte, error) {
+	serialized, err := serializeNostrEvent(e.Pubkey, e.CreatedAt, e.Kind, e.Tags, e.Content)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(serialized)
+	return hash[:], nil
+}
+
+// Verify checks that ID is the id of the event and Sig a BIP-340 signature
+// of it by Pubkey.
+func (e NostrEvent) Verify() error {
+	id, err := e.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash event: %w", err)
+	}
+	if hex.EncodeToString(id) != e.ID {
+		return fmt.Errorf("event id does not match its content")
+	}
+	return verifySchnorrHash(e.Pubkey, e.Sig, id)
+}
+
+// NewHTTPAuthEvent returns the unsigned KindHTTPAuth event for a request; the
+// payload tag is only set for a non-empty body.
+func NewHTTPAuthEvent(pubkey, method, url string, body []byte, createdAt int64) NostrEvent {
+	tags := [][]string{{URLTag, url}, {MethodTag, method}}
+	if len(body) > 0 {
+		tags = append(tags, []string{PayloadTag, PayloadHash(body)})
+	}
+	return NostrEvent{Pubkey: pubkey, CreatedAt: createdAt, Kind: KindHTTPAuth, Tags: tags}
+}
+
+// AuthorizationHeader returns the Authorization header value carrying the
+// signed event.
+func (e NostrEvent) AuthorizationHeader() (string, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return HTTPAuthScheme + " " + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// PayloadHash returns the value of the PayloadTag for a request body.
+func PayloadHash(body []byte) string {
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+func TestNostrEventVerify(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	event := NostrEvent{
+		Pubkey:    hex.EncodeToString(key.PubKey().SerializeCompressed()),
+		CreatedAt: 1700000000,
+		Kind:      KindHTTPAuth,
+		Tags:      [][]string{{URLTag, "https://chat.example.com/api/users/me"}, {MethodTag, "GET"}},
+	}
+	id, err := event.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	event.ID = hex.EncodeToString(id)
+	event.Sig = hex.EncodeToString(sig.Serialize())
+
+	if err := event.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	xOnly := event
+	xOnly.Pubkey = hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))
+	if err := xOnly.Verify(); err != nil {
+		t.Errorf("x-only pubkey: %v", err)
+	}
+
+	tampered := event
+	tampered.Tags = [][]string{{URLTag, "https://chat.example.com/api/admin/users"}, {MethodTag, "GET"}}
+	if err := tampered.Verify(); err == nil {
+		t.Error("expected tampered tags to fail verification")
+	}
+
+	reid := tampered
+	newID, _ := tampered.Hash()
+	reid.ID = hex.EncodeToString(newID)
+	if err := reid.Verify(); err == nil {
+		t.Error("expected a recomputed id to fail signature verification")
+	}
+}
+
+func TestTagValue(t *testing.T) {
+	tags := [][]string{{"u"}, {MethodTag, "POST"}, {MethodTag, "GET"}}
+	if got, ok := TagValue(tags, MethodTag); !ok || got != "POST" {
+		t.Errorf("TagValue(method) = %q, %v, want POST", got, ok)
+	}
+	if _, ok := TagValue(tags, URLTag); ok {
+		t.Error("a tag without a value should not match")
+	}
+}
@@ -0,0 +1,28 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// InviteTokenSize is the size of a room invite token, in bytes.
+const InviteTokenSize = 32
+
+// NewInviteToken returns a random base64url token for a room invite. Only its
+// InviteTokenHash is stored, so a leaked database does not leak invites.
+func NewInviteToken() (string, error) {
+	token := make([]byte, InviteTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generate invite token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// InviteTokenHash returns the hex SHA-256 of an invite token.
+func InviteTokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
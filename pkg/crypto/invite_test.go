@@ -0,0 +1,17 @@
+package crypto
+
+import "testing"
+
+func TestNewInviteToken(t *testing.T) {
+	token, err := NewInviteToken()
+	if err != nil {
+		t.Fatalf("NewInviteToken: %v", err)
+	}
+	other, _ := NewInviteToken()
+	if token == other {
+		t.Error("two tokens are equal")
+	}
+	if hash := InviteTokenHash(token); hash != InviteTokenHash(token) || len(hash) != 64 || hash == InviteTokenHash(other) {
+		t.Errorf("InviteTokenHash = %q, want a stable hex SHA-256 per token", hash)
+	}
+}
@@ -0,0 +1,190 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/chacha20"
+)
+
+// Direct messages are encrypted as NIP-44 version 2 payloads: ChaCha20 with
+// HMAC-SHA256, keyed by an ECDH conversation key between the two pubkeys, so
+// the same keys and payloads work with Nostr clients.
+const (
+	// DMVersion is the NIP-44 payload version produced by EncryptDM.
+	DMVersion = 2
+
+	// RecipientTag names the tag carrying the recipient of a direct message
+	// (NIP-01 "p").
+	RecipientTag = "p"
+
+	// MaxDMPlaintext is the longest plaintext a payload can carry, in bytes.
+	MaxDMPlaintext = 65535
+	// MaxDMPayload is the length of the base64 payload of the longest
+	// plaintext.
+	MaxDMPayload = 87472
+
+	minDMPayload = 132
+	dmNonceSize  = 32
+	dmMACSize    = 32
+)
+
+// ErrInvalidDMPayload is returned by DecryptDM and CheckDMPayload for data
+// that is not a version 2 NIP-44 payload.
+var ErrInvalidDMPayload = errors.New("invalid direct message payload")
+
+// ConversationKey derives the key shared by privKey and the hex public key
+// of the other party, compressed or x-only. Both parties derive the same key.
+func ConversationKey(privKey *secp256k1.PrivateKey, pubkeyHex string) ([]byte, error) {
+	pubkeyHex, err := XOnlyPubkey(pubkeyHex)
+	if err != nil {
+		return nil, err
+	}
+	pubkeyBytes, err := hex.DecodeString(pubkeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key hex: %w", err)
+	}
+	pubkey, err := schnorr.ParsePubKey(pubkeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	// The shared x coordinate does not depend on the parity of either key
+	shared := secp256k1.GenerateSharedSecret(privKey, pubkey)
+	return hkdf.Extract(sha256.New, shared, []byte("nip44-v2"))
+}
+
+// EncryptDM encrypts plaintext with a conversation key and a random nonce,
+// returning the base64 payload.
+func EncryptDM(conversationKey []byte, plaintext string) (string, error) {
+	nonce := make([]byte, dmNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	return encryptDM(conversationKey, plaintext, nonce)
+}
+
+func encryptDM(conversationKey []byte, plaintext string, nonce []byte) (string, error) {
+	if len(plaintext) == 0 || len(plaintext) > MaxDMPlaintext {
+		return "", fmt.Errorf("plaintext must be 1 to %d bytes", MaxDMPlaintext)
+	}
+	chachaKey, chachaNonce, hmacKey, err := dmMessageKeys(conversationKey, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	padded := make([]byte, 2+dmPaddedLen(len(plaintext)))
+	binary.BigEndian.PutUint16(padded, uint16(len(plaintext)))
+	copy(padded[2:], plaintext)
+	cipher, err := chacha20.NewUnauthenticatedCipher(chachaKey, chachaNonce)
+	if err != nil {
+		return "", err
+	}
+	cipher.XORKeyStream(padded, padded)
+
+	payload := make([]byte, 0, 1+dmNonceSize+len(padded)+dmMACSize)
+	payload = append(payload, DMVersion)
+	payload = append(payload, nonce...)
+	payload = append(payload, padded...)
+	payload = append(payload, dmMAC(hmacKey, nonce, padded)...)
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// DecryptDM authenticates and decrypts a payload with a conversation key.
+func DecryptDM(conversationKey []byte, payload string) (string, error) {
+	data, err := decodeDMPayload(payload)
+	if err != nil {
+		return "", err
+	}
+	nonce := data[1 : 1+dmNonceSize]
+	ciphertext := data[1+dmNonceSize : len(data)-dmMACSize]
+	mac := data[len(data)-dmMACSize:]
+
+	chachaKey, chachaNonce, hmacKey, err := dmMessageKeys(conversationKey, nonce)
+	if err != nil {
+		return "", err
+	}
+	if !hmac.Equal(mac, dmMAC(hmacKey, nonce, ciphertext)) {
+		return "", fmt.Errorf("%w: authentication failed", ErrInvalidDMPayload)
+	}
+
+	padded := make([]byte, len(ciphertext))
+	cipher, err := chacha20.NewUnauthenticatedCipher(chachaKey, chachaNonce)
+	if err != nil {
+		return "", err
+	}
+	cipher.XORKeyStream(padded, ciphertext)
+
+	n := int(binary.BigEndian.Uint16(padded))
+	if n == 0 || len(padded) != 2+dmPaddedLen(n) {
+		return "", fmt.Errorf("%w: invalid padding", ErrInvalidDMPayload)
+	}
+	return string(padded[2 : 2+n]), nil
+}
+
+// CheckDMPayload reports whether payload is shaped like a version 2 NIP-44
+// payload. It cannot tell whether it decrypts: only the two parties can.
+func CheckDMPayload(payload string) error {
+	_, err := decodeDMPayload(payload)
+	return err
+}
+
+func decodeDMPayload(payload string) ([]byte, error) {
+	if len(payload) < minDMPayload || len(payload) > MaxDMPayload {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidDMPayload, len(payload))
+	}
+	if payload[0] == '#' {
+		return nil, fmt.Errorf("%w: unsupported encryption version", ErrInvalidDMPayload)
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDMPayload, err)
+	}
+	if data[0] != DMVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidDMPayload, data[0])
+	}
+	return data, nil
+}
+
+// dmMessageKeys derives the ChaCha20 key and nonce and the HMAC key of one
+// message from the conversation key and its nonce.
+func dmMessageKeys(conversationKey, nonce []byte) (chachaKey, chachaNonce, hmacKey []byte, err error) {
+	if len(conversationKey) != 32 {
+		return nil, nil, nil, fmt.Errorf("conversation key must be 32 bytes, got %d", len(conversationKey))
+	}
+	keys, err := hkdf.Expand(sha256.New, conversationKey, string(nonce), 76)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return keys[:32], keys[32:44], keys[44:], nil
+}
+
+func dmMAC(hmacKey, nonce, ciphertext []byte) []byte {
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(nonce)
+	mac.Write(ciphertext)
+	return mac.Sum(nil)
+}
+
+// dmPaddedLen rounds a plaintext length up so that payloads only leak its
+// order of magnitude.
+func dmPaddedLen(n int) int {
+	if n <= 32 {
+		return 32
+	}
+	nextPower := 1 << bits.Len(uint(n-1))
+	chunk := 32
+	if nextPower > 256 {
+		chunk = nextPower / 8
+	}
+	return chunk * ((n-1)/chunk + 1)
+}
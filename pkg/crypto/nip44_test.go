@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+func TestEncryptDM_NIP44Vector(t *testing.T) {
+	// First valid vector of the NIP-44 specification
+	sec1, _ := hex.DecodeString(strings.Repeat("00", 31) + "01")
+	sec2, _ := hex.DecodeString(strings.Repeat("00", 31) + "02")
+	key2 := secp256k1.PrivKeyFromBytes(sec2)
+	nonce, _ := hex.DecodeString(strings.Repeat("00", 31) + "01")
+	const (
+		wantKey     = "c41c775356fd92eadc63ff5a0dc1da211b268cbea22316767095b2871ea1412d"
+		wantPayload = "AgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABee0G5VSK0/9YypIObAtDKfYEAjD35uVkHyB0F4DwrcNaCXlCWZKaArsGrY6M9wnuTMxWfp1RTN9Xga8no+kF5Vsb"
+	)
+
+	key, err := ConversationKey(secp256k1.PrivKeyFromBytes(sec1), hex.EncodeToString(schnorr.SerializePubKey(key2.PubKey())))
+	if err != nil {
+		t.Fatalf("ConversationKey: %v", err)
+	}
+	if hex.EncodeToString(key) != wantKey {
+		t.Fatalf("conversation key = %x, want %s", key, wantKey)
+	}
+	payload, err := encryptDM(key, "a", nonce)
+	if err != nil {
+		t.Fatalf("encryptDM: %v", err)
+	}
+	if payload != wantPayload {
+		t.Errorf("payload = %s, want %s", payload, wantPayload)
+	}
+	if plaintext, err := DecryptDM(key, wantPayload); err != nil || plaintext != "a" {
+		t.Errorf("DecryptDM = %q, %v; want %q", plaintext, err, "a")
+	}
+}
+
+func TestEncryptDM_RoundTripBetweenKeys(t *testing.T) {
+	alice, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+
+	// Alice addresses Bob by his compressed key, Bob replies to her x-only key
+	aliceKey, err := ConversationKey(alice, hex.EncodeToString(bob.PubKey().SerializeCompressed()))
+	if err != nil {
+		t.Fatalf("ConversationKey: %v", err)
+	}
+	bobKey, err := ConversationKey(bob, hex.EncodeToString(schnorr.SerializePubKey(alice.PubKey())))
+	if err != nil {
+		t.Fatalf("ConversationKey: %v", err)
+	}
+
+	for _, plaintext := range []string{"hi", strings.Repeat("é", 200), strings.Repeat("x", MaxDMPlaintext)} {
+		payload, err := EncryptDM(aliceKey, plaintext)
+		if err != nil {
+			t.Fatalf("EncryptDM(%d bytes): %v", len(plaintext), err)
+		}
+		if err := CheckDMPayload(payload); err != nil {
+			t.Errorf("CheckDMPayload(%d bytes): %v", len(plaintext), err)
+		}
+		got, err := DecryptDM(bobKey, payload)
+		if err != nil || got != plaintext {
+			t.Errorf("DecryptDM(%d bytes) = %d bytes, %v", len(plaintext), len(got), err)
+		}
+	}
+}
+
+func TestDecryptDM_Rejects(t *testing.T) {
+	alice, _ := secp256k1.GeneratePrivateKey()
+	bob, _ := secp256k1.GeneratePrivateKey()
+	carol, _ := secp256k1.GeneratePrivateKey()
+	key, _ := ConversationKey(alice, hex.EncodeToString(bob.PubKey().SerializeCompressed()))
+	otherKey, _ := ConversationKey(carol, hex.EncodeToString(bob.PubKey().SerializeCompressed()))
+
+	payload, err := EncryptDM(key, "secret")
+	if err != nil {
+		t.Fatalf("EncryptDM: %v", err)
+	}
+	tampered := []byte(payload)
+	tampered[50] ^= 1
+
+	for name, tt := range map[string]struct {
+		key     []byte
+		payload string
+	}{
+		"other conversation": {otherKey, payload},
+		"tampered":           {key, string(tampered)},
+		"too short":          {key, payload[:100]},
+		"version 1":          {key, "#" + payload[1:]},
+	} {
+		if _, err := DecryptDM(tt.key, tt.payload); !errors.Is(err, ErrInvalidDMPayload) {
+			t.Errorf("%s: err = %v, want ErrInvalidDMPayload", name, err)
+		}
+	}
+	if _, err := EncryptDM(key, ""); err == nil {
+		t.Error("EncryptDM should reject an empty plaintext")
+	}
+}
@@ -0,0 +1,222 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// Signature schemes a message can be signed with.
+const (
+	// SigECDSA is a compact ECDSA signature over Event.Hash (the default).
+	SigECDSA = "ecdsa"
+	// SigSchnorr is a BIP-340 Schnorr signature over the NIP-01 event id, so
+	// the same key and signature are valid for standard Nostr tooling.
+	SigSchnorr = "schnorr"
+)
+
+const (
+	// KindChatMessage is the Nostr event kind used for room messages
+	// (NIP-29 group chat message).
+	KindChatMessage = 9
+	// RoomTag names the tag carrying the room of a Nostr event (NIP-29 "h").
+	RoomTag = "h"
+	// NameTag names the tag carrying the display name of a schnorr message,
+	// which the NIP-01 serialization does not otherwise cover.
+	NameTag = "name"
+)
+
+// SignatureSchemes lists the schemes accepted by VerifyEventSignature.
+func SignatureSchemes() []string {
+	return []string{SigECDSA, SigSchnorr}
+}
+
+// XOnlyPubkey returns the 32-byte x-only form of a hex public key, accepting
+// both compressed (33-byte) and x-only (32-byte) encodings.
+func XOnlyPubkey(pubkeyHex string) (string, error) {
+	switch len(pubkeyHex) {
+	case 64:
+		return pubkeyHex, nil
+	case 66:
+		return pubkeyHex[2:], nil
+	default:
+		return "", fmt.Errorf("invalid public key length for schnorr: %d hex characters", len(pubkeyHex))
+	}
+}
+
+// SamePubkey reports whether two hex public keys, each compressed or x-only,
+// share their x coordinate, i.e. are controlled by the same private key.
+func SamePubkey(a, b string) bool {
+	xa, err := XOnlyPubkey(strings.ToLower(a))
+	if err != nil {
+		return false
+	}
+	xb, err := XOnlyPubkey(strings.ToLower(b))
+	return err == nil && xa == xb
+}
+
+// NostrDisplayName is the display name of a schnorr message signed without a
+// name tag, as Nostr clients send them. It derives from the pubkey, so it
+// cannot be changed without breaking the signature either.
+func NostrDisplayName(pubkey string) string {
+	if xOnly, err := XOnlyPubkey(pubkey); err == nil {
+		return "nostr:" + xOnly[:8]
+	}
+	return "nostr"
+}
+
+// serializeNostr returns the NIP-01 serialization of a chat message. The
+// signed tags must include the room tag, so a message cannot be moved to
+// another room, and a name tag with the display name unless it is the
+// NostrDisplayName of the pubkey, so a message cannot be renamed.
+func (e Event) serializeNostr() ([]byte, error) {
+	if !HasTag(e.Tags, RoomTag, e.Room) {
+		return nil, fmt.Errorf("schnorr events must carry a [%q, %q] tag", RoomTag, e.Room)
+	}
+	if e.User != "" {
+		name, ok := TagValue(e.Tags, NameTag)
+		if !ok {
+			name = NostrDisplayName(e.Pubkey)
+		}
+		if name != e.User {
+			return nil, fmt.Errorf("schnorr events must carry a [%q, %q] tag", NameTag, e.User)
+		}
+	}
+	return serializeNostrEvent(e.Pubkey, e.CreatedAt, KindChatMessage, e.Tags, e.Content)
+}
+
+// serializeNostrEvent returns the NIP-01 serialization
+// [0, pubkey, created_at, kind, tags, content], with pubkey in x-only form.
+func serializeNostrEvent(pubkey string, createdAt int64, kind int, tags [][]string, content string) ([]byte, error) {
+	pubkey, err := XOnlyPubkey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("[0,")
+	appendNostrString(&b, pubkey)
+	b.WriteByte(',')
+	b.WriteString(strconv.FormatInt(createdAt, 10))
+	b.WriteByte(',')
+	b.WriteString(strconv.Itoa(kind))
+	b.WriteString(",[")
+	for i, tag := range tags {
+		if tag == nil {
+			return nil, fmt.Errorf("tags must not contain null entries")
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('[')
+		for j, value := range tag {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			appendNostrString(&b, value)
+		}
+		b.WriteByte(']')
+	}
+	b.WriteString("],")
+	appendNostrString(&b, content)
+	b.WriteByte(']')
+	return []byte(b.String()), nil
+}
+
+// appendNostrString writes s as a JSON string the way NIP-01 (and
+// JSON.stringify) does: only quotes, backslashes and control characters are
+// escaped, everything else is copied verbatim. encoding/json cannot be used as
+// it also escapes <, >, & and U+2028/U+2029.
+func appendNostrString(b *strings.Builder, s string) {
+	const hexDigits = "0123456789abcdef"
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				b.WriteString(`\u00`)
+				b.WriteByte(hexDigits[r>>4])
+				b.WriteByte(hexDigits[r&0xf])
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+}
+
+// TagValue returns the value of the first [name, value, ...] entry of tags.
+func TagValue(tags [][]string, name string) (string, bool) {
+	for _, tag := range tags {
+		if len(tag) >= 2 && tag[0] == name {
+			return tag[1], true
+		}
+	}
+	return "", false
+}
+
+// HasTag reports whether tags contains a [name, value, ...] entry.
+func HasTag(tags [][]string, name, value string) bool {
+	for _, tag := range tags {
+		if len(tag) >= 2 && tag[0] == name && tag[1] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySchnorr checks a BIP-340 signature over the NIP-01 event id.
+func verifySchnorr(event Event, signatureHex string) error {
+	id, err := event.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash event: %w", err)
+	}
+	return verifySchnorrHash(event.Pubkey, signatureHex, id)
+}
+
+// verifySchnorrHash checks a BIP-340 signature over hash.
+func verifySchnorrHash(pubkeyHex, signatureHex string, hash []byte) error {
+	pubkeyHex, err := XOnlyPubkey(pubkeyHex)
+	if err != nil {
+		return err
+	}
+	pubkeyBytes, err := hex.DecodeString(pubkeyHex)
+	if err != nil {
+		return fmt.Errorf("invalid public key hex: %w", err)
+	}
+	pubkey, err := schnorr.ParsePubKey(pubkeyBytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	signatureBytes, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return fmt.Errorf("invalid signature hex: %w", err)
+	}
+	signature, err := schnorr.ParseSignature(signatureBytes)
+	if err != nil {
+		return fmt.Errorf("invalid schnorr signature: %w", err)
+	}
+
+	if !signature.Verify(hash, pubkey) {
+		return fmt.Errorf("signature verification failed: signature does not match")
+	}
+	return nil
+}
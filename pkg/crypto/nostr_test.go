@@ -0,0 +1,135 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+func TestEventSerialize_Nostr(t *testing.T) {
+	pubkey := strings.Repeat("ab", 32)
+	testCases := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{
+			name:     "compressed pubkey is reduced to x-only",
+			event:    Event{Scheme: SigSchnorr, Pubkey: "02" + pubkey, CreatedAt: 1700000000, Content: "hi", Room: "general", Tags: [][]string{{"h", "general"}}},
+			expected: `[0,"` + pubkey + `",1700000000,9,[["h","general"]],"hi"]`,
+		},
+		{
+			name:     "only NIP-01 characters are escaped",
+			event:    Event{Scheme: SigSchnorr, Pubkey: pubkey, CreatedAt: 1, Content: "<a&b>\"\\\n\t \x01é", Room: "r", Tags: [][]string{{"h", "r"}}},
+			expected: `[0,"` + pubkey + `",1,9,[["h","r"]],"<a&b>\"\\\n\t` + " " + `\u0001é"]`,
+		},
+		{
+			name:     "user and version are not part of the event but of its tags",
+			event:    Event{Scheme: SigSchnorr, Version: EventV1, Pubkey: pubkey, CreatedAt: 1, Content: "x", Room: "r", User: "alice", Tags: [][]string{{"t", "intro"}, {"h", "r"}, {"name", "alice"}}},
+			expected: `[0,"` + pubkey + `",1,9,[["t","intro"],["h","r"],["name","alice"]],"x"]`,
+		},
+		{
+			name:     "the name of the pubkey needs no tag",
+			event:    Event{Scheme: SigSchnorr, Pubkey: pubkey, CreatedAt: 1, Content: "x", Room: "r", User: "nostr:abababab", Tags: [][]string{{"h", "r"}}},
+			expected: `[0,"` + pubkey + `",1,9,[["h","r"]],"x"]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.event.Serialize()
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+			if string(got) != tc.expected {
+				t.Errorf("Serialize() = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestEventSerialize_NostrInvalid(t *testing.T) {
+	pubkey := strings.Repeat("ab", 32)
+	for name, event := range map[string]Event{
+		"missing room tag": {Scheme: SigSchnorr, Pubkey: pubkey, Room: "general"},
+		"other room tag":   {Scheme: SigSchnorr, Pubkey: pubkey, Room: "general", Tags: [][]string{{"h", "random"}}},
+		"bad pubkey":       {Scheme: SigSchnorr, Pubkey: "02ab", Room: "r", Tags: [][]string{{"h", "r"}}},
+		"null tag":         {Scheme: SigSchnorr, Pubkey: pubkey, Room: "r", Tags: [][]string{{"h", "r"}, nil}},
+		"missing name tag": {Scheme: SigSchnorr, Pubkey: pubkey, Room: "r", User: "alice", Tags: [][]string{{"h", "r"}}},
+		"other name tag":   {Scheme: SigSchnorr, Pubkey: pubkey, Room: "r", User: "alice", Tags: [][]string{{"h", "r"}, {"name", "mallory"}}},
+	} {
+		if _, err := event.Serialize(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func signSchnorr(t *testing.T, key *btcec.PrivateKey, event Event) string {
+	t.Helper()
+	id, err := event.Hash()
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	sig, err := schnorr.Sign(key, id)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	return hex.EncodeToString(sig.Serialize())
+}
+
+func TestVerifyEventSignature_Schnorr(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	compressed := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	xOnly := hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))
+
+	event := Event{Scheme: SigSchnorr, Pubkey: xOnly, CreatedAt: 1700000000, Content: "hello", Room: "general", Tags: [][]string{{RoomTag, "general"}}}
+	sig := signSchnorr(t, key, event)
+
+	for name, verify := range map[string]func(Event, string) error{
+		"decred": VerifyEventSignature,
+		"btcd":   VerifyEventSignatureBTCD,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := verify(event, sig); err != nil {
+				t.Errorf("x-only pubkey: %v", err)
+			}
+
+			withCompressed := event
+			withCompressed.Pubkey = compressed
+			if err := verify(withCompressed, sig); err != nil {
+				t.Errorf("compressed pubkey: %v", err)
+			}
+
+			tampered := event
+			tampered.Content = "goodbye"
+			if err := verify(tampered, sig); err == nil {
+				t.Error("expected tampered content to fail verification")
+			}
+
+			moved := event
+			moved.Room = "random"
+			moved.Tags = [][]string{{RoomTag, "random"}}
+			if err := verify(moved, sig); err == nil {
+				t.Error("expected a message moved to another room to fail verification")
+			}
+
+			asECDSA := event
+			asECDSA.Scheme = SigECDSA
+			if err := verify(asECDSA, sig); err == nil {
+				t.Error("expected a schnorr signature to fail as ECDSA")
+			}
+
+			unknown := event
+			unknown.Scheme = "rsa"
+			if err := verify(unknown, sig); err == nil {
+				t.Error("expected an unknown scheme to be rejected")
+			}
+		})
+	}
+}
@@ -26,10 +26,11 @@ func HashPassword(password string) (string, error) {
 	return string(hashedBytes), nil
 }
 
-// VerifyPassword verifies a password against a bcrypt hash
+// VerifyPassword verifies a password against a bcrypt hash. An empty password
+// never matches: it returns ErrInvalidPassword.
 func VerifyPassword(password, hash string) error {
 	if password == "" {
-		return fmt.Errorf("password cannot be empty")
+		return ErrInvalidPassword
 	}
 	if hash == "" {
 		return fmt.Errorf("hash cannot be empty")
@@ -39,8 +39,8 @@ func TestVerifyPassword_Wrong(t *testing.T) {
 }
 
 func TestVerifyPassword_EmptyInputs(t *testing.T) {
-	if err := VerifyPassword("", "somehash"); err == nil {
-		t.Fatal("expected error for empty password")
+	if err := VerifyPassword("", "somehash"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected ErrInvalidPassword for empty password, got %v", err)
 	}
 	if err := VerifyPassword("pw", ""); err == nil {
 		t.Fatal("expected error for empty hash")
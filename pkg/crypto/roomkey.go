@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Encrypted rooms share a key derived from the room password and a random
+// salt chosen by the client that created the room. Their messages are NIP-44
+// payloads encrypted with that key in place of a conversation key (see
+// EncryptDM), so the server only stores ciphertext. Members authenticate to
+// the server with the RoomVerifier of the key instead of the password.
+const (
+	// RoomKeySaltSize is the size of the salt of an encrypted room, in bytes.
+	RoomKeySaltSize = 16
+
+	// Argon2id parameters (RFC 9106, second recommended option)
+	roomKeyTime    = 3
+	roomKeyMemory  = 64 * 1024 // KiB
+	roomKeyThreads = 4
+	roomKeySize    = 32
+
+	// roomVerifierInfo binds the verifier to its use, so it reveals nothing
+	// of the key.
+	roomVerifierInfo = "microchat-room-verifier"
+	roomVerifierSize = 32
+)
+
+// NewRoomKeySalt returns a random base64 salt for an encrypted room.
+func NewRoomKeySalt() (string, error) {
+	salt := make([]byte, RoomKeySaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(salt), nil
+}
+
+// ValidateRoomKeySalt returns an error unless salt is RoomKeySaltSize bytes
+// of base64, as NewRoomKeySalt returns.
+func ValidateRoomKeySalt(salt string) error {
+	_, err := decodeRoomKeySalt(salt)
+	return err
+}
+
+func decodeRoomKeySalt(salt string) ([]byte, error) {
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil || len(saltBytes) != RoomKeySaltSize {
+		return nil, fmt.Errorf("room key salt must be %d bytes of base64", RoomKeySaltSize)
+	}
+	return saltBytes, nil
+}
+
+// RoomKey derives the key of an encrypted room from its password and base64
+// salt with Argon2id. Every member derives the same key.
+func RoomKey(password, salt string) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("password cannot be empty")
+	}
+	saltBytes, err := decodeRoomKeySalt(salt)
+	if err != nil {
+		return nil, err
+	}
+	return argon2.IDKey([]byte(password), saltBytes, roomKeyTime, roomKeyMemory, roomKeyThreads, roomKeySize), nil
+}
+
+// RoomVerifier returns the hex HKDF-SHA256 of the key of an encrypted room.
+// Members send it wherever a room password is expected, and the server stores
+// and checks it like one: it learns neither the password nor the key.
+func RoomVerifier(key []byte) string {
+	verifier, err := hkdf.Key(sha256.New, key, nil, roomVerifierInfo, roomVerifierSize)
+	if err != nil {
+		panic(err) // only for lengths beyond 255 hashes
+	}
+	return hex.EncodeToString(verifier)
+}
+
+// IsRoomVerifier reports whether s has the form of a RoomVerifier.
+func IsRoomVerifier(s string) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == roomVerifierSize && s == hex.EncodeToString(b)
+}
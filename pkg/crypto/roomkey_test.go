@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRoomKey_SharedByMembers(t *testing.T) {
+	salt, err := NewRoomKeySalt()
+	if err != nil {
+		t.Fatalf("NewRoomKeySalt: %v", err)
+	}
+	key, err := RoomKey("hunter22", salt)
+	if err != nil {
+		t.Fatalf("RoomKey: %v", err)
+	}
+	payload, err := EncryptDM(key, "meet at noon")
+	if err != nil {
+		t.Fatalf("EncryptDM: %v", err)
+	}
+
+	again, _ := RoomKey("hunter22", salt)
+	if plaintext, err := DecryptDM(again, payload); err != nil || plaintext != "meet at noon" {
+		t.Errorf("DecryptDM with the same password = %q, %v", plaintext, err)
+	}
+
+	otherSalt, _ := NewRoomKeySalt()
+	for name, tt := range map[string]struct{ password, salt string }{
+		"wrong password": {"hunter23", salt},
+		"other room":     {"hunter22", otherSalt},
+	} {
+		key, err := RoomKey(tt.password, tt.salt)
+		if err != nil {
+			t.Fatalf("%s: RoomKey: %v", name, err)
+		}
+		if bytes.Equal(key, again) {
+			t.Errorf("%s: derived the same key", name)
+		}
+		if _, err := DecryptDM(key, payload); !errors.Is(err, ErrInvalidDMPayload) {
+			t.Errorf("%s: DecryptDM err = %v, want ErrInvalidDMPayload", name, err)
+		}
+	}
+}
+
+func TestRoomKey_Rejects(t *testing.T) {
+	salt, _ := NewRoomKeySalt()
+	for name, tt := range map[string]struct{ password, salt string }{
+		"empty password": {"", salt},
+		"not base64":     {"hunter22", "not base64!"},
+		"short salt":     {"hunter22", "AAAA"},
+	} {
+		if _, err := RoomKey(tt.password, tt.salt); err == nil {
+			t.Errorf("%s: RoomKey should fail", name)
+		}
+	}
+}
+
+func TestRoomVerifier(t *testing.T) {
+	salt, _ := NewRoomKeySalt()
+	key, _ := RoomKey("hunter22", salt)
+	verifier := RoomVerifier(key)
+
+	if !IsRoomVerifier(verifier) {
+		t.Errorf("IsRoomVerifier(%q) = false", verifier)
+	}
+	if again, _ := RoomKey("hunter22", salt); RoomVerifier(again) != verifier {
+		t.Error("members derive different verifiers")
+	}
+	if verifier == hex.EncodeToString(key) {
+		t.Error("the verifier is the key")
+	}
+	other, _ := RoomKey("hunter23", salt)
+	if RoomVerifier(other) == verifier {
+		t.Error("another password derives the same verifier")
+	}
+	for _, s := range []string{"hunter22", "", strings.ToUpper(verifier), verifier[:62]} {
+		if IsRoomVerifier(s) {
+			t.Errorf("IsRoomVerifier(%q) = true", s)
+		}
+	}
+}
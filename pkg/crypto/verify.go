@@ -1,18 +1,67 @@
 package crypto
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 )
 
-// VerifyMessageSignature verifies a Nostr-style message signature
+// VerifyMessageSignature verifies a Nostr-style message signature over a v0 event
 // This matches the signing logic in the frontend
 func VerifyMessageSignature(pubkeyHex, signatureHex, content, room string, timestamp int64) error {
+	return VerifyEventSignature(Event{
+		Version:   EventV0,
+		Pubkey:    pubkeyHex,
+		CreatedAt: timestamp,
+		Content:   content,
+		Room:      room,
+	}, signatureHex)
+}
+
+// VerifyEventSignature verifies a signature over an event of any supported
+// version and signature scheme
+func VerifyEventSignature(event Event, signatureHex string) error {
+	switch event.Scheme {
+	case "", SigECDSA:
+	case SigSchnorr:
+		return verifySchnorr(event, signatureHex)
+	default:
+		return fmt.Errorf("unsupported signature scheme %q", event.Scheme)
+	}
+
+	// Create the event hash using the same serialization as the frontend
+	eventHashBytes, err := event.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash event: %w", err)
+	}
+
+	return verifyECDSA(event.Pubkey, signatureHex, eventHashBytes)
+}
+
+// CanonicalSignature returns the single form of a signature that duplicate
+// detection compares: lowercase hex of the 64-byte compact r||s, into which a
+// DER ECDSA signature is converted. Anything else is only lowercased, for
+// verification to reject.
+func CanonicalSignature(signatureHex string) string {
+	signatureHex = strings.ToLower(signatureHex)
+	signatureBytes, err := hex.DecodeString(signatureHex)
+	if err != nil || len(signatureBytes) == 64 {
+		return signatureHex
+	}
+	signature, err := ecdsa.ParseDERSignature(signatureBytes)
+	if err != nil {
+		return signatureHex
+	}
+	r, s := signature.R(), signature.S()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	return hex.EncodeToString(append(rBytes[:], sBytes[:]...))
+}
+
+// verifyECDSA checks a compact (or DER) low-S ECDSA signature over hash
+func verifyECDSA(pubkeyHex, signatureHex string, hash []byte) error {
 	// Decode public key from hex
 	pubkeyBytes, err := hex.DecodeString(pubkeyHex)
 	if err != nil {
@@ -57,38 +106,24 @@ func VerifyMessageSignature(pubkeyHex, signatureHex, content, room string, times
 		}
 	}
 
-	// Create the event hash using the same serialization as the frontend
-	eventHash := createEventHash(pubkeyHex, timestamp, content, room)
-	eventHashBytes, err := hex.DecodeString(eventHash)
-	if err != nil {
-		return fmt.Errorf("failed to decode event hash: %w", err)
+	// Only the low-S form is accepted: (r, n-s) is an equally valid signature
+	// over the same event and would otherwise slip past duplicate detection.
+	if sigS := signature.S(); sigS.IsOverHalfOrder() {
+		return fmt.Errorf("signature S value is not canonical (high-S)")
 	}
 
 	// Verify the signature
-	if !signature.Verify(eventHashBytes, pubkey) {
+	if !signature.Verify(hash, pubkey) {
 		return fmt.Errorf("signature verification failed: signature does not match")
 	}
 
 	return nil
 }
 
-// createEventHash creates a canonical event hash following Nostr's format
+// createEventHash creates a canonical v0 event hash following Nostr's format
 // This must match the frontend implementation exactly
 func createEventHash(pubkey string, timestamp int64, content, room string) string {
 	// Nostr event format: [0, pubkey, created_at, content, room]
-	event := []any{
-		0,         // reserved for future use
-		pubkey,    // public key
-		timestamp, // unix timestamp
-		content,   // message content
-		room,      // room name
-	}
-
-	// Serialize to JSON
-	serialized, _ := json.Marshal(event)
-
-	// Hash with SHA-256
-	hash := sha256.Sum256(serialized)
-
-	return hex.EncodeToString(hash[:])
+	hash, _ := Event{Version: EventV0, Pubkey: pubkey, CreatedAt: timestamp, Content: content, Room: room}.Hash()
+	return hex.EncodeToString(hash)
 }
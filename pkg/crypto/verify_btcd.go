@@ -1,20 +1,38 @@
 package crypto
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 )
 
-// VerifyMessageSignatureBTCD verifies a signature using btcd library
+// VerifyMessageSignatureBTCD verifies a v0 event signature using btcd library
 // This is more compatible with noble-secp256k1
 func VerifyMessageSignatureBTCD(pubkeyHex, signatureHex, content, room string, timestamp int64) error {
+	return VerifyEventSignatureBTCD(Event{
+		Version:   EventV0,
+		Pubkey:    pubkeyHex,
+		CreatedAt: timestamp,
+		Content:   content,
+		Room:      room,
+	}, signatureHex)
+}
+
+// VerifyEventSignatureBTCD verifies a signature over an event of any supported
+// version and signature scheme using btcd
+func VerifyEventSignatureBTCD(event Event, signatureHex string) error {
+	switch event.Scheme {
+	case "", SigECDSA:
+	case SigSchnorr:
+		return verifySchnorr(event, signatureHex)
+	default:
+		return fmt.Errorf("unsupported signature scheme %q", event.Scheme)
+	}
+
 	// Decode public key from hex
-	pubkeyBytes, err := hex.DecodeString(pubkeyHex)
+	pubkeyBytes, err := hex.DecodeString(event.Pubkey)
 	if err != nil {
 		return fmt.Errorf("invalid public key hex: %w", err)
 	}
@@ -48,10 +66,9 @@ func VerifyMessageSignatureBTCD(pubkeyHex, signatureHex, content, room string, t
 	signature := ecdsa.NewSignature(&r, &s)
 
 	// Create the event hash
-	eventHash := createEventHashBTCD(pubkeyHex, timestamp, content, room)
-	eventHashBytes, err := hex.DecodeString(eventHash)
+	eventHashBytes, err := event.Hash()
 	if err != nil {
-		return fmt.Errorf("failed to decode event hash: %w", err)
+		return fmt.Errorf("failed to hash event: %w", err)
 	}
 
 	// Verify the signature
@@ -62,17 +79,7 @@ func VerifyMessageSignatureBTCD(pubkeyHex, signatureHex, content, room string, t
 	return nil
 }
 
-// createEventHashBTCD creates the same event hash as the frontend
+// createEventHashBTCD creates the same v0 event hash as the frontend
 func createEventHashBTCD(pubkey string, timestamp int64, content, room string) string {
-	event := []any{
-		0,
-		pubkey,
-		timestamp,
-		content,
-		room,
-	}
-
-	serialized, _ := json.Marshal(event)
-	hash := sha256.Sum256(serialized)
-	return hex.EncodeToString(hash[:])
+	return createEventHash(pubkey, timestamp, content, room)
 }
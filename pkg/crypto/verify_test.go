@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -141,3 +142,59 @@ func BenchmarkVerifyMessageSignature(b *testing.B) {
 		_ = VerifyMessageSignature(pubkeyHex, signatureHex, content, room, timestamp)
 	}
 }
+
+func TestVerifyMessageSignature_HighSRejected(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	pubkeyHex := hex.EncodeToString(privateKey.PubKey().SerializeCompressed())
+
+	content := "Test message"
+	room := "test-room"
+	timestamp := int64(1234567890)
+
+	eventHashBytes, _ := hex.DecodeString(createEventHash(pubkeyHex, timestamp, content, room))
+	compact := ecdsa.SignCompact(privateKey, eventHashBytes, true)[1:]
+
+	// Flip S to n-S: still a mathematically valid signature over the same hash.
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(compact[32:])
+	s.Negate()
+	highS := s.Bytes()
+	malleated := append(append([]byte{}, compact[:32]...), highS[:]...)
+
+	if err := VerifyMessageSignature(pubkeyHex, hex.EncodeToString(compact), content, room, timestamp); err != nil {
+		t.Fatalf("low-S signature should verify: %v", err)
+	}
+	if err := VerifyMessageSignature(pubkeyHex, hex.EncodeToString(malleated), content, room, timestamp); err == nil {
+		t.Error("high-S signature should be rejected")
+	}
+}
+
+func TestCanonicalSignature(t *testing.T) {
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	pubkeyHex := hex.EncodeToString(privateKey.PubKey().SerializeCompressed())
+	hash, _ := hex.DecodeString(createEventHash(pubkeyHex, 1234567890, "content", "room"))
+	signature := ecdsa.Sign(privateKey, hash)
+	r, s := signature.R(), signature.S()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	compactHex := hex.EncodeToString(append(rBytes[:], sBytes[:]...))
+	derHex := hex.EncodeToString(signature.Serialize())
+
+	for name, in := range map[string]string{"compact": compactHex, "DER": derHex, "upper case DER": strings.ToUpper(derHex)} {
+		got := CanonicalSignature(in)
+		if got != compactHex {
+			t.Errorf("%s: CanonicalSignature = %q, want %q", name, got, compactHex)
+		}
+		if err := VerifyMessageSignature(pubkeyHex, got, "content", "room", 1234567890); err != nil {
+			t.Errorf("%s: canonical signature does not verify: %v", name, err)
+		}
+	}
+	if got := CanonicalSignature("NOT HEX"); got != "not hex" {
+		t.Errorf("CanonicalSignature(invalid) = %q, want it lowercased", got)
+	}
+}